@@ -10,7 +10,7 @@ import (
 
 func (s Server) GetGamesId(w http.ResponseWriter, r *http.Request, id int32) {
 
-	app, err := mongo.GetApp(int(id))
+	app, err := mongo.GetApp(r.Context(), int(id))
 	if err == mongo.ErrNoDocuments {
 
 		returnResponse(w, r, http.StatusNotFound, generated.GameResponse{Error: "app not found"})
@@ -53,6 +53,21 @@ func (s Server) GetGamesId(w http.ResponseWriter, r *http.Request, id int32) {
 			}
 		}
 
+		gameSchema.PriceStats = generated.GameSchema_PriceStats{
+			AdditionalProperties: map[string]generated.ProductPriceStatsSchema{},
+		}
+
+		for k, v := range app.PriceStats {
+			gameSchema.PriceStats.AdditionalProperties[string(k)] = generated.ProductPriceStatsSchema{
+				Currency:       string(v.Currency),
+				LowestEver:     int32(v.LowestEver),
+				LowestEverAt:   v.LowestEverAt.Unix(),
+				Lowest12Months: int32(v.Lowest12Months),
+				TypicalSale:    int32(v.TypicalSale),
+				Sales:          int32(v.Sales),
+			}
+		}
+
 		categories, err := app.GetCategories()
 		if err != nil {
 			log.ErrS(err)
@@ -59,7 +59,7 @@ func (s Server) GetGames(w http.ResponseWriter, r *http.Request, params generate
 		payload.Platforms = *params.Platforms
 	}
 
-	conn, ctx, err := backend.GetClient()
+	conn, ctx, err := backend.GetClient(r.Context())
 	if err != nil {
 		log.ErrS(err)
 		returnResponse(w, r, http.StatusInternalServerError, generated.GamesResponse{Error: err.Error()})
@@ -161,6 +161,9 @@ func (s Server) GetGames(w http.ResponseWriter, r *http.Request, params generate
 			Prices: generated.GameSchema_Prices{
 				AdditionalProperties: map[string]generated.ProductPriceSchema{},
 			},
+			PriceStats: generated.GameSchema_PriceStats{
+				AdditionalProperties: map[string]generated.ProductPriceStatsSchema{},
+			},
 			Tags:       []generated.StatSchema{},
 			Categories: []generated.StatSchema{},
 			Genres:     []generated.StatSchema{},
@@ -179,6 +182,17 @@ func (s Server) GetGames(w http.ResponseWriter, r *http.Request, params generate
 			}
 		}
 
+		for k, stat := range app.GetPriceStats() {
+			newApp.PriceStats.AdditionalProperties[k] = generated.ProductPriceStatsSchema{
+				Currency:       stat.GetCurrency(),
+				LowestEver:     stat.GetLowestEver(),
+				LowestEverAt:   stat.GetLowestEverAt().GetSeconds(),
+				Lowest12Months: stat.GetLowest12Months(),
+				TypicalSale:    stat.GetTypicalSale(),
+				Sales:          stat.GetSales(),
+			}
+		}
+
 		for _, v := range app.GetTags() {
 			stat := mapTagIDs[int(v)]
 			newApp.Tags = append(newApp.Tags, generated.StatSchema{Id: stat.ID, Name: stat.Name})
@@ -11,7 +11,7 @@ import (
 
 func (s Server) GetGamesIdSimilar(w http.ResponseWriter, r *http.Request, id int32) {
 
-	conn, ctx, err := backend.GetClient()
+	conn, ctx, err := backend.GetClient(r.Context())
 	if err != nil {
 		log.ErrS(err)
 		returnResponse(w, r, http.StatusInternalServerError, err)
@@ -34,6 +34,7 @@ func (s Server) GetGamesIdSimilar(w http.ResponseWriter, r *http.Request, id int
 			Count:  int(app.GetCount()),
 			Order:  int(app.GetOrder()),
 			Owners: int(app.GetOwners()),
+			Score:  float64(app.GetScore()),
 		})
 	}
 
@@ -40,7 +40,7 @@ func (s Server) GetArticles(w http.ResponseWriter, r *http.Request, params gener
 		payload.Feed = *params.Feed
 	}
 
-	conn, ctx, err := backend.GetClient()
+	conn, ctx, err := backend.GetClient(r.Context())
 	if err != nil {
 		log.ErrS(err)
 		returnResponse(w, r, http.StatusInternalServerError, generated.ArticlesResponse{Error: err.Error()})
@@ -42,22 +42,28 @@ type ArticleSchema struct {
 
 // GameSchema defines model for game-schema.
 type GameSchema struct {
-	Categories      []StatSchema      `json:"categories"`
-	Developers      []StatSchema      `json:"developers"`
-	Genres          []StatSchema      `json:"genres"`
-	Icon            string            `json:"icon"`
-	Id              int               `json:"id"`
-	MetacriticScore int32             `json:"metacritic_score"`
-	Name            string            `json:"name"`
-	PlayersMax      int               `json:"players_max"`
-	PlayersWeekMax  int               `json:"players_week_max"`
-	Prices          GameSchema_Prices `json:"prices"`
-	Publishers      []StatSchema      `json:"publishers"`
-	ReleaseDate     int64             `json:"release_date"`
-	ReviewsNegative int               `json:"reviews_negative"`
-	ReviewsPositive int               `json:"reviews_positive"`
-	ReviewsScore    float64           `json:"reviews_score"`
-	Tags            []StatSchema      `json:"tags"`
+	Categories      []StatSchema          `json:"categories"`
+	Developers      []StatSchema          `json:"developers"`
+	Genres          []StatSchema          `json:"genres"`
+	Icon            string                `json:"icon"`
+	Id              int                   `json:"id"`
+	MetacriticScore int32                 `json:"metacritic_score"`
+	Name            string                `json:"name"`
+	PlayersMax      int                   `json:"players_max"`
+	PlayersWeekMax  int                   `json:"players_week_max"`
+	PriceStats      GameSchema_PriceStats `json:"price_stats"`
+	Prices          GameSchema_Prices     `json:"prices"`
+	Publishers      []StatSchema          `json:"publishers"`
+	ReleaseDate     int64                 `json:"release_date"`
+	ReviewsNegative int                   `json:"reviews_negative"`
+	ReviewsPositive int                   `json:"reviews_positive"`
+	ReviewsScore    float64               `json:"reviews_score"`
+	Tags            []StatSchema          `json:"tags"`
+}
+
+// GameSchema_PriceStats defines model for GameSchema.PriceStats.
+type GameSchema_PriceStats struct {
+	AdditionalProperties map[string]ProductPriceStatsSchema `json:"-"`
 }
 
 // GameSchema_Prices defines model for GameSchema.Prices.
@@ -91,25 +97,31 @@ type MessageSchema struct {
 
 // PackageSchema defines model for package-schema.
 type PackageSchema struct {
-	Apps             []int32              `json:"apps"`
-	AppsCount        int32                `json:"apps_count"`
-	BillingType      string               `json:"billing_type"`
-	Bundle           []int32              `json:"bundle"`
-	ChangeId         int32                `json:"change_id"`
-	ChangeNumberDate int64                `json:"change_number_date"`
-	ComingSoon       bool                 `json:"coming_soon"`
-	DepotIds         []int32              `json:"depot_ids"`
-	Icon             string               `json:"icon"`
-	Id               int32                `json:"id"`
-	ImageLogo        string               `json:"image_logo"`
-	ImagePage        string               `json:"image_page"`
-	LicenseType      string               `json:"license_type"`
-	Name             string               `json:"name"`
-	Platforms        []string             `json:"platforms"`
-	Prices           PackageSchema_Prices `json:"prices"`
-	ReleaseDate      string               `json:"release_date"`
-	ReleaseDateUnix  int64                `json:"release_date_unix"`
-	Status           string               `json:"status"`
+	Apps             []int32                  `json:"apps"`
+	AppsCount        int32                    `json:"apps_count"`
+	BillingType      string                   `json:"billing_type"`
+	Bundle           []int32                  `json:"bundle"`
+	ChangeId         int32                    `json:"change_id"`
+	ChangeNumberDate int64                    `json:"change_number_date"`
+	ComingSoon       bool                     `json:"coming_soon"`
+	DepotIds         []int32                  `json:"depot_ids"`
+	Icon             string                   `json:"icon"`
+	Id               int32                    `json:"id"`
+	ImageLogo        string                   `json:"image_logo"`
+	ImagePage        string                   `json:"image_page"`
+	LicenseType      string                   `json:"license_type"`
+	Name             string                   `json:"name"`
+	Platforms        []string                 `json:"platforms"`
+	PriceStats       PackageSchema_PriceStats `json:"price_stats"`
+	Prices           PackageSchema_Prices     `json:"prices"`
+	ReleaseDate      string                   `json:"release_date"`
+	ReleaseDateUnix  int64                    `json:"release_date_unix"`
+	Status           string                   `json:"status"`
+}
+
+// PackageSchema_PriceStats defines model for PackageSchema.PriceStats.
+type PackageSchema_PriceStats struct {
+	AdditionalProperties map[string]ProductPriceStatsSchema `json:"-"`
 }
 
 // PackageSchema_Prices defines model for PackageSchema.Prices.
@@ -152,12 +164,33 @@ type ProductPriceSchema struct {
 	Initial         int32  `json:"initial"`
 }
 
+// ProductPriceStatsSchema defines model for product-price-stats-schema.
+type ProductPriceStatsSchema struct {
+	Currency       string `json:"currency"`
+	Lowest12Months int32  `json:"lowest12Months"`
+	LowestEver     int32  `json:"lowestEver"`
+	LowestEverAt   int64  `json:"lowestEverAt"`
+	Sales          int32  `json:"sales"`
+	TypicalSale    int32  `json:"typicalSale"`
+}
+
+// RecommendedGameSchema defines model for recommended-game-schema.
+type RecommendedGameSchema struct {
+	AppId      int     `json:"app_id"`
+	Friends    int     `json:"friends"`
+	Name       string  `json:"name"`
+	SameOwners int     `json:"same_owners"`
+	Score      float64 `json:"score"`
+	Tags       int     `json:"tags"`
+}
+
 // SimilarGameSchema defines model for similar-game-schema.
 type SimilarGameSchema struct {
-	AppId  int `json:"app_id"`
-	Count  int `json:"count"`
-	Order  int `json:"order"`
-	Owners int `json:"owners"`
+	AppId  int     `json:"app_id"`
+	Count  int     `json:"count"`
+	Order  int     `json:"order"`
+	Owners int     `json:"owners"`
+	Score  float64 `json:"score"`
 }
 
 // StatSchema defines model for stat-schema.
@@ -231,6 +264,12 @@ type PlayersResponse struct {
 	Players    []PlayerSchema   `json:"players"`
 }
 
+// List of games the player does not own
+type RecommendedGamesResponse struct {
+	Error string                  `json:"error"`
+	Games []RecommendedGameSchema `json:"games"`
+}
+
 // List of apps, with pagination
 type SimilarGamesResponse struct {
 	Error string              `json:"error"`
@@ -294,6 +333,59 @@ type GetPlayersParams struct {
 	Country   *[]string       `json:"country,omitempty"`
 }
 
+// Getter for additional properties for GameSchema_PriceStats. Returns the specified
+// element and whether it was found
+func (a GameSchema_PriceStats) Get(fieldName string) (value ProductPriceStatsSchema, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for GameSchema_PriceStats
+func (a *GameSchema_PriceStats) Set(fieldName string, value ProductPriceStatsSchema) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]ProductPriceStatsSchema)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for GameSchema_PriceStats to handle AdditionalProperties
+func (a *GameSchema_PriceStats) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]ProductPriceStatsSchema)
+		for fieldName, fieldBuf := range object {
+			var fieldVal ProductPriceStatsSchema
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for GameSchema_PriceStats to handle AdditionalProperties
+func (a GameSchema_PriceStats) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for GameSchema_Prices. Returns the specified
 // element and whether it was found
 func (a GameSchema_Prices) Get(fieldName string) (value ProductPriceSchema, found bool) {
@@ -347,6 +439,59 @@ func (a GameSchema_Prices) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
+// Getter for additional properties for PackageSchema_PriceStats. Returns the specified
+// element and whether it was found
+func (a PackageSchema_PriceStats) Get(fieldName string) (value ProductPriceStatsSchema, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for PackageSchema_PriceStats
+func (a *PackageSchema_PriceStats) Set(fieldName string, value ProductPriceStatsSchema) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]ProductPriceStatsSchema)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for PackageSchema_PriceStats to handle AdditionalProperties
+func (a *PackageSchema_PriceStats) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]ProductPriceStatsSchema)
+		for fieldName, fieldBuf := range object {
+			var fieldVal ProductPriceStatsSchema
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for PackageSchema_PriceStats to handle AdditionalProperties
+func (a PackageSchema_PriceStats) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for PackageSchema_Prices. Returns the specified
 // element and whether it was found
 func (a PackageSchema_Prices) Get(fieldName string) (value ProductPriceSchema, found bool) {
@@ -411,7 +556,7 @@ type ServerInterface interface {
 	// Retrieve Game
 	// (GET /games/{id})
 	GetGamesId(w http.ResponseWriter, r *http.Request, id int32)
-	// List games with similar owners
+	// List similar games, by tags, genres, developers, owners and reviews
 	// (GET /games/{id}/similar)
 	GetGamesIdSimilar(w http.ResponseWriter, r *http.Request, id int32)
 	// List Groups
@@ -429,6 +574,9 @@ type ServerInterface interface {
 	// Update Player
 	// (POST /players/{id})
 	PostPlayersId(w http.ResponseWriter, r *http.Request, id int64)
+	// List recommended games, by tag affinity, friends, owners and reviews
+	// (GET /players/{id}/recommendations)
+	GetPlayersIdRecommendations(w http.ResponseWriter, r *http.Request, id int64)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -1086,6 +1234,36 @@ func (siw *ServerInterfaceWrapper) PostPlayersId(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
+// GetPlayersIdRecommendations operation middleware
+func (siw *ServerInterfaceWrapper) GetPlayersIdRecommendations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, KeyHeaderScopes, []string{""})
+
+	ctx = context.WithValue(ctx, KeyQueryScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPlayersIdRecommendations(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // Handler creates http.Handler with routing matching OpenAPI spec.
 func Handler(si ServerInterface) http.Handler {
 	return HandlerWithOptions(si, ChiServerOptions{})
@@ -1150,6 +1328,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/players/{id}", wrapper.PostPlayersId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/players/{id}/recommendations", wrapper.GetPlayersIdRecommendations)
+	})
 
 	return r
 }
@@ -1157,45 +1338,47 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+wb227juPVXAraPcmxnkqLjt0EfpoNu0bSzfQoMg5aOZW4kSktSnhgD/3vBi6gLSVuW",
-	"HS92um+WdO43HvLQ31Fc5GVBgQqOFt9RiRnOQQBTTxnJiZiod/KRULRAv1bA9ihCFOeAFhoERYjHW8ix",
-	"hEpgg6tMoMV8FqEcv5G8yuXDTD4Sah4jJPalJECogBQYOhwiVGw2HE4w1DB+jm0OMz8HlgDTDCYJ8DjI",
-	"RcL5mSCFFyGgks0LwupJvVxanlwwQlN0kDwZ8LKgHJRJMRMkzoBP6rfyZVxQAVSo72WZkRgLUtDpL7yg",
-	"8l1bCB4zUsqvaIF+IlzcFZu7miaKUMmKEpggXWZKSwG5+vFnBhu0QH+aNp6fag58ahAmhuPB6oMZw3v5",
-	"DIwVTJLpKRqhEqeEYi3acS4NpGWkzPRrRRgk0qYtWhFqqae5L5VZT9jiEKEU5zDOzl0zhnVOVbQc11ZJ",
-	"EdBT4R9T69OdAjGsrhs1Zcmju29EbO865j5H9+GR1bGCG1bvFjxayiGRoyGlYqyoyquaWlM8x7YaYbBx",
-	"JfhvYt1asQHm1aCHCOXAOU5HZuYx4WvCVnJHmH9qCG2T+BWn102pmuYZnrYoQ31tEH4Lb7fUOyOdygzv",
-	"gb1vHdY8TqqrJQmpqmkcr8YGyLLk76zXJa60Mg6Pra6B+qF1NDYMqyGhUcMeIsRJTjLMJr/z1a2txijz",
-	"nblMacUM17viG1XmPNT9arv5m4SiDpflisTamo766mMiP20KlmOh2+gPD8jtqiOEK7ENmNF4kXs/JlhA",
-	"n8VfHr0sNgCJl4b8sMrwGrLwZ/12kCpBg7jGCEgqiMjAS6JiPhl7UUESVNPQGNa8LWMa03W0NzZqq2zd",
-	"GDXONiou6/Y4FB0xFpAWjJyTBAKLI8tSAjvIJIurUUyBsuvJd8L1rqdzEDhmRJB4xeOCDQ0xavYMgUWM",
-	"r3L85mdYA3wDeD0CxUhsMjxJiKweOHvu+PboEsCKpIrFRFFxrVWsf4FYKDbVOiN8e0V3MsgAc1idURYY",
-	"7Ah84ysKKRZkB36L1FBlwclpKNeXSVGtVT4aJFrla5PsOL2S+r4yQPXu0OSt4mWDPmpnaCe5Oq6x4dAN",
-	"L08s9V7hXYp6LvEY0uOBviE9ebKsdzjhtWm9llRs53PZ+hRe5beAk4xQfz4OKwjN6xxkWPCBQhnoFaGr",
-	"eIvF+Vj12cMZWAWtlb2oTjGS43plGEBIMKCJxG2INRk0fFE02dAJDbtE1uucdWiTNjX3xkGu8V3DOkar",
-	"w6it/7K1mz2/uTeYp9WvAZv20O7FjnV33co0xFG9gixprOKiokOjc02yjNDUNlyOyuuKJro7ukSueItp",
-	"CsPz38DroDtneYmLXKrDi04hWBdFBpjqnqYsxIokF5v6jL4z1LnmOIVVVqSFn476XPrjLUIZiYFyCHvu",
-	"WNcipHhdEzhgziHFbbqUfk/hyNUGWFWUvA0MDbmYV/x07qo87KSSzYJevrTj2huz3XhsB58tdgq1FQkd",
-	"v/e8bCtq48FWt+Cs/H0zWRMsO+cUwYKkB0XDjGtmPMOApW78bxVjQM9C+bkQOBu6qRsM23O/nVbVczJN",
-	"qSNCT4Vlc2YWLO47LLB/UVnjxJwm+soZFYQaM3m26hUVbH/8NMQl2hxVe2qSv1HKZLPqxzi6ORIkD/Tu",
-	"MhT9eDtMidivzm8ztImtQZsjGnvmrdVoidbYsG3rWrqOLMrJviLmbsRVWMR+vySEK5bPwGIYvExvCHWj",
-	"OQTLAPxrH6EJ2ZGkGkyKUCLIQOieZ6wRGiq1Gq4ROqIZDZa9s8bjp2JJKH+qTu60K5aaF/s/6dM5zzd3",
-	"qTC136wT9RDaUFiaQA/KHpI7kFTB6F+qk0SIK0bE/qtkpum/wv7vgI2iamq+1Y92bP4K+8aZuCT/ALXY",
-	"v8L+32q+Hpi2e9EOKmQ2hXvGuxWi5IvpFJfkPs2KNc64AJzf205dAMv5vzZfge1IDAZjMZ1mRYyzbcHF",
-	"4uPsr/OpArPHbQv0WdG6+yqJ3X16/iJTFhjXTOf3s/sZitDbRPdYAZqYcxB8SvJ0yvFknU7mHx/e5h8f",
-	"7kudsUUJFJcELdAHQ7DEYqvMO22P61O9/kn3qnX1SyIFBPGpNetv3dV48fdLDci0c7fiEJ2Eb9/9GADu",
-	"XKyQOD5f84J1b284UenH031Og3ZOt53jty8afD6buScvfoY6HW/M1Jzhhq2z7N0neZjNQs2yhZu6l04O",
-	"EXq8AHM+GvNxJObTSGllJavyHMvio2corQzSx3cvyL5SlW9qu51QEn42rcAfGfiuyWBOPC/nOJShPVu9",
-	"HcvOKe7t2HbOi2/HtnMyfUO2rR2uh6t7Mmd5fHBYjCrAvfH20OrrQ5uPQ3scg/Y0Rki34tblsi63+rlV",
-	"a6ffSXI4WXC/JG7JVd6WHVS7TKF2YytYBW2vOyF29E7qaG+f72znWtRQd/sRH8chPo0TteP0/4BgBHag",
-	"HO/4PULPsgrETgDUtygGBMJXA/n7iIfAJZehgXEMfX4Z+uMl6E+XCO9WifDFlkDlsAdPwWCx1y5/jD6t",
-	"uYKuYrq+gK4emtlWa+DVmlRFV+3yAsekR9fmcaW0dxd38Mrpw5uPxHschfc0Sk7P6llHsU0C/UJnQfv+",
-	"aCgPnlvXUn/8TOiOWrwTlu5UpTcV6TSLZsQR3XqPNLSzNfr9rEW/HV9js5vzNQ55F5ajCpR7jXxoiQpg",
-	"zkdjPo7EfBoprVuqWoWmLlb2lSlXzY3kYLWyN4n/D4pVPUNypkxqoDS87nTGTW5q5PjtJ6Cp2KLFQ3Te",
-	"fjfIsJ50XZfduCTs38UfnINexPlYxMdxiE/jRPXkn00dm37mTSf7Tm63Ddb1N9yqb7z+Bqv/F5PAdvS5",
-	"/odH3zqtLWmEyoJ7rPJc8Pc2iy9pLjTMVc8UrnE08N9SNl1hT+h/PtSzR2Xa1tTxZSnLUTNNfFlKs3Bg",
-	"u9oPas5/akAoiTSA/RnewxRJqkay77U7P5v/M9kXz/ZfLPbVp+bPpw1YvQC23n2u/wvXQOn4OywP/wsA",
-	"AP//iDblepI9AAA=",
+	"H4sIAAAAAAAC/+wb227juPVXDLaP8jjOJouO3wZFMR10F00726eBYdDSicwdidSSlJNg4H8veNWNsmnZ",
+	"yWKnfbOlc7/xkIf6hlJWVowClQKtvqEKc1yCBK7/FaQkcq6fqb+EohX6rQb+ghJEcQloZUBQgkS6gxIr",
+	"qAwecV1ItFreJKjEz6SsS/XnRv0l1P5NkHypFAFCJeTA0eGQIPb4KOAEQwMT5tjmcBPmwDPghsE8A5GO",
+	"clFwYSZI4yUIqGLzBWH9Tz9ce55CckJzdFA8OYiKUQHapJhLkhYg5u6pepgyKoFK/b6qCpJiSRhd/CoY",
+	"Vc/aQoiUk0q9RSv0ExFyxh5njiZKUMVZBVySLjOtpYRS//gzh0e0Qn9aNJ5fGA5iYRHmluPB64M5xy/q",
+	"P3DOuCLTUzRBFc4JxUa041waSM9Im+m3mnDIlE1btBLUUs9wX2uznrDFIUE5LmGanbtmHNc519FyXFst",
+	"xYieGv+YWh9mGsSyum7UVJVIZk9E7mYdc5+je3xkdawwDKtXCx4jZUzkGEilGGd1dVVTG4rn2NYgRBtX",
+	"gf8u1nWKRZjXgB4SVIIQOJ+YmceEd4S95ANhfjYQxibpV5xfN6UczTM87VFifW0Rfg9vt9Q7I52qAr8A",
+	"f906bHicVNdIMqaqoXG8Glsgz1K8sl6XuNLLGB9bXQP1Q+tobFhWMaHhYDW9lJUl0Ayy+dVXOE1wJndg",
+	"Oc4yBmJGmZyxp9db6foqxZozfqVqcWjSTJCSFJjP/+B9QluNSYEYb0bLypnw4Lr9dus8H8tZXFUbkhoL",
+	"DlTWLzP16pHxEkuzCfnhFg33JAnCtdyNmM56TgRfZlhCn8WPd0EWjwBZkIZ6sSnwForx1+ZplCqjBiFZ",
+	"pKSSyAKCJGoekrEXCSRDjobB8OZtGdOarqO9+YPaKns3Jo2zrYprt7kYi44US8gZJ+cEvsTyyKKewR4K",
+	"xeJqFHOg/HrynXD90NMlSJxyIkm6ESnjsSFG7Y5rpAUQmxI/hxk6gCeAr0egOElho5Q1aZ5lRJUNXDx0",
+	"HHx0FeUsq1M516TmmtTQcGz7K6TSc7wWsyNs6m1BxO6KAcShACxgc0Yh4rAn8CQ2FHIsyR7CPnBQFRPk",
+	"NNQwejJWb3UFsEi0LrcGR+L8SuqHCg81u3lbKTQvn2ZJuyZ00rnjGh8O3UjshncglnuP8D5HPQcFzBrw",
+	"R9+sgTxdu/3p+Nq43Soqvm+9bH0c7yx2gLOC0HA9iCtIzeMSVJCISKEs9IbQTbrD8nwsd3J0BhajTtmL",
+	"6iQnJXYrUwQhyYFmCnf1bZhP8YuyzY1OaPgl2q2z3qFNEjnujYOGxh8admA0F0Zt/dets4jzt2YW87T6",
+	"DrBpSf1O+lh32a1TMY7qlWdFY5OymsZG55YUBaG5b/gGKm9rmhVwqVzpDtMc4vPfwpugO2exSVmp1BGs",
+	"Uwi2jBWAqempKiY3JLvY1Gf0vSMUSYlz2BQsZ2E6+nUVjrcEFSQFKmDcc8e6JqnE65pgANZX+Lvtkvo9",
+	"zcASbYBNTclzZDAqBWtxulrozO8kr8+7Xoa2MymYJd0MaIe7L68atRV7nUjrxZWv4U3MjHYrg86jbzRv",
+	"kHXnlGu0IJoxY5yp7YQwDlhpKv5acw70LJRfmMRFJIKMhu0Fg591uimrodQRoafCujlxHV1c9lji8KK2",
+	"xZk9iw6VUyoJtWYKHFXUVPKX4ydAQ6LNoGP4bqRRK1TrHMY4ujmUpBzZSahQDOPtMSXyZXN+m2NM7A3a",
+	"HEv5iYlRoyVaY8O2rZ10HVm0k0MlbXgQocMiDfslI0KzfACeQnSb8EgoLmJhOUB47SU0I3uS1dGkCCWS",
+	"REL3POON0FBxagyN0BHNarAOnFQfPxkcOfR45ARoJs6MXoFL2LAnarcnQ8wpW+ATNvM9uY1otx20u1qn",
+	"SFe4de8cepKNfMs6fGVuZYRfXcU8Y0ZwK7G7FmK5OdJrW0RG9R3TdcTlo5UlkPjtxum89C/YEwi5vP2Z",
+	"UbmLbX0N0t/2wM9G+BC7wApcwBmtOElx8RkXcGFpaGnWk3pgqi5bJ/BaEReQ1pzIl8/KI0aLr/Dyd8A2",
+	"cvVlo5356/yvIBpRcUX+AbrL/gov/9LXkkYuKQXRDrpWPrLhQGcnZSVWiwWuyLu8YFtcCAm4fOe3yBJ4",
+	"Kf75+Bn4nqRgMVaLRcFSXOyYkKv3N39ZLjSYP2dfoY+a1uyzIjb78PBJrVXAhWG6fHfz7gYl6HluNjcj",
+	"NLEQIMWClPlC4Pk2ny/f3z4v39++q0yssgoorghaoR8swQrboF20bznlpvFTOaAbyk+ZEhDkh9YVqdYV",
+	"ty/hbUMDsuhcSTskJ+HbV+YiwAf30Q5J2NeC8e6lt0HJCOOZdr9BO2ebW+LnTwZ8eXMzPAANMzQ1842Z",
+	"2uHNuHXWvWt4tzc3Y3tGD7cY3tU7JOjuAszlZMy7iZj3E6VVlawuS8xf3MC0lUGmhfiC/CNd+Ra+zR9L",
+	"wo+2B/5/Br5qMtgW7XKOsQz9iOPtWHaGKW/HtjO2eTu2nQHRG7JtHfQEuA6PxD2PHwYsJhXg3l2W2Oob",
+	"QltOQ7ubgnY/RchhxXXl0pVb879VaxffSHY4WXA/ZcOSq72tOqh2mULt1ljyGtpeH4TY0av8k719vrMH",
+	"t0lj3R1GvJuGeD9N1I7T/w2SE9iDdvzA7wl6UFUgHQSAuzIVEQifLeQfIx5GbrTFBsYx9OVl6HeXoN9f",
+	"IvywSmgIcz3PIs386USwcvgT19Fg8bfVv48+rflyh2St73ZI1oyJu5Pm1og4uWqXN3LecXRtnlZKe58w",
+	"RK+cIbzlRLy7SXj3k+QMrJ4uin0SmAcmC9rX7sfy4KF1m//7z4TuxDE4aOwOF3vDwU6zaGd7yVvvkWI7",
+	"W6vfL0b0t+NrbfbmfK1DXoXlpAI1/PomtkSNYC4nY95NxLyfKO2wVLUKjStW/pEtV82HHKPVyoL8TxQr",
+	"NzwdjFf1JDW+7nTmrMPUKPHzT0BzuUOr2+S8/e4oQzfivS67aUnY/4QpOgeDiMupiHfTEO+niRrIP586",
+	"Pv3sk072ndxuW6zrb7h/vHuVDVb/y7yR7eiD+zCub53WljRBFRMBqzww8dpmCSXNhYa56pnCNY4G/lNl",
+	"WB7xxPrQnj1q07amjl/Wqhw108Qva2UWAXzv/KAvuJwaEB6SNmB/hne7QIe1l+ybc+dH+32af/DgP/7z",
+	"jz403+w3YG4BbD376D4hbqBM/B3Wh/8OAOx8XtzJQgAA",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
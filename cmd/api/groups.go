@@ -81,7 +81,7 @@ func (s Server) GetGroups(w http.ResponseWriter, r *http.Request, params generat
 		return
 	}
 
-	total, err := mongo.CountDocuments(mongo.CollectionGroups, filter, 0)
+	total, err := mongo.CountDocuments(r.Context(), mongo.CollectionGroups, filter, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
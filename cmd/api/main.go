@@ -12,19 +12,22 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Jleagle/rate-limit-go"
 	codegenMiddleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
 	"github.com/gamedb/gamedb/cmd/api/generated"
 	"github.com/gamedb/gamedb/pkg/api"
 	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/health"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	influxHelpers "github.com/gamedb/gamedb/pkg/influx"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/metrics"
 	"github.com/gamedb/gamedb/pkg/middleware"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/ratelimit"
 	"github.com/gamedb/gamedb/pkg/session"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
@@ -39,6 +42,8 @@ const (
 
 	ctxUserIDField    contextKey = "user_id"
 	ctxUserLevelField contextKey = "user_level"
+	ctxTeamIDField    contextKey = "team_id"
+	ctxTeamKeyField   contextKey = "team_key"
 )
 
 type Server struct {
@@ -54,15 +59,22 @@ func main() {
 		return
 	}
 
+	closeTracing := tracing.Init(log.LogNameAPI)
+	closeMetrics := metrics.Serve()
+
 	session.Init()
 
 	r := chi.NewRouter()
 	// r.Use(fixRequestURLMiddleware)
+	r.Use(tracing.Middleware)
+	r.Use(metrics.Middleware)
 	r.Use(chiMiddleware.Compress(flate.DefaultCompression))
 	r.Use(middleware.RealIP)
 
 	r.Get("/", rootHandler)
 	r.Get("/health-check", healthCheckHandler)
+	r.Get("/health-check/live", health.LivenessHandler)
+	r.Get("/health-check/ready", health.ReadinessHandler(health.ReadyChecks...))
 
 	generated.HandlerWithOptions(Server{}, generated.ChiServerOptions{
 		BaseRouter: r,
@@ -73,6 +85,13 @@ func main() {
 		},
 	})
 
+	streams, err := streamsRouter()
+	if err != nil {
+		log.ErrS(err)
+	} else {
+		r.Mount("/stream", streams)
+	}
+
 	r.NotFound(notFoundHandler)
 
 	s := &http.Server{
@@ -92,6 +111,8 @@ func main() {
 	}()
 
 	helpers.KeepAlive(
+		closeTracing,
+		closeMetrics,
 		mysql.Close,
 		mongo.Close,
 		memcache.Close,
@@ -135,7 +156,58 @@ func validateMiddlewear(next http.HandlerFunc) http.HandlerFunc {
 
 var apiKeyRegexp = regexp.MustCompile("^[A-Z0-9]{20}$")
 
+// Swapped out in tests
+var lookupAPIKey = func(key string) (user mysql.User, level mysql.UserLevel, access mysql.TeamAccess, err error) {
+
+	user, err = mysql.GetUserByAPIKey(key)
+	if err == mysql.ErrRecordNotFound {
+		return lookupTeamKey(key)
+	} else if err != nil {
+		return user, level, access, err
+	}
+
+	// Team members get the team's level
+	level, access, err = mysql.GetEffectiveUserLevel(user)
+	return user, level, access, err
+}
+
+// Shared keys act as the team's owner, who pays for the quota
+func lookupTeamKey(key string) (user mysql.User, level mysql.UserLevel, access mysql.TeamAccess, err error) {
+
+	teamKey, err := mysql.GetTeamKeyByKey(key)
+	if err != nil {
+		return user, level, access, err
+	}
+
+	team, err := mysql.GetTeam(teamKey.TeamID)
+	if err != nil {
+		return user, level, access, err
+	}
+
+	user, err = mysql.GetUserByID(team.OwnerID)
+	if err != nil {
+		return user, level, access, err
+	}
+
+	access = mysql.TeamAccess{TeamID: team.ID, Level: user.Level, KeyName: teamKey.Name}
+	return user, user.Level, access, nil
+}
+
+// Endpoints in the OpenAPI spec are public if they have the public tag
+func isPublicRoute(r *http.Request) (bool, error) {
+
+	route, _, err := api.GetRouter().FindRoute(r)
+	if err != nil {
+		return false, err
+	}
+	return helpers.SliceHasString(api.TagPublic, route.Operation.Tags), nil
+}
+
 func authMiddlewear(next http.HandlerFunc) http.HandlerFunc {
+	return apiKeyMiddlewear(isPublicRoute, next)
+}
+
+func apiKeyMiddlewear(isPublic func(r *http.Request) (bool, error), next http.HandlerFunc) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 
@@ -174,7 +246,7 @@ func authMiddlewear(next http.HandlerFunc) http.HandlerFunc {
 		}
 
 		// Check user has access to api
-		user, err := mysql.GetUserByAPIKey(key)
+		user, level, team, err := lookupAPIKey(key)
 		if err == mysql.ErrRecordNotFound {
 			returnResponse(w, r, http.StatusUnauthorized, generated.MessageResponse{Error: "invalid api key: " + key})
 			return
@@ -184,28 +256,32 @@ func authMiddlewear(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		route, _, err := api.GetRouter().FindRoute(r)
+		public, err := isPublic(r)
 		if err != nil {
 			log.Err("missing route", zap.Error(err), zap.String("method", r.Method), zap.String("url", r.URL.String()))
 			notFoundHandler(w, r)
 			return
 		}
-		if user.Level < mysql.UserLevel2 && !helpers.SliceHasString(api.TagPublic, route.Operation.Tags) {
+		if level < mysql.UserLevel2 && !public {
 			returnResponse(w, r, http.StatusUnauthorized, generated.MessageResponse{Error: "Invalid user level"})
 			return
 		}
 
 		// Save user info to context
 		r = r.WithContext(context.WithValue(r.Context(), ctxUserIDField, user.ID))
-		r = r.WithContext(context.WithValue(r.Context(), ctxUserLevelField, user.Level))
+		r = r.WithContext(context.WithValue(r.Context(), ctxUserLevelField, level))
+		r = r.WithContext(context.WithValue(r.Context(), ctxTeamIDField, team.TeamID))
+		r = r.WithContext(context.WithValue(r.Context(), ctxTeamKeyField, team.KeyName))
 
 		next.ServeHTTP(w, r)
 	}
 }
 
 var (
-	donatorLimiter = rate.New(time.Second*1, rate.WithBurst(10))
-	publicLimiter  = rate.New(time.Second*5, rate.WithBurst(1))
+	rateLimitStore = ratelimit.NewSharedStore()
+	donatorLimiter = ratelimit.New(ratelimit.Bucket{Name: "api-donator", Every: time.Second * 1, Burst: 10}, rateLimitStore)
+	publicLimiter  = ratelimit.New(ratelimit.Bucket{Name: "api-public", Every: time.Second * 5, Burst: 1}, rateLimitStore)
+	limiterGroups  = middleware.NewRouteGroups("games", "players", "stream")
 )
 
 func rateLimitMiddlewear(next http.HandlerFunc) http.HandlerFunc {
@@ -214,18 +290,24 @@ func rateLimitMiddlewear(next http.HandlerFunc) http.HandlerFunc {
 
 		level, _ := r.Context().Value(ctxUserLevelField).(mysql.UserLevel)
 
-		var limiters *rate.Limiters
+		var limiter *ratelimit.Limiter
 		if level > mysql.UserLevelFree {
-			limiters = donatorLimiter
+			limiter = donatorLimiter
 		} else {
-			limiters = publicLimiter
+			limiter = publicLimiter
+		}
+
+		// A team shares one quota
+		var limiterKey = r.RemoteAddr
+		if teamID, _ := r.Context().Value(ctxTeamIDField).(int); teamID > 0 {
+			limiterKey = "team-" + strconv.Itoa(teamID)
 		}
 
-		reservation := limiters.GetLimiter(r.RemoteAddr).Reserve()
+		res := limiter.Take(limiterGroups.Group(r), limiterKey)
 
-		middleware.SetRateLimitHeaders(w, limiters, reservation)
+		middleware.SetRateLimitHeaders(w, limiter, res)
 
-		if !reservation.OK() {
+		if !res.Allowed {
 			rateLimitedHandler(w, r)
 			return
 		}
@@ -264,6 +346,24 @@ func returnResponse(w http.ResponseWriter, r *http.Request, code int, i interfac
 		log.Err("encoding response", zap.Error(err))
 	}
 
+	// Team audit log
+	if teamID, _ := r.Context().Value(ctxTeamIDField).(int); teamID > 0 {
+
+		userID, _ := r.Context().Value(ctxUserIDField).(int)
+		details := r.Method + " " + r.URL.Path + " " + strconv.Itoa(code)
+		if keyName, _ := r.Context().Value(ctxTeamKeyField).(string); keyName != "" {
+			details += ", shared key " + keyName
+		}
+		req := r.Clone(context.Background()) // The request is finished with by the time this runs
+
+		go func() {
+			err := mongo.NewTeamEvent(req, userID, teamID, mongo.EventAPICall, details)
+			if err != nil {
+				log.ErrS(err)
+			}
+		}()
+	}
+
 	if config.IsProd() {
 		go func() {
 
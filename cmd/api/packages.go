@@ -89,6 +89,7 @@ func (s Server) GetPackages(w http.ResponseWriter, r *http.Request, params gener
 		"name":               1,
 		"platforms":          1,
 		"prices":             1,
+		"price_stats":        1,
 		"release_date":       1,
 		"release_date_unix":  1,
 		"status":             1,
@@ -101,7 +102,7 @@ func (s Server) GetPackages(w http.ResponseWriter, r *http.Request, params gener
 		return
 	}
 
-	total, err := mongo.CountDocuments(mongo.CollectionPackages, filter, 0)
+	total, err := mongo.CountDocuments(r.Context(), mongo.CollectionPackages, filter, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -130,6 +131,9 @@ func (s Server) GetPackages(w http.ResponseWriter, r *http.Request, params gener
 			Prices: generated.PackageSchema_Prices{
 				AdditionalProperties: map[string]generated.ProductPriceSchema{},
 			},
+			PriceStats: generated.PackageSchema_PriceStats{
+				AdditionalProperties: map[string]generated.ProductPriceStatsSchema{},
+			},
 			ReleaseDate:     pack.ReleaseDate,
 			ReleaseDateUnix: pack.ReleaseDateUnix,
 			Status:          pack.GetStatus(),
@@ -146,6 +150,17 @@ func (s Server) GetPackages(w http.ResponseWriter, r *http.Request, params gener
 			}
 		}
 
+		for k, stat := range pack.PriceStats {
+			newPackage.PriceStats.AdditionalProperties[string(k)] = generated.ProductPriceStatsSchema{
+				Currency:       string(stat.Currency),
+				LowestEver:     int32(stat.LowestEver),
+				LowestEverAt:   stat.LowestEverAt.Unix(),
+				Lowest12Months: int32(stat.Lowest12Months),
+				TypicalSale:    int32(stat.TypicalSale),
+				Sales:          int32(stat.Sales),
+			}
+		}
+
 		result.Packages = append(result.Packages, newPackage)
 	}
 
@@ -19,11 +19,11 @@ func (s Server) GetPlayersId(w http.ResponseWriter, r *http.Request, id int64) {
 		return
 	}
 
-	player, err := mongo.GetPlayer(id)
+	player, err := mongo.GetPlayer(r.Context(), id)
 	if err == mongo.ErrNoDocuments {
 
 		ua := r.UserAgent()
-		err = consumers.ProducePlayer(consumers.PlayerMessage{ID: id, UserAgent: &ua, ForceAchievementsRefresh: true}, "api-retrieve")
+		err = consumers.ProducePlayer(r.Context(), consumers.PlayerMessage{ID: id, UserAgent: &ua, ForceAchievementsRefresh: true}, "api-retrieve")
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -37,8 +37,15 @@ func (s Server) GetPlayersId(w http.ResponseWriter, r *http.Request, id int64) {
 		returnResponse(w, r, http.StatusInternalServerError, generated.PlayerResponse{Error: err.Error()})
 		return
 
+	} else if player.Private {
+
+		returnResponse(w, r, http.StatusNotFound, generated.PlayerResponse{Error: "player not found"})
+		return
+
 	} else {
 
+		player.ApplyPrivacy()
+
 		playerSchema := generated.PlayerSchema{}
 		playerSchema.Id = strconv.FormatInt(player.ID, 10)
 		playerSchema.Name = player.GetName()
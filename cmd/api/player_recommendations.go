@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gamedb/gamedb/cmd/api/generated"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+)
+
+func (s Server) GetPlayersIdRecommendations(w http.ResponseWriter, r *http.Request, id int64) {
+
+	id, err := helpers.IsValidPlayerID(id)
+	if err != nil {
+		returnResponse(w, r, http.StatusBadRequest, generated.RecommendedGamesResponse{Error: err.Error()})
+		return
+	}
+
+	player, err := mongo.GetPlayer(r.Context(), id)
+	if err == mongo.ErrNoDocuments {
+		returnResponse(w, r, http.StatusNotFound, generated.RecommendedGamesResponse{Error: "player not found"})
+		return
+	} else if err != nil {
+		log.ErrS(err)
+		returnResponse(w, r, http.StatusInternalServerError, generated.RecommendedGamesResponse{Error: err.Error()})
+		return
+	}
+
+	if player.Private {
+		returnResponse(w, r, http.StatusNotFound, generated.RecommendedGamesResponse{Error: "player not found"})
+		return
+	}
+
+	recs, err := mongo.GetPlayerRecommendations(player.ID, 0)
+	if err != nil {
+		log.ErrS(err)
+		returnResponse(w, r, http.StatusInternalServerError, generated.RecommendedGamesResponse{Error: err.Error()})
+		return
+	}
+
+	result := generated.RecommendedGamesResponse{}
+
+	for _, rec := range recs {
+
+		result.Games = append(result.Games, generated.RecommendedGameSchema{
+			AppId:      rec.AppID,
+			Name:       rec.GetName(),
+			Score:      rec.Score,
+			Tags:       rec.Tags,
+			Friends:    rec.Friends,
+			SameOwners: rec.SameOwners,
+		})
+	}
+
+	returnResponse(w, r, http.StatusOK, result)
+}
@@ -52,7 +52,15 @@ func (s Server) GetPlayers(w http.ResponseWriter, r *http.Request, params genera
 		}
 	}
 
-	filter := bson.D{}
+	// This is a ranking, so leave out players who opted out
+	filter := bson.D{
+		{Key: "private", Value: bson.M{"$ne": true}},
+		{Key: "privacy.hide_ranks", Value: bson.M{"$ne": true}},
+	}
+
+	if sort == "play_time" {
+		filter = append(filter, bson.E{Key: "privacy.hide_playtime", Value: bson.M{"$ne": true}})
+	}
 
 	if params.Continent != nil {
 		filter = append(filter, bson.E{Key: "continent_code", Value: *params.Continent})
@@ -62,7 +70,7 @@ func (s Server) GetPlayers(w http.ResponseWriter, r *http.Request, params genera
 		filter = append(filter, bson.E{Key: "country_code", Value: *params.Country})
 	}
 
-	players, err := mongo.GetPlayers(offset, limit, bson.D{{Key: sort, Value: order}}, filter, bson.M{
+	players, err := mongo.GetPlayers(r.Context(), offset, limit, bson.D{{Key: sort, Value: order}}, filter, bson.M{
 		"_id":            1,
 		"persona_name":   1,
 		"avatar":         1,
@@ -74,6 +82,7 @@ func (s Server) GetPlayers(w http.ResponseWriter, r *http.Request, params genera
 		"groups_count":   1,
 		"level":          1,
 		"play_time":      1,
+		"privacy":        1,
 	})
 	if err != nil {
 		log.ErrS(err)
@@ -81,7 +90,7 @@ func (s Server) GetPlayers(w http.ResponseWriter, r *http.Request, params genera
 		return
 	}
 
-	total, err := mongo.CountDocuments(mongo.CollectionPlayers, filter, 0)
+	total, err := mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, filter, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -91,6 +100,8 @@ func (s Server) GetPlayers(w http.ResponseWriter, r *http.Request, params genera
 
 	for _, player := range players {
 
+		player.ApplyPrivacy()
+
 		result.Players = append(result.Players, generated.PlayerSchema{
 			Id:     strconv.FormatInt(player.ID, 10),
 			Name:   player.PersonaName,
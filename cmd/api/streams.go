@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gamedb/gamedb/pkg/backend"
+	generatedBackend "github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/go-chi/chi/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Exposes the backend Watch RPCs as Server-Sent Events
+func streamsRouter() (http.Handler, error) {
+
+	conn, ctx, err := backend.GetClient(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &sseMarshaler{}))
+
+	err = generatedBackend.RegisterChangesServiceHandler(ctx, mux, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = generatedBackend.RegisterAppsServiceHandler(ctx, mux, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = generatedBackend.RegisterPricesServiceHandler(ctx, mux, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamRoutes(mux), nil
+}
+
+func streamRoutes(mux *runtime.ServeMux) http.Handler {
+
+	r := chi.NewRouter()
+
+	r.Get("/changes", streamHandler(mux, "/generated.ChangesService/WatchChanges", func(q url.Values) proto.Message {
+		return &generatedBackend.WatchChangesRequest{}
+	}))
+
+	r.Get("/apps/players", streamHandler(mux, "/generated.AppsService/WatchAppPlayers", func(q url.Values) proto.Message {
+
+		message := &generatedBackend.WatchAppPlayersRequest{}
+		for _, v := range strings.Split(q.Get("ids"), ",") {
+			i, err := strconv.Atoi(strings.TrimSpace(v))
+			if err == nil && i > 0 {
+				message.Ids = append(message.Ids, int32(i))
+			}
+		}
+		return message
+	}))
+
+	r.Get("/price-changes", streamHandler(mux, "/generated.PricesService/WatchPriceChanges", func(q url.Values) proto.Message {
+		return &generatedBackend.WatchPriceChangesRequest{
+			ProdCC: q.Get("cc"),
+			Type:   q.Get("type"),
+		}
+	}))
+
+	return r
+}
+
+// EventSource clients can only make GET requests, so the query string is turned into the RPC's request body
+func streamHandler(mux *runtime.ServeMux, path string, request func(q url.Values) proto.Message) http.HandlerFunc {
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+
+		b, err := protojson.Marshal(request(r.URL.Query()))
+		if err != nil {
+			returnResponse(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		r2 := r.Clone(r.Context())
+		r2.Method = http.MethodPost
+		r2.URL.Path = path
+		r2.Body = io.NopCloser(bytes.NewReader(b))
+
+		w.Header().Set("Cache-Control", "no-cache")
+
+		mux.ServeHTTP(w, r2)
+	}
+
+	return apiKeyMiddlewear(isPublicStream, rateLimitMiddlewear(handler))
+}
+
+// Streams are not in the OpenAPI spec, so can't be looked up by authMiddlewear, none are public
+func isPublicStream(r *http.Request) (bool, error) {
+	return false, nil
+}
+
+// Writes each streamed message as an SSE event
+type sseMarshaler struct {
+	runtime.JSONPb
+}
+
+func (m *sseMarshaler) Marshal(v interface{}) ([]byte, error) {
+
+	b, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte("data: "), b...), nil
+}
+
+func (m *sseMarshaler) ContentType(_ interface{}) string {
+	return "text/event-stream"
+}
+
+func (m *sseMarshaler) Delimiter() []byte {
+	return []byte("\n\n")
+}
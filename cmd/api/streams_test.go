@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/ratelimit"
+	"github.com/go-chi/chi/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestStreamAuth(t *testing.T) {
+
+	donatorLimiter = ratelimit.New(ratelimit.Bucket{Name: "api-donator", Every: time.Second, Burst: 10}, ratelimit.NewMemoryStore())
+	publicLimiter = ratelimit.New(ratelimit.Bucket{Name: "api-public", Every: time.Second, Burst: 10}, ratelimit.NewMemoryStore())
+
+	lookupAPIKey = func(key string) (user mysql.User, level mysql.UserLevel, access mysql.TeamAccess, err error) {
+
+		switch key {
+		case "DONATOR0000000000000":
+			return mysql.User{ID: 2}, mysql.UserLevel2, access, nil
+		case "FREE0000000000000000":
+			return mysql.User{ID: 3}, mysql.UserLevelFree, access, nil
+		default:
+			return user, level, access, mysql.ErrRecordNotFound
+		}
+	}
+
+	mux := runtime.NewServeMux()
+	err := mux.HandlePath(http.MethodPost, "/generated.ChangesService/WatchChanges", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, _ = w.Write([]byte("data: {}\n\n"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := chi.NewRouter()
+	router.Mount("/stream", streamRoutes(mux))
+
+	tests := map[string]int{
+		"":                     http.StatusUnauthorized,
+		"MISSING0000000000000": http.StatusUnauthorized,
+		"FREE0000000000000000": http.StatusUnauthorized,
+		"DONATOR0000000000000": http.StatusOK,
+	}
+
+	for key, code := range tests {
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream/changes?key="+key, nil))
+
+		if w.Code != code {
+			t.Errorf("key %q: got %d, want %d: %s", key, w.Code, code, w.Body.String())
+		}
+
+		if code == http.StatusOK {
+			if !strings.HasPrefix(w.Body.String(), "data: ") {
+				t.Errorf("key %q: got body %q", key, w.Body.String())
+			}
+			if w.Header().Get("X-RateLimit-Bucket") != "api-donator.stream" {
+				t.Errorf("key %q: unexpected rate limit bucket %q", key, w.Header().Get("X-RateLimit-Bucket"))
+			}
+		}
+	}
+}
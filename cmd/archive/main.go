@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/archive"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+)
+
+const usage = `Usage: archive [flags] <command>
+
+Commands:
+  stores   List the stores that get archived
+  run      Archive any days that are older than each store's retention
+  restore  Load archived days back in, needs -store, -from and -to
+
+Flags:
+`
+
+func main() {
+
+	err := config.Init(helpers.GetIP())
+	log.InitZap(log.LogNameArchive)
+	defer log.Flush()
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
+
+	defer mongo.Close()
+
+	days := flag.Int("days", 30, "Maximum number of days to archive per store with run")
+	store := flag.String("store", "", "Store to restore")
+	from := flag.String("from", "", "First day to restore, YYYY-MM-DD")
+	to := flag.String("to", "", "Last day to restore, YYYY-MM-DD, defaults to -from")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "stores":
+
+		for _, v := range archive.Stores {
+			fmt.Printf("%-30s %s\n", v.Name(), v.Retention())
+		}
+
+	case "run":
+
+		err = archive.Run(*days)
+		if err != nil {
+			log.ErrS(err)
+		}
+
+	case "restore":
+
+		if *to == "" {
+			*to = *from
+		}
+
+		fromTime, err := time.Parse(helpers.DateSQLDay, *from)
+		if err != nil {
+			log.ErrS(err)
+			return
+		}
+
+		toTime, err := time.Parse(helpers.DateSQLDay, *to)
+		if err != nil {
+			log.ErrS(err)
+			return
+		}
+
+		count, err := archive.Restore(*store, fromTime, toTime)
+		fmt.Println("Restored", count)
+		if err != nil {
+			log.ErrS(err)
+		}
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gamedb/gamedb/cmd/backend/helpers"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/gamedb/gamedb/pkg/elasticsearch"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/olivere/elastic/v7"
+)
+
+type AchievementsServer struct {
+	generated.UnimplementedAchievementsServiceServer
+}
+
+func (s AchievementsServer) Search(ctx context.Context, request *generated.SearchAchievementsRequest) (response *generated.AchievementsResponse, err error) {
+
+	sorters := helpers.MakeElasticSorters(request.GetPagination())
+
+	// Break ties on completion with the most popular games
+	if request.GetPagination().GetSortField() == "completed" {
+		sorters = append(sorters, elastic.NewFieldSort("app_owners").Desc())
+	}
+
+	achievements, filtered, err := elasticsearch.SearchAppAchievements(int(request.GetPagination().GetOffset()), request.GetSearch(), sorters)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := mongo.CountDocuments(ctx, mongo.CollectionAppAchievements, nil, 60*60*24)
+	if err != nil {
+		return nil, err
+	}
+
+	response = &generated.AchievementsResponse{}
+	response.Pagination = helpers.MakePaginationResponse(request.GetPagination(), total, filtered)
+
+	for _, achievement := range achievements {
+
+		response.Achievements = append(response.Achievements, &generated.AchievementResponse{
+			Id:          achievement.ID,
+			Name:        achievement.Name,
+			NameMarked:  achievement.NameMarked,
+			Icon:        achievement.Icon,
+			Description: achievement.Description,
+			Hidden:      achievement.Hidden,
+			Completed:   achievement.Completed,
+			AppId:       int32(achievement.AppID),
+			AppName:     achievement.AppName,
+			AppOwners:   achievement.AppOwners,
+			Score:       achievement.Score,
+		})
+	}
+
+	return response, nil
+}
@@ -7,10 +7,12 @@ import (
 
 	backendHelpers "github.com/gamedb/gamedb/cmd/backend/helpers"
 	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/streams"
 	"github.com/olivere/elastic/v7"
 	"go.mongodb.org/mongo-driver/bson"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -20,7 +22,7 @@ type AppsServer struct {
 	generated.UnimplementedAppsServiceServer
 }
 
-func (a AppsServer) List(_ context.Context, request *generated.ListAppsRequest) (response *generated.AppsMongoResponse, err error) {
+func (a AppsServer) List(ctx context.Context, request *generated.ListAppsRequest) (response *generated.AppsMongoResponse, err error) {
 
 	filter := bson.D{}
 
@@ -61,6 +63,7 @@ func (a AppsServer) List(_ context.Context, request *generated.ListAppsRequest)
 		"developers":          1,
 		"categories":          1,
 		"prices":              1,
+		"price_stats":         1,
 		"player_peak_alltime": 1,
 		"player_peak_week":    1,
 		"release_date":        1,
@@ -71,17 +74,17 @@ func (a AppsServer) List(_ context.Context, request *generated.ListAppsRequest)
 		// "player_avg_week":     1,
 	}
 
-	apps, err := mongo.GetApps(request.GetPagination().GetOffset(), request.GetPagination().GetLimit(), bson.D{{Key: "_id", Value: 1}}, filter, projection)
+	apps, err := mongo.GetApps(ctx, request.GetPagination().GetOffset(), request.GetPagination().GetLimit(), bson.D{{Key: "_id", Value: 1}}, filter, projection)
 	if err != nil {
 		return nil, err
 	}
 
-	total, err := mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+	total, err := mongo.CountDocuments(ctx, mongo.CollectionApps, nil, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	filtered, err := mongo.CountDocuments(mongo.CollectionApps, filter, 0)
+	filtered, err := mongo.CountDocuments(ctx, mongo.CollectionApps, filter, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +114,8 @@ func (a AppsServer) List(_ context.Context, request *generated.ListAppsRequest)
 			// PlayersWeekAvg:  app.avg,
 
 			// Fix nulls in JSON
-			Prices: map[string]*generated.Price{},
+			Prices:     map[string]*generated.Price{},
+			PriceStats: map[string]*generated.PriceStat{},
 		}
 
 		for k, price := range app.Prices {
@@ -126,13 +130,24 @@ func (a AppsServer) List(_ context.Context, request *generated.ListAppsRequest)
 			}
 		}
 
+		for k, stat := range app.PriceStats {
+			newApp.PriceStats[string(k)] = &generated.PriceStat{
+				Currency:       string(stat.Currency),
+				LowestEver:     int32(stat.LowestEver),
+				LowestEverAt:   timestamppb.New(stat.LowestEverAt),
+				Lowest12Months: int32(stat.Lowest12Months),
+				TypicalSale:    int32(stat.TypicalSale),
+				Sales:          int32(stat.Sales),
+			}
+		}
+
 		response.Apps = append(response.Apps, newApp)
 	}
 
 	return response, err
 }
 
-func (a AppsServer) Search(_ context.Context, request *generated.SearchAppsRequest) (response *generated.AppsElasticResponse, err error) {
+func (a AppsServer) Search(ctx context.Context, request *generated.SearchAppsRequest) (response *generated.AppsElasticResponse, err error) {
 
 	var filters []elastic.Query
 
@@ -235,7 +250,7 @@ func (a AppsServer) Search(_ context.Context, request *generated.SearchAppsReque
 		defer wg.Done()
 
 		var err error
-		apps, filtered, err = elasticsearch.SearchAppsAdvanced(int(request.GetPagination().GetOffset()), 100, request.GetSearch(), nil, elastic.NewBoolQuery().Filter(filters...))
+		apps, filtered, err = elasticsearch.SearchAppsAdvanced(ctx, int(request.GetPagination().GetOffset()), 100, request.GetSearch(), nil, elastic.NewBoolQuery().Filter(filters...))
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -249,7 +264,7 @@ func (a AppsServer) Search(_ context.Context, request *generated.SearchAppsReque
 		defer wg.Done()
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+		total, err = mongo.CountDocuments(ctx, mongo.CollectionApps, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -293,3 +308,75 @@ func (a AppsServer) Search(_ context.Context, request *generated.SearchAppsReque
 
 	return response, err
 }
+
+func (a AppsServer) Similar(ctx context.Context, request *generated.ListSimilarAppsRequest) (response *generated.SimilarAppsResponse, err error) {
+
+	limit := int(request.GetLimit())
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	similar, err := mongo.GetAppSimilar(ctx, int(request.GetAppId()), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response = &generated.SimilarAppsResponse{}
+
+	for k, v := range similar {
+
+		response.Apps = append(response.Apps, &generated.SimilarAppResponse{
+			AppId:  int32(v.SimilarAppID),
+			Count:  int32(v.SameOwners),
+			Order:  int32(k + 1),
+			Owners: int32(v.Owners),
+			Score:  float32(v.Score),
+		})
+	}
+
+	return response, nil
+}
+
+func (a AppsServer) WatchAppPlayers(request *generated.WatchAppPlayersRequest, stream generated.AppsService_WatchAppPlayersServer) error {
+
+	var ids = map[int]bool{}
+	for _, v := range request.GetIds() {
+		ids[int(v)] = true
+	}
+
+	messages, unsubscribe := streams.GetTopic(streams.TopicAppPlayers).Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case message := <-messages:
+
+			var payloads []consumers.AppPlayersStreamPayload
+
+			err := helpers.Unmarshal(message, &payloads)
+			if err != nil {
+				log.ErrS(err)
+				continue
+			}
+
+			for _, v := range payloads {
+
+				if len(ids) > 0 && !ids[v.AppID] {
+					continue
+				}
+
+				err = stream.Send(&generated.AppPlayersResponse{
+					Id:            int32(v.AppID),
+					Players:       int32(v.Players),
+					TwitchViewers: int32(v.TwitchViewers),
+					CreatedAt:     timestamppb.New(v.CreatedAt),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
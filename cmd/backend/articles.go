@@ -14,7 +14,7 @@ type ArticlesServer struct {
 	generated.UnimplementedArticlesServiceServer
 }
 
-func (as ArticlesServer) List(_ context.Context, request *generated.ListArticlesRequest) (response *generated.ArticlesResponse, err error) {
+func (as ArticlesServer) List(ctx context.Context, request *generated.ListArticlesRequest) (response *generated.ArticlesResponse, err error) {
 
 	sort := helpers.MakeMongoOrder(request.GetPagination())
 	// projection := helpers.MakeMongoProjection(request.GetProjection())
@@ -38,12 +38,12 @@ func (as ArticlesServer) List(_ context.Context, request *generated.ListArticles
 		return nil, err
 	}
 
-	total, err := mongo.CountDocuments(mongo.CollectionGroups, nil, 0)
+	total, err := mongo.CountDocuments(ctx, mongo.CollectionGroups, nil, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	filtered, err := mongo.CountDocuments(mongo.CollectionGroups, filter, 0)
+	filtered, err := mongo.CountDocuments(ctx, mongo.CollectionGroups, filter, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gamedb/gamedb/cmd/backend/helpers"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/gamedb/gamedb/pkg/mongo"
+)
+
+type BadgesServer struct {
+	generated.UnimplementedBadgesServiceServer
+}
+
+// Badge summaries are a small collection, so they are always returned in full
+func (s BadgesServer) List(ctx context.Context, request *generated.ListBadgesRequest) (response *generated.BadgesResponse, err error) {
+
+	badges, err := mongo.GetBadgeSummaries()
+	if err != nil {
+		return nil, err
+	}
+
+	response = &generated.BadgesResponse{}
+	response.Pagination = helpers.MakePaginationResponse(request.GetPagination(), int64(len(badges)), int64(len(badges)))
+
+	for _, badge := range badges {
+
+		response.Badges = append(response.Badges, &generated.BadgeResponse{
+			AppId:        int32(badge.AppID),
+			BadgeId:      int32(badge.BadgeID),
+			AppName:      badge.AppName,
+			AppIcon:      badge.AppIcon,
+			Players:      badge.PlayersCount,
+			MaxLevel:     int32(badge.MaxLevel),
+			MaxLevelFoil: int32(badge.MaxLevelFoil),
+			Leaders:      badge.Leaders,
+			LeadersFoil:  badge.LeadersFoil,
+			UpdatedAt:    badge.UpdatedAt,
+		})
+	}
+
+	return response, nil
+}
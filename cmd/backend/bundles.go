@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gamedb/gamedb/cmd/backend/helpers"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/gamedb/gamedb/pkg/elasticsearch"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/olivere/elastic/v7"
+)
+
+type BundlesServer struct {
+	generated.UnimplementedBundlesServiceServer
+}
+
+func (s BundlesServer) List(ctx context.Context, request *generated.ListBundlesRequest) (response *generated.BundlesResponse, err error) {
+
+	var filters []elastic.Query
+
+	switch request.GetType() {
+	case "cts", "pt":
+		filters = append(filters, elastic.NewTermQuery("type", request.GetType()))
+	}
+
+	if request.GetGiftable() {
+		filters = append(filters, elastic.NewTermQuery("giftable", true))
+	}
+
+	if request.GetOnSale() {
+		filters = append(filters, elastic.NewTermQuery("on_sale", true))
+	}
+
+	if request.GetDiscountMin() != nil {
+		filters = append(filters, elastic.NewRangeQuery("discount_sale").Gte(request.GetDiscountMin().GetValue()))
+	}
+
+	if request.GetDiscountMax() != nil {
+		filters = append(filters, elastic.NewRangeQuery("discount_sale").Lte(request.GetDiscountMax().GetValue()))
+	}
+
+	if request.GetAppsMin() != nil {
+		filters = append(filters, elastic.NewRangeQuery("apps").Gte(request.GetAppsMin().GetValue()))
+	}
+
+	if request.GetAppsMax() != nil {
+		filters = append(filters, elastic.NewRangeQuery("apps").Lte(request.GetAppsMax().GetValue()))
+	}
+
+	offset := int(request.GetPagination().GetOffset())
+	limit := int(request.GetPagination().GetLimit())
+	sorters := helpers.MakeElasticSorters(request.GetPagination())
+
+	bundles, filtered, err := elasticsearch.SearchBundles(offset, limit, request.GetSearch(), sorters, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := mongo.CountDocuments(ctx, mongo.CollectionBundles, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	response = &generated.BundlesResponse{}
+	response.Pagination = helpers.MakePaginationResponse(request.GetPagination(), total, filtered)
+
+	for _, bundle := range bundles {
+
+		b := &generated.BundleResponse{
+			Id:              int32(bundle.ID),
+			Name:            bundle.Name,
+			NameMarked:      bundle.NameMarked,
+			Apps:            int32(bundle.Apps),
+			CreatedAt:       bundle.CreatedAt,
+			Discount:        int32(bundle.Discount),
+			DiscountHighest: int32(bundle.DiscountHighest),
+			DiscountLowest:  int32(bundle.DiscountLowest),
+			DiscountSale:    int32(bundle.DiscountSale),
+			Giftable:        bundle.Giftable,
+			Icon:            bundle.Icon,
+			Image:           bundle.Image,
+			OnSale:          bundle.OnSale,
+			Packages:        int32(bundle.Packages),
+			Prices:          map[string]int32{},
+			PricesSale:      map[string]int32{},
+			Type:            bundle.Type,
+			UpdatedAt:       bundle.UpdatedAt,
+			Score:           float32(bundle.Score),
+		}
+
+		for k, v := range bundle.Prices {
+			b.Prices[string(k)] = int32(v)
+		}
+
+		for k, v := range bundle.PricesSale {
+			b.PricesSale[string(k)] = int32(v)
+		}
+
+		response.Bundles = append(response.Bundles, b)
+	}
+
+	return response, nil
+}
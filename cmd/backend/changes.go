@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	backendHelpers "github.com/gamedb/gamedb/cmd/backend/helpers"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/gamedb/gamedb/pkg/consumers"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/streams"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ChangesServer struct {
+	generated.UnimplementedChangesServiceServer
+}
+
+func (s ChangesServer) List(ctx context.Context, request *generated.ListChangesRequest) (response *generated.ChangesResponse, err error) {
+
+	changes, err := mongo.GetChanges(request.GetPagination().GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	var appIDs []int
+	var packageIDs []int
+	for _, v := range changes {
+		appIDs = append(appIDs, v.Apps...)
+		packageIDs = append(packageIDs, v.Packages...)
+	}
+
+	var wg sync.WaitGroup
+	var appErr, packageErr, countErr error
+
+	// App names
+	var appMap = map[int]string{}
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		apps, err := mongo.GetAppsByID(ctx, appIDs, bson.M{"_id": 1, "name": 1})
+		if err != nil {
+			appErr = err
+			return
+		}
+
+		for _, app := range apps {
+			appMap[app.ID] = app.GetName()
+		}
+	}()
+
+	// Package names
+	var packageMap = map[int]string{}
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		packages, err := mongo.GetPackagesByID(ctx, packageIDs, bson.M{"_id": 1, "name": 1})
+		if err != nil {
+			packageErr = err
+			return
+		}
+
+		for _, pack := range packages {
+			packageMap[pack.ID] = pack.GetName()
+		}
+	}()
+
+	// Count
+	var total int64
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		total, countErr = mongo.CountDocuments(ctx, mongo.CollectionChanges, nil, 0)
+	}()
+
+	wg.Wait()
+
+	for _, err := range []error{appErr, packageErr, countErr} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response = &generated.ChangesResponse{}
+	response.Pagination = backendHelpers.MakePaginationResponse(request.GetPagination(), total, total)
+
+	for _, change := range changes {
+		response.Changes = append(response.Changes, makeChangeResponse(change, appMap, packageMap))
+	}
+
+	return response, nil
+}
+
+func (s ChangesServer) WatchChanges(_ *generated.WatchChangesRequest, stream generated.ChangesService_WatchChangesServer) error {
+
+	messages, unsubscribe := streams.GetTopic(streams.TopicChanges).Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case message := <-messages:
+
+			payload := consumers.ChangesStreamPayload{}
+
+			err := helpers.Unmarshal(message, &payload)
+			if err != nil {
+				log.ErrS(err)
+				continue
+			}
+
+			for _, change := range payload.Changes {
+
+				err = stream.Send(makeChangeResponse(*change, payload.Apps, payload.Packages))
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func makeChangeResponse(change mongo.Change, appMap map[int]string, packageMap map[int]string) *generated.ChangeResponse {
+
+	c := &generated.ChangeResponse{
+		Id:        int32(change.ID),
+		CreatedAt: timestamppb.New(change.CreatedAt),
+	}
+
+	for _, id := range change.Apps {
+		c.Apps = append(c.Apps, &generated.ChangeItemResponse{Id: int32(id), Name: appMap[id]})
+	}
+
+	for _, id := range change.Packages {
+		c.Packages = append(c.Packages, &generated.ChangeItemResponse{Id: int32(id), Name: packageMap[id]})
+	}
+
+	return c
+}
@@ -15,7 +15,7 @@ type GroupsServer struct {
 	generated.UnimplementedGroupsServiceServer
 }
 
-func (g GroupsServer) List(_ context.Context, request *generated.GroupsRequest) (response *generated.GroupsResponse, err error) {
+func (g GroupsServer) List(ctx context.Context, request *generated.GroupsRequest) (response *generated.GroupsResponse, err error) {
 
 	sort := backendHelpers.MakeMongoOrder(request.GetPagination())
 	projection := backendHelpers.MakeMongoProjection(request.GetProjection())
@@ -33,12 +33,12 @@ func (g GroupsServer) List(_ context.Context, request *generated.GroupsRequest)
 		return nil, err
 	}
 
-	total, err := mongo.CountDocuments(mongo.CollectionGroups, bson.D{{Key: "type", Value: helpers.GroupTypeGroup}}, 0)
+	total, err := mongo.CountDocuments(ctx, mongo.CollectionGroups, bson.D{{Key: "type", Value: helpers.GroupTypeGroup}}, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	filtered, err := mongo.CountDocuments(mongo.CollectionGroups, filter, 0)
+	filtered, err := mongo.CountDocuments(ctx, mongo.CollectionGroups, filter, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,7 @@ func (g GroupsServer) List(_ context.Context, request *generated.GroupsRequest)
 	return response, err
 }
 
-func (g GroupsServer) Retrieve(_ context.Context, request *generated.GroupRequest) (*generated.GroupResponse, error) {
+func (g GroupsServer) Retrieve(ctx context.Context, request *generated.GroupRequest) (*generated.GroupResponse, error) {
 	panic("implement me")
 }
 
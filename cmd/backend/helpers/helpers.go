@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/olivere/elastic/v7"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
@@ -36,6 +37,24 @@ func MakeMongoOrder(request *generated.PaginationRequest) (o bson.D) {
 	return bson.D{{Key: field, Value: order}}
 }
 
+func MakeElasticSorters(request *generated.PaginationRequest) (sorters []elastic.Sorter) {
+
+	field := request.GetSortField()
+
+	if field == "" {
+		return nil
+	}
+
+	sorter := elastic.NewFieldSort(field)
+	if request.GetSortOrder() == "desc" {
+		sorter.Desc()
+	} else {
+		sorter.Asc()
+	}
+
+	return []elastic.Sorter{sorter}
+}
+
 func MakeMongoProjection(p []string) (b bson.M) {
 
 	b = bson.M{}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/Jleagle/rate-limit-go"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	influxHelpers "github.com/gamedb/gamedb/pkg/influx"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/metrics"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type contextKey string
+
+const (
+	ctxCallerField contextKey = "caller"
+
+	callerUnknown = "unknown" // No common name on the certificate
+
+	// Unary calls without a deadline, or with a longer one, get this instead
+	maxUnaryDuration = time.Second * 30
+)
+
+var callerLimiter = rate.New(time.Millisecond*10, rate.WithBurst(200))
+
+// Outermost first
+var unaryInterceptors = []grpc.UnaryServerInterceptor{
+	otelgrpc.UnaryServerInterceptor(),
+	callerUnaryInterceptor,
+	metricsUnaryInterceptor,
+	rateLimitUnaryInterceptor,
+	deadlineUnaryInterceptor,
+	recoveryUnaryInterceptor,
+}
+
+// Streams are long lived, so they don't get a deadline
+var streamInterceptors = []grpc.StreamServerInterceptor{
+	otelgrpc.StreamServerInterceptor(),
+	callerStreamInterceptor,
+	metricsStreamInterceptor,
+	rateLimitStreamInterceptor,
+	recoveryStreamInterceptor,
+}
+
+// Caller identity, from the common name on the client certificate
+func callerUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withCaller(ctx), req)
+}
+
+func callerStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, contextStream{ServerStream: ss, ctx: withCaller(ss.Context())})
+}
+
+func withCaller(ctx context.Context) context.Context {
+
+	caller := callerUnknown
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if certs := tlsInfo.State.PeerCertificates; len(certs) > 0 && certs[0].Subject.CommonName != "" {
+				caller = certs[0].Subject.CommonName
+			}
+		}
+	}
+
+	return context.WithValue(ctx, ctxCallerField, caller)
+}
+
+func getCaller(ctx context.Context) string {
+
+	caller, _ := ctx.Value(ctxCallerField).(string)
+	return caller
+}
+
+// Logging, latency and error counts
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	recordCall(ctx, info.FullMethod, start, err)
+
+	return resp, err
+}
+
+func metricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	start := time.Now()
+	err := handler(srv, ss)
+	recordCall(ss.Context(), info.FullMethod, start, err)
+
+	return err
+}
+
+func recordCall(ctx context.Context, method string, start time.Time, err error) {
+
+	duration := time.Since(start)
+	code := status.Code(err)
+
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("caller", getCaller(ctx)),
+		zap.String("code", code.String()),
+		zap.Duration("duration", duration),
+	}
+
+	switch code {
+	case codes.OK:
+		log.Info("grpc call", fields...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		log.Err("grpc call", append(fields, zap.Error(err))...)
+	default:
+		log.Warn("grpc call", append(fields, zap.Error(err))...)
+	}
+
+	metrics.ObserveGRPC(method, code.String(), duration)
+
+	if config.IsProd() {
+
+		var errorCount int
+		if code != codes.OK {
+			errorCount = 1
+		}
+
+		influxHelpers.Write(influxHelpers.InfluxMeasurementGRPCCalls, map[string]string{
+			"method": method,
+			"caller": getCaller(ctx),
+			"code":   code.String(),
+		}, map[string]interface{}{
+			"call":        1,
+			"error":       errorCount,
+			"duration_ms": float64(duration) / float64(time.Millisecond),
+		})
+	}
+}
+
+// Per caller rate limits, for callers outside of Global Steam
+func rateLimitUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+	if !allowCaller(getCaller(ctx)) {
+		return nil, status.Error(codes.ResourceExhausted, "rate limited")
+	}
+
+	return handler(ctx, req)
+}
+
+func rateLimitStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	if !allowCaller(getCaller(ss.Context())) {
+		return status.Error(codes.ResourceExhausted, "rate limited")
+	}
+
+	return handler(srv, ss)
+}
+
+// The frontend and API make most calls, they have their own limits in front of them.
+// Callers without a name on their certificate share one limit.
+func allowCaller(caller string) bool {
+
+	if helpers.SliceHasString(caller, config.C.BackendInternalCallers) {
+		return true
+	}
+
+	return callerLimiter.GetLimiter(caller).Allow()
+}
+
+// Deadlines
+func deadlineUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+	var cancel context.CancelFunc
+
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > maxUnaryDuration {
+		ctx, cancel = context.WithTimeout(ctx, maxUnaryDuration)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	// Stops the handler's Mongo and Elastic calls once we have given up on it
+	defer cancel()
+
+	type result struct {
+		resp interface{}
+		err  error
+	}
+
+	// Not everything the handler calls takes the context, so don't wait on it past the deadline.
+	// Panics are caught further down the chain, inside this goroutine.
+	c := make(chan result, 1)
+	go func() {
+		resp, err := handler(ctx, req)
+		c <- result{resp: resp, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case r := <-c:
+		return r.resp, r.err
+	}
+}
+
+// Panics
+func recoveryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(info.FullMethod, r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func recoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(info.FullMethod, r)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func panicError(method string, r interface{}) error {
+
+	log.Err("grpc panic", zap.String("method", method), zap.Any("panic", r), zap.ByteString("stack", debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// Lets stream interceptors replace the context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
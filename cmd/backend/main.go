@@ -9,14 +9,20 @@ import (
 
 	"github.com/gamedb/gamedb/pkg/backend/generated"
 	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/metrics"
+	"github.com/gamedb/gamedb/pkg/migrations"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 //go:generate bash ./scripts/generate.sh
@@ -31,6 +37,9 @@ func main() {
 		return
 	}
 
+	closeTracing := tracing.Init(log.LogNameBackend)
+	closeMetrics := metrics.Serve()
+
 	if config.C.GRPCKeysPath == "" {
 		log.Err("Missing environment variables")
 		return
@@ -72,7 +81,11 @@ func main() {
 	})
 
 	// Serve
-	grpcServer := grpc.NewServer(grpc.Creds(creds))
+	grpcServer := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
 
 	generated.RegisterAppsServiceServer(grpcServer, AppsServer{})
 	generated.RegisterPlayersServiceServer(grpcServer, PlayersServer{})
@@ -81,6 +94,17 @@ func main() {
 	generated.RegisterGroupsServiceServer(grpcServer, GroupsServer{})
 	generated.RegisterArticlesServiceServer(grpcServer, ArticlesServer{})
 	generated.RegisterPackagesServiceServer(grpcServer, PackagesServer{})
+	generated.RegisterBundlesServiceServer(grpcServer, BundlesServer{})
+	generated.RegisterBadgesServiceServer(grpcServer, BadgesServer{})
+	generated.RegisterAchievementsServiceServer(grpcServer, AchievementsServer{})
+	generated.RegisterChangesServiceServer(grpcServer, ChangesServer{})
+	generated.RegisterPricesServiceServer(grpcServer, PricesServer{})
+
+	// For readiness checks from the frontend and API
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+
+	// Feeds the Watch RPCs
+	consumers.Init(consumers.BackendDefinitions)
 
 	log.Info("Starting Backend on tcp://" + lis.Addr().String())
 
@@ -92,10 +116,15 @@ func main() {
 	}()
 
 	if config.IsProd() {
-		mongo.EnsureIndexes()
+		_, err = migrations.Up(false)
+		if err != nil {
+			log.ErrS(err)
+		}
 	}
 
 	helpers.KeepAlive(
+		closeTracing,
+		closeMetrics,
 		mysql.Close,
 		mongo.Close,
 		memcache.Close,
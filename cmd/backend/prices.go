@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	backendHelpers "github.com/gamedb/gamedb/cmd/backend/helpers"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/gamedb/gamedb/pkg/consumers"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/streams"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PricesServer struct {
+	generated.UnimplementedPricesServiceServer
+}
+
+func (s PricesServer) List(ctx context.Context, request *generated.ListPricesRequest) (response *generated.PricesResponse, err error) {
+
+	var filter = bson.D{
+		{Key: "prod_cc", Value: request.GetProdCC()},
+	}
+
+	switch request.GetType() {
+	case "apps":
+		filter = append(filter, bson.E{Key: "app_id", Value: bson.M{"$gt": 0}})
+	case "packages":
+		filter = append(filter, bson.E{Key: "package_id", Value: bson.M{"$gt": 0}})
+	case "bundles":
+		filter = append(filter, bson.E{Key: "bundle_id", Value: bson.M{"$gt": 0}})
+	}
+
+	if request.GetPercentMin() != nil {
+
+		min := request.GetPercentMin().GetValue()
+
+		filter = append(filter, bson.E{Key: "difference_percent", Value: bson.M{"$gte": min}})
+
+		// Dont show infinite difference_percent
+		if min > -100 {
+			filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+				bson.M{"difference_percent": bson.M{"$gt": 0}},
+				bson.M{"difference_percent": bson.M{"$lt": 0}},
+				bson.M{"difference": bson.M{"$gte": 0}},
+			}})
+		}
+	}
+
+	if request.GetPercentMax() != nil {
+
+		max := request.GetPercentMax().GetValue()
+
+		filter = append(filter, bson.E{Key: "difference_percent", Value: bson.M{"$lte": max}})
+
+		// Dont show infinite difference_percent
+		if max < 100 {
+			filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+				bson.M{"difference_percent": bson.M{"$gt": 0}},
+				bson.M{"difference_percent": bson.M{"$lt": 0}},
+				bson.M{"difference": bson.M{"$lte": 0}},
+			}})
+		}
+	}
+
+	if request.GetPriceMin() != nil {
+		filter = append(filter, bson.E{Key: "price_after", Value: bson.M{"$gte": request.GetPriceMin().GetValue()}})
+	}
+
+	if request.GetPriceMax() != nil {
+		filter = append(filter, bson.E{Key: "price_after", Value: bson.M{"$lte": request.GetPriceMax().GetValue()}})
+	}
+
+	var wg sync.WaitGroup
+	var pricesErr, filteredErr, totalErr error
+
+	// Rows
+	var prices []mongo.ProductPrice
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		prices, pricesErr = mongo.GetPrices(request.GetPagination().GetOffset(), request.GetPagination().GetLimit(), filter)
+	}()
+
+	// Filtered count
+	var filtered int64
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		filtered, filteredErr = mongo.CountDocuments(ctx, mongo.CollectionProductPrices, filter, 0)
+	}()
+
+	// Total count
+	var total int64
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		total, totalErr = mongo.CountDocuments(ctx, mongo.CollectionProductPrices, bson.D{{Key: "prod_cc", Value: request.GetProdCC()}}, 0)
+	}()
+
+	wg.Wait()
+
+	for _, err := range []error{pricesErr, filteredErr, totalErr} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response = &generated.PricesResponse{}
+	response.Pagination = backendHelpers.MakePaginationResponse(request.GetPagination(), total, filtered)
+
+	for _, price := range prices {
+		response.Prices = append(response.Prices, makePriceChangeResponse(price))
+	}
+
+	return response, nil
+}
+
+func (s PricesServer) WatchPriceChanges(request *generated.WatchPriceChangesRequest, stream generated.PricesService_WatchPriceChangesServer) error {
+
+	changes, unwatch := priceWatchers.watch()
+	defer unwatch()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case prices := <-changes:
+
+			for _, price := range prices {
+
+				if request.GetProdCC() != "" && request.GetProdCC() != string(price.ProdCC) {
+					continue
+				}
+
+				if (request.GetType() == "apps" && price.AppID == 0) || (request.GetType() == "packages" && price.PackageID == 0) {
+					continue
+				}
+
+				err := stream.Send(makePriceChangeResponse(price))
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+var priceWatchers = &priceFanOut{watchers: map[chan []mongo.ProductPrice]bool{}}
+
+// Looks up each price change message once, for every WatchPriceChanges stream
+type priceFanOut struct {
+	watchers map[chan []mongo.ProductPrice]bool
+	once     sync.Once
+	sync.Mutex
+}
+
+func (f *priceFanOut) watch() (changes <-chan []mongo.ProductPrice, unwatch func()) {
+
+	f.once.Do(func() {
+		go f.run()
+	})
+
+	c := make(chan []mongo.ProductPrice, 100)
+
+	f.Lock()
+	f.watchers[c] = true
+	f.Unlock()
+
+	return c, func() {
+
+		f.Lock()
+		defer f.Unlock()
+
+		delete(f.watchers, c)
+	}
+}
+
+func (f *priceFanOut) run() {
+
+	messages, _ := streams.GetTopic(streams.TopicPrices).Subscribe()
+
+	for message := range messages {
+
+		f.Lock()
+		watching := len(f.watchers) > 0
+		f.Unlock()
+
+		if !watching {
+			continue
+		}
+
+		payload := consumers.StringsPayload{}
+
+		err := helpers.Unmarshal(message, &payload)
+		if err != nil {
+			log.ErrS(err)
+			continue
+		}
+
+		prices, err := mongo.GetPricesByID(context.Background(), payload.IDs)
+		if err != nil {
+			log.ErrS(err)
+			continue
+		}
+
+		f.send(prices)
+	}
+}
+
+// Slow streams miss changes rather than holding up everyone else
+func (f *priceFanOut) send(prices []mongo.ProductPrice) {
+
+	f.Lock()
+	defer f.Unlock()
+
+	for c := range f.watchers {
+		select {
+		case c <- prices:
+		default:
+		}
+	}
+}
+
+func makePriceChangeResponse(price mongo.ProductPrice) *generated.PriceChangeResponse {
+
+	return &generated.PriceChangeResponse{
+		CreatedAt:         timestamppb.New(price.CreatedAt),
+		AppId:             int32(price.AppID),
+		PackageId:         int32(price.PackageID),
+		Currency:          string(price.Currency),
+		ProdCC:            string(price.ProdCC),
+		Name:              price.Name,
+		Icon:              price.Icon,
+		PriceBefore:       int32(price.PriceBefore),
+		PriceAfter:        int32(price.PriceAfter),
+		Difference:        int32(price.Difference),
+		DifferencePercent: price.DifferencePercent,
+	}
+}
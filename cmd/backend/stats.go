@@ -15,7 +15,7 @@ type StatsServer struct {
 	generated.UnimplementedStatsServiceServer
 }
 
-func (s StatsServer) List(_ context.Context, request *generated.StatsRequest) (response *generated.StatsResponse, err error) {
+func (s StatsServer) List(ctx context.Context, request *generated.StatsRequest) (response *generated.StatsResponse, err error) {
 
 	offset := request.GetPagination().GetOffset()
 	limit := request.GetPagination().GetLimit()
@@ -42,12 +42,12 @@ func (s StatsServer) List(_ context.Context, request *generated.StatsRequest) (r
 		return nil, err
 	}
 
-	total, err := mongo.CountDocuments(mongo.CollectionStats, filter, 0)
+	total, err := mongo.CountDocuments(ctx, mongo.CollectionStats, filter, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	filtered, err := mongo.CountDocuments(mongo.CollectionStats, filter2, 0)
+	filtered, err := mongo.CountDocuments(ctx, mongo.CollectionStats, filter2, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -13,8 +13,10 @@ import (
 	influxHelper "github.com/gamedb/gamedb/pkg/influx"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/metrics"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	influx "github.com/influxdata/influxdb1-client"
 	"go.uber.org/zap"
 )
@@ -29,6 +31,9 @@ func main() {
 		return
 	}
 
+	closeTracing := tracing.Init(log.LogNameChatbot)
+	closeMetrics := metrics.Serve()
+
 	if config.IsConsumer() {
 		log.Err("Prod & local only")
 		return
@@ -60,8 +65,11 @@ func main() {
 	refreshCommands(session)
 
 	go updateGuildsCount(session)
+	go heartbeat(session)
 
 	helpers.KeepAlive(
+		closeTracing,
+		closeMetrics,
 		mysql.Close,
 		mongo.Close,
 		memcache.Close,
@@ -152,6 +160,23 @@ func refreshCommands(session *discordgo.Session) {
 	}
 }
 
+// Read by the status page checks
+func heartbeat(session *discordgo.Session) {
+
+	for {
+		if session.DataReady {
+
+			item := memcache.ItemChatbotHeartbeat
+			err := memcache.Client().Set(item.Key, time.Now().Unix(), item.Expiration)
+			if err != nil {
+				log.ErrS(err)
+			}
+		}
+
+		time.Sleep(time.Minute)
+	}
+}
+
 func updateGuildsCount(session *discordgo.Session) {
 
 	if config.IsProd() {
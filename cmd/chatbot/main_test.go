@@ -20,30 +20,32 @@ func TestLegacyRegexes(t *testing.T) {
 	}
 
 	tests := map[string]string{
-		"app 440":          chatbot.CApp,
-		"app tf2":          chatbot.CApp,
-		"game 440":         chatbot.CApp,
-		"game tf2":         chatbot.CApp,
-		"new":              chatbot.CAppsNew,
-		"players tf2":      chatbot.CAppPlayers,
-		"online tf2":       chatbot.CAppPlayers,
-		"popular":          chatbot.CAppsPopular,
-		"random":           chatbot.CAppsRandom,
-		"trending":         chatbot.CAppsTrending,
-		"group tf2":        chatbot.CGroup,
-		"clan tf2":         chatbot.CGroup,
-		"trendinggroups":   chatbot.CGroupsTrending,
-		"trending-groups":  chatbot.CGroupsTrending,
-		"trending groups":  chatbot.CGroupsTrending,
-		"help":             chatbot.CHelp,
-		"players":          chatbot.CSteamOnline,
-		"games Jleagle":    chatbot.CPlayerApps,
-		"level Jleagle":    chatbot.CPlayerLevel,
-		"player Jleagle":   chatbot.CPlayer,
-		"playtime Jleagle": chatbot.CPlayerPlaytime,
-		"recent Jleagle":   chatbot.CPlayerRecent,
-		"update":           chatbot.CPlayerUpdate,
-		"update Jleagle":   chatbot.CPlayerUpdate,
+		"app 440":           chatbot.CApp,
+		"app tf2":           chatbot.CApp,
+		"game 440":          chatbot.CApp,
+		"game tf2":          chatbot.CApp,
+		"new":               chatbot.CAppsNew,
+		"players tf2":       chatbot.CAppPlayers,
+		"online tf2":        chatbot.CAppPlayers,
+		"popular":           chatbot.CAppsPopular,
+		"random":            chatbot.CAppsRandom,
+		"trending":          chatbot.CAppsTrending,
+		"group tf2":         chatbot.CGroup,
+		"clan tf2":          chatbot.CGroup,
+		"trendinggroups":    chatbot.CGroupsTrending,
+		"trending-groups":   chatbot.CGroupsTrending,
+		"trending groups":   chatbot.CGroupsTrending,
+		"help":              chatbot.CHelp,
+		"players":           chatbot.CSteamOnline,
+		"games Jleagle":     chatbot.CPlayerApps,
+		"level Jleagle":     chatbot.CPlayerLevel,
+		"player Jleagle":    chatbot.CPlayer,
+		"playtime Jleagle":  chatbot.CPlayerPlaytime,
+		"recent Jleagle":    chatbot.CPlayerRecent,
+		"recommend":         chatbot.CPlayerRecommend,
+		"recommend Jleagle": chatbot.CPlayerRecommend,
+		"update":            chatbot.CPlayerUpdate,
+		"update Jleagle":    chatbot.CPlayerUpdate,
 	}
 
 	for _, start := range []string{".", "!"} {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -268,7 +269,7 @@ func websocketServer() (session *discordgo.Session, err error) {
 			Members: e.MemberCount,
 		}
 
-		_, err := mongo.ReplaceOne(mongo.CollectionDiscordGuilds, bson.D{{Key: "_id", Value: e.ID}}, mongoGuild)
+		_, err := mongo.ReplaceOne(context.Background(), mongo.CollectionDiscordGuilds, bson.D{{Key: "_id", Value: e.ID}}, mongoGuild)
 		if err != nil {
 			log.Err("Updating guild row", zap.Error(err))
 		}
@@ -342,7 +343,7 @@ func saveToDB(command chatbot.Command, isSlash bool, wasSuccess *bool, message,
 		Slash:        isSlash,
 	}
 
-	_, err = mongo.InsertOne(mongo.CollectionChatBotCommands, row)
+	_, err = mongo.InsertOne(context.Background(), mongo.CollectionChatBotCommands, row)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -356,7 +357,7 @@ func saveToDB(command chatbot.Command, isSlash bool, wasSuccess *bool, message,
 	wsPayload := consumers.ChatBotPayload{}
 	wsPayload.RowData = row.GetTableRowJSON(guilds)
 
-	err = consumers.ProduceWebsocket(wsPayload, websockets.PageChatBot)
+	err = consumers.ProduceWebsocket(context.Background(), wsPayload, websockets.PageChatBot)
 	if err != nil {
 		log.ErrS(err)
 	}
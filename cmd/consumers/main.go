@@ -11,8 +11,10 @@ import (
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/metrics"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/tracing"
 )
 
 func main() {
@@ -27,6 +29,9 @@ func main() {
 		return
 	}
 
+	closeTracing := tracing.Init(log.LogNameConsumers)
+	closeMetrics := metrics.Serve()
+
 	//
 	log.Info("Starting consumers")
 
@@ -51,6 +56,8 @@ func main() {
 	consumers.Init(consumers.ConsumersDefinitions)
 
 	helpers.KeepAlive(
+		closeTracing,
+		closeMetrics,
 		mysql.Close,
 		mongo.Close,
 		memcache.Close,
@@ -10,8 +10,10 @@ import (
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/metrics"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	"github.com/robfig/cron/v3"
 )
 
@@ -25,6 +27,9 @@ func main() {
 		return
 	}
 
+	closeTracing := tracing.Init(log.LogNameCrons)
+	closeMetrics := metrics.Serve()
+
 	// Load queue producers
 	consumers.Init(consumers.QueueCronsDefinitions)
 
@@ -64,6 +69,8 @@ func main() {
 	go c.Run() // Blocks
 
 	helpers.KeepAlive(
+		closeTracing,
+		closeMetrics,
 		mysql.Close,
 		mongo.Close,
 		memcache.Close,
@@ -162,6 +162,14 @@ if ($('#admin-users-page').length > 0) {
                 },
                 'orderSequence': ['desc'],
             },
+            // Admin role
+            {
+                'targets': 5,
+                'render': function (data, type, row) {
+                    return row[6];
+                },
+                'orderable': false,
+            },
         ],
     };
 
@@ -123,9 +123,20 @@ if ($('#apps-page').length > 0) {
                     },
                     'orderSequence': ['desc'],
                 },
-                // Link
+                // Lowest Price
                 {
                     'targets': 6,
+                    'render': function (data, type, row) {
+                        if (row[13]) {
+                            return '<span class="text-success" data-toggle="tooltip" data-placement="left" title="Lowest ever price">' + row[12] + '</span>';
+                        }
+                        return row[12];
+                    },
+                    'orderSequence': ['asc', 'desc'],
+                },
+                // Link
+                {
+                    'targets': 7,
                     'render': function (data, type, row) {
                         if (row[8]) {
                             return '<a href="' + row[8] + '" target="_blank" rel="noopener"><i class="fas fa-link"></i></a>';
@@ -136,7 +147,7 @@ if ($('#apps-page').length > 0) {
                 },
                 // Search Score
                 {
-                    'targets': 7,
+                    'targets': 8,
                     'render': function (data, type, row) {
                         return row[10];
                     },
@@ -158,6 +169,7 @@ if ($('#apps-page').length > 0) {
         // Init table
         const searchFields = [
             $('#user'),
+            $('#price_history'),
             $('#tags'),
             $('#genres'),
             $('#categories'),
@@ -164,6 +164,32 @@ if (isIterable(user.toasts)) {
     }
 }
 
+// Saved searches
+$('[data-save-search]').on('click', function (e) {
+
+    const name = prompt('Name this search');
+    if (!name) {
+        return;
+    }
+
+    $.ajax({
+        type: 'post',
+        url: '/settings/saved-searches',
+        data: {
+            type: $(this).attr('data-save-search'),
+            name: name,
+            query: $.param($('table.table').DataTable().ajax.params()),
+        },
+        dataType: 'json',
+        success: function (data, textStatus, jqXHR) {
+            toast(data.success, data.message);
+        },
+        error: function (jqXHR, textStatus, errorThrown) {
+            toast(false, errorThrown);
+        },
+    });
+});
+
 // Fix URLs
 $(function (e) {
     const path = $('#app-page, #package-page, #player-page, #bundle-page, #group-page, #badge-page, #stat-page').attr('data-path');
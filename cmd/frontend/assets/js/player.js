@@ -20,8 +20,17 @@ if ($playerPage.length > 0) {
             cache: false,
             success: function (data, textStatus, jqXHR) {
 
+                if (data.captcha) {
+                    window.location.href = '/players/add?search=' + $playerPage.attr('data-id');
+                    return;
+                }
+
                 toast(data.success, data.toast);
-                $updateLink.contents().last()[0].textContent = ' In Queue';
+                if (data.success) {
+                    $updateLink.contents().last()[0].textContent = ' In Queue';
+                } else {
+                    $('i, svg', $updateLink).removeClass('fa-spin');
+                }
             },
         });
     });
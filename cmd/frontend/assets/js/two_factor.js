@@ -0,0 +1,113 @@
+// WebAuthn sends binary fields as base64url strings
+function webAuthnDecode(value) {
+
+    const base64 = value.replace(/-/g, '+').replace(/_/g, '/');
+    return Uint8Array.from(atob(base64), c => c.charCodeAt(0));
+}
+
+function webAuthnEncode(buffer) {
+
+    return btoa(String.fromCharCode.apply(null, new Uint8Array(buffer)))
+        .replace(/\+/g, '-')
+        .replace(/\//g, '_')
+        .replace(/=/g, '');
+}
+
+function webAuthnFinish(url, body) {
+
+    $.ajax({
+        type: 'POST',
+        url: url,
+        data: JSON.stringify(body),
+        contentType: 'application/json',
+        dataType: 'json',
+        success: function (data) {
+            if (data.error) {
+                toast(false, data.error);
+            }
+            if (data.redirect) {
+                window.location.replace(data.redirect);
+            }
+        },
+        error: function (xhr, textStatus, errorThrown) {
+            toast(false, errorThrown);
+        },
+    });
+}
+
+if ($('#login-two-factor-page').length > 0) {
+
+    $('#security-key-login').on('click', function (e) {
+
+        $.post('/login/two-factor/webauthn/begin', function (options) {
+
+            if (options.error) {
+                toast(false, options.error);
+                return;
+            }
+
+            options.publicKey.challenge = webAuthnDecode(options.publicKey.challenge);
+            (options.publicKey.allowCredentials || []).forEach(function (v) {
+                v.id = webAuthnDecode(v.id);
+            });
+
+            navigator.credentials.get(options)
+                .then(function (credential) {
+                    webAuthnFinish('/login/two-factor/webauthn/finish', {
+                        id: credential.id,
+                        rawId: webAuthnEncode(credential.rawId),
+                        type: credential.type,
+                        response: {
+                            authenticatorData: webAuthnEncode(credential.response.authenticatorData),
+                            clientDataJSON: webAuthnEncode(credential.response.clientDataJSON),
+                            signature: webAuthnEncode(credential.response.signature),
+                            userHandle: credential.response.userHandle ? webAuthnEncode(credential.response.userHandle) : '',
+                        },
+                    });
+                })
+                .catch(function (err) {
+                    toast(false, err.message);
+                });
+
+        }, 'json');
+    });
+}
+
+if ($('#settings-page').length > 0) {
+
+    $('#security-key-add').on('click', function (e) {
+
+        const name = $('#security-key-name').val();
+
+        $.post('/settings/security-keys/begin', function (options) {
+
+            if (options.error) {
+                toast(false, options.error);
+                return;
+            }
+
+            options.publicKey.challenge = webAuthnDecode(options.publicKey.challenge);
+            options.publicKey.user.id = webAuthnDecode(options.publicKey.user.id);
+            (options.publicKey.excludeCredentials || []).forEach(function (v) {
+                v.id = webAuthnDecode(v.id);
+            });
+
+            navigator.credentials.create(options)
+                .then(function (credential) {
+                    webAuthnFinish('/settings/security-keys/finish?name=' + encodeURIComponent(name), {
+                        id: credential.id,
+                        rawId: webAuthnEncode(credential.rawId),
+                        type: credential.type,
+                        response: {
+                            attestationObject: webAuthnEncode(credential.response.attestationObject),
+                            clientDataJSON: webAuthnEncode(credential.response.clientDataJSON),
+                        },
+                    });
+                })
+                .catch(function (err) {
+                    toast(false, err.message);
+                });
+
+        }, 'json');
+    });
+}
@@ -2,12 +2,12 @@ package handlers
 
 import (
 	"net/http"
-	"sync"
 
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/pkg/backend"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/log"
-	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -30,46 +30,45 @@ func achievementsHandler(w http.ResponseWriter, r *http.Request) {
 func achievementsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	var query = datatable.NewDataTableQuery(r, true)
-	var search = query.GetSearchString("search")
 
-	var wg sync.WaitGroup
-
-	var achievements []elasticsearch.Achievement
-	var filtered int64
-	wg.Add(1)
-	go func() {
-
-		defer wg.Done()
-
-		var err error
-		var sorters = query.GetOrderElastic(map[string]string{
-			"1": "completed, app_owners desc",
-		})
-
-		achievements, filtered, err = elasticsearch.SearchAppAchievements(query.GetOffset(), search, sorters)
-		if err != nil {
-			log.ErrS(err)
-		}
-	}()
-
-	var count int64
-	wg.Add(1)
-	go func() {
+	conn, ctx, err := backend.GetClient(r.Context())
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
 
-		defer wg.Done()
+	var columns = map[string]string{
+		"1": "completed",
+	}
 
-		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionAppAchievements, nil, 60*60*24)
-		if err != nil {
-			log.ErrS(err)
-		}
-	}()
+	message := &generated.SearchAchievementsRequest{
+		Pagination: backend.MakePaginationRequest(query, columns, 100),
+		Search:     query.GetSearchString("search"),
+	}
 
-	wg.Wait()
+	resp, err := generated.NewAchievementsServiceClient(conn).Search(ctx, message)
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
 
 	//
-	var response = datatable.NewDataTablesResponse(r, query, count, filtered, nil)
-	for _, achievement := range achievements {
+	var response = datatable.NewDataTablesResponse(r, query, resp.GetPagination().GetTotal(), resp.GetPagination().GetTotalFiltered(), nil)
+	for _, v := range resp.GetAchievements() {
+
+		achievement := elasticsearch.Achievement{
+			ID:          v.GetId(),
+			Name:        v.GetName(),
+			Icon:        v.GetIcon(),
+			Description: v.GetDescription(),
+			Hidden:      v.GetHidden(),
+			Completed:   v.GetCompleted(),
+			AppID:       int(v.GetAppId()),
+			AppName:     v.GetAppName(),
+			AppOwners:   v.GetAppOwners(),
+			NameMarked:  v.GetNameMarked(),
+			Score:       v.GetScore(),
+		}
 
 		response.AddRow([]interface{}{
 			achievement.Name,          // 0
@@ -7,6 +7,8 @@ import (
 
 	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/Jleagle/steam-go/steamid"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/captcha"
+	"github.com/gamedb/gamedb/pkg/abuse"
 	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -20,19 +22,6 @@ func playerAddHandler(w http.ResponseWriter, r *http.Request) {
 
 		message := func() string {
 
-			// Recaptcha
-			// if config.IsProd() {
-			// 	err = recaptcha.CheckFromRequest(r)
-			// 	if err != nil {
-			//
-			// 		if err == recaptcha.ErrNotChecked {
-			// 			return "Please check the captcha"
-			// 		}
-			//
-			// 		return err.Error()
-			// 	}
-			// }
-
 			// Parse form
 			err := r.ParseForm()
 			if err != nil {
@@ -46,6 +35,25 @@ func playerAddHandler(w http.ResponseWriter, r *http.Request) {
 
 			search = strings.TrimSpace(search)
 
+			// Asked for after too many blocked requests
+			if isCaptchaRequired(r) {
+
+				if config.IsProd() {
+
+					resp, err := captcha.Client().CheckRequest(r)
+					if err != nil {
+						log.ErrS(err)
+						return "Something went wrong"
+					}
+
+					if !resp.Success {
+						return "Please check the captcha"
+					}
+				}
+
+				setPassedCaptcha(r)
+			}
+
 			split := strings.Split(search, "/id/")
 			if len(split) > 1 {
 				search = split[1]
@@ -61,10 +69,15 @@ func playerAddHandler(w http.ResponseWriter, r *http.Request) {
 			// Check if search term is a Steam ID
 			id, err := steamid.ParsePlayerID(search)
 			if err == nil && id > 0 {
+				session.Save(w, r)
 				http.Redirect(w, r, "/players/"+fmt.Sprint(id), http.StatusFound)
 				return ""
 			}
 
+			if reason := checkPlayerAbuse(w, r, abuse.ActionPlayerAdd); reason != abuse.ReasonNone {
+				return reason.Message()
+			}
+
 			// Check in Steam API
 			resp, err := steam.GetSteam().ResolveVanityURL(search, steamapi.VanityURLProfile)
 			err = steam.AllowSteamCodes(err)
@@ -74,6 +87,7 @@ func playerAddHandler(w http.ResponseWriter, r *http.Request) {
 			}
 
 			if resp.SteamID > 0 {
+				session.Save(w, r)
 				http.Redirect(w, r, "/players/"+fmt.Sprint(resp.SteamID), http.StatusFound)
 				return ""
 			}
@@ -91,9 +105,12 @@ func playerAddHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	ensureClientID(w, r)
+
 	t := addPlayerTemplate{}
 	t.fill(w, r, "players_add", "Add Player", "Start tracking your stats in Global Steam.")
-	t.RecaptchaPublic = config.C.RecaptchaPublic
+	t.HCaptchaPublic = config.C.HCaptchaPublic
+	t.Captcha = isCaptchaRequired(r)
 	t.Default = r.URL.Query().Get("search")
 
 	//
@@ -102,6 +119,7 @@ func playerAddHandler(w http.ResponseWriter, r *http.Request) {
 
 type addPlayerTemplate struct {
 	globalTemplate
-	RecaptchaPublic string
-	Default         string
+	HCaptchaPublic string
+	Captcha        bool
+	Default        string
 }
@@ -11,9 +11,11 @@ import (
 	"github.com/Jleagle/go-durationfmt"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/geo"
+	"github.com/gamedb/gamedb/pkg/abuse"
 	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/crons"
+	"github.com/gamedb/gamedb/pkg/health"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/ldflags"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -37,22 +39,32 @@ func AdminRouter() http.Handler {
 	r.Use(middleware.MiddlewareAuthCheck)
 	r.Use(middleware.MiddlewareAdminCheck(Error404Handler))
 
+	can := func(permission mysql.AdminPermission) func(http.Handler) http.Handler {
+		return middleware.MiddlewareAdminPermission(permission, adminForbiddenHandler)
+	}
+
 	r.Get("/", adminHandler)
-	r.Get("/consumers", adminConsumersHandler)
-	r.Get("/consumers.json", adminConsumersAjaxHandler)
-	r.Get("/queues", adminQueuesHandler)
-	r.Get("/settings", adminSettingsHandler)
-	r.Get("/stats", adminStatsHandler)
-	r.Get("/tasks", adminTasksHandler)
-	r.Get("/users", adminUsersHandler)
-	r.Get("/users.json", adminUsersAjaxHandler)
-	r.Get("/webhooks", adminWebhooksHandler)
-	r.Get("/webhooks.json", adminWebhooksAjaxHandler)
-	r.Get("/websockets", adminWebsocketsHandler)
-	r.Get("/discord-guilds", adminDiscordGuildsHandler)
-	r.Get("/discord-guilds.json", adminDiscordGuildsAjaxHandler)
-	r.Post("/queues", adminQueuesHandler)
-	r.Post("/settings", adminSettingsHandler)
+	r.With(can(mysql.AdminPermissionStats)).Get("/consumers", adminConsumersHandler)
+	r.With(can(mysql.AdminPermissionStats)).Get("/consumers.json", adminConsumersAjaxHandler)
+	r.With(can(mysql.AdminPermissionQueues)).Get("/queues", adminQueuesHandler)
+	r.With(can(mysql.AdminPermissionSettings)).Get("/settings", adminSettingsHandler)
+	r.With(can(mysql.AdminPermissionStats)).Get("/stats", adminStatsHandler)
+	r.With(can(mysql.AdminPermissionTasks)).Get("/tasks", adminTasksHandler)
+	r.With(can(mysql.AdminPermissionUsers)).Get("/users", adminUsersHandler)
+	r.With(can(mysql.AdminPermissionUsers)).Get("/users.json", adminUsersAjaxHandler)
+	r.With(can(mysql.AdminPermissionWebhooks)).Get("/webhooks", adminWebhooksHandler)
+	r.With(can(mysql.AdminPermissionWebhooks)).Get("/webhooks.json", adminWebhooksAjaxHandler)
+	r.With(can(mysql.AdminPermissionStats)).Get("/websockets", adminWebsocketsHandler)
+	r.With(can(mysql.AdminPermissionGuilds)).Get("/discord-guilds", adminDiscordGuildsHandler)
+	r.With(can(mysql.AdminPermissionGuilds)).Get("/discord-guilds.json", adminDiscordGuildsAjaxHandler)
+	r.With(can(mysql.AdminPermissionStats)).Get("/health", adminHealthHandler)
+	r.With(can(mysql.AdminPermissionIncidents)).Get("/incidents", adminIncidentsHandler)
+	r.With(can(mysql.AdminPermissionAudit)).Get("/audit", adminAuditHandler)
+	r.With(can(mysql.AdminPermissionAbuse)).Get("/abuse", adminAbuseHandler)
+	r.With(can(mysql.AdminPermissionIncidents)).Post("/incidents", adminIncidentsHandler)
+	r.With(can(mysql.AdminPermissionQueues)).Post("/queues", adminQueuesHandler)
+	r.With(can(mysql.AdminPermissionSettings)).Post("/settings", adminSettingsHandler)
+	r.With(can(mysql.AdminPermissionRoles)).Post("/users/role", adminUserRoleHandler)
 	return r
 }
 
@@ -60,16 +72,85 @@ func adminHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/admin/stats", http.StatusFound)
 }
 
+// An admin, but their role doesn't cover this page
+func adminForbiddenHandler(w http.ResponseWriter, r *http.Request) {
+	returnErrorTemplate(w, r, errorTemplate{Code: http.StatusForbidden, Message: "Your admin role doesn't have access to this page"})
+}
+
+func adminAudit(r *http.Request, action mongo.AdminActionEnum, details string) {
+
+	err := mongo.NewAdminEvent(r, session.GetUserIDFromSesion(r), string(session.GetAdminRole(r)), action, details)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
+
 func adminUsersHandler(w http.ResponseWriter, r *http.Request) {
 
 	t := adminUsersTemplate{}
 	t.fill(w, r, "admin_users", "Admin", "Admin")
+	t.Roles = mysql.AdminRoles
 
 	returnTemplate(w, r, t)
 }
 
 type adminUsersTemplate struct {
 	globalTemplate
+	Roles []mysql.AdminRole
+}
+
+func adminUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/admin/users", http.StatusFound)
+	}()
+
+	err := r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	role := mysql.AdminRole(r.PostFormValue("role"))
+	if role != mysql.AdminRoleNone && !role.IsValid() {
+		session.SetFlash(r, session.SessionBad, "Invalid role")
+		return
+	}
+
+	user, err := mysql.GetUserByEmail(strings.TrimSpace(r.PostFormValue("email")))
+	if err == mysql.ErrRecordNotFound {
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return
+	} else if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	// Stops an owner locking themselves out, the first user can't be changed at all
+	if user.ID == session.GetUserIDFromSesion(r) || user.ID == mysql.AdminOwnerUserID {
+		session.SetFlash(r, session.SessionBad, "This user's role can't be changed")
+		return
+	}
+
+	err = mysql.SetUserAdminRole(user.ID, role)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1003)")
+		return
+	}
+
+	// The role is stored in the session, so log them out to pick up the new one
+	err = mongo.DeleteUserSessions(user.ID, "")
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	adminAudit(r, mongo.AdminActionRole, user.Email+": "+user.AdminRole.GetName()+" to "+role.GetName())
+
+	session.SetFlash(r, session.SessionGood, "Role updated")
 }
 
 func adminUsersAjaxHandler(w http.ResponseWriter, r *http.Request) {
@@ -91,7 +172,7 @@ func adminUsersAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		db = db.Model(&mysql.User{})
-		db = db.Select([]string{"id", "created_at", "email", "email_verified", "level", "logged_in_at"})
+		db = db.Select([]string{"id", "created_at", "email", "email_verified", "level", "logged_in_at", "admin_role"})
 		db = db.Limit(100)
 		db = db.Offset(query.GetOffset())
 
@@ -172,12 +253,13 @@ func adminUsersAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		response.AddRow([]interface{}{
-			createdAt,          // 0
-			user.Email,         // 1
-			user.EmailVerified, // 2
-			playerIDs[user.ID], // 3
-			user.Level,         // 4
-			loggedIn,           // 5
+			createdAt,           // 0
+			user.Email,          // 1
+			user.EmailVerified,  // 2
+			playerIDs[user.ID],  // 3
+			user.Level,          // 4
+			loggedIn,            // 5
+			user.GetAdminRole(), // 6
 		})
 	}
 
@@ -280,7 +362,7 @@ func adminWebhooksHandler(w http.ResponseWriter, r *http.Request) {
 	t.fill(w, r, "admin_webhooks", "Admin", "Admin")
 	t.addAssetChosen()
 
-	services, err := mongo.GetDistict(mongo.CollectionWebhooks, "service")
+	services, err := mongo.GetDistict(r.Context(), mongo.CollectionWebhooks, "service")
 	if err != nil {
 		log.ErrS(err)
 	} else {
@@ -331,7 +413,7 @@ func adminWebhooksAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionWebhooks, filter, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionWebhooks, filter, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -364,7 +446,7 @@ func adminStatsHandler(w http.ResponseWriter, r *http.Request) {
 	t.Hash = config.GetShortCommitHash()
 
 	// Oldest player
-	players, err := mongo.GetPlayers(0, 1, bson.D{{Key: "updated_at", Value: 1}}, helpers.LastUpdatedQuery, bson.M{"updated_at": 1})
+	players, err := mongo.GetPlayers(r.Context(), 0, 1, bson.D{{Key: "updated_at", Value: 1}}, helpers.LastUpdatedQuery, bson.M{"updated_at": 1})
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -376,16 +458,21 @@ func adminStatsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	t.Private, err = mongo.CountDocuments(mongo.CollectionPlayers, bson.D{{Key: "community_visibility_state", Value: 1}}, 0)
+	t.Private, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, bson.D{{Key: "community_visibility_state", Value: 1}}, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
 
-	t.Removed, err = mongo.CountDocuments(mongo.CollectionPlayers, bson.D{{Key: "removed", Value: true}}, 0)
+	t.Removed, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, bson.D{{Key: "removed", Value: true}}, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
 
+	t.Freshness, err = mongo.GetLatestFreshnessReport()
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.ErrS(err)
+	}
+
 	t.IP = r.RemoteAddr
 	t.Cores = runtime.NumCPU()
 
@@ -407,14 +494,15 @@ func adminStatsHandler(w http.ResponseWriter, r *http.Request) {
 
 type adminStatsTemplate struct {
 	globalTemplate
-	Oldest   string
-	Commits  string
-	Hash     string
-	Private  int64
-	Removed  int64
-	IP       string
-	Location string
-	Cores    int
+	Oldest    string
+	Commits   string
+	Hash      string
+	Private   int64
+	Removed   int64
+	IP        string
+	Location  string
+	Cores     int
+	Freshness mongo.FreshnessReport
 }
 
 func adminTasksHandler(w http.ResponseWriter, r *http.Request) {
@@ -425,6 +513,7 @@ func adminTasksHandler(w http.ResponseWriter, r *http.Request) {
 		c := r.URL.Query().Get("run")
 
 		if val, ok := crons.TaskRegister[c]; ok {
+			adminAudit(r, mongo.AdminActionTask, val.ID())
 			go crons.Run(val)
 		}
 
@@ -505,10 +594,16 @@ func adminSettingsHandler(w http.ResponseWriter, r *http.Request) {
 			log.ErrS(err)
 		}
 
-		middleware.DownMessage = r.PostFormValue("down-message")
+		downMessage := r.PostFormValue("down-message")
+		if downMessage != middleware.DownMessage {
+			adminAudit(r, mongo.AdminActionSetting, "Down message: "+downMessage)
+		}
+
+		middleware.DownMessage = downMessage
 
 		mcItem := r.PostFormValue("del-mc-item")
 		if mcItem != "" {
+			adminAudit(r, mongo.AdminActionSetting, "Deleted memcache item: "+mcItem)
 			err := memcache.Client().Delete(mcItem)
 			if err != nil {
 				log.ErrS(err)
@@ -545,6 +640,16 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 
 		ua := r.UserAgent()
 
+		var queued []string
+		for _, field := range []string{"app-id", "app-id-search", "apps-ts", "package-id", "player-id", "player-id-new", "player-id-search", "bundle-id", "test-id", "group-id", "group-members"} {
+			if val := strings.TrimSpace(r.PostForm.Get(field)); val != "" {
+				queued = append(queued, field+": "+val)
+			}
+		}
+		if len(queued) > 0 {
+			adminAudit(r, mongo.AdminActionQueue, strings.Join(queued, ", "))
+		}
+
 		// App IDs
 		var appIDs []int
 		if val := r.PostForm.Get("app-id"); val != "" {
@@ -569,7 +674,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 
 				appID, err := strconv.Atoi(val)
 				if err == nil {
-					err = consumers.ProduceAppSearch(nil, appID, nil)
+					err = consumers.ProduceAppSearch(r.Context(), nil, appID, nil)
 					err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 					if err != nil {
 						log.Err("Producing app search", zap.Error(err), zap.Int("app", appID))
@@ -632,7 +737,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 						ForceAchievementsRefresh: true,
 						UserAgent:                &ua,
 					}
-					err = consumers.ProducePlayer(message, "frontend-admin")
+					err = consumers.ProducePlayer(r.Context(), message, "frontend-admin")
 					err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 					if err != nil {
 						log.Err(err.Error(), zap.Int64("id", playerID))
@@ -651,7 +756,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 				playerID, err := strconv.ParseInt(val, 10, 64)
 				if err == nil {
 
-					_, err := mongo.GetPlayer(playerID)
+					_, err := mongo.GetPlayer(r.Context(), playerID)
 					if err != nil {
 
 						message := consumers.PlayerMessage{
@@ -660,7 +765,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 							ForceAchievementsRefresh: true,
 							UserAgent:                &ua,
 						}
-						err = consumers.ProducePlayer(message, "frontend-admin")
+						err = consumers.ProducePlayer(r.Context(), message, "frontend-admin")
 						err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 						if err != nil {
 							log.Err(err.Error(), zap.Int64("id", playerID))
@@ -681,7 +786,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 
 				playerID, err := strconv.ParseInt(val, 10, 64)
 				if err == nil {
-					err = consumers.ProducePlayerSearch(nil, playerID)
+					err = consumers.ProducePlayerSearch(r.Context(), nil, playerID)
 					err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 					if err != nil {
 						log.Err("Producing player search", zap.Error(err), zap.Int64("id", playerID))
@@ -700,7 +805,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 				bundleID, err := strconv.Atoi(val)
 				if err == nil {
 
-					err = consumers.ProduceBundle(bundleID)
+					err = consumers.ProduceBundle(r.Context(), bundleID)
 					err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 					if err != nil {
 						log.Err(err.Error(), zap.Int("id", bundleID))
@@ -720,7 +825,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 
 			for i := 1; i <= count; i++ {
 
-				err = consumers.ProduceTest(i)
+				err = consumers.ProduceTest(r.Context(), i)
 				err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 				if err != nil {
 					log.Err(err.Error(), zap.Int("id", i))
@@ -735,7 +840,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 
 				val = strings.TrimSpace(val)
 
-				err := consumers.ProduceGroup(consumers.GroupMessage{ID: val, UserAgent: &ua})
+				err := consumers.ProduceGroup(r.Context(), consumers.GroupMessage{ID: val, UserAgent: &ua})
 				err = helpers.IgnoreErrors(err, consumers.ErrIsBot, consumers.ErrInQueue)
 				if err != nil {
 					log.ErrS(err)
@@ -761,7 +866,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 
 					for _, playerID := range resp.Members.SteamID64 {
 
-						err = consumers.ProducePlayer(consumers.PlayerMessage{ID: int64(playerID), SkipExistingPlayer: true}, "frontend-admin-group")
+						err = consumers.ProducePlayer(r.Context(), consumers.PlayerMessage{ID: int64(playerID), SkipExistingPlayer: true}, "frontend-admin-group")
 						err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 						if err != nil {
 							log.ErrS(err)
@@ -778,7 +883,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		//
-		err = consumers.ProduceSteam(consumers.SteamMessage{AppIDs: appIDs, PackageIDs: packageIDs})
+		err = consumers.ProduceSteam(r.Context(), consumers.SteamMessage{AppIDs: appIDs, PackageIDs: packageIDs})
 		if err != nil {
 			log.Err(err.Error(), zap.Ints("app-ids", appIDs), zap.Ints("pack-ids", packageIDs))
 		}
@@ -786,7 +891,7 @@ func adminQueuesHandler(w http.ResponseWriter, r *http.Request) {
 		session.SetFlash(r, session.SessionGood, "Done")
 		session.Save(w, r)
 
-		http.Redirect(w, r, "/admin/tasks", http.StatusFound)
+		http.Redirect(w, r, "/admin/queues", http.StatusFound)
 		return
 	}
 
@@ -819,6 +924,93 @@ type adminWebsocketsTemplate struct {
 	Total      int
 }
 
+func adminHealthHandler(w http.ResponseWriter, r *http.Request) {
+
+	t := adminHealthTemplate{}
+	t.fill(w, r, "admin_health", "Admin", "Admin")
+	t.hideAds = true
+	t.Report = health.Run(r.Context(), health.AllChecks...)
+
+	returnTemplate(w, r, t)
+}
+
+type adminHealthTemplate struct {
+	globalTemplate
+	Report health.Report
+}
+
+func adminIncidentsHandler(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method == http.MethodPost {
+
+		err := r.ParseForm()
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		if id := r.PostFormValue("resolve"); id != "" {
+
+			err = mongo.ResolveStatusIncident(id)
+			if err != nil {
+				log.ErrS(err)
+				session.SetFlash(r, session.SessionBad, "Something went wrong")
+			} else {
+				adminAudit(r, mongo.AdminActionIncident, "Resolved "+id)
+				session.SetFlash(r, session.SessionGood, "Incident resolved")
+			}
+
+		} else {
+
+			var components []mongo.StatusComponent
+			for _, v := range r.PostForm["components"] {
+				if c := mongo.StatusComponent(v); c.IsValid() {
+					components = append(components, c)
+				}
+			}
+
+			title := strings.TrimSpace(r.PostFormValue("title"))
+
+			if title == "" || len(components) == 0 {
+				session.SetFlash(r, session.SessionBad, "Title and at least one component are required")
+			} else {
+
+				_, err = mongo.NewStatusIncident(title, strings.TrimSpace(r.PostFormValue("message")), components)
+				if err != nil {
+					log.ErrS(err)
+					session.SetFlash(r, session.SessionBad, "Something went wrong")
+				} else {
+					adminAudit(r, mongo.AdminActionIncident, "Created: "+title)
+					session.SetFlash(r, session.SessionGood, "Incident created")
+				}
+			}
+		}
+
+		session.Save(w, r)
+
+		http.Redirect(w, r, "/admin/incidents", http.StatusFound)
+		return
+	}
+
+	t := adminIncidentsTemplate{}
+	t.fill(w, r, "admin_incidents", "Admin", "Admin")
+	t.hideAds = true
+	t.Components = mongo.StatusComponents
+
+	var err error
+	t.Incidents, err = mongo.GetStatusIncidents(100)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	returnTemplate(w, r, t)
+}
+
+type adminIncidentsTemplate struct {
+	globalTemplate
+	Components []mongo.StatusComponent
+	Incidents  []mongo.StatusIncident
+}
+
 func adminDiscordGuildsHandler(w http.ResponseWriter, r *http.Request) {
 
 	t := adminDiscordGuildsTemplate{}
@@ -865,7 +1057,7 @@ func adminDiscordGuildsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionDiscordGuilds, nil, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionDiscordGuilds, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -888,3 +1080,73 @@ func adminDiscordGuildsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	returnJSON(w, r, response)
 }
+
+func adminAuditHandler(w http.ResponseWriter, r *http.Request) {
+
+	t := adminAuditTemplate{}
+	t.fill(w, r, "admin_audit", "Admin", "Admin")
+	t.hideAds = true
+
+	var err error
+	t.Events, err = mongo.GetAdminEvents(nil, 0)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	// Emails, so the log can be read without looking up IDs
+	var userIDs []int
+	for _, v := range t.Events {
+		userIDs = append(userIDs, v.UserID)
+	}
+
+	t.Emails = map[int]string{}
+
+	if len(userIDs) > 0 {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			log.ErrS(err)
+		} else {
+
+			var users []mysql.User
+			db = db.Select([]string{"id", "email"}).Where("id IN (?)", userIDs).Find(&users)
+			if db.Error != nil {
+				log.ErrS(db.Error)
+			}
+
+			for _, v := range users {
+				t.Emails[v.ID] = v.Email
+			}
+		}
+	}
+
+	returnTemplate(w, r, t)
+}
+
+type adminAuditTemplate struct {
+	globalTemplate
+	Events []mongo.AdminEvent
+	Emails map[int]string
+}
+
+func adminAbuseHandler(w http.ResponseWriter, r *http.Request) {
+
+	t := adminAbuseTemplate{}
+	t.fill(w, r, "admin_abuse", "Admin", "Admin")
+	t.hideAds = true
+	t.QueueDepth, t.Budget = playerGuard.Budget()
+	t.Requesters = playerGuard.Top(50)
+
+	returnTemplate(w, r, t)
+}
+
+type adminAbuseTemplate struct {
+	globalTemplate
+	QueueDepth int
+	Budget     float64
+	Requesters []abuse.Requester
+}
+
+func (t adminAbuseTemplate) GetBudgetPercent() string {
+	return strconv.FormatFloat(t.Budget*100, 'f', 0, 64) + "%"
+}
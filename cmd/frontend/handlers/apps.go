@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/filters"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -15,7 +16,6 @@ import (
 	"github.com/gamedb/gamedb/pkg/session"
 	"github.com/go-chi/chi/v5"
 	"github.com/olivere/elastic/v7"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 func GamesRouter() http.Handler {
@@ -234,96 +234,8 @@ func appsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	//
 	var wg sync.WaitGroup
 	var code = session.GetProductCC(r)
-	var filters []elastic.Query
 
-	types := query.GetSearchSliceInterface("types")
-	if len(types) > 0 {
-		filters = append(filters, elastic.NewTermsQuery("type", types...))
-	}
-
-	tags := query.GetSearchSliceInterface("tags")
-	if len(tags) > 0 {
-		filters = append(filters, elastic.NewTermsQuery("tags", tags...))
-	}
-
-	genres := query.GetSearchSliceInterface("genres")
-	if len(genres) > 0 {
-		filters = append(filters, elastic.NewTermsQuery("genres", genres...))
-	}
-
-	developers := query.GetSearchSliceInterface("developers")
-	if len(developers) > 0 {
-		filters = append(filters, elastic.NewTermsQuery("developers", developers...))
-	}
-
-	publishers := query.GetSearchSliceInterface("publishers")
-	if len(publishers) > 0 {
-		filters = append(filters, elastic.NewTermsQuery("publishers", publishers...))
-	}
-
-	categories := query.GetSearchSliceInterface("categories")
-	if len(categories) > 0 {
-		filters = append(filters, elastic.NewTermsQuery("categories", categories...))
-	}
-
-	platforms := query.GetSearchSliceInterface("platforms")
-	if len(platforms) > 0 {
-		filters = append(filters, elastic.NewTermsQuery("platforms", platforms...))
-	}
-
-	prices := query.GetSearchSlice("price")
-	if len(prices) == 2 {
-
-		low, err := strconv.Atoi(strings.Replace(prices[0], ".", "", 1))
-		if err == nil && low > 0 {
-			filters = append(filters, elastic.NewRangeQuery("prices."+string(code)+".final").From(low))
-		}
-
-		high, err := strconv.Atoi(strings.Replace(prices[1], ".", "", 1))
-		if err == nil && high < 100_00 {
-			filters = append(filters, elastic.NewRangeQuery("prices."+string(code)+".final").To(high))
-		}
-	}
-
-	scores := query.GetSearchSlice("score")
-	if len(scores) == 2 {
-
-		low, err := strconv.Atoi(scores[0])
-		if err == nil && low > 0 {
-			filters = append(filters, elastic.NewRangeQuery("score").From(low))
-		}
-
-		high, err := strconv.Atoi(scores[1])
-		if err == nil && high < 100 {
-			filters = append(filters, elastic.NewRangeQuery("score").To(high))
-		}
-	}
-
-	// Filter by owned games
-	userFilter := query.GetSearchString("user")
-	if userFilter == "owned" || userFilter == "played" {
-		playerID := session.GetPlayerIDFromSesion(r)
-		if playerID > 0 {
-
-			filter := bson.D{}
-
-			if userFilter == "played" {
-				filter = append(filter, bson.E{Key: "app_time", Value: bson.M{"$gt": 0}})
-			}
-
-			apps, err := mongo.GetPlayerAppsByPlayer(playerID, 0, 0, nil, bson.M{"app_id": 1}, filter)
-			if err != nil {
-				log.ErrS(err)
-			} else {
-				var appIDs []interface{}
-				for _, app := range apps {
-					appIDs = append(appIDs, app.AppID)
-				}
-
-				filters = append(filters, elastic.NewTermsQuery("id", appIDs...))
-			}
-		}
-	}
+	appFilters := filters.AppsFilters(query, code, session.GetPlayerIDFromSesion(r))
 
 	// Get apps
 	var apps []elasticsearch.App
@@ -333,18 +245,11 @@ func appsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		cols := map[string]string{
-			"2": "players",
-			"3": "followers",
-			"4": "score",
-			"5": "prices." + string(code) + ".final",
-		}
-
-		order := query.GetOrderElastic(cols)
+		order := filters.AppsOrder(query, code)
 		search := query.GetSearchString("search")
 
 		var err error
-		apps, recordsFiltered, err = elasticsearch.SearchAppsAdvanced(query.GetOffset(), 100, search, order, elastic.NewBoolQuery().Filter(filters...))
+		apps, recordsFiltered, err = elasticsearch.SearchAppsAdvanced(r.Context(), query.GetOffset(), 100, search, order, elastic.NewBoolQuery().Filter(appFilters...))
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -358,7 +263,7 @@ func appsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -371,20 +276,23 @@ func appsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	for k, app := range apps {
 
 		var formattedReviewScore = helpers.RoundFloatTo2DP(app.ReviewScore)
+		var priceStat = app.PriceStats.Get(code)
 
 		response.AddRow([]interface{}{
-			query.GetOffset() + k + 1,       // 0
-			app.ID,                          // 1
-			app.GetName(),                   // 2
-			app.GetIcon(),                   // 3
-			app.GetPath(),                   // 4
-			formattedReviewScore,            // 5
-			app.Prices.Get(code).GetFinal(), // 6
-			app.PlayersCount,                // 7
-			app.GetStoreLink(),              // 8
-			app.FollowersCount,              // 9
-			app.Score,                       // 10
-			app.GetMarkedName(),             // 11
+			query.GetOffset() + k + 1,                // 0
+			app.ID,                                   // 1
+			app.GetName(),                            // 2
+			app.GetIcon(),                            // 3
+			app.GetPath(),                            // 4
+			formattedReviewScore,                     // 5
+			app.Prices.Get(code).GetFinal(),          // 6
+			app.PlayersCount,                         // 7
+			app.GetStoreLink(),                       // 8
+			app.FollowersCount,                       // 9
+			app.Score,                                // 10
+			app.GetMarkedName(),                      // 11
+			priceStat.GetLowestEver(),                // 12
+			priceStat.IsLowest(app.Prices.Get(code)), // 13
 		})
 	}
 
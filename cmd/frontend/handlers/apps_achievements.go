@@ -64,7 +64,7 @@ func appsAchievementsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		sort = append(sort, bson.E{Key: "achievements_average_completion", Value: -1})
 
 		var err error
-		apps, err = mongo.GetApps(query.GetOffset64(), 100, sort, filter2, projection)
+		apps, err = mongo.GetApps(r.Context(), query.GetOffset64(), 100, sort, filter2, projection)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -78,7 +78,7 @@ func appsAchievementsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		filtered, err = mongo.CountDocuments(mongo.CollectionApps, filter2, 0)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter2, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -93,7 +93,7 @@ func appsAchievementsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		count, err = mongo.CountDocuments(mongo.CollectionApps, filter, 60*60*24)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter, 60*60*24)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -31,7 +31,7 @@ func appCompareAchievementsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	app, err := mongo.GetApp(appID)
+	app, err := mongo.GetApp(r.Context(), appID)
 	if err == mongo.ErrNoDocuments {
 		returnErrorTemplate(w, r, errorTemplate{Code: 404, Message: "App Not Found"})
 		return
@@ -42,7 +42,7 @@ func appCompareAchievementsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get achievements
-	achievements, err := mongo.GetAppAchievements(0, 0, bson.D{{Key: "app_id", Value: app.ID}}, bson.D{{Key: "completed", Value: -1}})
+	achievements, err := mongo.GetAppAchievements(r.Context(), 0, 0, bson.D{{Key: "app_id", Value: app.ID}}, bson.D{{Key: "completed", Value: -1}})
 	if err != nil {
 		log.ErrS(err)
 		returnErrorTemplate(w, r, errorTemplate{Code: 500, Message: "Something went wrong (1001)"})
@@ -70,7 +70,7 @@ func appCompareAchievementsHandler(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		player, err := mongo.GetPlayer(playerID)
+		player, err := mongo.GetPlayer(r.Context(), playerID)
 		if err != nil {
 			err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 			if err != nil {
@@ -76,7 +76,7 @@ func appHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get app
-	app, err := mongo.GetApp(id)
+	app, err := mongo.GetApp(r.Context(), id)
 	if err != nil && strings.HasPrefix(err.Error(), "memcache: unexpected response line from \"set\":") {
 		log.WarnS(err)
 		err = nil
@@ -136,7 +136,7 @@ func appHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		err = consumers.ProduceSteam(consumers.SteamMessage{AppIDs: []int{app.ID}})
+		err = consumers.ProduceSteam(r.Context(), consumers.SteamMessage{AppIDs: []int{app.ID}})
 		if err == nil {
 			t.addToast(Toast{Title: "Update", Message: "App has been queued for an update", Success: true})
 			log.Info("app queued", zap.String("ua", r.UserAgent()))
@@ -232,7 +232,7 @@ func appHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PlayersCount, err = mongo.CountDocuments(mongo.CollectionPlayers, nil, 0)
+		t.PlayersCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -441,7 +441,7 @@ func appLocalizationHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	app := mongo.App{}
-	err = mongo.FindOne(mongo.CollectionApps, bson.D{{Key: "_id", Value: id}}, nil, bson.M{"localization": 1}, &app)
+	err = mongo.FindOne(r.Context(), mongo.CollectionApps, bson.D{{Key: "_id", Value: id}}, nil, bson.M{"localization": 1}, &app)
 	if err != nil {
 		err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 		if err != nil {
@@ -478,7 +478,7 @@ func appSamdOwnersHandler(w http.ResponseWriter, r *http.Request) {
 		limit = 5
 	}
 
-	sameApps, err := mongo.GetAppSameOwners(id, limit)
+	sameApps, err := mongo.GetAppSameOwners(r.Context(), id, limit)
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -491,7 +491,7 @@ func appSamdOwnersHandler(w http.ResponseWriter, r *http.Request) {
 		countsMap[sameApp.SameAppID] = sameApp
 	}
 
-	apps, err := mongo.GetAppsByID(appIDs, bson.M{"_id": 1, "name": 1, "icon": 1})
+	apps, err := mongo.GetAppsByID(r.Context(), appIDs, bson.M{"_id": 1, "name": 1, "icon": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -538,7 +538,7 @@ func appSimilarHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	app, err := mongo.GetApp(id)
+	app, err := mongo.GetApp(r.Context(), id)
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -550,6 +550,27 @@ func appSimilarHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Fall back to our own similar apps
+	if len(related) == 0 {
+
+		similar, err := mongo.GetAppSimilar(r.Context(), app.ID, 12)
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		var similarIDs []int
+		for _, v := range similar {
+			similarIDs = append(similarIDs, v.SimilarAppID)
+		}
+
+		related, err = mongo.GetAppsByID(r.Context(), similarIDs, bson.M{"_id": 1, "name": 1, "icon": 1, "tags": 1})
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		related = mongo.SortAppsByID(related, similarIDs)
+	}
+
 	var tagIDs []int
 	for _, v := range related {
 		for _, vv := range v.Tags {
@@ -608,7 +629,7 @@ func appReviewsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	app := mongo.App{}
-	err = mongo.FindOne(mongo.CollectionApps, bson.D{{Key: "_id", Value: id}}, nil, bson.M{"reviews": 1}, &app)
+	err = mongo.FindOne(r.Context(), mongo.CollectionApps, bson.D{{Key: "_id", Value: id}}, nil, bson.M{"reviews": 1}, &app)
 	if err != nil {
 		err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 		if err != nil {
@@ -683,7 +704,7 @@ func appNewsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionAppArticles, bson.D{{Key: "app_id", Value: id}}, 60*60*24)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppArticles, bson.D{{Key: "app_id", Value: id}}, 60*60*24)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -830,7 +851,7 @@ func appAchievementsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			"2": "completed",
 		})
 
-		appAchievements, err = mongo.GetAppAchievements(query.GetOffset64(), 1000, filter, sortOrder)
+		appAchievements, err = mongo.GetAppAchievements(r.Context(), query.GetOffset64(), 1000, filter, sortOrder)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -844,7 +865,7 @@ func appAchievementsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionAppAchievements, filter, 60*60*24*28)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppAchievements, filter, 60*60*24*28)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -921,7 +942,7 @@ func appDLCAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		})
 
 		var err error
-		dlcs, err = mongo.GetDLCForApp(query.GetOffset64(), 100, filter2, sortOrder, nil)
+		dlcs, err = mongo.GetDLCForApp(r.Context(), query.GetOffset64(), 100, filter2, sortOrder, nil)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -939,13 +960,13 @@ func appDLCAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 
-		total, err = mongo.CountDocuments(mongo.CollectionAppDLC, filter, 60*60*24)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppDLC, filter, 60*60*24)
 		if err != nil {
 			log.ErrS(err)
 			return
 		}
 
-		filtered, err = mongo.CountDocuments(mongo.CollectionAppDLC, filter2, 60*60*24)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppDLC, filter2, 60*60*24)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -1005,7 +1026,7 @@ func appItemsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		items, err = mongo.GetAppItems(query.GetOffset64(), 100, filter2, nil)
+		items, err = mongo.GetAppItems(r.Context(), query.GetOffset64(), 100, filter2, nil)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -1024,12 +1045,12 @@ func appItemsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 
-		total, err = mongo.CountDocuments(mongo.CollectionAppItems, filter, 0)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppItems, filter, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
 
-		filtered, err = mongo.CountDocuments(mongo.CollectionAppItems, filter2, 0)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppItems, filter2, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -1088,7 +1109,7 @@ func appBundlesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	app, err := mongo.GetApp(id)
+	app, err := mongo.GetApp(r.Context(), id)
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -1133,7 +1154,7 @@ func appPackagesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	app, err := mongo.GetApp(id)
+	app, err := mongo.GetApp(r.Context(), id)
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -1144,7 +1165,7 @@ func appPackagesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	var packages []mongo.Package
 	var callback = func() (interface{}, error) {
-		return mongo.GetPackagesByID(app.Packages, bson.M{})
+		return mongo.GetPackagesByID(r.Context(), app.Packages, bson.M{})
 	}
 
 	item := memcache.ItemAppPackages(app.ID)
@@ -1300,7 +1321,7 @@ func appTagsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	var tagsMap = map[int]string{}
 	var tagsOrder []int
 
-	app, err := mongo.GetApp(id)
+	app, err := mongo.GetApp(r.Context(), id)
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -1455,7 +1476,7 @@ func appTimeAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		players, err := mongo.GetPlayersByID(playerIDsSlice, bson.M{"_id": 1, "persona_name": 1, "avatar": 1, "country_code": 1})
+		players, err := mongo.GetPlayersByID(r.Context(), playerIDsSlice, bson.M{"_id": 1, "persona_name": 1, "avatar": 1, "country_code": 1, "privacy": 1})
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -1467,6 +1488,10 @@ func appTimeAjaxHandler(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 
+			if player.Privacy.HideRanks || player.Privacy.HidePlaytime {
+				continue
+			}
+
 			playersAppRows = append(playersAppRows, appTimeAjax{
 				ID:      player.ID,
 				Name:    player.GetName(),
@@ -1493,7 +1518,7 @@ func appTimeAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionPlayerApps, playerAppFilter, 0)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerApps, playerAppFilter, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -1607,7 +1632,10 @@ func friendsJSONHandler(w http.ResponseWriter, r *http.Request) {
 	var callback = func() (interface{}, error) {
 
 		// Get player's friends
-		friends, err := mongo.GetFriends(playerID, 0, 0, nil, bson.D{{Key: "name", Value: bson.M{"$ne": ""}}})
+		friends, err := mongo.GetFriends(r.Context(), playerID, 0, 0, nil, bson.D{
+			{Key: "name", Value: bson.M{"$ne": ""}},
+			{Key: "hidden", Value: bson.M{"$ne": true}},
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -61,7 +61,7 @@ func appsCompareHandler(w http.ResponseWriter, r *http.Request) {
 		id, err := strconv.Atoi(appID)
 		if err == nil && helpers.IsValidAppID(id) {
 
-			app, err := mongo.GetApp(id)
+			app, err := mongo.GetApp(r.Context(), id)
 			if err != nil {
 				err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 				if err != nil {
@@ -212,7 +212,7 @@ func compareAppsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	ids2 := helpers.StringSliceToIntSlice(ids)
 
-	apps, err := mongo.GetAppsByID(ids2, bson.M{"_id": 1, "name": 1, "icon": 1, "prices": 1})
+	apps, err := mongo.GetAppsByID(r.Context(), ids2, bson.M{"_id": 1, "name": 1, "icon": 1, "prices": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
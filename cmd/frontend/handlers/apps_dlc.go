@@ -106,7 +106,7 @@ func appsDLCAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			defer wg.Done()
 
 			var err error
-			count, err = mongo.CountDocuments(mongo.CollectionPlayerApps, filter, 0)
+			count, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerApps, filter, 0)
 			if err != nil {
 				log.ErrS(err)
 			}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"html"
 	"html/template"
 	"net/http"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/feed"
 	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -22,6 +24,7 @@ func newReleasesRouter() http.Handler {
 	r := chi.NewRouter()
 	r.Get("/", newReleasesHandler)
 	r.Get("/new-releases.json", newReleasesAjaxHandler)
+	r.Get("/feed.{format:(rss|atom|json)}", newReleasesFeedHandler)
 	return r
 }
 
@@ -31,6 +34,7 @@ func newReleasesHandler(w http.ResponseWriter, r *http.Request) {
 
 	t := newReleasesTemplate{}
 	t.fill(w, r, "new_releases", "New Releases", template.HTML("Games released in the last "+strconv.Itoa(days)+" days"))
+	t.FeedPath = "/games/new-releases/feed"
 	t.addAssetHighCharts()
 	t.Days = days
 
@@ -85,13 +89,13 @@ func newReleasesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		var projection = bson.M{"_id": 1, "name": 1, "icon": 1, "type": 1, "prices": 1, "release_date_unix": 1, "release_date": 1, "player_peak_week": 1, "reviews_score": 1}
 		var sort = query.GetOrderMongo(columns)
 
-		apps, err = mongo.GetApps(query.GetOffset64(), 100, sort, filter2, projection)
+		apps, err = mongo.GetApps(r.Context(), query.GetOffset64(), 100, sort, filter2, projection)
 		if err != nil {
 			log.ErrS(err)
 		}
 
 		countLock.Lock()
-		filtered, err = mongo.CountDocuments(mongo.CollectionApps, filter2, 0)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter2, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -111,7 +115,7 @@ func newReleasesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		countLock.Lock()
-		count, err = mongo.CountDocuments(mongo.CollectionApps, filter, 60*60*24)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter, 60*60*24)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -143,3 +147,59 @@ func newReleasesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	returnJSON(w, r, response)
 }
+
+func newReleasesFeedHandler(w http.ResponseWriter, r *http.Request) {
+
+	var code = session.GetProductCC(r)
+
+	var filter = bson.D{
+		{Key: "release_date_unix", Value: bson.M{"$lt": time.Now().Unix()}},
+		{Key: "release_date_unix", Value: bson.M{"$gt": time.Now().AddDate(0, 0, -config.C.NewReleaseDays).Unix()}},
+	}
+
+	var projection = bson.M{"_id": 1, "name": 1, "icon": 1, "type": 1, "prices": 1, "release_date_unix": 1, "description_short": 1}
+
+	apps, err := mongo.GetApps(r.Context(), 0, 100, bson.D{{Key: "release_date_unix", Value: -1}}, filter, projection)
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	format := feed.Format(chi.URLParam(r, "format"))
+
+	f := feed.Feed{
+		Title:       "New Releases - Global Steam",
+		Link:        config.C.GlobalSteamDomain + "/games/new-releases",
+		FeedLink:    config.C.GlobalSteamDomain + "/games/new-releases/feed." + string(format),
+		Description: "Games released in the last " + strconv.Itoa(config.C.NewReleaseDays) + " days",
+		MaxAge:      time.Hour,
+	}
+
+	for k, app := range apps {
+
+		released := time.Unix(app.ReleaseDateUnix, 0)
+		if k == 0 {
+			f.Updated = released
+		}
+
+		description := html.EscapeString(app.ShortDescription)
+		if price := app.Prices.Get(code); price.Exists {
+			description += "<br>" + price.GetFinal()
+		}
+
+		f.Items = append(f.Items, feed.Item{
+			ID:          app.GetPathAbsolute(),
+			Title:       app.GetName() + " (" + app.GetType() + ")",
+			Link:        app.GetPathAbsolute(),
+			Description: description,
+			Image:       app.GetHeaderImage(),
+			Published:   released,
+		})
+	}
+
+	err = f.Write(w, r, format)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
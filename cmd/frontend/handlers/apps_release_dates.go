@@ -67,7 +67,7 @@ func releaseDatesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 				elastic.NewTermQuery("release_date_original.raw", ""),
 			)
 
-		apps, filtered, err = elasticsearch.SearchAppsAdvanced(query.GetOffset(), 100, "", query.GetOrderElastic(columns), boolQuery)
+		apps, filtered, err = elasticsearch.SearchAppsAdvanced(r.Context(), query.GetOffset(), 100, "", query.GetOrderElastic(columns), boolQuery)
 		if err != nil {
 			log.ErrS(err)
 		}
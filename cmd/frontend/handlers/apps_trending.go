@@ -72,7 +72,7 @@ func trendingAppsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		order := query.GetOrderMongo(columns)
 		offset := query.GetOffset64()
 
-		apps, err = mongo.GetApps(offset, 100, order, filter, projection)
+		apps, err = mongo.GetApps(r.Context(), offset, 100, order, filter, projection)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -87,7 +87,7 @@ func trendingAppsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		filtered, err = mongo.CountDocuments(mongo.CollectionApps, filter, 0)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -103,7 +103,7 @@ func trendingAppsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		count, err = mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, nil, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
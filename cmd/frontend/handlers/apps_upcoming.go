@@ -63,7 +63,7 @@ func upcomingAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			elastic.NewRangeQuery("release_date").From(time.Now().Add(upcomingFilterHours).Unix()),
 		}
 
-		apps, filtered, err = elasticsearch.SearchAppsAdvanced(query.GetOffset(), 100, query.GetSearchString("search"), query.GetOrderElastic(columns), elastic.NewBoolQuery().Filter(filters...))
+		apps, filtered, err = elasticsearch.SearchAppsAdvanced(r.Context(), query.GetOffset(), 100, query.GetSearchString("search"), query.GetOrderElastic(columns), elastic.NewBoolQuery().Filter(filters...))
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -77,7 +77,7 @@ func upcomingAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionApps, upcomingFilter, 60*60)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, upcomingFilter, 60*60)
 		if err != nil {
 			log.ErrS(err)
 		}
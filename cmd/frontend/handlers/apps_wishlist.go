@@ -28,7 +28,7 @@ func wishlistsHandler(w http.ResponseWriter, r *http.Request) {
 	t.fill(w, r, "wishlists", "Wishlists", "Games on the most wishlists")
 
 	var err error
-	t.Players, err = mongo.CountDocuments(mongo.CollectionPlayers, nil, 0)
+	t.Players, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, nil, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -91,7 +91,7 @@ func wishlistAppsHandler(w http.ResponseWriter, r *http.Request) {
 		})
 
 		var err error
-		apps, err = mongo.GetApps(query.GetOffset64(), 100, order, filter2, projection)
+		apps, err = mongo.GetApps(r.Context(), query.GetOffset64(), 100, order, filter2, projection)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -106,7 +106,7 @@ func wishlistAppsHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		filtered, err = mongo.CountDocuments(mongo.CollectionApps, filter2, 0)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter2, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -122,7 +122,7 @@ func wishlistAppsHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		count, err = mongo.CountDocuments(mongo.CollectionApps, filter, 86400)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter, 86400)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
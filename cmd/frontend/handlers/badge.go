@@ -51,7 +51,7 @@ func badgeHandler(w http.ResponseWriter, r *http.Request) {
 			playerBadge.AppName = builtInBadge.Name
 		} else {
 
-			app, err = mongo.GetApp(id)
+			app, err = mongo.GetApp(r.Context(), id)
 			if err != nil {
 				if err == mongo.ErrNoDocuments || err == mongo.ErrInvalidAppID {
 					returnErrorTemplate(w, r, errorTemplate{Code: 404, Message: "Invalid badge ID"})
@@ -95,7 +95,7 @@ func badgeHandler(w http.ResponseWriter, r *http.Request) {
 		if playerBadge.PlayerID > 0 {
 
 			var row = mongo.PlayerBadge{}
-			err = mongo.FindOne(mongo.CollectionPlayerBadges, bson.D{{Key: "_id", Value: playerBadge.GetKey()}}, nil, nil, &row)
+			err = mongo.FindOne(r.Context(), mongo.CollectionPlayerBadges, bson.D{{Key: "_id", Value: playerBadge.GetKey()}}, nil, nil, &row)
 			if err != nil && err != mongo.ErrNoDocuments {
 				log.ErrS(err)
 				returnErrorTemplate(w, r, errorTemplate{Code: 500, Message: err.Error()})
@@ -125,7 +125,7 @@ func badgeHandler(w http.ResponseWriter, r *http.Request) {
 					)
 				}
 
-				count, err := mongo.CountDocuments(mongo.CollectionPlayerBadges, filter, 60*60*24*14)
+				count, err := mongo.CountDocuments(r.Context(), mongo.CollectionPlayerBadges, filter, 60*60*24*14)
 				if err != nil {
 					log.ErrS(err)
 					returnErrorTemplate(w, r, errorTemplate{Code: 500, Message: err.Error()})
@@ -217,7 +217,7 @@ func badgeAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionPlayerBadges, filter, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerBadges, filter, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -3,6 +3,8 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/gamedb/gamedb/pkg/backend"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/go-chi/chi/v5"
@@ -23,10 +25,31 @@ func badgesHandler(w http.ResponseWriter, r *http.Request) {
 	t := badgesTemplate{}
 	t.fill(w, r, "badges", "Steam badge leaderboards", "See who's the highst badge level, and who got it first")
 
-	var err error
-	t.Badges, err = mongo.GetBadgeSummaries()
+	conn, ctx, err := backend.GetClient(r.Context())
 	if err != nil {
 		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	resp, err := generated.NewBadgesServiceClient(conn).List(ctx, &generated.ListBadgesRequest{})
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	for _, v := range resp.GetBadges() {
+		t.Badges = append(t.Badges, mongo.PlayerBadgeSummary{
+			AppID:        int(v.GetAppId()),
+			BadgeID:      int(v.GetBadgeId()),
+			AppName:      v.GetAppName(),
+			AppIcon:      v.GetAppIcon(),
+			PlayersCount: v.GetPlayers(),
+			MaxLevel:     int(v.GetMaxLevel()),
+			MaxLevelFoil: int(v.GetMaxLevelFoil()),
+			Leaders:      v.GetLeaders(),
+			LeadersFoil:  v.GetLeadersFoil(),
+			UpdatedAt:    v.GetUpdatedAt(),
+		})
 	}
 
 	returnTemplate(w, r, t)
@@ -33,7 +33,7 @@ func bundleHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get bundle
-	bundle, err := mongo.GetBundle(id)
+	bundle, err := mongo.GetBundle(r.Context(), id)
 	if err != nil {
 
 		if err == mongo.ErrNoDocuments {
@@ -56,7 +56,7 @@ func bundleHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		apps, err = mongo.GetAppsByID(bundle.Apps, nil)
+		apps, err = mongo.GetAppsByID(r.Context(), bundle.Apps, nil)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -73,7 +73,7 @@ func bundleHandler(w http.ResponseWriter, r *http.Request) {
 				}
 
 				if !found {
-					err = consumers.ProduceSteam(consumers.SteamMessage{AppIDs: []int{v}})
+					err = consumers.ProduceSteam(r.Context(), consumers.SteamMessage{AppIDs: []int{v}})
 					err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 					if err != nil {
 						log.ErrS(err)
@@ -90,7 +90,7 @@ func bundleHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		packages, err = mongo.GetPackagesByID(bundle.Packages, nil)
+		packages, err = mongo.GetPackagesByID(r.Context(), bundle.Packages, nil)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -162,7 +162,7 @@ func bundlePricesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Add current price
-	price, err := mongo.GetBundle(id)
+	price, err := mongo.GetBundle(r.Context(), id)
 	if err != nil {
 		log.ErrS(err)
 	} else {
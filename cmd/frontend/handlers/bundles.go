@@ -3,16 +3,17 @@ package handlers
 import (
 	"net/http"
 	"strconv"
-	"sync"
 
+	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/pkg/backend"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/log"
-	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/session"
 	"github.com/go-chi/chi/v5"
-	"github.com/olivere/elastic/v7"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func BundlesRouter() http.Handler {
@@ -44,129 +45,106 @@ func bundlesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := datatable.NewDataTableQuery(r, false)
 
-	//
-	var wg sync.WaitGroup
-
-	// Get apps
-	var bundles []elasticsearch.Bundle
-	var countFiltered int64
-	wg.Add(1)
-	go func() {
-
-		defer wg.Done()
-
-		var code = session.GetProductCC(r)
-		var err error
-		var sortCols = map[string]string{
-			// "0": "name",
-			"1": "discount_sale",
-			"2": "prices_sale." + string(code),
-			"3": "apps",
-			// "4": "giftable",
-			// "5": "type,
-			"6": "created_at",
-		}
+	conn, ctx, err := backend.GetClient(r.Context())
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
 
-		var filters []elastic.Query
+	var code = session.GetProductCC(r)
+	var columns = map[string]string{
+		// "0": "name",
+		"1": "discount_sale",
+		"2": "prices_sale." + string(code),
+		"3": "apps",
+		// "4": "giftable",
+		// "5": "type,
+		"6": "created_at",
+	}
 
-		//
-		typex := query.GetSearchString("type")
-		switch typex {
-		case "cts", "pt":
-			filters = append(filters, elastic.NewTermQuery("type", typex))
-		}
+	message := &generated.ListBundlesRequest{
+		Pagination: backend.MakePaginationRequest(query, columns, 100),
+		Search:     query.GetSearchString("search"),
+		Type:       query.GetSearchString("type"),
+		Giftable:   query.GetSearchString("giftable") == "1",
+		OnSale:     query.GetSearchString("onsale") == "1",
+	}
 
-		//
-		giftable := query.GetSearchString("giftable")
-		switch giftable {
-		case "1":
-			filters = append(filters, elastic.NewTermQuery("giftable", true))
-		}
+	//
+	discount := query.GetSearchSlice("discount")
+	if len(discount) == 2 {
 
-		//
-		onsale := query.GetSearchString("onsale")
-		switch onsale {
-		case "1":
-			filters = append(filters, elastic.NewTermQuery("on_sale", true))
+		min, err := strconv.Atoi(discount[0])
+		if err == nil && min > 0 {
+			message.DiscountMin = wrapperspb.Int32(int32(min))
 		}
 
-		//
-		discount := query.GetSearchSlice("discount")
-		if len(discount) == 2 {
-
-			min, err := strconv.Atoi(discount[0])
-			if err == nil && min > 0 {
-				filters = append(filters, elastic.NewRangeQuery("discount_sale").Gte(min))
-			}
-
-			max, err := strconv.Atoi(discount[1])
-			if err == nil && max < 100 {
-				filters = append(filters, elastic.NewRangeQuery("discount_sale").Lte(max))
-			}
+		max, err := strconv.Atoi(discount[1])
+		if err == nil && max < 100 {
+			message.DiscountMax = wrapperspb.Int32(int32(max))
 		}
+	}
 
-		//
-		apps := query.GetSearchSlice("apps")
-		if len(apps) == 2 {
-
-			min, err := strconv.Atoi(apps[0])
-			if err == nil && min > 0 {
-				filters = append(filters, elastic.NewRangeQuery("apps").Gte(min))
-
-			}
+	//
+	apps := query.GetSearchSlice("apps")
+	if len(apps) == 2 {
 
-			max, err := strconv.Atoi(apps[1])
-			if err == nil && max < 100 {
-				filters = append(filters, elastic.NewRangeQuery("apps").Lte(max))
-			}
+		min, err := strconv.Atoi(apps[0])
+		if err == nil && min > 0 {
+			message.AppsMin = wrapperspb.Int32(int32(min))
 		}
 
-		//
-		// packages := query.GetSearchSlice("packages")
-		// if len(packages) == 2 {
-		// 	if packages[0] != "0" {
-		// 		min, err := strconv.Atoi(packages[0])
-		// 		if err == nil {
-		// 			filters = append(filters, elastic.NewRangeQuery("packages").Gte(min))
-		// 		}
-		// 	}
-		// 	if packages[1] != "100" {
-		// 		max, err := strconv.Atoi(packages[1])
-		// 		if err == nil {
-		// 			filters = append(filters, elastic.NewRangeQuery("packages").Lte(max))
-		// 		}
-		// 	}
-		// }
-
-		search := query.GetSearchString("search")
-
-		bundles, countFiltered, err = elasticsearch.SearchBundles(query.GetOffset(), 100, search, query.GetOrderElastic(sortCols), filters)
-		if err != nil {
-			log.Err("Searching bundles", zap.Error(err))
+		max, err := strconv.Atoi(apps[1])
+		if err == nil && max < 100 {
+			message.AppsMax = wrapperspb.Int32(int32(max))
 		}
-	}()
+	}
 
-	// Get total
-	var count int64
-	wg.Add(1)
-	go func() {
+	resp, err := generated.NewBundlesServiceClient(conn).List(ctx, message)
+	if err != nil {
+		log.Err("Searching bundles", zap.Error(err))
+		return
+	}
 
-		defer wg.Done()
+	var response = datatable.NewDataTablesResponse(r, query, resp.GetPagination().GetTotal(), resp.GetPagination().GetTotalFiltered(), nil)
+	for _, v := range resp.GetBundles() {
+		response.AddRow(bundleFromBackend(v).OutputForJSON())
+	}
 
-		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionBundles, nil, 0)
-		if err != nil {
-			log.ErrS(err)
-		}
-	}()
+	returnJSON(w, r, response)
+}
+
+func bundleFromBackend(b *generated.BundleResponse) (bundle elasticsearch.Bundle) {
+
+	bundle = elasticsearch.Bundle{
+		Apps:            int(b.GetApps()),
+		CreatedAt:       b.GetCreatedAt(),
+		Discount:        int(b.GetDiscount()),
+		DiscountHighest: int(b.GetDiscountHighest()),
+		DiscountLowest:  int(b.GetDiscountLowest()),
+		DiscountSale:    int(b.GetDiscountSale()),
+		Giftable:        b.GetGiftable(),
+		Icon:            b.GetIcon(),
+		ID:              int(b.GetId()),
+		Image:           b.GetImage(),
+		Name:            b.GetName(),
+		OnSale:          b.GetOnSale(),
+		Packages:        int(b.GetPackages()),
+		Prices:          map[steamapi.ProductCC]int{},
+		PricesSale:      map[steamapi.ProductCC]int{},
+		Type:            b.GetType(),
+		UpdatedAt:       b.GetUpdatedAt(),
+		NameMarked:      b.GetNameMarked(),
+		Score:           float64(b.GetScore()),
+	}
 
-	// Wait
-	wg.Wait()
+	for k, v := range b.GetPrices() {
+		bundle.Prices[steamapi.ProductCC(k)] = int(v)
+	}
 
-	var response = datatable.NewDataTablesResponse(r, query, count, countFiltered, nil)
-	for _, v := range bundles {
-		response.AddRow(v.OutputForJSON())
+	for k, v := range b.GetPricesSale() {
+		bundle.PricesSale[steamapi.ProductCC(k)] = int(v)
 	}
 
-	returnJSON(w, r, response)
+	return bundle
 }
@@ -55,7 +55,7 @@ func changeHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		apps, err := mongo.GetAppsByID(change.Apps, bson.M{"_id": 1, "icon": 1, "type": 1, "name": 1})
+		apps, err := mongo.GetAppsByID(r.Context(), change.Apps, bson.M{"_id": 1, "icon": 1, "type": 1, "name": 1})
 		if err != nil {
 
 			log.ErrS(err)
@@ -73,7 +73,7 @@ func changeHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		packagesSlice, err := mongo.GetPackagesByID(change.Packages, bson.M{})
+		packagesSlice, err := mongo.GetPackagesByID(r.Context(), change.Packages, bson.M{})
 		if err != nil {
 
 			log.ErrS(err)
@@ -91,7 +91,7 @@ func changeHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		err = mongo.FindOne(mongo.CollectionChanges, bson.D{{Key: "_id", Value: bson.M{"$lt": change.ID}}}, bson.D{{Key: "_id", Value: -1}}, bson.M{"_id": 1}, &t.Previous)
+		err = mongo.FindOne(r.Context(), mongo.CollectionChanges, bson.D{{Key: "_id", Value: bson.M{"$lt": change.ID}}}, bson.D{{Key: "_id", Value: -1}}, bson.M{"_id": 1}, &t.Previous)
 		if err != nil {
 			err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 			log.ErrS(err)
@@ -104,7 +104,7 @@ func changeHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		err = mongo.FindOne(mongo.CollectionChanges, bson.D{{Key: "_id", Value: bson.M{"$gt": change.ID}}}, bson.D{{Key: "_id", Value: 1}}, bson.M{"_id": 1}, &t.Next)
+		err = mongo.FindOne(r.Context(), mongo.CollectionChanges, bson.D{{Key: "_id", Value: bson.M{"$gt": change.ID}}}, bson.D{{Key: "_id", Value: 1}}, bson.M{"_id": 1}, &t.Next)
 		if err != nil {
 			err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 			if err != nil {
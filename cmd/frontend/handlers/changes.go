@@ -1,14 +1,21 @@
 package handlers
 
 import (
+	"context"
+	"html"
 	"net/http"
-	"sync"
+	"strings"
+	"time"
 
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/feed"
+	"github.com/gamedb/gamedb/pkg/backend"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/go-chi/chi/v5"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 func ChangesRouter() http.Handler {
@@ -18,6 +25,7 @@ func ChangesRouter() http.Handler {
 
 	r.Get("/", changesHandler)
 	r.Get("/changes.json", changesAjaxHandler)
+	r.Get("/feed.{format:(rss|atom|json)}", changesFeedHandler)
 
 	return r
 }
@@ -27,6 +35,7 @@ func changesHandler(w http.ResponseWriter, r *http.Request) {
 	// Template
 	t := changesTemplate{}
 	t.fill(w, r, "changes", "Changes", "Every time the Steam library gets updated, a change record is created. We use these to keep website information up to date.")
+	t.FeedPath = "/changes/feed"
 
 	returnTemplate(w, r, t)
 }
@@ -39,82 +48,103 @@ func changesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := datatable.NewDataTableQuery(r, true)
 
-	changes, err := mongo.GetChanges(query.GetOffset64())
+	changes, appMap, packageMap, total, err := getChangesFromBackend(r.Context(), backend.MakePaginationRequest(query, nil, 100))
 	if err != nil {
 		log.ErrS(err)
 		return
 	}
 
-	var wg sync.WaitGroup
+	var response = datatable.NewDataTablesResponse(r, query, total, total, nil)
+	for _, v := range changes {
+		response.AddRow(v.OutputForJSON(appMap, packageMap))
+	}
+
+	returnJSON(w, r, response)
+}
+
+func changesFeedHandler(w http.ResponseWriter, r *http.Request) {
+
+	changes, appMap, packageMap, _, err := getChangesFromBackend(r.Context(), &generated.PaginationRequest{Limit: 100})
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
 
-	// Get changes
-	wg.Add(1)
-	var appMap = map[int]string{}
-	go func() {
+	format := feed.Format(chi.URLParam(r, "format"))
 
-		defer wg.Done()
+	f := feed.Feed{
+		Title:       "Steam Changes - Global Steam",
+		Link:        config.C.GlobalSteamDomain + "/changes",
+		FeedLink:    config.C.GlobalSteamDomain + "/changes/feed." + string(format),
+		Description: "Every time the Steam library gets updated, a change record is created",
+		MaxAge:      time.Minute,
+	}
 
-		var err error
-		var appIDs []int
+	for k, change := range changes {
 
-		for _, v := range changes {
-			appIDs = append(appIDs, v.Apps...)
+		if k == 0 {
+			f.Updated = change.CreatedAt
 		}
 
-		// App map
-		apps, err := mongo.GetAppsByID(appIDs, bson.M{"_id": 1, "name": 1})
-		if err != nil {
-			log.ErrS(err)
+		var lines []string
+		for _, id := range change.Apps {
+			lines = append(lines, `<li>App: <a href="`+helpers.GetAppPathAbsolute(id, appMap[id])+`">`+html.EscapeString(helpers.GetAppName(id, appMap[id]))+`</a></li>`)
 		}
-
-		for _, app := range apps {
-			appMap[app.ID] = app.GetName()
+		for _, id := range change.Packages {
+			lines = append(lines, `<li>Package: <a href="`+config.C.GlobalSteamDomain+helpers.GetPackagePath(id, packageMap[id])+`">`+html.EscapeString(helpers.GetPackageName(id, packageMap[id]))+`</a></li>`)
 		}
-	}()
 
-	wg.Add(1)
-	var packageMap = map[int]string{}
-	go func() {
+		f.Items = append(f.Items, feed.Item{
+			ID:          config.C.GlobalSteamDomain + change.GetPath(),
+			Title:       change.GetName(),
+			Link:        config.C.GlobalSteamDomain + change.GetPath(),
+			Description: "<ul>" + strings.Join(lines, "") + "</ul>",
+			Published:   change.CreatedAt,
+		})
+	}
 
-		defer wg.Done()
+	err = f.Write(w, r, format)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
 
-		var err error
-		var packageIDs []int
+// Returns the changes along with app and package names, keyed by ID
+func getChangesFromBackend(ctx context.Context, pagination *generated.PaginationRequest) (changes []mongo.Change, appMap map[int]string, packageMap map[int]string, total int64, err error) {
 
-		for _, v := range changes {
-			packageIDs = append(packageIDs, v.Packages...)
-		}
+	conn, ctx, err := backend.GetClient(ctx)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
 
-		// Package map
-		packages, err := mongo.GetPackagesByID(packageIDs, bson.M{"_id": 1, "name": 1})
-		if err != nil {
-			log.ErrS(err)
-		}
+	resp, err := generated.NewChangesServiceClient(conn).List(ctx, &generated.ListChangesRequest{Pagination: pagination})
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
 
-		for _, v := range packages {
-			packageMap[v.ID] = v.GetName()
-		}
-	}()
+	appMap = map[int]string{}
+	packageMap = map[int]string{}
 
-	// Get count
-	var count int64
-	wg.Add(1)
-	go func() {
+	for _, v := range resp.GetChanges() {
 
-		defer wg.Done()
+		change := mongo.Change{
+			ID:        int(v.GetId()),
+			CreatedAt: v.GetCreatedAt().AsTime(),
+		}
 
-		count, err = mongo.CountDocuments(mongo.CollectionChanges, nil, 0)
-		if err != nil {
-			log.ErrS(err)
+		for _, app := range v.GetApps() {
+			change.Apps = append(change.Apps, int(app.GetId()))
+			appMap[int(app.GetId())] = app.GetName()
 		}
-	}()
 
-	wg.Wait()
+		for _, pack := range v.GetPackages() {
+			change.Packages = append(change.Packages, int(pack.GetId()))
+			packageMap[int(pack.GetId())] = pack.GetName()
+		}
 
-	var response = datatable.NewDataTablesResponse(r, query, count, count, nil)
-	for _, v := range changes {
-		response.AddRow(v.OutputForJSON(appMap, packageMap))
+		changes = append(changes, change)
 	}
 
-	returnJSON(w, r, response)
+	return changes, appMap, packageMap, resp.GetPagination().GetTotal(), nil
 }
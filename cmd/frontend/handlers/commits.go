@@ -44,7 +44,7 @@ func commitsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	callback := func() (interface{}, error) {
 
-		conn, ctx, err := backend.GetClient()
+		conn, ctx, err := backend.GetClient(r.Context())
 		if err != nil {
 			return nil, err
 		}
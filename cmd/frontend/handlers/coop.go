@@ -63,7 +63,7 @@ func coopSearchAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	if search != "" {
 
-		players, _, err := elasticsearch.SearchPlayers(5, 0, search, nil, nil)
+		players, _, err := elasticsearch.SearchPlayers(r.Context(), 5, 0, search, nil, nil)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -100,7 +100,7 @@ func coopPlayersAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	ids := helpers.StringToSlice(query.GetSearchString("ids"), ",")
 	ids2 := helpers.StringSliceToInt64Slice(ids)
 
-	players, err := mongo.GetPlayersByID(ids2, bson.M{"_id": 1, "persona_name": 1, "avatar": 1, "level": 1, "games_count": 1})
+	players, err := mongo.GetPlayersByID(r.Context(), ids2, bson.M{"_id": 1, "persona_name": 1, "avatar": 1, "level": 1, "games_count": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -176,7 +176,7 @@ func coopGames(w http.ResponseWriter, r *http.Request) {
 	playerIDs = helpers.UniqueInt64(playerIDs)
 
 	// Get players
-	players, err := mongo.GetPlayersByID(playerIDs, bson.M{"_id": 1, "persona_name": 1, "avatar": 1})
+	players, err := mongo.GetPlayersByID(r.Context(), playerIDs, bson.M{"_id": 1, "persona_name": 1, "avatar": 1})
 	if err != nil {
 		log.ErrS(err)
 		returnErrorTemplate(w, r, errorTemplate{Code: 500})
@@ -193,7 +193,7 @@ func coopGames(w http.ResponseWriter, r *http.Request) {
 		if !helpers.SliceHasInt64(foundPlayerIDs, playerID) {
 
 			ua := r.UserAgent()
-			err = consumers.ProducePlayer(consumers.PlayerMessage{ID: playerID, UserAgent: &ua}, "frontend-coop")
+			err = consumers.ProducePlayer(r.Context(), consumers.PlayerMessage{ID: playerID, UserAgent: &ua}, "frontend-coop")
 
 			if err = helpers.IgnoreErrors(err, consumers.ErrIsBot, consumers.ErrInQueue); err != nil {
 				log.ErrS(err)
@@ -205,7 +205,7 @@ func coopGames(w http.ResponseWriter, r *http.Request) {
 	var allApps = map[int]bool{}
 	var allAppsByPlayer = map[int64][]int{}
 
-	playerApps, err := mongo.GetPlayerAppsByPlayers(foundPlayerIDs, bson.M{"_id": 0, "player_id": 1, "app_id": 1})
+	playerApps, err := mongo.GetPlayerAppsByPlayers(r.Context(), foundPlayerIDs, bson.M{"_id": 0, "player_id": 1, "app_id": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -262,7 +262,7 @@ func coopGames(w http.ResponseWriter, r *http.Request) {
 
 			defer wg.Done()
 
-			apps, err := mongo.GetApps(query.GetOffset64(), 100, bson.D{{Key: "reviews_score", Value: -1}}, filter, projection)
+			apps, err := mongo.GetApps(r.Context(), query.GetOffset64(), 100, bson.D{{Key: "reviews_score", Value: -1}}, filter, projection)
 			if err != nil {
 				log.ErrS(err)
 			}
@@ -289,7 +289,7 @@ func coopGames(w http.ResponseWriter, r *http.Request) {
 			defer wg.Done()
 
 			var err error
-			count, err = mongo.CountDocuments(mongo.CollectionApps, filter, 60*60)
+			count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter, 60*60)
 			if err != nil {
 				log.ErrS(err)
 			}
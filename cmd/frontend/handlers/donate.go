@@ -56,7 +56,7 @@ func topDonateHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var playersMap = map[int64]mongo.Player{}
-	players, err := mongo.GetPlayersByID(playerIDs, bson.M{"_id": 1, "persona_name": 1, "avatar": 1, "country_code": 1})
+	players, err := mongo.GetPlayersByID(r.Context(), playerIDs, bson.M{"_id": 1, "persona_name": 1, "avatar": 1, "country_code": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -110,7 +110,7 @@ func latestDonateHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var playersMap = map[int64]mongo.Player{}
-	players, err := mongo.GetPlayersByID(playerIDs, bson.M{"_id": 1, "persona_name": 1, "avatar": 1, "country_code": 1})
+	players, err := mongo.GetPlayersByID(r.Context(), playerIDs, bson.M{"_id": 1, "persona_name": 1, "avatar": 1, "country_code": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
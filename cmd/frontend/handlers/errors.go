@@ -8,6 +8,10 @@ func Error404Handler(w http.ResponseWriter, r *http.Request) {
 	returnErrorTemplate(w, r, errorTemplate{Code: http.StatusNotFound, Message: "This page doesnt exist"})
 }
 
+func Error503Handler(w http.ResponseWriter, r *http.Request) {
+	returnErrorTemplate(w, r, errorTemplate{Code: http.StatusServiceUnavailable, Message: "This page is unavailable right now, please try again soon"})
+}
+
 // func error403Handler(w http.ResponseWriter, r *http.Request) {
 // 	returnErrorTemplate(w, r, errorTemplate{Code: http.StatusForbidden, Message: "Please login"})
 // }
@@ -31,15 +31,15 @@ func featuresHandler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		log.ErrS(err)
 	}
-	t.AppCount, err = mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+	t.AppCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, nil, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
-	t.ArticleCount, err = mongo.CountDocuments(mongo.CollectionAppArticles, nil, 0)
+	t.ArticleCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppArticles, nil, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
-	t.GroupsCount, err = mongo.CountDocuments(mongo.CollectionGroups, nil, 0)
+	t.GroupsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionGroups, nil, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -237,6 +237,12 @@ func forgotResetPasswordHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Log out everywhere, in case someone else has the old password
+	err = mongo.DeleteUserSessions(user.ID, "")
+	if err != nil {
+		log.ErrS(err)
+	}
+
 	//
 	success = true
 	session.SetFlash(r, session.SessionGood, "A new password has been emailed to you (You might need to check the spam folder)")
@@ -44,13 +44,13 @@ func groupHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get group
-	group, err := mongo.GetGroup(id)
+	group, err := mongo.GetGroup(r.Context(), id)
 	if err != nil {
 
 		if err == mongo.ErrNoDocuments {
 
 			ua := r.UserAgent()
-			err = consumers.ProduceGroup(consumers.GroupMessage{ID: id, UserAgent: &ua})
+			err = consumers.ProduceGroup(r.Context(), consumers.GroupMessage{ID: id, UserAgent: &ua})
 			err = helpers.IgnoreErrors(err, consumers.ErrInQueue, consumers.ErrIsBot)
 			if err != nil {
 				log.ErrS(err)
@@ -71,7 +71,7 @@ func groupHandler(w http.ResponseWriter, r *http.Request) {
 	if group.Type == helpers.GroupTypeGame && group.AppID > 0 {
 
 		var err error
-		app, err := mongo.GetApp(group.AppID)
+		app, err := mongo.GetApp(r.Context(), group.AppID)
 		if err != nil {
 			err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 			if err != nil {
@@ -94,7 +94,7 @@ func groupHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		ua := r.UserAgent()
-		err = consumers.ProduceGroup(consumers.GroupMessage{ID: group.ID, UserAgent: &ua})
+		err = consumers.ProduceGroup(r.Context(), consumers.GroupMessage{ID: group.ID, UserAgent: &ua})
 		if err == nil {
 			log.Info("group queued", zap.String("ua", ua))
 			t.addToast(Toast{Title: "Update", Message: "Group has been queued for an update", Success: true})
@@ -136,7 +136,7 @@ func groupTableAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get group
-	group, err := mongo.GetGroup(id)
+	group, err := mongo.GetGroup(r.Context(), id)
 	if err != nil {
 		err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 		if err != nil {
@@ -179,7 +179,7 @@ func groupTableAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionPlayerGroups, bson.D{{Key: "group_id", Value: id}}, 60*60*6)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerGroups, bson.D{{Key: "group_id", Value: id}}, 60*60*6)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -312,6 +312,7 @@ type globalTemplate struct {
 	CSSFiles     []Asset
 	JSFiles      []Asset
 	Canonical    string
+	FeedPath     string // Without extension, for feed discovery
 	ProductCCs   []i18n.ProductCountryCode
 	Continents   []i18n.Continent
 	CurrentCC    string
@@ -653,6 +654,10 @@ func (t globalTemplate) IsAdmin() bool {
 	return session.IsAdmin(t.request)
 }
 
+func (t globalTemplate) AdminCan(permission string) bool {
+	return session.HasAdminPermission(t.request, mysql.AdminPermission(permission))
+}
+
 func (t globalTemplate) IsLocal() bool {
 	return config.IsLocal()
 }
@@ -811,5 +816,5 @@ func getPlayerFromSession(r *http.Request) (player mongo.Player, err error) {
 		return player, ErrLoggedOut
 	}
 
-	return mongo.GetPlayer(playerID)
+	return mongo.GetPlayer(r.Context(), playerID)
 }
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/gamedb/gamedb/pkg/health"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -10,10 +11,13 @@ func HealthCheckRouter() http.Handler {
 
 	r := chi.NewRouter()
 	r.Get("/", healthCheckHandler)
+	r.Get("/live", health.LivenessHandler)
+	r.Get("/ready", health.ReadinessHandler(health.ReadyChecks...))
 
 	return r
 }
 
+// Kept as plain text for the Docker health check script
 func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, http.StatusText(http.StatusOK), http.StatusOK)
 }
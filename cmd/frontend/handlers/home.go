@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"html/template"
@@ -22,6 +23,7 @@ import (
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
 	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/session"
 	"github.com/gamedb/gamedb/pkg/steam"
 	"github.com/go-chi/chi/v5"
 	"github.com/olivere/elastic/v7"
@@ -134,7 +136,7 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 					i, err := strconv.Atoi(matches[1])
 					if err == nil {
 
-						app, err := mongo.GetApp(i)
+						app, err := mongo.GetApp(r.Context(), i)
 						if err != nil {
 							log.ErrS(err, zap.Int("app", i))
 							continue
@@ -155,7 +157,7 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 					i, err := strconv.Atoi(matches[1])
 					if err == nil {
 
-						sub, err := mongo.GetPackage(i)
+						sub, err := mongo.GetPackage(r.Context(), i)
 						if err != nil {
 							log.ErrS(err, zap.Int("sub", i))
 							continue
@@ -198,13 +200,31 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 		t.TopSellers = topSellers
 	}()
 
+	// Recommended games
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		playerID := session.GetPlayerIDFromSesion(r)
+		if playerID == 0 {
+			return
+		}
+
+		var err error
+		t.Recommended, err = mongo.GetPlayerRecommendations(playerID, 8)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
 	wg.Add(1)
 	go func() {
 
 		defer wg.Done()
 
 		var err error
-		t.PlayersCount, err = mongo.CountDocuments(mongo.CollectionPlayers, nil, 0)
+		t.PlayersCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -216,7 +236,7 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.AppsCount, err = mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+		t.AppsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -228,7 +248,7 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.BundlesCount, err = mongo.CountDocuments(mongo.CollectionBundles, nil, 0)
+		t.BundlesCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionBundles, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -240,7 +260,7 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PackagesCount, err = mongo.CountDocuments(mongo.CollectionPackages, nil, 0)
+		t.PackagesCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPackages, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -252,7 +272,7 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.AchievementsCount, err = mongo.CountDocuments(mongo.CollectionAppAchievements, nil, 0)
+		t.AchievementsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppAchievements, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -264,7 +284,7 @@ func HomeHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.ArticlesCount, err = mongo.CountDocuments(mongo.CollectionAppArticles, nil, 0)
+		t.ArticlesCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppArticles, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -285,6 +305,7 @@ type homeTemplate struct {
 	NewGames          []mongo.App
 	Upcoming          []elasticsearch.App
 	TopSellers        []homeTopSellerTemplate
+	Recommended       []mongo.PlayerRecommendation
 	Players           []mongo.Player
 	ConstApp          helpers.ProductType
 	ConstPackage      helpers.ProductType
@@ -474,7 +495,7 @@ type homeTweet struct {
 // 		{Key: "offer_end", Value: bson.M{"$gt": time.Now()}},
 // 	}
 //
-// 	sales, err := mongo.GetAllSales(0, 10, filter, bson.D{{Key: sort, Value: order}})
+// 	sales, err := mongo.GetAllSales(r.Context(), 0, 10, filter, bson.D{{Key: sort, Value: order}})
 // 	if err != nil {
 // 		log.ErrS(err)
 // 	}
@@ -521,7 +542,7 @@ func homeNewPlayersHandler(w http.ResponseWriter, r *http.Request) {
 		"created_at":   1,
 	}
 
-	players, err := mongo.GetPlayers(0, 10, bson.D{{Key: "created_at", Value: -1}}, nil, projection)
+	players, err := mongo.GetPlayers(r.Context(), 0, 10, bson.D{{Key: "created_at", Value: -1}}, nil, projection)
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -561,7 +582,7 @@ func homeTopPlayersHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	players, err := getPlayersForHome(sort)
+	players, err := getPlayersForHome(r.Context(), sort)
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -607,7 +628,7 @@ type homePlayer struct {
 	AwardsReceived string `json:"awards_received"`
 }
 
-func getPlayersForHome(sort string) (players []mongo.Player, err error) {
+func getPlayersForHome(ctx context.Context, sort string) (players []mongo.Player, err error) {
 
 	item := memcache.ItemHomePlayers(sort)
 	err = memcache.Client().GetSet(item.Key, item.Expiration, &players, func() (interface{}, error) {
@@ -624,9 +645,20 @@ func getPlayersForHome(sort string) (players []mongo.Player, err error) {
 			"bans_cav":               1,
 			"awards_given_points":    1,
 			"awards_received_points": 1,
+			"privacy":                1,
+		}
+
+		filter := bson.D{
+			{Key: sort, Value: bson.M{"$gt": 0}},
+			{Key: "privacy.hide_ranks", Value: bson.M{"$ne": true}},
+		}
+
+		players, err := mongo.GetPlayers(ctx, 0, 10, bson.D{{Key: sort, Value: -1}}, filter, projection)
+		for k := range players {
+			players[k].ApplyPrivacy()
 		}
 
-		return mongo.GetPlayers(0, 10, bson.D{{Key: sort, Value: -1}}, bson.D{{Key: sort, Value: bson.M{"$gt": 0}}}, projection)
+		return players, err
 	})
 
 	return players, err
@@ -8,6 +8,7 @@ import (
 	"github.com/Jleagle/steam-go/steamid"
 	"github.com/badoux/checkmail"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/captcha"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/twofactor"
 	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -26,6 +27,11 @@ func LoginRouter() http.Handler {
 	r := chi.NewRouter()
 	r.Get("/", loginHandler)
 	r.Post("/", loginPostHandler)
+	r.Get("/two-factor", loginTwoFactorHandler)
+	r.Post("/two-factor", loginTwoFactorPostHandler)
+	r.Get("/two-factor/cancel", loginTwoFactorCancelHandler)
+	r.Post("/two-factor/webauthn/begin", loginWebAuthnBeginHandler)
+	r.Post("/two-factor/webauthn/finish", loginWebAuthnFinishHandler)
 
 	return r
 }
@@ -37,6 +43,11 @@ func loginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, err := getTwoFactorUser(r); err == nil {
+		http.Redirect(w, r, "/login/two-factor", http.StatusFound)
+		return
+	}
+
 	t := loginTemplate{}
 	t.fill(w, r, "login", "Login", "Login to Global Steam")
 	t.hideAds = true
@@ -56,6 +67,8 @@ type loginTemplate struct {
 
 func loginPostHandler(w http.ResponseWriter, r *http.Request) {
 
+	var twoFactor bool
+
 	message, success := func() (message string, success bool) {
 
 		// Parse form
@@ -117,23 +130,22 @@ func loginPostHandler(w http.ResponseWriter, r *http.Request) {
 			return "Incorrect credentials", false
 		}
 
-		return login(r, user)
+		message, success, twoFactor = loginOrTwoFactor(r, user)
+		return message, success
 	}()
 
 	//
-	if success {
+	if twoFactor {
 
-		session.SetFlash(r, session.SessionGood, message)
 		session.Save(w, r)
+		http.Redirect(w, r, "/login/two-factor", http.StatusFound)
 
-		// Get last page
-		val := session.Get(r, session.SessionLastPage)
-		if val == "" {
-			val = "/settings"
-		}
+	} else if success {
+
+		session.SetFlash(r, session.SessionGood, message)
+		session.Save(w, r)
 
-		//
-		http.Redirect(w, r, val, http.StatusFound)
+		http.Redirect(w, r, loginRedirectPath(r), http.StatusFound)
 
 	} else {
 
@@ -146,25 +158,72 @@ func loginPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// The page the user was on before logging in
+func loginRedirectPath(r *http.Request) string {
+
+	val := session.Get(r, session.SessionLastPage)
+	if val == "" {
+		val = "/settings"
+	}
+	return val
+}
+
+// Logs the user in, or starts the second step if they have two-factor set up
+func loginOrTwoFactor(r *http.Request, user mysql.User) (message string, success bool, twoFactor bool) {
+
+	if !user.EmailVerified {
+		return "Please verify your email address first", false, false
+	}
+
+	methods, err := twofactor.GetMethods(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		return "An error occurred", false, false
+	}
+
+	if methods.IsEnabled() {
+		startTwoFactor(r, user)
+		return "", false, true
+	}
+
+	message, success = login(r, user)
+	return message, success, false
+}
+
+// Only call once any second factor has been checked
 func login(r *http.Request, user mysql.User) (string, bool) {
 
 	if !user.EmailVerified {
 		return "Please verify your email address first", false
 	}
 
+	userSession, err := mongo.CreateUserSession(r, user.ID)
+	if err != nil {
+		log.ErrS(err)
+		return "An error occurred", false
+	}
+
+	// Team members get the team's level
+	level, _, err := mysql.GetEffectiveUserLevel(user)
+	if err != nil {
+		log.ErrS(err)
+	}
+
 	// Log user in
 	session.SetMany(r, map[string]string{
-		session.SessionUserID:     strconv.Itoa(user.ID),
-		session.SessionUserEmail:  user.Email,
-		session.SessionUserProdCC: string(user.ProductCC),
-		session.SessionUserAPIKey: user.APIKey,
-		session.SessionUserLevel:  strconv.Itoa(int(user.Level)),
+		session.SessionUserSessionID: userSession.ID,
+		session.SessionUserID:        strconv.Itoa(user.ID),
+		session.SessionUserEmail:     user.Email,
+		session.SessionUserProdCC:    string(user.ProductCC),
+		session.SessionUserAPIKey:    user.APIKey,
+		session.SessionUserLevel:     strconv.Itoa(int(level)),
+		session.SessionUserAdminRole: string(user.GetAdminRole()),
 		// session.SessionUserShowAlerts: strconv.FormatBool(user.ShowAlerts),
 	})
 
 	playerID := mysql.GetUserSteamID(user.ID)
 	if playerID > 0 {
-		player, err := mongo.GetPlayer(playerID)
+		player, err := mongo.GetPlayer(r.Context(), playerID)
 		if err == nil {
 			session.SetMany(r, map[string]string{
 				session.SessionPlayerID:    strconv.FormatInt(player.ID, 10),
@@ -180,7 +239,7 @@ func login(r *http.Request, user mysql.User) (string, bool) {
 	}
 
 	// Create login event
-	err := mongo.NewEvent(r, user.ID, mongo.EventLogin)
+	err = mongo.NewEvent(r, user.ID, mongo.EventLogin)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/twofactor"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/session"
+	"go.uber.org/zap"
+)
+
+// Set once the password or OAuth step has passed, until the second factor is checked
+const (
+	loginSessionTwoFactorUser = "login-two-factor-user"
+	loginSessionTwoFactorTime = "login-two-factor-time"
+
+	loginTwoFactorTimeout  = time.Minute * 10
+	loginTwoFactorMaxFails = 5
+)
+
+var errTwoFactorExpired = errors.New("two-factor login expired")
+
+func startTwoFactor(r *http.Request, user mysql.User) {
+
+	session.SetMany(r, map[string]string{
+		loginSessionTwoFactorUser: strconv.Itoa(user.ID),
+		loginSessionTwoFactorTime: strconv.FormatInt(time.Now().Unix(), 10),
+	})
+}
+
+func clearTwoFactor(r *http.Request) {
+	session.DeleteMany(r, []string{loginSessionTwoFactorUser, loginSessionTwoFactorTime})
+}
+
+func getTwoFactorUser(r *http.Request) (user mysql.User, err error) {
+
+	userID, err := strconv.Atoi(session.Get(r, loginSessionTwoFactorUser))
+	if err != nil || userID == 0 {
+		return user, errTwoFactorExpired
+	}
+
+	started, err := strconv.ParseInt(session.Get(r, loginSessionTwoFactorTime), 10, 64)
+	if err != nil || time.Since(time.Unix(started, 0)) > loginTwoFactorTimeout {
+		return user, errTwoFactorExpired
+	}
+
+	locked, err := twoFactorLocked(userID)
+	if err != nil {
+		return user, err
+	}
+	if locked {
+		return user, errTwoFactorExpired
+	}
+
+	return mysql.GetUserByID(userID)
+}
+
+// Failures are counted in memcache, the session cookie can be replayed
+func twoFactorLocked(userID int) (bool, error) {
+
+	fails, err := memcache.Client().Counter(memcache.ItemTwoFactorFails(userID).Key)
+	if err != nil {
+		return true, err
+	}
+
+	return fails >= loginTwoFactorMaxFails, nil
+}
+
+// Returns true if there are attempts left
+func countTwoFactorFail(r *http.Request, user mysql.User) bool {
+
+	err := mongo.NewEvent(r, user.ID, mongo.EventTwoFactorFail)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	item := memcache.ItemTwoFactorFails(user.ID)
+	fails, err := memcache.Client().Increment(item.Key, item.Expiration)
+	if err != nil {
+		log.ErrS(err)
+		return false
+	}
+
+	return fails < loginTwoFactorMaxFails
+}
+
+// Returns true if there are attempts left
+func failTwoFactor(r *http.Request, user mysql.User) bool {
+
+	if !countTwoFactorFail(r, user) {
+		clearTwoFactor(r)
+		return false
+	}
+
+	return true
+}
+
+func resetTwoFactorFails(user mysql.User) {
+
+	err := memcache.Client().Delete(memcache.ItemTwoFactorFails(user.ID).Key)
+	err = helpers.IgnoreErrors(err, memcache.ErrNotFound)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
+
+// Checks an authenticator app code, or a recovery code
+func checkTwoFactorCode(user mysql.User, methods twofactor.Methods, code string) (ok bool, err error) {
+
+	code = strings.Replace(strings.TrimSpace(code), " ", "", -1)
+
+	if methods.TOTP && helpers.RegexIntsOnly.MatchString(code) {
+		return twofactor.CheckTOTP(user.ID, code)
+	}
+
+	ok, err = mysql.UseUserRecoveryCode(user.ID, code)
+	if ok {
+		log.Info("Recovery code used", zap.Int("user", user.ID))
+	}
+	return ok, err
+}
+
+func loginTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getTwoFactorUser(r)
+	if err != nil {
+		err = helpers.IgnoreErrors(err, errTwoFactorExpired, mysql.ErrRecordNotFound)
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		clearTwoFactor(r)
+		session.SetFlash(r, session.SessionBad, "Please login again")
+		session.Save(w, r)
+
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	t := loginTwoFactorTemplate{}
+	t.fill(w, r, "login_two_factor", "Login", "Two-factor authentication")
+	t.hideAds = true
+
+	t.Methods, err = twofactor.GetMethods(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	returnTemplate(w, r, t)
+}
+
+type loginTwoFactorTemplate struct {
+	globalTemplate
+	Methods twofactor.Methods
+}
+
+func loginTwoFactorPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getTwoFactorUser(r)
+	if err != nil {
+		http.Redirect(w, r, "/login/two-factor", http.StatusFound)
+		return
+	}
+
+	ok, err := func() (bool, error) {
+
+		err := r.ParseForm()
+		if err != nil {
+			return false, err
+		}
+
+		methods, err := twofactor.GetMethods(user.ID)
+		if err != nil {
+			return false, err
+		}
+
+		return checkTwoFactorCode(user, methods, r.PostForm.Get("code"))
+	}()
+	if err != nil {
+		err = helpers.IgnoreErrors(err, mysql.ErrRecordNotFound)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+
+	if !ok {
+
+		time.Sleep(time.Second)
+
+		if failTwoFactor(r, user) {
+			session.SetFlash(r, session.SessionBad, "Incorrect code")
+		} else {
+			session.SetFlash(r, session.SessionBad, "Too many incorrect codes, please try again in 10 minutes")
+		}
+
+		session.Save(w, r)
+		http.Redirect(w, r, "/login/two-factor", http.StatusFound)
+		return
+	}
+
+	clearTwoFactor(r)
+	resetTwoFactorFails(user)
+
+	message, success := login(r, user)
+	if success {
+		session.SetFlash(r, session.SessionGood, message)
+		session.Save(w, r)
+		http.Redirect(w, r, loginRedirectPath(r), http.StatusFound)
+	} else {
+		session.SetFlash(r, session.SessionBad, message)
+		session.Save(w, r)
+		http.Redirect(w, r, "/login", http.StatusFound)
+	}
+}
+
+func loginTwoFactorCancelHandler(w http.ResponseWriter, r *http.Request) {
+
+	clearTwoFactor(r)
+	session.Save(w, r)
+
+	http.Redirect(w, r, "/login", http.StatusFound)
+}
+
+func loginWebAuthnBeginHandler(w http.ResponseWriter, r *http.Request) {
+
+	options, err := func() (interface{}, error) {
+
+		user, err := getTwoFactorUser(r)
+		if err != nil {
+			return nil, err
+		}
+
+		wa, err := twofactor.WebAuthn()
+		if err != nil {
+			return nil, err
+		}
+
+		waUser, err := twofactor.NewUser(user)
+		if err != nil {
+			return nil, err
+		}
+
+		options, data, err := wa.BeginLogin(waUser)
+		if err != nil {
+			return nil, err
+		}
+
+		return options, twofactor.SaveSession(r, data)
+	}()
+
+	if err != nil {
+		err = helpers.IgnoreErrors(err, errTwoFactorExpired)
+		if err != nil {
+			log.ErrS(err)
+		}
+		returnJSON(w, r, webAuthnResponse{Error: "Please login again"})
+		return
+	}
+
+	session.Save(w, r)
+	returnJSON(w, r, options)
+}
+
+type webAuthnResponse struct {
+	Error    string `json:"error,omitempty"`
+	Redirect string `json:"redirect,omitempty"`
+}
+
+func loginWebAuthnFinishHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getTwoFactorUser(r)
+	if err != nil {
+		returnJSON(w, r, webAuthnResponse{Error: "Please login again"})
+		return
+	}
+
+	err = func() error {
+
+		data, err := twofactor.GetSession(r)
+		if err != nil {
+			return err
+		}
+
+		wa, err := twofactor.WebAuthn()
+		if err != nil {
+			return err
+		}
+
+		waUser, err := twofactor.NewUser(user)
+		if err != nil {
+			return err
+		}
+
+		credential, err := wa.FinishLogin(waUser, data, r)
+		if err != nil {
+			return err
+		}
+
+		if credential.Authenticator.CloneWarning {
+			log.Warn("Security key may have been cloned", zap.Int("user", user.ID))
+			return errors.New("clone warning")
+		}
+
+		return mysql.TouchUserSecurityKey(user.ID, credential.ID, credential.Authenticator.SignCount)
+	}()
+
+	if err != nil {
+
+		log.Info("Security key login failed", zap.Int("user", user.ID), zap.Error(err))
+
+		var response = webAuthnResponse{Error: "Your security key could not be verified"}
+		if !failTwoFactor(r, user) {
+			response = webAuthnResponse{Error: "Too many failed attempts, please try again in 10 minutes", Redirect: "/login"}
+		}
+
+		session.Save(w, r)
+		returnJSON(w, r, response)
+		return
+	}
+
+	clearTwoFactor(r)
+	resetTwoFactorFails(user)
+
+	message, success := login(r, user)
+	if !success {
+		session.SetFlash(r, session.SessionBad, message)
+		session.Save(w, r)
+		returnJSON(w, r, webAuthnResponse{Redirect: "/login"})
+		return
+	}
+
+	session.SetFlash(r, session.SessionGood, message)
+	session.Save(w, r)
+	returnJSON(w, r, webAuthnResponse{Redirect: loginRedirectPath(r)})
+}
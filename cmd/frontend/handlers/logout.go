@@ -25,6 +25,11 @@ func logoutHandler(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			log.ErrS(err)
 		}
+
+		err = mongo.DeleteUserSession(userID, session.Get(r, session.SessionUserSessionID))
+		if err != nil {
+			log.ErrS(err)
+		}
 	}
 
 	// Get last page
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/feed"
+	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
+	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/session"
@@ -19,6 +24,7 @@ func NewsRouter() http.Handler {
 	r := chi.NewRouter()
 	r.Get("/", newsHandler)
 	r.Get("/news.json", newsAjaxHandler)
+	r.Get("/feed.{format:(rss|atom|json)}", newsFeedHandler)
 	return r
 }
 
@@ -26,6 +32,7 @@ func newsHandler(w http.ResponseWriter, r *http.Request) {
 
 	t := newsTemplate{}
 	t.fill(w, r, "news", "News", "All the news from all the games on Steam")
+	t.FeedPath = "/news/feed"
 	t.addAssetChosen()
 
 	feeds, err := mongo.GetAppArticlesGroupedByFeed(0)
@@ -101,7 +108,7 @@ func newsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 				break
 			}
 
-			apps, err := mongo.GetRecentApps(playerID, 0, 0, nil)
+			apps, err := mongo.GetRecentApps(r.Context(), playerID, 0, 0, nil)
 			if err != nil {
 				log.ErrS(err)
 				break
@@ -152,7 +159,7 @@ func newsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionAppArticles, nil, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppArticles, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -167,3 +174,66 @@ func newsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	returnJSON(w, r, response)
 }
+
+func newsFeedHandler(w http.ResponseWriter, r *http.Request) {
+
+	var filter = bson.D{}
+	var title = "Steam News - Global Steam"
+
+	// Optionally limit to one game
+	appID, err := strconv.Atoi(r.URL.Query().Get("app"))
+	if err == nil && helpers.IsValidAppID(appID) {
+		filter = append(filter, bson.E{Key: "app_id", Value: appID})
+	}
+
+	articles, err := mongo.GetArticles(0, 50, bson.D{{Key: "date", Value: -1}}, filter)
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	if appID > 0 && len(articles) > 0 {
+		title = helpers.GetAppName(articles[0].AppID, articles[0].AppName) + " News - Global Steam"
+	}
+
+	format := feed.Format(chi.URLParam(r, "format"))
+
+	f := feed.Feed{
+		Title:       title,
+		Link:        config.C.GlobalSteamDomain + "/news",
+		FeedLink:    config.C.GlobalSteamDomain + "/news/feed." + string(format),
+		Description: "All the news from all the games on Steam",
+		MaxAge:      time.Minute * 10,
+	}
+
+	if r.URL.RawQuery != "" {
+		f.FeedLink += "?" + r.URL.RawQuery
+	}
+
+	for k, article := range articles {
+
+		if k == 0 {
+			f.Updated = article.Date
+		}
+
+		link := article.URL
+		if link == "" {
+			link = helpers.GetAppPathAbsolute(article.AppID, article.AppName) + "#news"
+		}
+
+		f.Items = append(f.Items, feed.Item{
+			ID:          config.C.GlobalSteamDomain + "/news#" + strconv.FormatInt(article.ID, 10),
+			Title:       helpers.GetAppName(article.AppID, article.AppName) + ": " + article.Title,
+			Link:        link,
+			Description: string(article.GetBody()),
+			Image:       article.GetArticleIcon(),
+			Published:   article.Date,
+		})
+	}
+
+	err = f.Write(w, r, format)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
@@ -25,6 +25,8 @@ const (
 	authPageLogin    = "login"
 	authPageSignup   = "signup"
 	authPageSettings = "settings"
+
+	authPageTwoFactor = "two-factor" // Only set after the provider, can't be passed in
 )
 
 func OauthRouter() http.Handler {
@@ -278,6 +280,8 @@ func oauthRedirect(w http.ResponseWriter, r *http.Request, page *string) {
 		http.Redirect(w, r, "/signup", http.StatusFound)
 	case authPageSettings:
 		http.Redirect(w, r, "/settings", http.StatusFound)
+	case authPageTwoFactor:
+		http.Redirect(w, r, "/login/two-factor", http.StatusFound)
 	default:
 		http.Redirect(w, r, "/", http.StatusFound)
 	}
@@ -414,9 +418,12 @@ func oauthHandleUser(provider oauth.Provider, resp oauth.User, page *string, r *
 	// Log the user in
 	switch *page {
 	case authPageLogin, authPageSignup:
-		err, ok := login(r, user)
-		if !ok {
-			session.SetFlash(r, session.SessionBad, err)
+		message, ok, twoFactor := loginOrTwoFactor(r, user)
+		if twoFactor {
+			*page = authPageTwoFactor
+			return
+		} else if !ok {
+			session.SetFlash(r, session.SessionBad, message)
 		}
 	}
 
@@ -446,14 +453,14 @@ func oauthHandleUser(provider oauth.Provider, resp oauth.User, page *string, r *
 		}
 
 		ua := r.UserAgent()
-		err = consumers.ProducePlayer(consumers.PlayerMessage{ID: i, UserAgent: &ua}, "frontend-oauth")
+		err = consumers.ProducePlayer(r.Context(), consumers.PlayerMessage{ID: i, UserAgent: &ua}, "frontend-oauth")
 		if err = helpers.IgnoreErrors(err, consumers.ErrIsBot, consumers.ErrInQueue); err != nil {
 			log.ErrS(err)
 			break
 		}
 
 		// This already happens in login() but do it again in case you're just linking
-		player, err := mongo.GetPlayer(i)
+		player, err := mongo.GetPlayer(r.Context(), i)
 		if err != nil {
 			err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 			if err != nil {
@@ -500,7 +507,7 @@ func oauthHandleUser(provider oauth.Provider, resp oauth.User, page *string, r *
 				break
 			}
 
-			player, err := mongo.GetPlayer(i)
+			player, err := mongo.GetPlayer(r.Context(), i)
 			if err != nil {
 				err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
 				if err != nil {
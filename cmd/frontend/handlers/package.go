@@ -33,7 +33,7 @@ func packageHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get package
-	pack, err := mongo.GetPackage(id)
+	pack, err := mongo.GetPackage(r.Context(), id)
 	if err != nil {
 
 		if err == mongo.ErrNoDocuments {
@@ -65,7 +65,7 @@ func packageHandler(w http.ResponseWriter, r *http.Request) {
 			appsMap[v] = mongo.App{ID: v}
 		}
 
-		appsSlice, err = mongo.GetAppsByID(pack.Apps, bson.M{"_id": 1, "name": 1, "icon": 1, "type": 1, "platforms": 1, "dlc": 1, "common": 1, "background": 1})
+		appsSlice, err = mongo.GetAppsByID(r.Context(), pack.Apps, bson.M{"_id": 1, "name": 1, "icon": 1, "type": 1, "platforms": 1, "dlc": 1, "common": 1, "background": 1})
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -83,7 +83,7 @@ func packageHandler(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		err = consumers.ProduceSteam(consumers.SteamMessage{AppIDs: missingAppIDs})
+		err = consumers.ProduceSteam(r.Context(), consumers.SteamMessage{AppIDs: missingAppIDs})
 		err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 		if err != nil {
 			log.ErrS(err)
@@ -142,7 +142,7 @@ func packageHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		err = consumers.ProduceSteam(consumers.SteamMessage{PackageIDs: []int{pack.ID}})
+		err = consumers.ProduceSteam(r.Context(), consumers.SteamMessage{PackageIDs: []int{pack.ID}})
 		if err == nil {
 			t.addToast(Toast{Title: "Update", Message: "Package has been queued for an update", Success: true})
 			log.Info("package queued", zap.String("ua", r.UserAgent()))
@@ -135,7 +135,7 @@ func packagesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		count, err = mongo.CountDocuments(mongo.CollectionPackages, nil, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionPackages, nil, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -151,7 +151,7 @@ func packagesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		filtered, err = mongo.CountDocuments(mongo.CollectionPackages, filter, 0)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionPackages, filter, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -12,6 +12,7 @@ import (
 	"github.com/Jleagle/rabbit-go"
 	"github.com/dustin/go-humanize"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/pkg/abuse"
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/crons/helpers/rabbitweb"
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -72,20 +73,38 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Find the player row
-	player, err := mongo.GetPlayer(id)
+	player, err := mongo.GetPlayer(r.Context(), id)
 	if err == mongo.ErrNoDocuments {
 
-		ua := r.UserAgent()
-		err = consumers.ProducePlayer(consumers.PlayerMessage{ID: id, UserAgent: &ua, ForceAchievementsRefresh: true}, "frontend-player-missing")
-		if err = helpers.IgnoreErrors(err, consumers.ErrInQueue, consumers.ErrIsBot); err != nil {
-			log.ErrS(err)
+		reason := checkPlayerAbuse(w, r, abuse.ActionPlayerAdd)
+		if reason == abuse.ReasonCaptcha {
+
+			session.SetFlash(r, session.SessionBad, reason.Message())
+			session.Save(w, r)
+
+			http.Redirect(w, r, "/players/add?search="+strconv.FormatInt(id, 10), http.StatusFound)
+			return
+		}
+
+		if reason == abuse.ReasonNone {
+
+			ua := r.UserAgent()
+			err = consumers.ProducePlayer(r.Context(), consumers.PlayerMessage{ID: id, UserAgent: &ua, ForceAchievementsRefresh: true}, "frontend-player-missing")
+			if err = helpers.IgnoreErrors(err, consumers.ErrInQueue, consumers.ErrIsBot); err != nil {
+				log.ErrS(err)
+			}
 		}
 
 		// Template
 		tm := playerMissingTemplate{}
-		tm.fill(w, r, "player_missing", "Looking for player!", "")
+		if reason == abuse.ReasonNone {
+			tm.fill(w, r, "player_missing", "Looking for player!", "")
+			tm.addToast(Toast{Title: "Update", Message: "Player has been queued for an update", Success: true})
+		} else {
+			tm.fill(w, r, "player_missing", reason.Message(), "")
+			tm.Blocked = reason.Message()
+		}
 		tm.addAssetHighCharts()
-		tm.addToast(Toast{Title: "Update", Message: "Player has been queued for an update", Success: true})
 		tm.Player = player
 		tm.DefaultAvatar = helpers.DefaultPlayerAvatar
 
@@ -115,6 +134,12 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Hide what the player has opted out of, unless it's their own profile
+	var privacy = getPlayerPrivacy(r, player)
+	if privacy != (mongo.PlayerPrivacy{}) {
+		player.ApplyPrivacy()
+	}
+
 	var code = session.GetProductCC(r)
 	player.GameStats.All.ProductCC = code
 	player.GameStats.Played.ProductCC = code
@@ -130,7 +155,7 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		playersCount, err = mongo.CountDocuments(mongo.CollectionPlayers, nil, 0)
+		playersCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -145,7 +170,7 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 			defer wg.Done()
 
 			var err error
-			playersContinentCount, err = mongo.CountDocuments(mongo.CollectionPlayers, bson.D{{Key: "continent_code", Value: player.ContinentCode}}, 60*60*24*7)
+			playersContinentCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, bson.D{{Key: "continent_code", Value: player.ContinentCode}}, 60*60*24*7)
 			if err != nil {
 				log.ErrS(err)
 			}
@@ -161,7 +186,7 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 			defer wg.Done()
 
 			var err error
-			playersCountryCount, err = mongo.CountDocuments(mongo.CollectionPlayers, bson.D{{Key: "country_code", Value: player.CountryCode}}, 60*60*24*7)
+			playersCountryCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, bson.D{{Key: "country_code", Value: player.CountryCode}}, 60*60*24*7)
 			if err != nil {
 				log.ErrS(err)
 			}
@@ -177,7 +202,7 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 			defer wg.Done()
 
 			var err error
-			playersStateCount, err = mongo.CountDocuments(mongo.CollectionPlayers, bson.D{{Key: "country_code", Value: player.CountryCode}, {Key: "status_code", Value: player.StateCode}}, 60*60*24*7)
+			playersStateCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, bson.D{{Key: "country_code", Value: player.CountryCode}, {Key: "status_code", Value: player.StateCode}}, 60*60*24*7)
 			if err != nil {
 				log.ErrS(err)
 			}
@@ -193,10 +218,10 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 			defer wg.Done()
 
 			var err error
-			backgroundApp, err = mongo.GetApp(player.BackgroundAppID)
+			backgroundApp, err = mongo.GetApp(r.Context(), player.BackgroundAppID)
 			err = helpers.IgnoreErrors(err, mongo.ErrInvalidAppID)
 			if err == mongo.ErrNoDocuments {
-				err = consumers.ProduceSteam(consumers.SteamMessage{AppIDs: []int{player.BackgroundAppID}})
+				err = consumers.ProduceSteam(r.Context(), consumers.SteamMessage{AppIDs: []int{player.BackgroundAppID}})
 				if err != nil {
 					log.ErrS(err, player.BackgroundAppID)
 				}
@@ -234,7 +259,7 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		aliases, err = mongo.GetPlayerAliases(player.ID, 0, 20)
+		aliases, err = mongo.GetPlayerAliases(r.Context(), player.ID, 0, 20)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -295,7 +320,7 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 	if player.NeedsUpdate(mongo.PlayerUpdateAuto) {
 
 		ua := r.UserAgent()
-		err = consumers.ProducePlayer(consumers.PlayerMessage{ID: player.ID, UserAgent: &ua}, "frontend-update-request")
+		err = consumers.ProducePlayer(r.Context(), consumers.PlayerMessage{ID: player.ID, UserAgent: &ua}, "frontend-update-request")
 		if err == nil {
 			t.addToast(Toast{Title: "Update", Message: "Player has been queued for an update", Success: true})
 		}
@@ -319,6 +344,7 @@ func playerHandler(w http.ResponseWriter, r *http.Request) {
 	t.InQueue = inQueue
 	t.User = user
 	t.Aliases = aliases
+	t.Privacy = privacy
 
 	for _, metric := range helpers.PlayerRankFields {
 
@@ -434,6 +460,30 @@ type playerTemplate struct {
 	User          mysql.User
 	WishListTotal string
 	Aliases       []mongo.PlayerAlias
+	Privacy       mongo.PlayerPrivacy
+}
+
+// The owner always sees their own profile in full
+func getPlayerPrivacy(r *http.Request, player mongo.Player) mongo.PlayerPrivacy {
+
+	if player.ID == session.GetPlayerIDFromSesion(r) {
+		return mongo.PlayerPrivacy{}
+	}
+	return player.Privacy
+}
+
+// For the ajax endpoints, which only have the ID
+func getPlayerPrivacyByID(r *http.Request, playerID int64) (privacy mongo.PlayerPrivacy, err error) {
+
+	player, err := mongo.GetPlayer(r.Context(), playerID)
+	if err == mongo.ErrNoDocuments {
+		return privacy, nil
+	}
+	if err != nil {
+		return privacy, err
+	}
+
+	return getPlayerPrivacy(r, player), nil
 }
 
 func (t playerTemplate) TypePercent(typex string) string {
@@ -456,6 +506,7 @@ type playerMissingTemplate struct {
 	Player        mongo.Player
 	DefaultAvatar string
 	Queue         int
+	Blocked       string // Why the player wasn't queued
 }
 
 func playerAddFriendsHandler(w http.ResponseWriter, r *http.Request) {
@@ -472,7 +523,7 @@ func playerAddFriendsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !session.IsAdmin(r) {
+	if !session.HasAdminPermission(r, mysql.AdminPermissionQueues) {
 
 		user, err := getUserFromSession(r)
 		if err != nil {
@@ -497,7 +548,7 @@ func playerAddFriendsHandler(w http.ResponseWriter, r *http.Request) {
 	var friendIDs []int64
 	var friendIDsMap = map[int64]bool{}
 
-	friends, err := mongo.GetFriends(idx, 0, 0, nil, nil)
+	friends, err := mongo.GetFriends(r.Context(), idx, 0, 0, nil, nil)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -507,7 +558,7 @@ func playerAddFriendsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Remove players we already have
-	players, err := mongo.GetPlayersByID(friendIDs, bson.M{"_id": 1})
+	players, err := mongo.GetPlayersByID(r.Context(), friendIDs, bson.M{"_id": 1})
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -519,7 +570,7 @@ func playerAddFriendsHandler(w http.ResponseWriter, r *http.Request) {
 	for friendID := range friendIDsMap {
 
 		ua := r.UserAgent()
-		err = consumers.ProducePlayer(consumers.PlayerMessage{ID: friendID, UserAgent: &ua}, "frontend-friends")
+		err = consumers.ProducePlayer(r.Context(), consumers.PlayerMessage{ID: friendID, UserAgent: &ua}, "frontend-friends")
 		if err = helpers.IgnoreErrors(err, consumers.ErrIsBot, consumers.ErrInQueue); err != nil {
 			log.ErrS(err)
 		}
@@ -536,6 +587,12 @@ func playerGamesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	privacy, err := getPlayerPrivacyByID(r, id)
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
+
 	var query = datatable.NewDataTableQuery(r, false)
 	var code = session.GetProductCC(r)
 
@@ -567,6 +624,11 @@ func playerGamesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			"4": "app_achievements_percent, app_achievements_have x, app_achievements_total x",
 		}
 
+		if privacy.HidePlaytime {
+			delete(columns, "2")
+			delete(columns, "3")
+		}
+
 		var err error
 		playerApps, err = mongo.GetPlayerApps(query.GetOffset64(), 100, filter2, query.GetOrderMongo(columns))
 		if err != nil {
@@ -582,7 +644,7 @@ func playerGamesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		totalFiltered, err = mongo.CountDocuments(mongo.CollectionPlayerApps, filter2, 0)
+		totalFiltered, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerApps, filter2, 0)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -597,7 +659,7 @@ func playerGamesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionPlayerApps, filter, 0)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerApps, filter, 0)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -609,6 +671,12 @@ func playerGamesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	var response = datatable.NewDataTablesResponse(r, query, total, totalFiltered, nil)
 	for _, pa := range playerApps {
+
+		if privacy.HidePlaytime {
+			pa.AppTime = 0
+			pa.AppPriceHour = nil
+		}
+
 		response.AddRow([]interface{}{
 			pa.AppID,                       // 0
 			pa.AppName,                     // 1
@@ -634,6 +702,12 @@ func playerRecentAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	privacy, err := getPlayerPrivacyByID(r, id)
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
+
 	query := datatable.NewDataTableQuery(r, false)
 
 	//
@@ -652,8 +726,12 @@ func playerRecentAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			"2": "playtime_forever",
 		}
 
+		if privacy.HidePlaytime {
+			columns = map[string]string{"0": "name"}
+		}
+
 		var err error
-		apps, err = mongo.GetRecentApps(id, query.GetOffset64(), 100, query.GetOrderMongo(columns))
+		apps, err = mongo.GetRecentApps(r.Context(), id, query.GetOffset64(), 100, query.GetOrderMongo(columns))
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -667,7 +745,7 @@ func playerRecentAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		player, err := mongo.GetPlayer(id)
+		player, err := mongo.GetPlayer(r.Context(), id)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -681,6 +759,12 @@ func playerRecentAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	var response = datatable.NewDataTablesResponse(r, query, total, total, nil)
 	for _, app := range apps {
+
+		if privacy.HidePlaytime {
+			app.PlayTime2Weeks = 0
+			app.PlayTimeForever = 0
+		}
+
 		response.AddRow([]interface{}{
 			app.AppID,                               // 0
 			helpers.GetAppIcon(app.AppID, app.Icon), // 1
@@ -750,7 +834,7 @@ func playerAchievementsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		count, err = mongo.CountDocuments(mongo.CollectionPlayerAchievements, bson.D{{Key: "player_id", Value: playerID}}, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerAchievements, bson.D{{Key: "player_id", Value: playerID}}, 0)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -793,6 +877,20 @@ func playerFriendsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := datatable.NewDataTableQuery(r, true)
 
+	privacy, err := getPlayerPrivacyByID(r, playerIDInt)
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
+
+	if privacy.HideFriends {
+		returnJSON(w, r, datatable.NewDataTablesResponse(r, query, 0, 0, nil))
+		return
+	}
+
+	// Friends that hide from other players' lists
+	var filter = bson.D{{Key: "hidden", Value: bson.M{"$ne": true}}}
+
 	//
 	var wg sync.WaitGroup
 
@@ -810,7 +908,7 @@ func playerFriendsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		var err error
-		friends, err = mongo.GetFriends(playerIDInt, query.GetOffset64(), 100, query.GetOrderMongo(columns), nil)
+		friends, err = mongo.GetFriends(r.Context(), playerIDInt, query.GetOffset64(), 100, query.GetOrderMongo(columns), filter)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -824,7 +922,7 @@ func playerFriendsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		count, err = mongo.CountFriends(playerIDInt)
+		count, err = mongo.CountFriends(playerIDInt, filter)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -908,7 +1006,7 @@ func playerBadgesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		var err error
 
 		lock.Lock()
-		total, err = mongo.CountDocuments(mongo.CollectionPlayerBadges, filter, 0)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerBadges, filter, 0)
 		lock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -925,7 +1023,7 @@ func playerBadgesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		var err error
 
 		lock.Lock()
-		filtered, err = mongo.CountDocuments(mongo.CollectionPlayerBadges, filter2, 0)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerBadges, filter2, 0)
 		lock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -992,7 +1090,7 @@ func playerWishlistAppsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		var err error
-		wishlistApps, err = mongo.GetPlayerWishlistAppsByPlayer(id, query.GetOffset64(), 0, query.GetOrderMongo(columns), nil)
+		wishlistApps, err = mongo.GetPlayerWishlistAppsByPlayer(r.Context(), id, query.GetOffset64(), 0, query.GetOrderMongo(columns), nil)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -1007,7 +1105,7 @@ func playerWishlistAppsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		player, err := mongo.GetPlayer(id)
+		player, err := mongo.GetPlayer(r.Context(), id)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -1051,7 +1149,7 @@ func playerGroupsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := mongo.GetPlayer(id)
+	player, err := mongo.GetPlayer(r.Context(), id)
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -1075,7 +1173,7 @@ func playerGroupsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		var err error
-		groups, err = mongo.GetPlayerGroups(id, query.GetOffset64(), 100, query.GetOrderMongo(columns))
+		groups, err = mongo.GetPlayerGroups(r.Context(), id, query.GetOffset64(), 100, query.GetOrderMongo(columns))
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -1090,7 +1188,7 @@ func playerGroupsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		player, err := mongo.GetPlayer(id)
+		player, err := mongo.GetPlayer(r.Context(), id)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -1121,6 +1219,8 @@ func playerGroupsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 func playersUpdateAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
+	var reason abuse.Reason
+
 	message, success, err := func(r *http.Request) (string, bool, error) {
 
 		if !nosurf.VerifyToken(nosurf.Token(r), r.URL.Query().Get("csrf")) || r.URL.Query().Get("csrf") == "" {
@@ -1139,7 +1239,7 @@ func playersUpdateAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var message string
 
-		player, err := mongo.GetPlayer(idx)
+		player, err := mongo.GetPlayer(r.Context(), idx)
 		if err == nil {
 			message = "Updating player!"
 		} else if err == mongo.ErrNoDocuments {
@@ -1151,7 +1251,7 @@ func playersUpdateAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		updateType := mongo.PlayerUpdateManual
-		if session.IsAdmin(r) {
+		if session.HasAdminPermission(r, mysql.AdminPermissionQueues) {
 			message = "Admin update!"
 			updateType = mongo.PlayerUpdateAdmin
 		}
@@ -1160,8 +1260,13 @@ func playersUpdateAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			return "Player can't be updated yet", false, nil
 		}
 
+		reason = checkPlayerAbuse(w, r, abuse.ActionPlayerUpdate)
+		if reason != abuse.ReasonNone {
+			return reason.Message(), false, nil
+		}
+
 		ua := r.UserAgent()
-		err = consumers.ProducePlayer(consumers.PlayerMessage{ID: player.ID, UserAgent: &ua, ForceAchievementsRefresh: true}, "frontend-udate-click")
+		err = consumers.ProducePlayer(r.Context(), consumers.PlayerMessage{ID: player.ID, UserAgent: &ua, ForceAchievementsRefresh: true}, "frontend-udate-click")
 		if err = helpers.IgnoreErrors(err, consumers.ErrIsBot, consumers.ErrInQueue); err != nil {
 			log.ErrS(err)
 			return "Something has gone wrong", false, err
@@ -1174,6 +1279,7 @@ func playersUpdateAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		Success: success,
 		Toast:   message,
 		Log:     err,
+		Captcha: reason == abuse.ReasonCaptcha,
 	}
 
 	returnJSON(w, r, response)
@@ -1183,6 +1289,7 @@ type PlayersUpdateResponse struct {
 	Success bool   `json:"success"` // Red or green
 	Toast   string `json:"toast"`   // Browser notification
 	Log     error  `json:"log"`     // Console log
+	Captcha bool   `json:"captcha"` // Send to the add page to do a captcha
 }
 
 func playersHistoryAjaxHandler(w http.ResponseWriter, r *http.Request) {
@@ -1193,6 +1300,24 @@ func playersHistoryAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	playerID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return
+	}
+
+	privacy, err := getPlayerPrivacyByID(r, playerID)
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
+
+	var hc influx.HighChartsJSON
+
+	if privacy.NoHistory {
+		returnJSON(w, r, hc)
+		return
+	}
+
 	fields := []schemas.PlayerField{
 		schemas.InfPlayersAchievements,
 		schemas.InfPlayersBadges,
@@ -1219,6 +1344,9 @@ func playersHistoryAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	builder := influxql.NewBuilder()
 	for _, v := range fields {
+		if privacy.HidePlaytime && (v == schemas.InfPlayersPlaytime || v == schemas.InfPlayersPlaytimeRank) {
+			continue
+		}
 		builder.AddSelect("MAX("+string(v)+")", "max_"+string(v))
 	}
 	builder.SetFrom(influx.InfluxGameDB, influx.InfluxRetentionPolicyAllTime.String(), influx.InfluxMeasurementPlayers.String())
@@ -1233,8 +1361,6 @@ func playersHistoryAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var hc influx.HighChartsJSON
-
 	if len(resp.Results) > 0 && len(resp.Results[0].Series) > 0 {
 
 		hc = influx.InfluxResponseToHighCharts(resp.Results[0].Series[0], true)
@@ -1257,6 +1383,17 @@ func playerAchievementInfluxAjaxHandler(w http.ResponseWriter, r *http.Request)
 
 	var hc influx.HighChartsJSON
 
+	privacy, err := getPlayerPrivacyByID(r, playerID)
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
+
+	if privacy.NoHistory {
+		returnJSON(w, r, hc)
+		return
+	}
+
 	item := memcache.ItemPlayerAchievementsInflux(playerID)
 	err = memcache.Client().GetSet(item.Key, item.Expiration, &hc, func() (interface{}, error) {
 
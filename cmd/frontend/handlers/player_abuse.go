@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Jleagle/rabbit-go"
+	"github.com/gamedb/gamedb/pkg/abuse"
+	"github.com/gamedb/gamedb/pkg/consumers"
+	"github.com/gamedb/gamedb/pkg/crons/helpers/rabbitweb"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	influxHelper "github.com/gamedb/gamedb/pkg/influx"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/ratelimit"
+	"github.com/gamedb/gamedb/pkg/session"
+	influx "github.com/influxdata/influxdb1-client"
+)
+
+// Guards everything that lets a visitor queue a Steam lookup for a player
+var playerGuard = abuse.New(ratelimit.NewSharedStore(), func() (int, error) {
+
+	p := rabbit.Payload{}
+	p.Preset(rabbit.RangeOneMinute)
+
+	q, err := rabbitweb.GetRabbitWebClient().GetQueue(consumers.QueuePlayers, p)
+	return q.Messages, err
+})
+
+// Must be called before anything is written, it can save the session
+func checkPlayerAbuse(w http.ResponseWriter, r *http.Request, action abuse.Action) abuse.Reason {
+
+	// Admins can already skip the update timers
+	if session.HasAdminPermission(r, mysql.AdminPermissionQueues) {
+		return abuse.ReasonNone
+	}
+
+	req := playerAbuseRequest(r, action)
+	if req.SessionID == "" {
+		ensureClientID(w, r)
+	}
+
+	reason := playerGuard.Check(req)
+	if reason == abuse.ReasonNone {
+		return reason
+	}
+
+	if reason == abuse.ReasonCaptcha {
+		session.Set(r, session.SessionCaptchaRequired, "1")
+		session.Save(w, r)
+	}
+
+	go func() {
+
+		point := influx.Point{
+			Measurement: string(influxHelper.InfluxMeasurementPlayerAbuse),
+			Tags: map[string]string{
+				"action": string(req.Action),
+				"reason": string(reason),
+			},
+			Fields: map[string]interface{}{
+				"blocked":    1,
+				"ip":         req.IP,
+				"user_id":    req.UserID,
+				"user_agent": req.UserAgent,
+			},
+			Time:      time.Now(),
+			Precision: "ms",
+		}
+
+		if _, err := influxHelper.InfluxWrite(influxHelper.InfluxRetentionPolicy14Day, point); err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	return reason
+}
+
+// Logged out visitors are rate limited on this
+func ensureClientID(w http.ResponseWriter, r *http.Request) {
+
+	if session.Get(r, session.SessionClientID) == "" {
+		session.Set(r, session.SessionClientID, helpers.RandString(20, helpers.Numbers+helpers.Letters))
+		session.Save(w, r)
+	}
+}
+
+func playerAbuseRequest(r *http.Request, action abuse.Action) abuse.Request {
+
+	return abuse.Request{
+		Action:    action,
+		IP:        r.RemoteAddr,
+		UserID:    session.GetUserIDFromSesion(r),
+		SessionID: session.Get(r, session.SessionClientID),
+		UserAgent: r.UserAgent(),
+	}
+}
+
+// The pass is kept in the guard's store against the client ID, not in the cookie
+func setPassedCaptcha(r *http.Request) {
+
+	playerGuard.PassCaptcha(playerAbuseRequest(r, abuse.ActionPlayerAdd))
+	session.DeleteMany(r, []string{session.SessionCaptchaRequired})
+}
+
+func isCaptchaRequired(r *http.Request) bool {
+	return session.Get(r, session.SessionCaptchaRequired) != "" && !playerGuard.PassedCaptcha(playerAbuseRequest(r, abuse.ActionPlayerAdd))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/filters"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/i18n"
@@ -61,7 +62,7 @@ func playersHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionPlayers, nil, 0)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -200,66 +201,13 @@ func playersAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := datatable.NewDataTableQuery(r, true)
 
-	country := query.GetSearchString("country")
-	state := query.GetSearchString("state")
 	search := query.GetSearchString("search")
+	sorters := filters.PlayersOrder(query)
+	playerFilters := filters.PlayersFilters(query)
 
-	var sorters = query.GetOrderElastic(map[string]string{
-		"3":  "level",
-		"4":  "badges",
-		"12": "badges_foil",
-
-		"5": "games",
-		"6": "play_time",
-
-		"7": "game_bans",
-		"8": "vac_bans",
-		"9": "last_ban",
-
-		"10": "achievements",
-		"11": "achievements_100",
-
-		"13": "awards_given_count",
-		"14": "awards_given_points",
-		"15": "awards_received_count",
-		"16": "awards_received_points",
-	})
-
-	var isContinent bool
-
-	var filters []elastic.Query
-
-	if country != "" {
-
-		for _, v := range i18n.Continents {
-			if "c-"+v.Key == country {
-
-				isContinent = true
-				filters = append(filters, elastic.NewTermQuery("continent", v.Key))
-				break
-			}
-		}
-
-		if !isContinent {
-
-			if _, ok := i18n.States[country]; ok || country == "_" {
-
-				if country == "_" {
-					country = ""
-				}
-
-				filters = append(filters, elastic.NewTermQuery("country_code", country))
-
-				if _, ok := i18n.States[country][state]; ok || state == "_" {
-
-					if state == "_" {
-						state = ""
-					}
-
-					filters = append(filters, elastic.NewTermQuery("state_code", state))
-				}
-			}
-		}
+	// Players who hide from rankings can still be searched for by name
+	if search == "" {
+		playerFilters = append(playerFilters, elastic.NewBoolQuery().MustNot(elastic.NewTermQuery("hide_ranks", true)))
 	}
 
 	//
@@ -275,7 +223,7 @@ func playersAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 
-		players, filtered, err = elasticsearch.SearchPlayers(100, query.GetOffset(), search, sorters, filters)
+		players, filtered, err = elasticsearch.SearchPlayers(r.Context(), 100, query.GetOffset(), search, sorters, playerFilters)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -289,7 +237,7 @@ func playersAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionPlayers, nil, 0)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
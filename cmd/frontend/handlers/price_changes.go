@@ -1,19 +1,28 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
+	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/feed"
+	"github.com/gamedb/gamedb/pkg/backend"
+	"github.com/gamedb/gamedb/pkg/backend/generated"
+	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/i18n"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/session"
 	"github.com/go-chi/chi/v5"
-	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func PriceChangeRouter() http.Handler {
@@ -21,6 +30,7 @@ func PriceChangeRouter() http.Handler {
 	r := chi.NewRouter()
 	r.Get("/", priceChangesHandler)
 	r.Get("/price-changes.json", priceChangesAjaxHandler)
+	r.Get("/feed.{format:(rss|atom|json)}", priceChangesFeedHandler)
 
 	return r
 }
@@ -29,6 +39,7 @@ func priceChangesHandler(w http.ResponseWriter, r *http.Request) {
 
 	t := priceChangesTemplate{}
 	t.fill(w, r, "price_changes", "Price Changes", "All game price changes")
+	t.FeedPath = "/price-changes/feed"
 	t.addAssetChosen()
 	t.addAssetSlider()
 
@@ -50,23 +61,12 @@ func priceChangesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := datatable.NewDataTableQuery(r, true)
 
-	//
-	var wg sync.WaitGroup
-
-	// Get ranks
 	var code = session.GetProductCC(r)
 
-	var filter = bson.D{
-		{Key: "prod_cc", Value: string(code)},
-	}
-
-	switch query.GetSearchString("type") {
-	case "apps":
-		filter = append(filter, bson.E{Key: "app_id", Value: bson.M{"$gt": 0}})
-	case "packages":
-		filter = append(filter, bson.E{Key: "package_id", Value: bson.M{"$gt": 0}})
-	case "bundles":
-		filter = append(filter, bson.E{Key: "bundle_id", Value: bson.M{"$gt": 0}})
+	message := &generated.ListPricesRequest{
+		Pagination: backend.MakePaginationRequest(query, nil, 100),
+		ProdCC:     string(code),
+		Type:       query.GetSearchString("type"),
 	}
 
 	percents := query.GetSearchSlice("change")
@@ -77,16 +77,7 @@ func priceChangesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.ErrS(err)
 			} else {
-				filter = append(filter, bson.E{Key: "difference_percent", Value: bson.M{"$gte": min}})
-			}
-
-			// Dont show infinite difference_percent
-			if min > -100 {
-				filter = append(filter, bson.E{Key: "$or", Value: bson.A{
-					bson.M{"difference_percent": bson.M{"$gt": 0}},
-					bson.M{"difference_percent": bson.M{"$lt": 0}},
-					bson.M{"difference": bson.M{"$gte": 0}},
-				}})
+				message.PercentMin = wrapperspb.Double(min)
 			}
 		}
 		if percents[1] != "100.00" {
@@ -95,21 +86,12 @@ func priceChangesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.ErrS(err)
 			} else {
-				filter = append(filter, bson.E{Key: "difference_percent", Value: bson.M{"$lte": max}})
-			}
-
-			// Dont show infinite difference_percent
-			if max < 100 {
-				filter = append(filter, bson.E{Key: "$or", Value: bson.A{
-					bson.M{"difference_percent": bson.M{"$gt": 0}},
-					bson.M{"difference_percent": bson.M{"$lt": 0}},
-					bson.M{"difference": bson.M{"$lte": 0}},
-				}})
+				message.PercentMax = wrapperspb.Double(max)
 			}
 		}
 	}
 
-	maxPrice, err := elasticsearch.GetMostExpensiveApp(session.GetProductCC(r))
+	maxPrice, err := elasticsearch.GetMostExpensiveApp(code)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -122,7 +104,7 @@ func priceChangesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.ErrS(err)
 			} else {
-				filter = append(filter, bson.E{Key: "price_after", Value: bson.M{"$gte": min}})
+				message.PriceMin = wrapperspb.Int32(int32(min))
 			}
 		}
 
@@ -131,62 +113,130 @@ func priceChangesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				log.ErrS(err)
 			} else {
-				filter = append(filter, bson.E{Key: "price_after", Value: bson.M{"$lte": max}})
+				message.PriceMax = wrapperspb.Int32(int32(max))
 			}
 		}
 	}
 
-	// Get rows
-	var priceChanges []mongo.ProductPrice
-	wg.Add(1)
-	go func() {
+	priceChanges, resp, err := getPriceChangesFromBackend(r.Context(), message)
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
 
-		defer wg.Done()
+	var response = datatable.NewDataTablesResponse(r, query, resp.GetPagination().GetTotal(), resp.GetPagination().GetTotalFiltered(), nil)
+	for _, price := range priceChanges {
 
-		var err error
-		priceChanges, err = mongo.GetPrices(query.GetOffset64(), 100, filter)
-		if err != nil {
-			log.ErrS(err)
-			return
-		}
-	}()
+		response.AddRow(price.OutputForJSON())
+	}
 
-	// Get filtered count
-	var filtered int64
-	wg.Add(1)
-	go func() {
+	returnJSON(w, r, response)
+}
 
-		defer wg.Done()
+// Filters are query params, e.g. ?cc=us&type=apps&min=-100&max=-50
+func priceChangesFeedHandler(w http.ResponseWriter, r *http.Request) {
 
-		var err error
-		filtered, err = mongo.CountDocuments(mongo.CollectionProductPrices, filter, 0)
-		if err != nil {
-			log.ErrS(err)
-		}
-	}()
+	var q = r.URL.Query()
+
+	var code = steamapi.ProductCC(q.Get("cc"))
+	if !i18n.IsValidProdCC(code) {
+		code = session.GetProductCC(r)
+	}
+
+	message := &generated.ListPricesRequest{
+		Pagination: &generated.PaginationRequest{Limit: 100},
+		ProdCC:     string(code),
+	}
+
+	switch q.Get("type") {
+	case "apps", "packages":
+		message.Type = q.Get("type")
+	}
+
+	min, err := strconv.ParseFloat(q.Get("min"), 64)
+	if err == nil && min > -100 {
+		message.PercentMin = wrapperspb.Double(min)
+	}
+
+	max, err := strconv.ParseFloat(q.Get("max"), 64)
+	if err == nil && max < 100 {
+		message.PercentMax = wrapperspb.Double(max)
+	}
 
-	// Get total count
-	var total int64
-	wg.Add(1)
-	go func() {
+	priceChanges, _, err := getPriceChangesFromBackend(r.Context(), message)
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
 
-		defer wg.Done()
+	format := feed.Format(chi.URLParam(r, "format"))
 
-		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionProductPrices, bson.D{{Key: "prod_cc", Value: string(code)}}, 0)
-		if err != nil {
-			log.ErrS(err)
+	f := feed.Feed{
+		Title:       "Price Changes (" + strings.ToUpper(string(code)) + ") - Global Steam",
+		Link:        config.C.GlobalSteamDomain + "/price-changes",
+		FeedLink:    config.C.GlobalSteamDomain + "/price-changes/feed." + string(format),
+		Description: "Price changes for " + i18n.GetProdCC(code).Name,
+		MaxAge:      time.Minute * 5,
+	}
+
+	if r.URL.RawQuery != "" {
+		f.FeedLink += "?" + r.URL.RawQuery
+	}
+
+	for k, price := range priceChanges {
+
+		if k == 0 {
+			f.Updated = price.CreatedAt
 		}
-	}()
 
-	// Wait
-	wg.Wait()
+		title := price.Name + ": " + i18n.FormatPrice(price.Currency, price.PriceBefore) + " to " + i18n.FormatPrice(price.Currency, price.PriceAfter)
+		if !math.IsInf(price.DifferencePercent, 0) {
+			title += fmt.Sprintf(" (%+.0f%%)", price.DifferencePercent)
+		}
 
-	var response = datatable.NewDataTablesResponse(r, query, total, filtered, nil)
-	for _, price := range priceChanges {
+		f.Items = append(f.Items, feed.Item{
+			ID:        config.C.GlobalSteamDomain + price.GetPath() + "," + strconv.FormatInt(price.CreatedAt.Unix(), 10),
+			Title:     title,
+			Link:      config.C.GlobalSteamDomain + price.GetPath(),
+			Image:     helpers.GetAppIcon(price.AppID, price.Icon),
+			Published: price.CreatedAt,
+		})
+	}
 
-		response.AddRow(price.OutputForJSON())
+	err = f.Write(w, r, format)
+	if err != nil {
+		log.ErrS(err)
 	}
+}
 
-	returnJSON(w, r, response)
+func getPriceChangesFromBackend(ctx context.Context, message *generated.ListPricesRequest) (prices []mongo.ProductPrice, resp *generated.PricesResponse, err error) {
+
+	conn, ctx, err := backend.GetClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err = generated.NewPricesServiceClient(conn).List(ctx, message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, v := range resp.GetPrices() {
+		prices = append(prices, mongo.ProductPrice{
+			CreatedAt:         v.GetCreatedAt().AsTime(),
+			AppID:             int(v.GetAppId()),
+			PackageID:         int(v.GetPackageId()),
+			Currency:          steamapi.CurrencyCode(v.GetCurrency()),
+			ProdCC:            steamapi.ProductCC(v.GetProdCC()),
+			Name:              v.GetName(),
+			Icon:              v.GetIcon(),
+			PriceBefore:       int(v.GetPriceBefore()),
+			PriceAfter:        int(v.GetPriceAfter()),
+			Difference:        int(v.GetDifference()),
+			DifferencePercent: v.GetDifferencePercent(),
+		})
+	}
+
+	return prices, resp, nil
 }
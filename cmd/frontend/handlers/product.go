@@ -26,9 +26,9 @@ func productPricesAjaxHandler(w http.ResponseWriter, r *http.Request, productTyp
 	var product helpers.ProductInterface
 
 	if productType == helpers.ProductTypeApp {
-		product, err = mongo.GetApp(id)
+		product, err = mongo.GetApp(r.Context(), id)
 	} else {
-		product, err = mongo.GetPackage(id)
+		product, err = mongo.GetPackage(r.Context(), id)
 	}
 	if err != nil {
 		log.ErrS(err)
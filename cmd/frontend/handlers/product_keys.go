@@ -133,7 +133,7 @@ func productKeysAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		} else {
 
-			apps, err := mongo.GetApps(query.GetOffset64(), 100, bson.D{{Key: "_id", Value: 1}}, filter, projection)
+			apps, err := mongo.GetApps(r.Context(), query.GetOffset64(), 100, bson.D{{Key: "_id", Value: 1}}, filter, projection)
 			if err != nil {
 				log.ErrS(err, zap.String("key", key))
 				return
@@ -169,9 +169,9 @@ func productKeysAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		if productType == "packages" {
-			filteredCount, err = mongo.CountDocuments(mongo.CollectionPackages, filter, 0)
+			filteredCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPackages, filter, 0)
 		} else {
-			filteredCount, err = mongo.CountDocuments(mongo.CollectionApps, filter, 0)
+			filteredCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter, 0)
 		}
 		if err != nil {
 			log.ErrS(err)
@@ -188,9 +188,9 @@ func productKeysAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		if productType == "packages" {
-			count, err = mongo.CountDocuments(mongo.CollectionPackages, nil, 0)
+			count, err = mongo.CountDocuments(r.Context(), mongo.CollectionPackages, nil, 0)
 		} else {
-			count, err = mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+			count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, nil, 0)
 		}
 		if err != nil {
 			log.ErrS(err)
@@ -2,19 +2,17 @@ package handlers
 
 import (
 	"net/http"
-	"regexp"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/filters"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/session"
 	"github.com/go-chi/chi/v5"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 func salesRouter() http.Handler {
@@ -170,171 +168,8 @@ func salesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	var code = session.GetProductCC(r)
 	var countLock sync.Mutex
-	var baseFilter = bson.D{
-		{Key: "offer_end", Value: bson.M{"$gt": time.Now()}},
-	}
-	var filter = baseFilter
-
-	search := helpers.RegexNonAlphaNumericSpace.ReplaceAllString(query.GetSearchString("search"), "")
-	if search != "" {
-
-		quoted := regexp.QuoteMeta(search)
-
-		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
-			bson.M{"app_name": bson.M{"$regex": quoted, "$options": "i"}},
-			bson.M{"offer_name": bson.M{"$regex": quoted, "$options": "i"}},
-		}})
-	}
-
-	// Index
-	index := query.GetSearchString("index")
-	if index != "" {
-		orderI, err := strconv.Atoi(strings.TrimSuffix(index, ".00"))
-		if err == nil {
-			filter = append(filter, bson.E{Key: "sub_order", Value: bson.M{"$lte": orderI - 1}})
-		}
-	}
-
-	// Score
-	scores := query.GetSearchSlice("score")
-	if len(scores) == 2 {
-
-		low, err := strconv.Atoi(strings.TrimSuffix(scores[0], ".00"))
-		if err != nil {
-			log.ErrS(err)
-		}
-
-		high, err := strconv.Atoi(strings.TrimSuffix(scores[1], ".00"))
-		if err != nil {
-			log.ErrS(err)
-		}
-
-		if low > 0 {
-			filter = append(filter, bson.E{Key: "app_rating", Value: bson.M{"$gte": low}})
-		}
-		if high < 100 {
-			filter = append(filter, bson.E{Key: "app_rating", Value: bson.M{"$lte": high}})
-		}
-	}
-
-	// Price
-	prices := query.GetSearchSlice("price")
-	if len(prices) == 2 {
-
-		low, err := strconv.Atoi(strings.TrimSuffix(prices[0], ".00"))
-		if err != nil {
-			log.ErrS(err)
-		}
-
-		high, err := strconv.Atoi(strings.TrimSuffix(prices[1], ".00"))
-		if err != nil {
-			log.ErrS(err)
-		}
-
-		if low > 0 {
-			filter = append(filter, bson.E{Key: "app_prices." + string(code), Value: bson.M{"$gte": low * 100}})
-		}
-		if high < 100 {
-			filter = append(filter, bson.E{Key: "app_prices." + string(code), Value: bson.M{"$lte": high * 100}})
-		}
-	}
-
-	// Discount
-	discounts := query.GetSearchSlice("discount")
-	if len(discounts) == 2 {
-
-		low, err := strconv.Atoi(strings.TrimSuffix(discounts[0], ".00"))
-		if err != nil {
-			log.ErrS(err)
-		}
-
-		high, err := strconv.Atoi(strings.TrimSuffix(discounts[1], ".00"))
-		if err != nil {
-			log.ErrS(err)
-		}
-
-		if low > 0 {
-			filter = append(filter, bson.E{Key: "offer_percent", Value: bson.M{"$lte": -low}})
-		}
-		if high < 100 {
-			filter = append(filter, bson.E{Key: "offer_percent", Value: bson.M{"$gte": -high}})
-		}
-	}
-
-	// App type
-	appTypes := query.GetSearchSlice("app-type")
-	if len(appTypes) > 0 {
-
-		var or bson.A
-		for _, v := range appTypes {
-			or = append(or, bson.M{"app_type": v})
-		}
-		filter = append(filter, bson.E{Key: "$or", Value: or})
-	}
-
-	// Sale type
-	saleTypes := query.GetSearchSlice("sale-type")
-	if len(saleTypes) > 0 {
-
-		var or bson.A
-		for _, v := range saleTypes {
-			or = append(or, bson.M{"offer_type": v})
-		}
-		filter = append(filter, bson.E{Key: "$or", Value: or})
-	}
-
-	// Tag in
-	tagsIn := query.GetSearchSlice("tags-in")
-	if len(tagsIn) > 0 {
-
-		var or bson.A
-		for _, tag := range tagsIn {
-			i, err := strconv.Atoi(tag)
-			if err == nil {
-				or = append(or, bson.M{"app_tags": i})
-			}
-		}
-		filter = append(filter, bson.E{Key: "$or", Value: or})
-	}
-
-	// Tag out
-	tagsOut := query.GetSearchSlice("tags-out")
-	if len(tagsOut) > 0 {
-
-		var or bson.A
-		for _, tag := range tagsOut {
-			i, err := strconv.Atoi(tag)
-			if err == nil {
-				or = append(or, bson.M{"app_tags": bson.M{"$ne": i}})
-			}
-		}
-		filter = append(filter, bson.E{Key: "$or", Value: or})
-	}
-
-	// Categories
-	categories := query.GetSearchSlice("categories")
-	if len(categories) > 0 {
-
-		var in bson.A
-		for _, tag := range categories {
-			i, err := strconv.Atoi(tag)
-			if err == nil {
-				in = append(in, i)
-			}
-		}
-		filter = append(filter, bson.E{Key: "app_categories", Value: bson.M{"$in": in}})
-	}
-
-	// Platforms
-	platforms := query.GetSearchSlice("platforms")
-	if len(platforms) > 0 {
-
-		var in bson.A
-		for _, tag := range platforms {
-			in = append(in, tag)
-		}
-		filter = append(filter, bson.E{Key: "app_platforms", Value: bson.M{"$in": in}})
-	}
+	var baseFilter = filters.SalesBaseFilter()
+	var filter = filters.SalesFilter(query, code)
 
 	//
 	var wg sync.WaitGroup
@@ -346,22 +181,8 @@ func salesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		defer wg.Done()
 
-		var columns = map[string]string{
-			"0": "offer_name",
-			"1": "app_prices." + string(code),
-			"2": "offer_percent",
-			"3": "app_rating",
-			"4": "offer_end",
-			"5": "app_date",
-		}
-
-		order := query.GetOrderMongo(columns)
-		order = append(order, bson.E{Key: "app_rating", Value: -1})
-		order = append(order, bson.E{Key: "app_name", Value: 1})
-		order = append(order, bson.E{Key: "sub_order", Value: 1})
-
 		var err error
-		sales, err = mongo.GetAllSales(query.GetOffset64(), 100, filter, order)
+		sales, err = mongo.GetAllSales(r.Context(), query.GetOffset64(), 100, filter, filters.SalesOrder(query, code))
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -377,7 +198,7 @@ func salesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		count, err = mongo.CountDocuments(mongo.CollectionAppSales, baseFilter, 0)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppSales, baseFilter, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -393,7 +214,7 @@ func salesAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		filtered, err = mongo.CountDocuments(mongo.CollectionAppSales, filter, 0)
+		filtered, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppSales, filter, 0)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/feed"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/session"
+	"github.com/go-chi/chi/v5"
+)
+
+func SavedSearchesRouter() http.Handler {
+
+	r := chi.NewRouter()
+	r.Get("/{id:[a-z0-9]+}.{format:(rss|atom|json)}", savedSearchFeedHandler)
+	return r
+}
+
+func savedSearchFeedHandler(w http.ResponseWriter, r *http.Request) {
+
+	search, err := mongo.GetSavedSearch(r.Context(), chi.URLParam(r, "id"))
+	if err == mongo.ErrNoDocuments {
+		Error404Handler(w, r)
+		return
+	} else if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	format := feed.Format(chi.URLParam(r, "format"))
+
+	f := feed.Feed{
+		Title:       search.Name + " - Global Steam",
+		Link:        config.C.GlobalSteamDomain + search.Type.GetPath(),
+		FeedLink:    config.C.GlobalSteamDomain + search.GetFeedPath(string(format)),
+		Description: "New " + string(search.Type) + " matching a saved search",
+		Updated:     search.CheckedAt,
+	}
+
+	for _, v := range search.Items {
+		f.Items = append(f.Items, feed.Item{
+			ID:        config.C.GlobalSteamDomain + v.Path + "#" + v.ID,
+			Title:     v.Name,
+			Link:      config.C.GlobalSteamDomain + v.Path,
+			Image:     v.Icon,
+			Published: v.FoundAt,
+		})
+	}
+
+	err = f.Write(w, r, format)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
+
+func settingsSavedSearchAddHandler(w http.ResponseWriter, r *http.Request) {
+
+	var response = Toast{Success: false}
+	defer func() {
+		returnJSON(w, r, response)
+	}()
+
+	userID := session.GetUserIDFromSesion(r)
+	if userID == 0 {
+		response.Message = "Please login to save searches"
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+		response.Message = "An error occurred (1001)"
+		return
+	}
+
+	search := mongo.SavedSearch{
+		UserID:      userID,
+		PlayerID:    session.GetPlayerIDFromSesion(r),
+		Name:        strings.TrimSpace(r.PostForm.Get("name")),
+		Type:        mongo.SavedSearchType(r.PostForm.Get("type")),
+		Query:       r.PostForm.Get("query"),
+		ProductCC:   session.GetProductCC(r),
+		NotifyEmail: true,
+	}
+
+	if !search.Type.IsValid() || search.Query == "" {
+		response.Message = "Invalid search"
+		return
+	}
+
+	if search.Name == "" || utf8.RuneCountInString(search.Name) > 100 {
+		response.Message = "Please enter a name up to 100 characters"
+		return
+	}
+
+	searches, err := mongo.GetSavedSearchesByUser(userID)
+	if err != nil {
+		log.ErrS(err)
+		response.Message = "An error occurred (1002)"
+		return
+	}
+
+	if len(searches) >= mongo.SavedSearchesPerUser {
+		response.Message = "You can only save 20 searches"
+		return
+	}
+
+	_, err = mongo.NewSavedSearch(search)
+	if err != nil {
+		log.ErrS(err)
+		response.Message = "An error occurred (1003)"
+		return
+	}
+
+	response.Success = true
+	response.Message = "Search saved, manage it from your settings"
+}
+
+func settingsSavedSearchDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings#saved-searches", http.StatusFound)
+	}()
+
+	err := mongo.DeleteSavedSearch(session.GetUserIDFromSesion(r), chi.URLParam(r, "id"))
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "An error occurred (1001)")
+		return
+	}
+
+	session.SetFlash(r, session.SessionGood, "Saved search deleted")
+}
+
+func settingsSavedSearchNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings#saved-searches", http.StatusFound)
+	}()
+
+	err := r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "An error occurred (1001)")
+		return
+	}
+
+	email := r.PostForm.Get("email") == "1"
+	discord := r.PostForm.Get("discord") == "1"
+
+	err = mongo.UpdateSavedSearchNotifications(session.GetUserIDFromSesion(r), chi.URLParam(r, "id"), email, discord)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "An error occurred (1002)")
+		return
+	}
+
+	session.SetFlash(r, session.SessionGood, "Notifications updated")
+}
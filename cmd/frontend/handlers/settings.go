@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"html/template"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,9 +16,12 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/geo"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/twofactor"
 	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/i18n"
+	"github.com/gamedb/gamedb/pkg/influx"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
 	"github.com/gamedb/gamedb/pkg/middleware"
@@ -37,11 +42,32 @@ func SettingsRouter() http.Handler {
 	r.Use(middleware.MiddlewareAuthCheck)
 
 	r.Get("/", settingsHandler)
+	r.Get("/billing", settingsBillingHandler)
+	r.Get("/data", settingsDataHandler)
+	r.Post("/data/delete", settingsDataDeleteHandler)
+	r.Post("/data/delete/cancel", settingsDataDeleteCancelHandler)
+	r.Post("/data/export", settingsDataExportHandler)
+	r.Get("/data/exports/{token:[a-f0-9]+}", settingsDataDownloadHandler)
 	r.Get("/donations.json", settingsDonationsAjaxHandler)
 	r.Get("/events.json", settingsEventsAjaxHandler)
+	r.Post("/ignore-app/{id:[0-9]+}", settingsIgnoreAppHandler)
 	r.Get("/join-discord-server", joinDiscordServerHandler)
 	r.Get("/new-key", settingsNewKeyHandler)
 	r.Get("/remove-provider/{provider:[a-z]+}", settingsRemoveProviderHandler)
+	r.Post("/unignore-app/{id:[0-9]+}", settingsUnignoreAppHandler)
+	r.Get("/recovery-codes", settingsRecoveryCodesHandler)
+	r.Post("/recovery-codes", settingsRecoveryCodesPostHandler)
+	r.Post("/saved-searches", settingsSavedSearchAddHandler)
+	r.Post("/saved-searches/{id:[a-z0-9]+}/delete", settingsSavedSearchDeleteHandler)
+	r.Post("/saved-searches/{id:[a-z0-9]+}/notifications", settingsSavedSearchNotificationsHandler)
+	r.Post("/sessions/{id:[a-f0-9]+}/delete", settingsSessionDeleteHandler)
+	r.Post("/sessions/logout-all", settingsSessionsLogoutAllHandler)
+	r.Post("/security-keys/begin", settingsSecurityKeyBeginHandler)
+	r.Post("/security-keys/finish", settingsSecurityKeyFinishHandler)
+	r.Post("/security-keys/{id:[0-9]+}/delete", settingsSecurityKeyDeleteHandler)
+	r.Get("/totp", settingsTOTPHandler)
+	r.Post("/totp", settingsTOTPPostHandler)
+	r.Post("/totp/disable", settingsTOTPDisableHandler)
 	r.Post("/update", settingsPostHandler)
 
 	return r
@@ -125,7 +151,7 @@ func settingsHandler(w http.ResponseWriter, r *http.Request) {
 
 		var groupIDs []string
 
-		groups, err := mongo.GetPlayerGroups(t.Player.ID, 0, 0, nil)
+		groups, err := mongo.GetPlayerGroups(r.Context(), t.Player.ID, 0, 0, nil)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -232,6 +258,105 @@ func settingsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	// Get recommendations
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		if t.Player.ID == 0 {
+			return
+		}
+
+		var err error
+		t.Recommendations, err = mongo.GetPlayerRecommendations(t.Player.ID, 50)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	// Get ignored apps
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		if t.Player.ID == 0 {
+			return
+		}
+
+		ignored, err := mongo.GetPlayerIgnoredApps(t.Player.ID)
+		if err != nil {
+			log.ErrS(err)
+			return
+		}
+
+		var appIDs []int
+		for _, v := range ignored {
+			appIDs = append(appIDs, v.AppID)
+		}
+
+		t.IgnoredApps, err = mongo.GetAppsByID(r.Context(), appIDs, bson.M{"_id": 1, "name": 1, "icon": 1})
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	// Get saved searches
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		var err error
+		t.SavedSearches, err = mongo.GetSavedSearchesByUser(t.User.ID)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	// Get two-factor methods
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		var err error
+		t.TwoFactor, err = twofactor.GetMethods(t.User.ID)
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		t.SecurityKeys, err = mysql.GetUserSecurityKeys(t.User.ID)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	// Get sessions
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		sessions, err := mongo.GetUserSessions(t.User.ID)
+		if err != nil {
+			log.ErrS(err)
+			return
+		}
+
+		current := session.Get(r, session.SessionUserSessionID)
+
+		for _, v := range sessions {
+			t.Sessions = append(t.Sessions, settingsSessionTemplate{
+				UserSession: v,
+				Device:      getUserAgentNice(v.UserAgent),
+				Location:    getLocationNice(v.IP),
+				Current:     v.ID == current,
+			})
+		}
+	}()
+
 	// Get event types
 	wg.Add(1)
 	go func() {
@@ -285,16 +410,29 @@ func settingsHandler(w http.ResponseWriter, r *http.Request) {
 
 type settingsTemplate struct {
 	globalTemplate
-	User          mysql.User
-	Player        mongo.Player
-	ProdCCs       []i18n.ProductCountryCode
-	Groups        template.JS
-	Badges        template.JS
-	Games         template.JS
-	Providers     []oauth.Provider
-	UserProviders map[oauth.ProviderEnum]mysql.UserProvider
-	Banners       []template.HTML
-	EventTypes    []settingsEventTemplate
+	User            mysql.User
+	Player          mongo.Player
+	ProdCCs         []i18n.ProductCountryCode
+	Groups          template.JS
+	Badges          template.JS
+	Games           template.JS
+	Providers       []oauth.Provider
+	UserProviders   map[oauth.ProviderEnum]mysql.UserProvider
+	Banners         []template.HTML
+	EventTypes      []settingsEventTemplate
+	Recommendations []mongo.PlayerRecommendation
+	IgnoredApps     []mongo.App
+	SavedSearches   []mongo.SavedSearch
+	TwoFactor       twofactor.Methods
+	SecurityKeys    []mysql.UserSecurityKey
+	Sessions        []settingsSessionTemplate
+}
+
+type settingsSessionTemplate struct {
+	mongo.UserSession
+	Device   string
+	Location string
+	Current  bool
 }
 
 type settingsEventTemplate struct {
@@ -415,14 +553,47 @@ func settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Save player
-	playerID := session.GetPlayerIDFromSesion(r)
+	// Log out other sessions, in case someone else has the old password
+	if password != "" {
+		err = mongo.DeleteUserSessions(user.ID, session.Get(r, session.SessionUserSessionID))
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+
+	// Save player, from the linked Steam account so only its owner can change it
+	playerID := mysql.GetUserSteamID(user.ID)
 
 	if playerID > 0 {
+
+		player, err := mongo.GetPlayer(r.Context(), playerID)
+		err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
+		if err != nil {
+			log.ErrS(err)
+			session.SetFlash(r, session.SessionBad, "We had trouble saving your settings")
+			return
+		}
+
+		privacy := mongo.PlayerPrivacy{
+			HideRanks:    r.PostForm.Get("hide_ranks") == "1",
+			HideSearch:   r.PostForm.Get("hide_search") == "1",
+			HideFriends:  r.PostForm.Get("hide_friends") == "1",
+			HidePlaytime: r.PostForm.Get("hide_playtime") == "1",
+			NoHistory:    r.PostForm.Get("no_history") == "1",
+		}
+
 		filter := bson.D{{Key: "_id", Value: playerID}}
-		update := bson.D{{Key: "private", Value: r.PostForm.Get("private") == "1"}}
+		update := bson.D{
+			{Key: "private", Value: r.PostForm.Get("private") == "1"},
+			{Key: "privacy", Value: privacy},
+		}
+
+		// Ranks are only recalculated for ranked players, so clear the old ones now
+		if privacy.HideRanks {
+			update = append(update, bson.E{Key: "ranks", Value: bson.M{}})
+		}
 
-		_, err = mongo.UpdateOne(mongo.CollectionPlayers, filter, update)
+		_, err = mongo.UpdateOne(r.Context(), mongo.CollectionPlayers, filter, update)
 		if err != nil {
 			log.ErrS(err)
 			session.SetFlash(r, session.SessionBad, "We had trouble saving your settings")
@@ -433,6 +604,13 @@ func settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			log.ErrS(err)
 		}
+
+		err = savePlayerPrivacy(r.Context(), player, privacy)
+		if err != nil {
+			log.ErrS(err)
+			session.SetFlash(r, session.SessionBad, "We had trouble saving your privacy settings")
+			return
+		}
 	}
 
 	// Update session
@@ -442,9 +620,50 @@ func settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		// session.SessionUserShowAlerts: strconv.FormatBool(user.ShowAlerts),
 	})
 
+	newTeamAuditEvent(r, user.ID, mongo.EventSettings)
+
 	session.SetFlash(r, session.SessionGood, "Settings saved")
 }
 
+// Applies a privacy change to the copies of the player outside the players collection
+func savePlayerPrivacy(ctx context.Context, player mongo.Player, privacy mongo.PlayerPrivacy) (err error) {
+
+	if privacy.HideFriends != player.Privacy.HideFriends {
+
+		update := bson.D{{Key: "hidden", Value: privacy.HideFriends}}
+
+		_, err = mongo.UpdateManySet(ctx, mongo.CollectionPlayerFriends, bson.D{{Key: "friend_id", Value: player.ID}}, update)
+		if err != nil {
+			return err
+		}
+	}
+
+	if privacy.HideRanks != player.Privacy.HideRanks ||
+		privacy.HideSearch != player.Privacy.HideSearch ||
+		privacy.HidePlaytime != player.Privacy.HidePlaytime {
+
+		err = consumers.ProducePlayerSearch(ctx, nil, player.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if privacy.NoHistory && !player.Privacy.NoHistory {
+
+		err = influx.DeleteTagPoints(influx.InfluxMeasurementPlayers, "player_id", strconv.FormatInt(player.ID, 10))
+		if err != nil {
+			return err
+		}
+
+		err = memcache.Client().Delete(memcache.ItemPlayerAchievementsInflux(player.ID).Key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func joinDiscordServerHandler(w http.ResponseWriter, r *http.Request) {
 
 	defer func() {
@@ -531,9 +750,25 @@ func settingsNewKeyHandler(w http.ResponseWriter, r *http.Request) {
 		session.SessionUserAPIKey: user.APIKey,
 	})
 
+	newTeamAuditEvent(r, user.ID, mongo.EventAPIKey)
+
 	session.SetFlash(r, session.SessionGood, "New API key generated")
 }
 
+// Settings changes also go in the team's audit log
+func newTeamAuditEvent(r *http.Request, userID int, eventType mongo.EventEnum) {
+
+	access, err := mysql.GetTeamAccess(userID)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	err = mongo.NewTeamEvent(r, userID, access.TeamID, eventType, "")
+	if err != nil {
+		log.ErrS(err)
+	}
+}
+
 func settingsEventsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	userID := session.GetUserIDFromSesion(r)
@@ -547,6 +782,9 @@ func settingsEventsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	var filter = bson.D{{Key: "user_id", Value: userID}}
 	if len(types) > 0 {
 		filter = append(filter, bson.E{Key: "type", Value: bson.M{"$in": types}})
+	} else {
+		// Team members can make a lot of these, only show them when asked
+		filter = append(filter, bson.E{Key: "type", Value: bson.M{"$ne": mongo.EventAPICall}})
 	}
 
 	var wg sync.WaitGroup
@@ -573,7 +811,7 @@ func settingsEventsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		total, err = mongo.CountDocuments(mongo.CollectionEvents, bson.D{{Key: "user_id", Value: userID}}, 86400)
+		total, err = mongo.CountDocuments(r.Context(), mongo.CollectionEvents, bson.D{{Key: "user_id", Value: userID}}, 86400)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -584,44 +822,49 @@ func settingsEventsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 	var response = datatable.NewDataTablesResponse(r, query, total, total, nil)
 	for _, event := range events {
 
-		// Parse user agent
-		ua := user_agent.New(event.UserAgent)
-		browser, version := ua.Browser()
-		agent := ua.OSInfo().Name + " " + ua.OSInfo().Version + " - " + browser + " " + version
-
-		// Get IP location
-		var location []string
-		record, err := geo.GetLocation(event.IP)
-		if err == nil {
-			if val, ok := record.Country.Names["en"]; ok {
-				location = append(location, val)
-			}
-			if val, ok := record.City.Names["en"]; ok {
-				location = append(location, val)
-			}
-		}
-
-		if len(location) == 0 {
-			location = append(location, geo.GetFirstIP(event.IP))
-		}
-
-		//
 		response.AddRow([]interface{}{
-			event.CreatedAt.Unix(),       // 0
-			event.GetCreatedNice(),       // 1
-			event.Type.ToString(),        // 2
-			geo.GetFirstIP(event.IP),     // 3
-			event.UserAgent,              // 4
-			agent,                        // 5
-			geo.GetFirstIP(r.RemoteAddr), // 6
-			event.GetIcon(),              // 7
-			strings.Join(location, ", "), // 8
+			event.CreatedAt.Unix(),            // 0
+			event.GetCreatedNice(),            // 1
+			event.Type.ToString(),             // 2
+			geo.GetFirstIP(event.IP),          // 3
+			event.UserAgent,                   // 4
+			getUserAgentNice(event.UserAgent), // 5
+			geo.GetFirstIP(r.RemoteAddr),      // 6
+			event.GetIcon(),                   // 7
+			getLocationNice(event.IP),         // 8
 		})
 	}
 
 	returnJSON(w, r, response)
 }
 
+func getUserAgentNice(agent string) string {
+
+	ua := user_agent.New(agent)
+	browser, version := ua.Browser()
+	return ua.OSInfo().Name + " " + ua.OSInfo().Version + " - " + browser + " " + version
+}
+
+func getLocationNice(ip string) string {
+
+	var location []string
+	record, err := geo.GetLocation(ip)
+	if err == nil {
+		if val, ok := record.Country.Names["en"]; ok {
+			location = append(location, val)
+		}
+		if val, ok := record.City.Names["en"]; ok {
+			location = append(location, val)
+		}
+	}
+
+	if len(location) == 0 {
+		location = append(location, geo.GetFirstIP(ip))
+	}
+
+	return strings.Join(location, ", ")
+}
+
 func settingsDonationsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := datatable.NewDataTableQuery(r, false)
@@ -668,7 +911,7 @@ func settingsDonationsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 			donation.CreatedAt.Unix(),                       // 0
 			donation.CreatedAt.Format(helpers.DateYearTime), // 1
 			donation.AmountUSD,                              // 2
-			strings.Title(donation.Source),                  // 3
+			donation.GetSourceNice(),                        // 3
 		})
 	}
 
@@ -735,3 +978,49 @@ func settingsRemoveProviderHandler(w http.ResponseWriter, r *http.Request) {
 		log.ErrS(err)
 	}
 }
+
+func settingsIgnoreAppHandler(w http.ResponseWriter, r *http.Request) {
+	settingsToggleIgnoredApp(w, r, true)
+}
+
+func settingsUnignoreAppHandler(w http.ResponseWriter, r *http.Request) {
+	settingsToggleIgnoredApp(w, r, false)
+}
+
+func settingsToggleIgnoredApp(w http.ResponseWriter, r *http.Request, ignore bool) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings#recommendations", http.StatusFound)
+	}()
+
+	appID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || !helpers.IsValidAppID(appID) {
+		session.SetFlash(r, session.SessionBad, "Invalid App ID")
+		return
+	}
+
+	playerID := session.GetPlayerIDFromSesion(r)
+	if playerID == 0 {
+		session.SetFlash(r, session.SessionBad, "You need to link your Steam account")
+		return
+	}
+
+	if ignore {
+		err = mongo.IgnorePlayerApp(playerID, appID)
+	} else {
+		err = mongo.UnignorePlayerApp(playerID, appID)
+	}
+
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "An error occurred (1001)")
+		return
+	}
+
+	if ignore {
+		session.SetFlash(r, session.SessionGood, "Game will no longer be recommended")
+	} else {
+		session.SetFlash(r, session.SessionGood, "Game can be recommended again")
+	}
+}
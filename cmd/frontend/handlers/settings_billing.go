@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/session"
+)
+
+func settingsBillingHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	t := settingsBillingTemplate{}
+	t.fill(w, r, "settings_billing", "Billing", "Your subscriptions and payments")
+	t.hideAds = true
+	t.User = user
+
+	var wg sync.WaitGroup
+
+	// Get subscriptions
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		var err error
+		t.Subscriptions, err = mysql.GetUserSubscriptions(user.ID)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	// Get payments
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		var err error
+		t.Donations, err = mysql.GetDonationsByUser(user.ID, 0)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	wg.Wait()
+
+	returnTemplate(w, r, t)
+}
+
+type settingsBillingTemplate struct {
+	globalTemplate
+	User          mysql.User
+	Subscriptions []mysql.UserSubscription
+	Donations     []mysql.Donation
+}
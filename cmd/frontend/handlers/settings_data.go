@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/email"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/geo"
+	"github.com/gamedb/gamedb/pkg/archive"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/consumers"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/session"
+	"github.com/gamedb/gamedb/pkg/userdata"
+	"github.com/go-chi/chi/v5"
+)
+
+func settingsDataHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	t := settingsDataTemplate{}
+	t.fill(w, r, "settings_data", "Your Data", "Download or delete everything we hold about you")
+	t.hideAds = true
+	t.User = user
+	t.ExportDays = int(userdata.ExportLifetime.Hours() / 24)
+	t.DeleteDays = int(userdata.DeleteDelay.Hours() / 24)
+
+	t.Requests, err = mysql.GetUserDataRequests(user.ID)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	for _, v := range t.Requests {
+		if v.Type == mysql.UserDataDelete && v.Status == mysql.UserDataPending {
+			v := v
+			t.Delete = &v
+			break
+		}
+	}
+
+	returnTemplate(w, r, t)
+}
+
+type settingsDataTemplate struct {
+	globalTemplate
+	User       mysql.User
+	Requests   []mysql.UserDataRequest
+	Delete     *mysql.UserDataRequest // A pending deletion
+	ExportDays int
+	DeleteDays int
+}
+
+func settingsDataExportHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings/data", http.StatusFound)
+	}()
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return
+	}
+
+	// Exports are heavy, one a day is plenty
+	requests, err := mysql.GetUserDataRequests(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	for _, v := range requests {
+		if v.Type == mysql.UserDataExport && v.CreatedAt.After(time.Now().Add(-userdata.ExportCooldown)) {
+			session.SetFlash(r, session.SessionBad, "You can only ask for one export a day, check your email for the last one")
+			return
+		}
+	}
+
+	request, err := mysql.CreateUserDataRequest(user.ID, mysql.UserDataExport, time.Now())
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	err = consumers.ProduceUserData(r.Context(), request.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1003)")
+		return
+	}
+
+	err = mongo.NewEvent(r, user.ID, mongo.EventDataExport)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "We will email you a link when your export is ready")
+}
+
+func settingsDataDownloadHandler(w http.ResponseWriter, r *http.Request) {
+
+	userID := session.GetUserIDFromSesion(r)
+
+	request, err := mysql.GetUserDataExport(userID, chi.URLParam(r, "token"))
+	if err != nil {
+		err = helpers.IgnoreErrors(err, mysql.ErrRecordNotFound)
+		if err != nil {
+			log.ErrS(err)
+		}
+		session.SetFlash(r, session.SessionBad, "This export has expired, or belongs to another account")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings/data", http.StatusFound)
+		return
+	}
+
+	file, err := archive.GetFile(request.File)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings/data", http.StatusFound)
+		return
+	}
+
+	defer func() {
+		err = file.Close()
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="globalsteam-data-`+request.CreatedAt.Format(helpers.DateSQLDay)+`.zip"`)
+
+	_, err = io.Copy(w, file)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
+
+func settingsDataDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings/data", http.StatusFound)
+	}()
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(r.PostForm.Get("email")), user.Email) {
+		session.SetFlash(r, session.SessionBad, "Please type your email address to confirm")
+		return
+	}
+
+	_, err = mysql.GetPendingUserDataRequest(user.ID, mysql.UserDataDelete)
+	if err == nil {
+		session.SetFlash(r, session.SessionBad, "Your account is already scheduled for deletion")
+		return
+	} else if err != mysql.ErrRecordNotFound {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	// The cron queues it once this has passed
+	request, err := mysql.CreateUserDataRequest(user.ID, mysql.UserDataDelete, time.Now().Add(userdata.DeleteDelay))
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1003)")
+		return
+	}
+
+	err = email.GetProvider().Send(
+		user.Email,
+		"",
+		"",
+		"Your Global Steam account will be deleted",
+		email.UserDeleteScheduledTemplate{
+			Domain: config.C.GlobalSteamDomain,
+			Due:    request.GetDueNice(),
+			IP:     geo.GetFirstIP(r.RemoteAddr),
+		},
+	)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	err = mongo.NewEvent(r, user.ID, mongo.EventDeleteRequest)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Your account will be deleted on "+request.GetDueNice()+", you can cancel until then")
+}
+
+func settingsDataDeleteCancelHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings/data", http.StatusFound)
+	}()
+
+	userID := session.GetUserIDFromSesion(r)
+
+	request, err := mysql.GetPendingUserDataRequest(userID, mysql.UserDataDelete)
+	if err == mysql.ErrRecordNotFound {
+		session.SetFlash(r, session.SessionBad, "Your account is not scheduled for deletion")
+		return
+	} else if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	err = mysql.SetUserDataRequestStatus(request, mysql.UserDataCancelled)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	err = mongo.NewEvent(r, userID, mongo.EventDeleteCancel)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Your account will not be deleted")
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/session"
+	"github.com/go-chi/chi/v5"
+)
+
+func settingsSessionDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+	}()
+
+	userID := session.GetUserIDFromSesion(r)
+	id := chi.URLParam(r, "id")
+
+	err := mongo.DeleteUserSession(userID, id)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	err = mongo.NewEvent(r, userID, mongo.EventLogoutRemote)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Session logged out")
+}
+
+func settingsSessionsLogoutAllHandler(w http.ResponseWriter, r *http.Request) {
+
+	userID := session.GetUserIDFromSesion(r)
+
+	err := mongo.DeleteUserSessions(userID, "")
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	err = mongo.NewEvent(r, userID, mongo.EventLogoutAll)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.DeleteAll(r)
+	session.SetFlash(r, session.SessionGood, "You have been logged out everywhere")
+	session.Save(w, r)
+
+	http.Redirect(w, r, "/login", http.StatusFound)
+}
@@ -0,0 +1,467 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/twofactor"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/session"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	settingsSessionTOTPSecret    = "totp-secret"    // Until the first code is confirmed
+	settingsSessionRecoveryCodes = "recovery-codes" // Shown once after they are made
+)
+
+func settingsTOTPHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	key, err := twofactor.NewTOTPKey(user.Email)
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	session.Set(r, settingsSessionTOTPSecret, key.Secret())
+	session.Save(w, r)
+
+	t := settingsTOTPTemplate{}
+	t.fill(w, r, "settings_totp", "Authenticator App", "Set up two-factor authentication")
+	t.hideAds = true
+	t.Secret = key.Secret()
+
+	t.QRCode, err = twofactor.QRCode(key)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	returnTemplate(w, r, t)
+}
+
+type settingsTOTPTemplate struct {
+	globalTemplate
+	Secret string
+	QRCode template.URL
+}
+
+func settingsTOTPPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	secret := session.Get(r, settingsSessionTOTPSecret)
+	if secret == "" {
+		session.SetFlash(r, session.SessionBad, "Setup expired, please try again")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	step, ok, err := twofactor.ValidateTOTP(secret, r.PostForm.Get("code"))
+	if err != nil {
+		log.ErrS(err)
+	}
+	if !ok {
+		time.Sleep(time.Second)
+		session.SetFlash(r, session.SessionBad, "Incorrect code, please scan the new QR code and try again")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings/totp", http.StatusFound)
+		return
+	}
+
+	session.DeleteMany(r, []string{settingsSessionTOTPSecret})
+
+	err = mysql.SaveUserTOTP(user.ID, secret, step)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	err = mongo.NewEvent(r, user.ID, mongo.EventTOTPEnable)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Authenticator app enabled")
+	redirect := settingsTwoFactorEnabledRedirect(r, user)
+	session.Save(w, r)
+
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+// Makes recovery codes the first time a method is added, returns the page to go to next
+func settingsTwoFactorEnabledRedirect(r *http.Request, user mysql.User) string {
+
+	count, err := mysql.CountUserRecoveryCodes(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		return "/settings"
+	}
+
+	if count > 0 {
+		return "/settings"
+	}
+
+	codes, err := mysql.CreateUserRecoveryCodes(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		return "/settings"
+	}
+
+	session.Set(r, settingsSessionRecoveryCodes, strings.Join(codes, ","))
+	return "/settings/recovery-codes"
+}
+
+func settingsTOTPDisableHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+	}()
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return
+	}
+
+	// Needs a current code, so a hijacked session can't turn it off
+	if !settingsCheckCurrentCode(r, user, twofactor.Methods{TOTP: true}) {
+		return
+	}
+
+	err = mysql.DeleteUserTOTP(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	err = mongo.NewEvent(r, user.ID, mongo.EventTOTPDisable)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	settingsClearUnusedRecoveryCodes(user)
+
+	session.SetFlash(r, session.SessionGood, "Authenticator app disabled")
+}
+
+// Recovery codes are only any use while another method is on
+func settingsClearUnusedRecoveryCodes(user mysql.User) {
+
+	methods, err := twofactor.GetMethods(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
+
+	if !methods.IsEnabled() {
+		err = mysql.DeleteUserRecoveryCodes(user.ID)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+}
+
+func settingsRecoveryCodesHandler(w http.ResponseWriter, r *http.Request) {
+
+	val := session.Get(r, settingsSessionRecoveryCodes)
+	if val == "" {
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	session.DeleteMany(r, []string{settingsSessionRecoveryCodes})
+	session.Save(w, r)
+
+	t := settingsRecoveryCodesTemplate{}
+	t.fill(w, r, "settings_recovery_codes", "Recovery Codes", "Two-factor recovery codes")
+	t.hideAds = true
+	t.Codes = strings.Split(val, ",")
+
+	returnTemplate(w, r, t)
+}
+
+type settingsRecoveryCodesTemplate struct {
+	globalTemplate
+	Codes []string
+}
+
+func settingsRecoveryCodesPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	methods, err := twofactor.GetMethods(user.ID)
+	if err != nil || !methods.IsEnabled() {
+		if err != nil {
+			log.ErrS(err)
+		}
+		session.SetFlash(r, session.SessionBad, "Two-factor authentication is not enabled")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	// Recovery codes can turn off the other methods, so making them needs a current code too
+	if !settingsCheckCurrentCode(r, user, methods) {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	codes, err := mysql.CreateUserRecoveryCodes(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	session.Set(r, settingsSessionRecoveryCodes, strings.Join(codes, ","))
+	session.Save(w, r)
+
+	http.Redirect(w, r, "/settings/recovery-codes", http.StatusFound)
+}
+
+func settingsSecurityKeyBeginHandler(w http.ResponseWriter, r *http.Request) {
+
+	options, err := func() (interface{}, error) {
+
+		user, err := getUserFromSession(r)
+		if err != nil {
+			return nil, err
+		}
+
+		wa, err := twofactor.WebAuthn()
+		if err != nil {
+			return nil, err
+		}
+
+		waUser, err := twofactor.NewUser(user)
+		if err != nil {
+			return nil, err
+		}
+
+		options, data, err := wa.BeginRegistration(waUser, webauthn.WithExclusions(waUser.Exclusions()))
+		if err != nil {
+			return nil, err
+		}
+
+		return options, twofactor.SaveSession(r, data)
+	}()
+
+	if err != nil {
+		log.ErrS(err)
+		returnJSON(w, r, webAuthnResponse{Error: "Something went wrong"})
+		return
+	}
+
+	session.Save(w, r)
+	returnJSON(w, r, options)
+}
+
+func settingsSecurityKeyFinishHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		returnJSON(w, r, webAuthnResponse{Error: "User not found"})
+		return
+	}
+
+	err = func() error {
+
+		data, err := twofactor.GetSession(r)
+		if err != nil {
+			return err
+		}
+
+		wa, err := twofactor.WebAuthn()
+		if err != nil {
+			return err
+		}
+
+		waUser, err := twofactor.NewUser(user)
+		if err != nil {
+			return err
+		}
+
+		credential, err := wa.FinishRegistration(waUser, data, r)
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSpace(r.URL.Query().Get("name"))
+		if name == "" {
+			name = "Security key"
+		}
+		if len(name) > 50 {
+			name = name[:50]
+		}
+
+		return mysql.CreateUserSecurityKey(mysql.UserSecurityKey{
+			UserID:       user.ID,
+			Name:         name,
+			CredentialID: credential.ID,
+			PublicKey:    credential.PublicKey,
+			AAGUID:       credential.Authenticator.AAGUID,
+			SignCount:    credential.Authenticator.SignCount,
+		})
+	}()
+
+	if err != nil {
+		log.Info("Security key registration failed", zap.Int("user", user.ID), zap.Error(err))
+		session.Save(w, r)
+		returnJSON(w, r, webAuthnResponse{Error: "Your security key could not be added"})
+		return
+	}
+
+	err = mongo.NewEvent(r, user.ID, mongo.EventKeyAdd)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Security key added")
+
+	redirect := settingsTwoFactorEnabledRedirect(r, user)
+
+	session.Save(w, r)
+	returnJSON(w, r, webAuthnResponse{Redirect: redirect})
+}
+
+func settingsSecurityKeyDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+	}()
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		session.SetFlash(r, session.SessionBad, "Invalid security key")
+		return
+	}
+
+	methods, err := twofactor.GetMethods(user.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	// An authenticator code, or a recovery code if there is no app
+	if !settingsCheckCurrentCode(r, user, methods) {
+		return
+	}
+
+	ok, err := mysql.DeleteUserSecurityKey(user.ID, id)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+	if !ok {
+		session.SetFlash(r, session.SessionBad, "Security key not found")
+		return
+	}
+
+	err = mongo.NewEvent(r, user.ID, mongo.EventKeyRemove)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	settingsClearUnusedRecoveryCodes(user)
+
+	session.SetFlash(r, session.SessionGood, "Security key removed")
+}
+
+// Sets a flash and returns false if the posted code is wrong
+func settingsCheckCurrentCode(r *http.Request, user mysql.User, methods twofactor.Methods) bool {
+
+	// Shares the login limit, so guessing here doesn't get more attempts
+	locked, err := twoFactorLocked(user.ID)
+	if err != nil {
+		log.ErrS(err)
+	}
+	if locked {
+		session.SetFlash(r, session.SessionBad, "Too many incorrect codes, please try again in 10 minutes")
+		return false
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	ok, err := checkTwoFactorCode(user, methods, r.PostForm.Get("code"))
+	if err != nil {
+		err = helpers.IgnoreErrors(err, mysql.ErrRecordNotFound)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+	if !ok {
+
+		time.Sleep(time.Second)
+
+		if !countTwoFactorFail(r, user) {
+			session.SetFlash(r, session.SessionBad, "Too many incorrect codes, please try again in 10 minutes")
+			return false
+		}
+
+		session.SetFlash(r, session.SessionBad, "Incorrect code")
+		return false
+	}
+
+	resetTwoFactorFails(user)
+
+	return true
+}
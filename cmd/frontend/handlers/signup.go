@@ -210,7 +210,8 @@ func verifyHandler(w http.ResponseWriter, r *http.Request) {
 		log.ErrS(err)
 	}
 
-	login(r, user)
+	// Users with two-factor set up will be sent to the second step from the login page
+	loginOrTwoFactor(r, user)
 
 	//
 	session.SetFlash(r, session.SessionGood, "Email has been verified")
@@ -102,7 +102,7 @@ func SiteMapPagesHandler(w http.ResponseWriter, r *http.Request) {
 
 func SiteMapGamesByPlayersHandler(w http.ResponseWriter, r *http.Request) {
 
-	apps, err := mongo.GetApps(0, siteMapLimit, bson.D{{Key: "player_peak_week", Value: -1}}, bson.D{}, bson.M{"_id": 1, "name": 1, "updated_at": 1})
+	apps, err := mongo.GetApps(r.Context(), 0, siteMapLimit, bson.D{{Key: "player_peak_week", Value: -1}}, bson.D{}, bson.M{"_id": 1, "name": 1, "updated_at": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -123,7 +123,7 @@ func SiteMapGamesByPlayersHandler(w http.ResponseWriter, r *http.Request) {
 
 func SiteMapGamesByScoreHandler(w http.ResponseWriter, r *http.Request) {
 
-	apps, err := mongo.GetApps(0, siteMapLimit, bson.D{{Key: "reviews_score", Value: -1}}, bson.D{}, bson.M{"_id": 1, "name": 1, "updated_at": 1})
+	apps, err := mongo.GetApps(r.Context(), 0, siteMapLimit, bson.D{{Key: "reviews_score", Value: -1}}, bson.D{}, bson.M{"_id": 1, "name": 1, "updated_at": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -144,7 +144,7 @@ func SiteMapGamesByScoreHandler(w http.ResponseWriter, r *http.Request) {
 
 func SiteMapGamesUpcomingHandler(w http.ResponseWriter, r *http.Request) {
 
-	apps, err := mongo.GetApps(0, siteMapLimit, bson.D{{Key: "release_date_unix", Value: 1}}, upcomingFilter, bson.M{"_id": 1, "name": 1, "updated_at": 1})
+	apps, err := mongo.GetApps(r.Context(), 0, siteMapLimit, bson.D{{Key: "release_date_unix", Value: 1}}, upcomingFilter, bson.M{"_id": 1, "name": 1, "updated_at": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -170,7 +170,7 @@ func SiteMapGamesNewHandler(w http.ResponseWriter, r *http.Request) {
 		{Key: "release_date_unix", Value: bson.M{"$gt": time.Now().AddDate(0, 0, -config.C.NewReleaseDays).Unix()}},
 	}
 
-	apps, err := mongo.GetApps(0, siteMapLimit, bson.D{{Key: "release_date_unix", Value: -1}}, filter, bson.M{"_id": 1, "name": 1, "updated_at": 1})
+	apps, err := mongo.GetApps(r.Context(), 0, siteMapLimit, bson.D{{Key: "release_date_unix", Value: -1}}, filter, bson.M{"_id": 1, "name": 1, "updated_at": 1})
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -194,7 +194,7 @@ func SiteMapPlayersByLevel(w http.ResponseWriter, r *http.Request) {
 
 	sm := sitemap.NewSitemap()
 
-	players, err := mongo.GetPlayers(0, siteMapLimit, bson.D{{Key: "level", Value: -1}}, nil, bson.M{"_id": 1, "persona_name": 1, "updated_at": 1})
+	players, err := mongo.GetPlayers(r.Context(), 0, siteMapLimit, bson.D{{Key: "level", Value: -1}}, nil, bson.M{"_id": 1, "persona_name": 1, "updated_at": 1})
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -216,7 +216,7 @@ func SiteMapPlayersByGamesCount(w http.ResponseWriter, r *http.Request) {
 
 	sm := sitemap.NewSitemap()
 
-	players, err := mongo.GetPlayers(0, siteMapLimit, bson.D{{Key: "games_count", Value: -1}}, nil, bson.M{"_id": 1, "persona_name": 1, "updated_at": 1})
+	players, err := mongo.GetPlayers(r.Context(), 0, siteMapLimit, bson.D{{Key: "games_count", Value: -1}}, nil, bson.M{"_id": 1, "persona_name": 1, "updated_at": 1})
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -236,7 +236,7 @@ func SiteMapPlayersByGamesCount(w http.ResponseWriter, r *http.Request) {
 //noinspection GoUnusedParameter
 func SiteMapGroups(w http.ResponseWriter, r *http.Request) {
 
-	conn, ctx, err := backend.GetClient()
+	conn, ctx, err := backend.GetClient(r.Context())
 	if err != nil {
 		log.Err(err.Error())
 		return
@@ -113,7 +113,7 @@ func statAppsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 		var sort = query.GetOrderMongo(columns)
 
 		var err error
-		apps, err = mongo.GetApps(query.GetOffset64(), 100, sort, filter, projection)
+		apps, err = mongo.GetApps(r.Context(), query.GetOffset64(), 100, sort, filter, projection)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -127,7 +127,7 @@ func statAppsAjaxHandler(w http.ResponseWriter, r *http.Request) {
 
 		var err error
 		countLock.Lock()
-		count, err = mongo.CountDocuments(mongo.CollectionApps, filter, 60*60*24)
+		count, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, filter, 60*60*24)
 		countLock.Unlock()
 		if err != nil {
 			log.ErrS(err)
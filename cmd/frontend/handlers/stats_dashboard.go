@@ -51,7 +51,7 @@ func statsSteamHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.AppsCount, err = mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+		t.AppsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionApps, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -63,7 +63,7 @@ func statsSteamHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.BundlesCount, err = mongo.CountDocuments(mongo.CollectionBundles, nil, 0)
+		t.BundlesCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionBundles, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -75,7 +75,7 @@ func statsSteamHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PackagesCount, err = mongo.CountDocuments(mongo.CollectionPackages, nil, 0)
+		t.PackagesCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPackages, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -87,7 +87,7 @@ func statsSteamHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.AchievementsCount, err = mongo.CountDocuments(mongo.CollectionAppAchievements, nil, 0)
+		t.AchievementsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppAchievements, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -99,7 +99,7 @@ func statsSteamHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.ArticlesCount, err = mongo.CountDocuments(mongo.CollectionAppArticles, nil, 0)
+		t.ArticlesCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionAppArticles, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -166,7 +166,7 @@ func statsGameDBHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PlayersCount, err = mongo.CountDocuments(mongo.CollectionPlayers, nil, 0)
+		t.PlayersCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayers, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -178,7 +178,7 @@ func statsGameDBHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PlayerAppsCount, err = mongo.CountDocuments(mongo.CollectionPlayerApps, nil, 0)
+		t.PlayerAppsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerApps, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -190,7 +190,7 @@ func statsGameDBHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PlayerFriendsCount, err = mongo.CountDocuments(mongo.CollectionPlayerFriends, nil, 0)
+		t.PlayerFriendsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerFriends, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -202,7 +202,7 @@ func statsGameDBHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PlayerAchievementsCount, err = mongo.CountDocuments(mongo.CollectionPlayerAchievements, nil, 0)
+		t.PlayerAchievementsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerAchievements, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -214,7 +214,7 @@ func statsGameDBHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PlayerBadgesCount, err = mongo.CountDocuments(mongo.CollectionPlayerBadges, nil, 0)
+		t.PlayerBadgesCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerBadges, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -226,12 +226,24 @@ func statsGameDBHandler(w http.ResponseWriter, r *http.Request) {
 		defer wg.Done()
 
 		var err error
-		t.PlayerGroupsCount, err = mongo.CountDocuments(mongo.CollectionPlayerGroups, nil, 0)
+		t.PlayerGroupsCount, err = mongo.CountDocuments(r.Context(), mongo.CollectionPlayerGroups, nil, 0)
 		if err != nil {
 			log.ErrS(err)
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		var err error
+		t.Freshness, err = mongo.GetLatestFreshnessReport()
+		if err != nil && err != mongo.ErrNoDocuments {
+			log.ErrS(err)
+		}
+	}()
+
 	wg.Wait()
 
 	returnTemplate(w, r, t)
@@ -245,6 +257,7 @@ type gamedbStatsTemplate struct {
 	PlayerBadgesCount       int64
 	PlayerGroupsCount       int64
 	PlayersCount            int64
+	Freshness               mongo.FreshnessReport
 }
 
 func statsPlayerLevelsHandler(w http.ResponseWriter, r *http.Request) {
@@ -71,7 +71,7 @@ func statsListJSONHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := datatable.NewDataTableQuery(r, true)
 
-	conn, ctx, err := backend.GetClient()
+	conn, ctx, err := backend.GetClient(r.Context())
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/feed"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/go-chi/chi/v5"
+)
+
+const statusUptimeDays = 30
+
+func StatusRouter() http.Handler {
+
+	r := chi.NewRouter()
+	r.Get("/", statusHandler)
+	r.Get("/status.json", statusAjaxHandler)
+	r.Get("/feed.{format:(rss|atom|json)}", statusFeedHandler)
+
+	return r
+}
+
+func statusHandler(w http.ResponseWriter, r *http.Request) {
+
+	t := statusTemplate{}
+	t.fill(w, r, "status", "Status", "Current status and uptime history of Global Steam.")
+	t.FeedPath = "/status/feed"
+
+	var err error
+
+	t.Components, err = getStatusComponents()
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	t.Incidents, err = mongo.GetStatusIncidents(20)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	t.Healthy = true
+	for _, v := range t.Components {
+		if !v.Healthy {
+			t.Healthy = false
+		}
+	}
+
+	returnTemplate(w, r, t)
+}
+
+type statusTemplate struct {
+	globalTemplate
+	Healthy    bool
+	Components []statusComponent
+	Incidents  []mongo.StatusIncident
+}
+
+type statusComponent struct {
+	ID        mongo.StatusComponent `json:"id"`
+	Name      string                `json:"name"`
+	Healthy   bool                  `json:"healthy"`
+	Message   string                `json:"message,omitempty"`
+	CheckedAt time.Time             `json:"checked_at"`
+	Uptime    float64               `json:"uptime"` // Percent over statusUptimeDays
+	Days      []statusComponentDay  `json:"-"`
+}
+
+type statusComponentDay struct {
+	Day     string
+	Checks  int
+	Percent float64
+}
+
+func (day statusComponentDay) GetClass() string {
+
+	switch {
+	case day.Checks == 0:
+		return "bg-secondary"
+	case day.Percent >= 99:
+		return "bg-success"
+	case day.Percent >= 90:
+		return "bg-warning"
+	default:
+		return "bg-danger"
+	}
+}
+
+func (day statusComponentDay) GetTitle() string {
+
+	if day.Checks == 0 {
+		return day.Day + ": no data"
+	}
+	return day.Day + ": " + strconv.FormatFloat(day.Percent, 'f', 2, 64) + "% uptime"
+}
+
+// Components in display order, with their latest check and daily uptime
+func getStatusComponents() (components []statusComponent, err error) {
+
+	latest, err := mongo.GetLatestStatusChecks()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -(statusUptimeDays - 1)).Truncate(time.Hour * 24)
+
+	uptime, err := mongo.GetStatusUptime(since)
+	if err != nil {
+		return nil, err
+	}
+
+	uptimeMap := map[mongo.StatusComponent]map[string]mongo.StatusUptimeDay{}
+	for _, v := range uptime {
+		if _, ok := uptimeMap[v.Component]; !ok {
+			uptimeMap[v.Component] = map[string]mongo.StatusUptimeDay{}
+		}
+		uptimeMap[v.Component][v.Day] = v
+	}
+
+	for _, id := range mongo.StatusComponents {
+
+		component := statusComponent{
+			ID:   id,
+			Name: id.GetName(),
+		}
+
+		// No recent check counts as down
+		if check, ok := latest[id]; ok {
+			component.Healthy = check.Healthy
+			component.Message = check.Message
+			component.CheckedAt = check.CreatedAt
+		} else {
+			component.Message = "No recent checks"
+		}
+
+		var checks, healthy int
+		for day := since; !day.After(now); day = day.AddDate(0, 0, 1) {
+
+			key := day.Format("2006-01-02")
+			v := uptimeMap[id][key]
+
+			checks += v.Checks
+			healthy += v.Healthy
+
+			component.Days = append(component.Days, statusComponentDay{Day: key, Checks: v.Checks, Percent: v.Percent()})
+		}
+
+		if checks > 0 {
+			component.Uptime = float64(healthy) / float64(checks) * 100
+		}
+
+		components = append(components, component)
+	}
+
+	return components, nil
+}
+
+// Polled by the Discord announcements
+func statusAjaxHandler(w http.ResponseWriter, r *http.Request) {
+
+	components, err := getStatusComponents()
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	incidents, err := mongo.GetStatusIncidents(20)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	response := statusResponse{
+		Healthy:    true,
+		Components: components,
+		Incidents:  []mongo.StatusIncident{},
+	}
+
+	for _, v := range components {
+		if !v.Healthy {
+			response.Healthy = false
+		}
+	}
+
+	for _, v := range incidents {
+		if !v.IsResolved() {
+			response.Incidents = append(response.Incidents, v)
+		}
+	}
+
+	returnJSON(w, r, response)
+}
+
+type statusResponse struct {
+	Healthy    bool                   `json:"healthy"`
+	Components []statusComponent      `json:"components"`
+	Incidents  []mongo.StatusIncident `json:"incidents"` // Unresolved only
+}
+
+func statusFeedHandler(w http.ResponseWriter, r *http.Request) {
+
+	incidents, err := mongo.GetStatusIncidents(50)
+	if err != nil {
+		log.ErrS(err)
+		returnErrorTemplate(w, r, errorTemplate{Code: 500})
+		return
+	}
+
+	format := feed.Format(chi.URLParam(r, "format"))
+
+	f := feed.Feed{
+		Title:       "Status Incidents - Global Steam",
+		Link:        config.C.GlobalSteamDomain + "/status",
+		FeedLink:    config.C.GlobalSteamDomain + "/status/feed." + string(format),
+		Description: "Outages and other incidents affecting Global Steam",
+		MaxAge:      time.Minute,
+	}
+
+	for k, incident := range incidents {
+
+		if k == 0 {
+			f.Updated = incident.CreatedAt
+		}
+
+		description := "<p>" + html.EscapeString(incident.Message) + "</p>" +
+			"<p>Affects: " + html.EscapeString(strings.Join(incident.GetComponentNames(), ", ")) + "</p>"
+
+		if incident.IsResolved() {
+			description += "<p>Resolved " + incident.ResolvedAt.Format(time.RFC1123) + "</p>"
+		}
+
+		f.Items = append(f.Items, feed.Item{
+			ID:          config.C.GlobalSteamDomain + "/status#" + incident.ID,
+			Title:       incident.Title,
+			Link:        config.C.GlobalSteamDomain + "/status#" + incident.ID,
+			Description: description,
+			Published:   incident.CreatedAt,
+		})
+	}
+
+	err = f.Write(w, r, format)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
@@ -0,0 +1,705 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/badoux/checkmail"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/email"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/geo"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/middleware"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/session"
+	"github.com/go-chi/chi/v5"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TeamsRouter() http.Handler {
+
+	r := chi.NewRouter()
+
+	// Logged out users get sent back here after logging in
+	r.Get("/join/{token:[a-f0-9]+}", teamJoinHandler)
+
+	r.Group(func(r chi.Router) {
+
+		r.Use(middleware.MiddlewareAuthCheck)
+
+		r.Get("/", teamHandler)
+		r.Post("/", teamCreateHandler)
+		r.Post("/delete", teamDeleteHandler)
+		r.Post("/invite", teamInviteHandler)
+		r.Post("/invites/{id:[0-9]+}/delete", teamInviteDeleteHandler)
+		r.Post("/keys", teamKeyCreateHandler)
+		r.Post("/keys/{id:[0-9]+}/delete", teamKeyDeleteHandler)
+		r.Post("/leave", teamLeaveHandler)
+		r.Post("/members/{id:[0-9]+}/new-key", teamMemberKeyHandler)
+		r.Post("/members/{id:[0-9]+}/remove", teamMemberRemoveHandler)
+	})
+
+	return r
+}
+
+func teamHandler(w http.ResponseWriter, r *http.Request) {
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		session.Save(w, r)
+		http.Redirect(w, r, "/settings", http.StatusFound)
+		return
+	}
+
+	t := teamTemplate{}
+	t.fill(w, r, "team", "Team", "Share your supporter level and API quota")
+	t.hideAds = true
+	t.User = user
+	t.MaxMembers = mysql.TeamMaxMembers
+	t.CanCreate = user.Level >= mysql.UserLevel1
+
+	t.Team, err = mysql.GetTeamByUserID(user.ID)
+	if err == mysql.ErrRecordNotFound {
+		returnTemplate(w, r, t)
+		return
+	} else if err != nil {
+		log.ErrS(err)
+	}
+
+	t.InTeam = true
+	t.IsOwner = t.Team.OwnerID == user.ID
+
+	t.Members, err = mysql.GetTeamMembers(t.Team.ID)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	for _, member := range t.Members {
+		if member.ID == t.Team.OwnerID {
+			t.Owner = member
+		}
+	}
+
+	// Every member can use the shared keys
+	t.Keys, err = mysql.GetTeamKeys(t.Team.ID)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	if !t.IsOwner {
+		returnTemplate(w, r, t)
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	// Get invites
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		var err error
+		t.Invites, err = mysql.GetTeamInvites(t.Team.ID)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	// Get audit log
+	wg.Add(1)
+	go func() {
+
+		defer wg.Done()
+
+		events, err := mongo.GetEvents(bson.D{{Key: "team_id", Value: t.Team.ID}}, 0)
+		if err != nil {
+			log.ErrS(err)
+			return
+		}
+
+		emails := map[int]string{}
+		for _, member := range t.Members {
+			emails[member.ID] = member.Email
+		}
+
+		for _, event := range events {
+
+			row := teamAuditRow{Event: event, Email: emails[event.UserID]}
+			if row.Email == "" {
+				row.Email = "Former member #" + strconv.Itoa(event.UserID)
+			}
+
+			t.Audit = append(t.Audit, row)
+		}
+	}()
+
+	wg.Wait()
+
+	returnTemplate(w, r, t)
+}
+
+type teamTemplate struct {
+	globalTemplate
+	User       mysql.User
+	Team       mysql.Team
+	Owner      mysql.User
+	Members    []mysql.User
+	Invites    []mysql.TeamInvite
+	Keys       []mysql.TeamKey
+	Audit      []teamAuditRow
+	CanCreate  bool
+	InTeam     bool
+	IsOwner    bool
+	MaxMembers int
+}
+
+type teamAuditRow struct {
+	mongo.Event
+	Email string
+}
+
+func teamCreateHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return
+	}
+
+	if user.Level < mysql.UserLevel1 {
+		session.SetFlash(r, session.SessionBad, "Only supporters can start a team")
+		return
+	}
+
+	_, err = mysql.GetTeamByUserID(user.ID)
+	if err == nil {
+		session.SetFlash(r, session.SessionBad, "You are already in a team")
+		return
+	} else if err != mysql.ErrRecordNotFound {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	name := strings.TrimSpace(r.PostForm.Get("name"))
+	if name == "" || len(name) > 50 {
+		session.SetFlash(r, session.SessionBad, "Team names must be between 1 and 50 characters")
+		return
+	}
+
+	team, err := mysql.CreateTeam(user, name)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1003)")
+		return
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, team.ID, mongo.EventTeamCreate, name)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Team created")
+}
+
+func teamDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, team, ok := getTeamAsOwner(r)
+	if !ok {
+		return
+	}
+
+	err := mysql.DeleteTeam(team)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, team.ID, mongo.EventTeamDelete, team.Name)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Team deleted")
+}
+
+func teamInviteHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, team, ok := getTeamAsOwner(r)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	userEmail := strings.TrimSpace(r.PostForm.Get("email"))
+
+	err = checkmail.ValidateFormat(userEmail)
+	if err != nil {
+		session.SetFlash(r, session.SessionBad, "Invalid email address")
+		return
+	}
+
+	count, err := mysql.CountTeamMembers(team.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	if count >= mysql.TeamMaxMembers {
+		session.SetFlash(r, session.SessionBad, "Teams can have up to "+strconv.Itoa(mysql.TeamMaxMembers)+" members")
+		return
+	}
+
+	token, err := mysql.CreateTeamInvite(team.ID, user.ID, userEmail)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1003)")
+		return
+	}
+
+	err = email.GetProvider().Send(
+		userEmail,
+		"",
+		"",
+		"Global Steam Team Invite",
+		email.TeamInviteTemplate{
+			Domain: config.C.GlobalSteamDomain,
+			Team:   team.Name,
+			From:   user.Email,
+			Token:  token,
+			IP:     geo.GetFirstIP(r.RemoteAddr),
+		},
+	)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1004)")
+		return
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, team.ID, mongo.EventTeamInvite, strings.ToLower(userEmail))
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Invite sent")
+}
+
+func teamInviteDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	_, team, ok := getTeamAsOwner(r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		session.SetFlash(r, session.SessionBad, "Invalid invite")
+		return
+	}
+
+	err = mysql.DeleteTeamInvite(team.ID, id)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	session.SetFlash(r, session.SessionGood, "Invite cancelled")
+}
+
+func teamKeyCreateHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, team, ok := getTeamAsOwner(r)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	name := strings.TrimSpace(r.PostForm.Get("name"))
+	if name == "" || len(name) > 50 {
+		session.SetFlash(r, session.SessionBad, "Key names must be between 1 and 50 characters")
+		return
+	}
+
+	_, err = mysql.CreateTeamKey(team.ID, user.ID, name)
+	if err == mysql.ErrTeamKeysFull {
+		session.SetFlash(r, session.SessionBad, "Teams can have up to "+strconv.Itoa(mysql.TeamMaxKeys)+" shared keys")
+		return
+	} else if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, team.ID, mongo.EventTeamKeyCreate, name)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Shared key created")
+}
+
+func teamKeyDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, team, ok := getTeamAsOwner(r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		session.SetFlash(r, session.SessionBad, "Invalid key")
+		return
+	}
+
+	key, err := mysql.DeleteTeamKey(team.ID, id)
+	if err == mysql.ErrRecordNotFound {
+		session.SetFlash(r, session.SessionBad, "Invalid key")
+		return
+	} else if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, team.ID, mongo.EventTeamKeyDelete, key.Name)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Shared key deleted")
+}
+
+func teamJoinHandler(w http.ResponseWriter, r *http.Request) {
+
+	userID := session.GetUserIDFromSesion(r)
+	if userID == 0 {
+		session.Set(r, session.SessionLastPage, r.URL.Path)
+		session.SetFlash(r, session.SessionGood, "Please login or sign up to join the team")
+		session.Save(w, r)
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return
+	}
+
+	invite, err := mysql.GetTeamInvite(chi.URLParam(r, "token"))
+	if err == mysql.ErrRecordNotFound {
+		session.SetFlash(r, session.SessionBad, "This invite has expired, ask the team owner for a new one")
+		return
+	} else if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	// Invites can't be forwarded
+	if !strings.EqualFold(invite.Email, user.Email) {
+		session.SetFlash(r, session.SessionBad, "This invite was sent to a different email address")
+		return
+	}
+
+	_, err = mysql.GetTeamByUserID(user.ID)
+	if err == nil {
+		session.SetFlash(r, session.SessionBad, "You are already in a team, leave it first")
+		return
+	} else if err != mysql.ErrRecordNotFound {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	err = mysql.AddTeamMember(invite.TeamID, user.ID)
+	if err == mysql.ErrTeamFull {
+		session.SetFlash(r, session.SessionBad, "This team is full")
+		return
+	} else if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1003)")
+		return
+	}
+
+	err = mysql.DeleteTeamInvite(invite.TeamID, invite.ID)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, invite.TeamID, mongo.EventTeamJoin, "")
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	setSessionUserLevel(r, user)
+
+	session.SetFlash(r, session.SessionGood, "You have joined the team")
+}
+
+func teamLeaveHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return
+	}
+
+	team, err := mysql.GetTeamByUserID(user.ID)
+	if err != nil {
+		err = helpers.IgnoreErrors(err, mysql.ErrRecordNotFound)
+		if err != nil {
+			log.ErrS(err)
+		}
+		session.SetFlash(r, session.SessionBad, "You are not in a team")
+		return
+	}
+
+	if team.OwnerID == user.ID {
+		session.SetFlash(r, session.SessionBad, "Owners can't leave, delete the team instead")
+		return
+	}
+
+	err = mysql.RemoveTeamMember(team.ID, user.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, team.ID, mongo.EventTeamLeave, "")
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	setSessionUserLevel(r, user)
+
+	session.SetFlash(r, session.SessionGood, "You have left the team")
+}
+
+func teamMemberRemoveHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, team, ok := getTeamAsOwner(r)
+	if !ok {
+		return
+	}
+
+	member, ok := getTeamMember(r, team)
+	if !ok {
+		return
+	}
+
+	if member.ID == user.ID {
+		session.SetFlash(r, session.SessionBad, "Owners can't leave, delete the team instead")
+		return
+	}
+
+	err := mysql.RemoveTeamMember(team.ID, member.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, team.ID, mongo.EventTeamRemove, member.Email)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "Member removed")
+}
+
+// For when a member's key leaks, the owner pays for the quota it uses
+func teamMemberKeyHandler(w http.ResponseWriter, r *http.Request) {
+
+	defer func() {
+		session.Save(w, r)
+		http.Redirect(w, r, "/teams", http.StatusFound)
+	}()
+
+	user, team, ok := getTeamAsOwner(r)
+	if !ok {
+		return
+	}
+
+	member, ok := getTeamMember(r, team)
+	if !ok {
+		return
+	}
+
+	oldKey := member.APIKey
+	member.SetAPIKey()
+
+	db, err := mysql.GetMySQLClient()
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1001)")
+		return
+	}
+
+	db = db.Model(&member).Update("api_key", member.APIKey)
+	if db.Error != nil {
+		log.ErrS(db.Error)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1002)")
+		return
+	}
+
+	err = memcache.Client().Delete(memcache.ItemUserByAPIKey(oldKey).Key)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	if member.ID == user.ID {
+		session.Set(r, session.SessionUserAPIKey, member.APIKey)
+	}
+
+	err = mongo.NewTeamEvent(r, user.ID, team.ID, mongo.EventTeamKeyReset, member.Email)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.SetFlash(r, session.SessionGood, "New API key generated for "+member.Email)
+}
+
+// Sets a flash and returns false if the user doesn't own a team
+func getTeamAsOwner(r *http.Request) (user mysql.User, team mysql.Team, ok bool) {
+
+	user, err := getUserFromSession(r)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "User not found")
+		return user, team, false
+	}
+
+	team, err = mysql.GetTeamByUserID(user.ID)
+	if err != nil {
+		err = helpers.IgnoreErrors(err, mysql.ErrRecordNotFound)
+		if err != nil {
+			log.ErrS(err)
+		}
+		session.SetFlash(r, session.SessionBad, "You are not in a team")
+		return user, team, false
+	}
+
+	if team.OwnerID != user.ID {
+		session.SetFlash(r, session.SessionBad, "Only the team owner can do this")
+		return user, team, false
+	}
+
+	return user, team, true
+}
+
+// The member from the URL, if they are in the team
+func getTeamMember(r *http.Request, team mysql.Team) (member mysql.User, ok bool) {
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		session.SetFlash(r, session.SessionBad, "Invalid member")
+		return member, false
+	}
+
+	members, err := mysql.GetTeamMembers(team.ID)
+	if err != nil {
+		log.ErrS(err)
+		session.SetFlash(r, session.SessionBad, "Something went wrong (1101)")
+		return member, false
+	}
+
+	for _, v := range members {
+		if v.ID == id {
+			return v, true
+		}
+	}
+
+	session.SetFlash(r, session.SessionBad, "Member not found")
+	return member, false
+}
+
+// Joining or leaving a team changes what the site shows straight away
+func setSessionUserLevel(r *http.Request, user mysql.User) {
+
+	level, _, err := mysql.GetEffectiveUserLevel(user)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	session.Set(r, session.SessionUserLevel, strconv.Itoa(int(level)))
+}
@@ -9,9 +9,11 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/Jleagle/patreon-go/patreon"
+	patreonwebhooks "github.com/Jleagle/patreon-go/patreon"
 	"github.com/bwmarrin/discordgo"
+	"github.com/gamedb/gamedb/pkg/billing"
 	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -19,6 +21,7 @@ import (
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql"
 	"github.com/gamedb/gamedb/pkg/oauth"
+	"github.com/gamedb/gamedb/pkg/patreon"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 	"golang.org/x/text/currency"
@@ -28,9 +31,12 @@ func WebhooksRouter() http.Handler {
 
 	r := chi.NewRouter()
 	r.Post("/github", gitHubWebhookPostHandler)
+	r.Post("/github-sponsors", gitHubSponsorsWebhookPostHandler)
+	r.Post("/kofi", kofiWebhookPostHandler)
 	r.Post("/mailjet", mailjetWebhookPostHandler)
 	r.Post("/patreon", patreonWebhookPostHandler)
 	r.Post("/sendgrid", sendgridWebhookPostHandler)
+	r.Post("/stripe", stripeWebhookPostHandler)
 	r.Post("/twitter", twitterZapierWebhookPostHandler)
 
 	return r
@@ -184,37 +190,28 @@ type twitterWebhook struct {
 	CreatedAt string `json:"created_at"`
 }
 
-type patreonTier int
+func patreonWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
 
-func (t patreonTier) toUserLevel() mysql.UserLevel {
-	switch t {
-	case patreonTier1:
-		return mysql.UserLevel1
-	case patreonTier2:
-		return mysql.UserLevel2
-	case patreonTier3:
-		return mysql.UserLevel3
+	// Get body
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.ErrS(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-	return mysql.UserLevelFree
-}
 
-const (
-	patreonTier1 patreonTier = 2431311
-	patreonTier2 patreonTier = 2431320
-	patreonTier3 patreonTier = 2431347
-)
-
-func patreonWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
+	defer helpers.Close(r.Body)
 
 	// Validate
-	if config.C.PatreonSecret == "" {
-		log.Err("Missing patreon environment variable")
-	}
+	var event = r.Header.Get("X-Patreon-Event")
 
-	b, event, err := patreon.Validate(r, config.C.PatreonSecret)
-	if err != nil {
-		log.ErrS(err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	err = patreon.ValidateSignature(b, r.Header.Get("X-Patreon-Signature"), config.C.PatreonSecret)
+	if err == patreon.ErrMissingSecret {
+		log.Err("Missing patreon environment variable")
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	} else if err != nil || event == "" {
+		http.Error(w, "Invalid signature", http.StatusBadRequest)
 		return
 	}
 
@@ -225,7 +222,7 @@ func patreonWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Handle
-	pwr, err := patreon.Unmarshal(b)
+	pwr, err := patreonwebhooks.Unmarshal(b)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(b)))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -312,23 +309,7 @@ func patreonWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
 		// Update user
 		if user.ID > 0 {
 
-			var level = mysql.UserLevelFree
-
-			for _, v := range pwr.Data.Relationships.CurrentlyEntitledTiers.Data {
-				switch i := patreonTier(v.ID); i {
-				case patreonTier1, patreonTier2, patreonTier3:
-					if i.toUserLevel() > level {
-						level = i.toUserLevel()
-					}
-				}
-			}
-
-			update := map[string]interface{}{
-				"donated_patreon": pwr.Data.Attributes.LifetimeSupportCents,
-				"level":           level,
-			}
-
-			db = db.Model(&user).Updates(update)
+			db = db.Model(&user).Update("donated_patreon", pwr.Data.Attributes.LifetimeSupportCents)
 			if db.Error != nil {
 				log.ErrS(db.Error)
 				http.Error(w, db.Error.Error(), http.StatusInternalServerError)
@@ -345,6 +326,35 @@ func patreonWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Update level, also on cancellations which don't come with money
+	if user.ID > 0 {
+
+		var tiers []patreon.Tier
+		for _, v := range pwr.Data.Relationships.CurrentlyEntitledTiers.Data {
+			tiers = append(tiers, patreon.Tier(v.ID))
+		}
+
+		err = mysql.SaveUserSubscription(mysql.UserSubscription{
+			UserID:     user.ID,
+			Source:     mysql.DonationSourcePatreon,
+			ExternalID: pwr.Data.ID,
+			Level:      patreon.TiersToUserLevel(tiers),
+			Active:     pwr.Data.Attributes.PatronStatus == patreon.PatronStatusActive && event != "members:pledge:delete",
+		})
+		if err != nil {
+			log.ErrS(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		err = billing.Recalculate(user)
+		if err != nil {
+			log.ErrS(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Return
 	_, err = w.Write([]byte(http.StatusText(http.StatusOK)))
 	if err != nil {
@@ -410,3 +420,118 @@ func gitHubWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
 		log.ErrS(err)
 	}
 }
+
+func stripeWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	// Get body
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.ErrS(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	defer helpers.Close(r.Body)
+
+	// Validate
+	err = billing.VerifyStripe(body, r.Header.Get("Stripe-Signature"), config.C.StripeWebhookSecret, time.Now())
+	if !billingWebhookValid(w, err, "stripe") {
+		return
+	}
+
+	// Handle
+	event, updates, err := billing.ParseStripe(body)
+
+	billingWebhookApply(w, mongo.WebhookServiceStripe, event, body, updates, err)
+}
+
+func kofiWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	// Ko-fi posts a form with the JSON in one field
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var data = r.PostForm.Get("data")
+
+	// Validate & handle
+	event, updates, err := billing.ParseKofi(data, config.C.KofiVerificationToken)
+	if err == billing.ErrMissingSecret || err == billing.ErrInvalidSignature {
+		billingWebhookValid(w, err, "kofi")
+		return
+	}
+
+	billingWebhookApply(w, mongo.WebhookServiceKofi, event, []byte(data), updates, err)
+}
+
+func gitHubSponsorsWebhookPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	// Get body
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.ErrS(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	defer helpers.Close(r.Body)
+
+	// Validate
+	err = billing.VerifyGitHub(body, r.Header.Get("X-Hub-Signature-256"), config.C.GithubSponsorSecret)
+	if !billingWebhookValid(w, err, "github sponsors") {
+		return
+	}
+
+	// Handle
+	event, updates, err := billing.ParseGitHub(r.Header.Get("X-GitHub-Event"), body)
+
+	billingWebhookApply(w, mongo.WebhookServiceGithubSponsors, event, body, updates, err)
+}
+
+func billingWebhookValid(w http.ResponseWriter, err error, provider string) bool {
+
+	switch err {
+	case nil:
+		return true
+	case billing.ErrMissingSecret:
+		log.Err("Missing " + provider + " environment variable")
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	default:
+		http.Error(w, "Invalid signature", http.StatusBadRequest)
+	}
+
+	return false
+}
+
+// Failing to apply returns a 500 so the provider retries, applying an update twice is safe
+func billingWebhookApply(w http.ResponseWriter, service mongo.WebhookService, event string, body []byte, updates []billing.Update, err error) {
+
+	// Save webhook
+	saveErr := mongo.SaveWebhook(service, event, string(body))
+	if saveErr != nil {
+		log.ErrS(saveErr)
+	}
+
+	if err != nil {
+		log.Err(err.Error(), zap.String("body", string(body)))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, update := range updates {
+		err = billing.Apply(update)
+		if err != nil {
+			log.ErrS(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Return
+	_, err = w.Write([]byte(http.StatusText(http.StatusOK)))
+	if err != nil {
+		log.ErrS(err)
+	}
+}
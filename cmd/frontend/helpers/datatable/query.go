@@ -14,18 +14,24 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-// DataTablesQuery
-func NewDataTableQuery(r *http.Request, limit bool) (query DataTablesQuery) {
+// Used to replay a query outside of a request, e.g. saved searches
+func NewDataTableQueryFromString(encoded string) (query DataTablesQuery, err error) {
 
 	// Convert string into map
-	queryMap, err := qs.Unmarshal(r.URL.Query().Encode())
+	queryMap, err := qs.Unmarshal(encoded)
 	if err != nil {
-		log.ErrS(err)
-		return
+		return query, err
 	}
 
 	// Convert map into struct
 	err = helpers.MarshalUnmarshal(queryMap, &query)
+	return query, err
+}
+
+// DataTablesQuery
+func NewDataTableQuery(r *http.Request, limit bool) (query DataTablesQuery) {
+
+	query, err := NewDataTableQueryFromString(r.URL.Query().Encode())
 	if err != nil {
 		log.ErrS(err)
 		return
@@ -58,3 +58,61 @@ type VerifyTemplate struct {
 func (t VerifyTemplate) filename() string {
 	return "verify"
 }
+
+type SavedSearchTemplate struct {
+	IP     string // Empty, sent by a consumer
+	Domain string
+	Name   string
+	Path   string
+	Items  []SavedSearchTemplateItem
+}
+
+type SavedSearchTemplateItem struct {
+	Name string
+	Path string
+}
+
+func (t SavedSearchTemplate) filename() string {
+	return "saved_search"
+}
+
+type TeamInviteTemplate struct {
+	IP     string
+	Domain string
+	Team   string
+	From   string
+	Token  string
+}
+
+func (t TeamInviteTemplate) filename() string {
+	return "team_invite"
+}
+
+type UserDataExportTemplate struct {
+	IP      string
+	Domain  string
+	Token   string
+	Expires string
+}
+
+func (t UserDataExportTemplate) filename() string {
+	return "user_data_export"
+}
+
+type UserDeleteScheduledTemplate struct {
+	IP     string
+	Domain string
+	Due    string
+}
+
+func (t UserDeleteScheduledTemplate) filename() string {
+	return "user_delete_scheduled"
+}
+
+type UserDeletedTemplate struct {
+	IP string
+}
+
+func (t UserDeletedTemplate) filename() string {
+	return "user_deleted"
+}
@@ -0,0 +1,292 @@
+package feed
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatRSS  Format = "rss"
+	FormatAtom Format = "atom"
+	FormatJSON Format = "json"
+)
+
+const defaultMaxAge = time.Minute * 10
+
+func (f Format) IsValid() bool {
+	return f == FormatRSS || f == FormatAtom || f == FormatJSON
+}
+
+type Feed struct {
+	Title       string
+	Link        string // Absolute
+	FeedLink    string // Absolute, the feed itself
+	Description string
+	Updated     time.Time
+	MaxAge      time.Duration // How long clients can cache the feed for
+	Items       []Item
+}
+
+type Item struct {
+	ID          string
+	Title       string
+	Link        string // Absolute
+	Description string
+	Image       string // Absolute, only used by JSON Feed
+	Published   time.Time
+}
+
+// Write outputs the feed with caching headers, responding with a 304 if the client already has it
+func (f Feed) Write(w http.ResponseWriter, r *http.Request, format Format) error {
+
+	var b []byte
+	var err error
+	var contentType string
+
+	switch format {
+	case FormatJSON:
+		contentType = "application/feed+json; charset=utf-8"
+		b, err = json.Marshal(f.json())
+	case FormatAtom:
+		contentType = "application/atom+xml; charset=utf-8"
+		b, err = f.xml(f.atom())
+	default:
+		contentType = "application/rss+xml; charset=utf-8"
+		b, err = f.xml(f.rss())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sum := sha1.Sum(b)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(f.maxAge().Seconds())))
+	w.Header().Set("ETag", etag)
+
+	if !f.Updated.IsZero() {
+		w.Header().Set("Last-Modified", f.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+func (f Feed) maxAge() time.Duration {
+
+	if f.MaxAge == 0 {
+		return defaultMaxAge
+	}
+	return f.MaxAge
+}
+
+func (f Feed) updated() time.Time {
+
+	if f.Updated.IsZero() {
+		return time.Now()
+	}
+	return f.Updated
+}
+
+func (f Feed) xml(v interface{}) ([]byte, error) {
+
+	buf := bytes.NewBufferString(xml.Header)
+
+	err := xml.NewEncoder(buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+// RSS 2.0
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	TTL           int       `xml:"ttl,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+func (f Feed) rss() (out rss) {
+
+	out.Version = "2.0"
+	out.Channel = rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		TTL:         int(f.maxAge().Minutes()),
+	}
+
+	if !f.Updated.IsZero() {
+		out.Channel.LastBuildDate = f.Updated.Format(time.RFC1123Z)
+	}
+
+	for _, v := range f.Items {
+
+		item := rssItem{
+			Title:       v.Title,
+			Link:        v.Link,
+			Description: v.Description,
+			GUID:        rssGUID{Value: v.ID},
+		}
+
+		if !v.Published.IsZero() {
+			item.PubDate = v.Published.Format(time.RFC1123Z)
+		}
+
+		out.Channel.Items = append(out.Channel.Items, item)
+	}
+
+	return out
+}
+
+// Atom 1.0
+type atom struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID      string    `xml:"id"`
+	Title   string    `xml:"title"`
+	Link    atomLink  `xml:"link"`
+	Updated string    `xml:"updated"`
+	Summary *atomText `xml:"summary,omitempty"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (f Feed) atom() (out atom) {
+
+	out = atom{
+		ID:       f.Link,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  f.updated().UTC().Format(time.RFC3339),
+		Links:    []atomLink{{Href: f.Link}},
+	}
+
+	if f.FeedLink != "" {
+		out.Links = append(out.Links, atomLink{Href: f.FeedLink, Rel: "self"})
+	}
+
+	for _, v := range f.Items {
+
+		entry := atomEntry{
+			ID:      v.ID,
+			Title:   v.Title,
+			Link:    atomLink{Href: v.Link},
+			Updated: v.Published.UTC().Format(time.RFC3339),
+		}
+
+		if v.Description != "" {
+			entry.Summary = &atomText{Type: "html", Value: v.Description}
+		}
+
+		out.Entries = append(out.Entries, entry)
+	}
+
+	return out
+}
+
+// JSON Feed 1.1
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string `json:"id"`
+	URL           string `json:"url"`
+	Title         string `json:"title"`
+	ContentHTML   string `json:"content_html,omitempty"`
+	ContentText   string `json:"content_text,omitempty"`
+	Image         string `json:"image,omitempty"`
+	DatePublished string `json:"date_published,omitempty"`
+}
+
+func (f Feed) json() (out jsonFeed) {
+
+	out = jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedLink,
+		Description: f.Description,
+		Items:       []jsonFeedItem{},
+	}
+
+	for _, v := range f.Items {
+
+		item := jsonFeedItem{
+			ID:          v.ID,
+			URL:         v.Link,
+			Title:       v.Title,
+			ContentHTML: v.Description,
+			Image:       v.Image,
+		}
+
+		// One of the content fields is required
+		if item.ContentHTML == "" {
+			item.ContentText = v.Title
+		}
+
+		if !v.Published.IsZero() {
+			item.DatePublished = v.Published.UTC().Format(time.RFC3339)
+		}
+
+		out.Items = append(out.Items, item)
+	}
+
+	return out
+}
@@ -0,0 +1,391 @@
+package filters
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jleagle/steam-go/steamapi"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/i18n"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/olivere/elastic/v7"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Shared between the list pages and saved searches, so both return the same results
+
+// Games
+func AppsFilters(query datatable.DataTablesQuery, code steamapi.ProductCC, playerID int64) (filters []elastic.Query) {
+
+	types := query.GetSearchSliceInterface("types")
+	if len(types) > 0 {
+		filters = append(filters, elastic.NewTermsQuery("type", types...))
+	}
+
+	tags := query.GetSearchSliceInterface("tags")
+	if len(tags) > 0 {
+		filters = append(filters, elastic.NewTermsQuery("tags", tags...))
+	}
+
+	genres := query.GetSearchSliceInterface("genres")
+	if len(genres) > 0 {
+		filters = append(filters, elastic.NewTermsQuery("genres", genres...))
+	}
+
+	developers := query.GetSearchSliceInterface("developers")
+	if len(developers) > 0 {
+		filters = append(filters, elastic.NewTermsQuery("developers", developers...))
+	}
+
+	publishers := query.GetSearchSliceInterface("publishers")
+	if len(publishers) > 0 {
+		filters = append(filters, elastic.NewTermsQuery("publishers", publishers...))
+	}
+
+	categories := query.GetSearchSliceInterface("categories")
+	if len(categories) > 0 {
+		filters = append(filters, elastic.NewTermsQuery("categories", categories...))
+	}
+
+	platforms := query.GetSearchSliceInterface("platforms")
+	if len(platforms) > 0 {
+		filters = append(filters, elastic.NewTermsQuery("platforms", platforms...))
+	}
+
+	prices := query.GetSearchSlice("price")
+	if len(prices) == 2 {
+
+		low, err := strconv.Atoi(strings.Replace(prices[0], ".", "", 1))
+		if err == nil && low > 0 {
+			filters = append(filters, elastic.NewRangeQuery("prices."+string(code)+".final").From(low))
+		}
+
+		high, err := strconv.Atoi(strings.Replace(prices[1], ".", "", 1))
+		if err == nil && high < 100_00 {
+			filters = append(filters, elastic.NewRangeQuery("prices."+string(code)+".final").To(high))
+		}
+	}
+
+	switch query.GetSearchString("price_history") {
+	case "lowest":
+		filters = append(filters, elastic.NewTermQuery("at_lowest", string(code)))
+	case "sales":
+		filters = append(filters, elastic.NewRangeQuery("price_stats."+string(code)+".sales").From(1))
+	}
+
+	scores := query.GetSearchSlice("score")
+	if len(scores) == 2 {
+
+		low, err := strconv.Atoi(scores[0])
+		if err == nil && low > 0 {
+			filters = append(filters, elastic.NewRangeQuery("score").From(low))
+		}
+
+		high, err := strconv.Atoi(scores[1])
+		if err == nil && high < 100 {
+			filters = append(filters, elastic.NewRangeQuery("score").To(high))
+		}
+	}
+
+	// Filter by owned games
+	userFilter := query.GetSearchString("user")
+	if (userFilter == "owned" || userFilter == "played") && playerID > 0 {
+
+		filter := bson.D{}
+
+		if userFilter == "played" {
+			filter = append(filter, bson.E{Key: "app_time", Value: bson.M{"$gt": 0}})
+		}
+
+		apps, err := mongo.GetPlayerAppsByPlayer(playerID, 0, 0, nil, bson.M{"app_id": 1}, filter)
+		if err != nil {
+			log.ErrS(err)
+		} else {
+			var appIDs []interface{}
+			for _, app := range apps {
+				appIDs = append(appIDs, app.AppID)
+			}
+
+			filters = append(filters, elastic.NewTermsQuery("id", appIDs...))
+		}
+	}
+
+	return filters
+}
+
+func AppsOrder(query datatable.DataTablesQuery, code steamapi.ProductCC) []elastic.Sorter {
+
+	return query.GetOrderElastic(map[string]string{
+		"2": "players",
+		"3": "followers",
+		"4": "score",
+		"5": "prices." + string(code) + ".final",
+		"6": "price_stats." + string(code) + ".lowest_ever",
+	})
+}
+
+// Sales
+func SalesBaseFilter() bson.D {
+	return bson.D{
+		{Key: "offer_end", Value: bson.M{"$gt": time.Now()}},
+	}
+}
+
+func SalesFilter(query datatable.DataTablesQuery, code steamapi.ProductCC) (filter bson.D) {
+
+	filter = SalesBaseFilter()
+
+	search := helpers.RegexNonAlphaNumericSpace.ReplaceAllString(query.GetSearchString("search"), "")
+	if search != "" {
+
+		quoted := regexp.QuoteMeta(search)
+
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.M{"app_name": bson.M{"$regex": quoted, "$options": "i"}},
+			bson.M{"offer_name": bson.M{"$regex": quoted, "$options": "i"}},
+		}})
+	}
+
+	// Index
+	index := query.GetSearchString("index")
+	if index != "" {
+		orderI, err := strconv.Atoi(strings.TrimSuffix(index, ".00"))
+		if err == nil {
+			filter = append(filter, bson.E{Key: "sub_order", Value: bson.M{"$lte": orderI - 1}})
+		}
+	}
+
+	// Score
+	scores := query.GetSearchSlice("score")
+	if len(scores) == 2 {
+
+		low, err := strconv.Atoi(strings.TrimSuffix(scores[0], ".00"))
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		high, err := strconv.Atoi(strings.TrimSuffix(scores[1], ".00"))
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		if low > 0 {
+			filter = append(filter, bson.E{Key: "app_rating", Value: bson.M{"$gte": low}})
+		}
+		if high < 100 {
+			filter = append(filter, bson.E{Key: "app_rating", Value: bson.M{"$lte": high}})
+		}
+	}
+
+	// Price
+	prices := query.GetSearchSlice("price")
+	if len(prices) == 2 {
+
+		low, err := strconv.Atoi(strings.TrimSuffix(prices[0], ".00"))
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		high, err := strconv.Atoi(strings.TrimSuffix(prices[1], ".00"))
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		if low > 0 {
+			filter = append(filter, bson.E{Key: "app_prices." + string(code), Value: bson.M{"$gte": low * 100}})
+		}
+		if high < 100 {
+			filter = append(filter, bson.E{Key: "app_prices." + string(code), Value: bson.M{"$lte": high * 100}})
+		}
+	}
+
+	// Discount
+	discounts := query.GetSearchSlice("discount")
+	if len(discounts) == 2 {
+
+		low, err := strconv.Atoi(strings.TrimSuffix(discounts[0], ".00"))
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		high, err := strconv.Atoi(strings.TrimSuffix(discounts[1], ".00"))
+		if err != nil {
+			log.ErrS(err)
+		}
+
+		if low > 0 {
+			filter = append(filter, bson.E{Key: "offer_percent", Value: bson.M{"$lte": -low}})
+		}
+		if high < 100 {
+			filter = append(filter, bson.E{Key: "offer_percent", Value: bson.M{"$gte": -high}})
+		}
+	}
+
+	// App type
+	appTypes := query.GetSearchSlice("app-type")
+	if len(appTypes) > 0 {
+
+		var or bson.A
+		for _, v := range appTypes {
+			or = append(or, bson.M{"app_type": v})
+		}
+		filter = append(filter, bson.E{Key: "$or", Value: or})
+	}
+
+	// Sale type
+	saleTypes := query.GetSearchSlice("sale-type")
+	if len(saleTypes) > 0 {
+
+		var or bson.A
+		for _, v := range saleTypes {
+			or = append(or, bson.M{"offer_type": v})
+		}
+		filter = append(filter, bson.E{Key: "$or", Value: or})
+	}
+
+	// Tag in
+	tagsIn := query.GetSearchSlice("tags-in")
+	if len(tagsIn) > 0 {
+
+		var or bson.A
+		for _, tag := range tagsIn {
+			i, err := strconv.Atoi(tag)
+			if err == nil {
+				or = append(or, bson.M{"app_tags": i})
+			}
+		}
+		filter = append(filter, bson.E{Key: "$or", Value: or})
+	}
+
+	// Tag out
+	tagsOut := query.GetSearchSlice("tags-out")
+	if len(tagsOut) > 0 {
+
+		var or bson.A
+		for _, tag := range tagsOut {
+			i, err := strconv.Atoi(tag)
+			if err == nil {
+				or = append(or, bson.M{"app_tags": bson.M{"$ne": i}})
+			}
+		}
+		filter = append(filter, bson.E{Key: "$or", Value: or})
+	}
+
+	// Categories
+	categories := query.GetSearchSlice("categories")
+	if len(categories) > 0 {
+
+		var in bson.A
+		for _, tag := range categories {
+			i, err := strconv.Atoi(tag)
+			if err == nil {
+				in = append(in, i)
+			}
+		}
+		filter = append(filter, bson.E{Key: "app_categories", Value: bson.M{"$in": in}})
+	}
+
+	// Platforms
+	platforms := query.GetSearchSlice("platforms")
+	if len(platforms) > 0 {
+
+		var in bson.A
+		for _, tag := range platforms {
+			in = append(in, tag)
+		}
+		filter = append(filter, bson.E{Key: "app_platforms", Value: bson.M{"$in": in}})
+	}
+
+	return filter
+}
+
+func SalesOrder(query datatable.DataTablesQuery, code steamapi.ProductCC) (order bson.D) {
+
+	var columns = map[string]string{
+		"0": "offer_name",
+		"1": "app_prices." + string(code),
+		"2": "offer_percent",
+		"3": "app_rating",
+		"4": "offer_end",
+		"5": "app_date",
+	}
+
+	order = query.GetOrderMongo(columns)
+	order = append(order, bson.E{Key: "app_rating", Value: -1})
+	order = append(order, bson.E{Key: "app_name", Value: 1})
+	order = append(order, bson.E{Key: "sub_order", Value: 1})
+
+	return order
+}
+
+// Players
+func PlayersFilters(query datatable.DataTablesQuery) (filters []elastic.Query) {
+
+	country := query.GetSearchString("country")
+	state := query.GetSearchString("state")
+
+	var isContinent bool
+
+	if country != "" {
+
+		for _, v := range i18n.Continents {
+			if "c-"+v.Key == country {
+
+				isContinent = true
+				filters = append(filters, elastic.NewTermQuery("continent", v.Key))
+				break
+			}
+		}
+
+		if !isContinent {
+
+			if _, ok := i18n.States[country]; ok || country == "_" {
+
+				if country == "_" {
+					country = ""
+				}
+
+				filters = append(filters, elastic.NewTermQuery("country_code", country))
+
+				if _, ok := i18n.States[country][state]; ok || state == "_" {
+
+					if state == "_" {
+						state = ""
+					}
+
+					filters = append(filters, elastic.NewTermQuery("state_code", state))
+				}
+			}
+		}
+	}
+
+	return filters
+}
+
+func PlayersOrder(query datatable.DataTablesQuery) []elastic.Sorter {
+
+	return query.GetOrderElastic(map[string]string{
+		"3":  "level",
+		"4":  "badges",
+		"12": "badges_foil",
+
+		"5": "games",
+		"6": "play_time",
+
+		"7": "game_bans",
+		"8": "vac_bans",
+		"9": "last_ban",
+
+		"10": "achievements",
+		"11": "achievements_100",
+
+		"13": "awards_given_count",
+		"14": "awards_given_points",
+		"15": "awards_received_count",
+		"16": "awards_received_points",
+	})
+}
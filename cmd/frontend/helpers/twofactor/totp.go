@@ -0,0 +1,93 @@
+package twofactor
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/base64"
+	"html/template"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const totpPeriod = 30
+
+var totpOptions = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1, // The only one most apps support
+}
+
+func NewTOTPKey(email string) (*otp.Key, error) {
+
+	return totp.Generate(totp.GenerateOpts{
+		Issuer:      "Global Steam",
+		AccountName: email,
+		Period:      totpPeriod,
+		Digits:      totpOptions.Digits,
+		Algorithm:   totpOptions.Algorithm,
+	})
+}
+
+// Returns the time step the code is for, allowing one step of clock drift either way
+func ValidateTOTP(secret string, code string) (step int64, ok bool, err error) {
+
+	code = strings.Replace(code, " ", "", -1)
+	if len(code) != totpOptions.Digits.Length() {
+		return 0, false, nil
+	}
+
+	now := time.Now().Unix() / totpPeriod
+
+	for _, step := range []int64{now - 1, now, now + 1} {
+
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totpOptions)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// Checks a code against the user's saved secret and uses up its time step
+func CheckTOTP(userID int, code string) (ok bool, err error) {
+
+	row, err := mysql.GetUserTOTP(userID)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok, err := ValidateTOTP(row.Secret, code)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return mysql.UseUserTOTPStep(userID, step)
+}
+
+// A data URI of the QR code for authenticator apps to scan
+func QRCode(key *otp.Key) (template.URL, error) {
+
+	img, err := key.Image(200, 200)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = png.Encode(&buf, img)
+	if err != nil {
+		return "", err
+	}
+
+	//goland:noinspection GoRedundantConversion
+	return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
@@ -0,0 +1,33 @@
+package twofactor
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+type Methods struct {
+	TOTP          bool
+	SecurityKeys  int
+	RecoveryCodes int
+}
+
+func (m Methods) IsEnabled() bool {
+	return m.TOTP || m.SecurityKeys > 0
+}
+
+func GetMethods(userID int) (methods Methods, err error) {
+
+	_, err = mysql.GetUserTOTP(userID)
+	if err == nil {
+		methods.TOTP = true
+	} else if err != mysql.ErrRecordNotFound {
+		return methods, err
+	}
+
+	methods.SecurityKeys, err = mysql.CountUserSecurityKeys(userID)
+	if err != nil {
+		return methods, err
+	}
+
+	methods.RecoveryCodes, err = mysql.CountUserRecoveryCodes(userID)
+	return methods, err
+}
@@ -0,0 +1,135 @@
+package twofactor
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/session"
+)
+
+const webAuthnSession = "webauthn-session"
+
+var (
+	webAuthnClient *webauthn.WebAuthn
+	webAuthnLock   sync.Mutex
+
+	ErrNoWebAuthnSession = errors.New("no webauthn session")
+)
+
+func WebAuthn() (*webauthn.WebAuthn, error) {
+
+	webAuthnLock.Lock()
+	defer webAuthnLock.Unlock()
+
+	if webAuthnClient == nil {
+
+		u, err := url.Parse(config.C.GlobalSteamDomain)
+		if err != nil {
+			return nil, err
+		}
+
+		webAuthnClient, err = webauthn.New(&webauthn.Config{
+			RPDisplayName: "Global Steam",
+			RPID:          u.Hostname(),
+			RPOrigin:      strings.TrimSuffix(config.C.GlobalSteamDomain, "/"),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return webAuthnClient, nil
+}
+
+// Implements webauthn.User
+type User struct {
+	user mysql.User
+	keys []mysql.UserSecurityKey
+}
+
+func NewUser(user mysql.User) (u User, err error) {
+
+	u.user = user
+	u.keys, err = mysql.GetUserSecurityKeys(user.ID)
+	return u, err
+}
+
+func (u User) WebAuthnID() []byte {
+	return []byte(strconv.Itoa(u.user.ID))
+}
+
+func (u User) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u User) WebAuthnDisplayName() string {
+	return u.user.Email
+}
+
+func (u User) WebAuthnIcon() string {
+	return ""
+}
+
+func (u User) WebAuthnCredentials() (credentials []webauthn.Credential) {
+
+	for _, v := range u.keys {
+		credentials = append(credentials, webauthn.Credential{
+			ID:        v.CredentialID,
+			PublicKey: v.PublicKey,
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    v.AAGUID,
+				SignCount: v.SignCount,
+			},
+		})
+	}
+
+	return credentials
+}
+
+// Stops the same key being registered twice
+func (u User) Exclusions() (descriptors []protocol.CredentialDescriptor) {
+
+	for _, v := range u.keys {
+		descriptors = append(descriptors, protocol.CredentialDescriptor{
+			Type:         protocol.PublicKeyCredentialType,
+			CredentialID: v.CredentialID,
+		})
+	}
+
+	return descriptors
+}
+
+// The challenge is kept in the (encrypted) cookie between the begin and finish requests
+func SaveSession(r *http.Request, data *webauthn.SessionData) error {
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	session.Set(r, webAuthnSession, string(b))
+	return nil
+}
+
+// Can only be read once, so a challenge can't be replayed
+func GetSession(r *http.Request) (data webauthn.SessionData, err error) {
+
+	val := session.Get(r, webAuthnSession)
+	if val == "" {
+		return data, ErrNoWebAuthnSession
+	}
+
+	session.DeleteMany(r, []string{webAuthnSession})
+
+	err = json.Unmarshal([]byte(val), &data)
+	return data, err
+}
@@ -8,7 +8,6 @@ import (
 	_ "net/http/pprof"
 	"time"
 
-	"github.com/Jleagle/rate-limit-go"
 	"github.com/gamedb/gamedb/cmd/frontend/handlers"
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/email"
 	handlers2 "github.com/gamedb/gamedb/cmd/frontend/helpers/handlers"
@@ -17,10 +16,13 @@ import (
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/metrics"
 	"github.com/gamedb/gamedb/pkg/middleware"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/ratelimit"
 	"github.com/gamedb/gamedb/pkg/session"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/gobuffalo/packr/v2"
@@ -44,6 +46,9 @@ func main() {
 		return
 	}
 
+	closeTracing := tracing.Init(log.LogNameFrontend)
+	closeMetrics := metrics.Serve()
+
 	if config.C.MailjetPublic == "" || config.C.MailjetPrivate == "" {
 		log.ErrS(errors.New("missing mailjet environment variables"))
 		return
@@ -81,16 +86,20 @@ func main() {
 		}
 	}
 
-	var limiter = rate.New(time.Second, rate.WithBurst(10))
+	var limiter = ratelimit.New(ratelimit.Bucket{Name: "frontend", Every: time.Second, Burst: 10}, ratelimit.NewSharedStore())
+	var limiterGroups = middleware.NewRouteGroups("assets", "games", "players")
 
 	// Routes
 	r := chi.NewRouter()
+	r.Use(tracing.Middleware)
+	r.Use(metrics.Middleware)
 	r.Use(chiMiddleware.RedirectSlashes)
 	r.Use(middleware.MiddlewareDownMessage)
 	r.Use(middleware.MiddlewareCors())
 	r.Use(middleware.RealIP)
+	r.Use(middleware.MiddlewareUserSession(handlers.Error503Handler))
 	r.Use(chiMiddleware.Compress(flate.DefaultCompression))
-	r.Use(middleware.RateLimiterWait(limiter))
+	r.Use(middleware.RateLimiterWait(limiter, limiterGroups))
 
 	// Pages
 	r.Mount("/{type:(categories|developers|genres|publishers|tags)}", handlers.StatsListRouter())
@@ -123,9 +132,12 @@ func main() {
 	r.Mount("/price-changes", handlers.PriceChangeRouter())
 	r.Mount("/product-keys", handlers.ProductKeysRouter())
 	r.Mount("/queues", handlers.QueuesRouter())
+	r.Mount("/saved-searches", handlers.SavedSearchesRouter())
 	r.Mount("/settings", handlers.SettingsRouter())
 	r.Mount("/signup", handlers.SignupRouter())
 	r.Mount("/stats", handlers.StatsRouter())
+	r.Mount("/status", handlers.StatusRouter())
+	r.Mount("/teams", handlers.TeamsRouter())
 	r.Mount("/terms", handlers.TermsRouter())
 	r.Mount("/webhooks", handlers.WebhooksRouter())
 	r.Mount("/websocket", handlers.WebsocketsRouter())
@@ -214,6 +226,8 @@ func main() {
 	}()
 
 	helpers.KeepAlive(
+		closeTracing,
+		closeMetrics,
 		mysql.Close,
 		mongo.Close,
 		memcache.Close,
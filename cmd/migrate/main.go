@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/migrations"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+const usage = `Usage: migrate [flags] <command>
+
+Commands:
+  status  List migrations and when they were applied
+  up      Apply all pending migrations
+  down    Roll back the most recent migrations
+
+Flags:
+`
+
+func main() {
+	os.Exit(run())
+}
+
+// Returns the exit code, so the deferred closes run first
+func run() int {
+
+	err := config.Init(helpers.GetIP())
+	log.InitZap(log.LogNameMigrate)
+	defer log.Flush()
+	if err != nil {
+		log.ErrS(err)
+		return 1
+	}
+
+	defer mongo.Close()
+	defer mysql.Close()
+
+	dryRun := flag.Bool("dry-run", false, "Show what would run without changing anything")
+	steps := flag.Int("steps", 1, "Number of migrations to roll back with down")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var ran []migrations.Migration
+
+	switch flag.Arg(0) {
+	case "status":
+
+		statuses, err := migrations.GetStatuses()
+		if err != nil {
+			log.ErrS(err)
+			return 1
+		}
+
+		for _, v := range statuses {
+
+			applied := "pending"
+			if v.IsApplied() {
+				applied = v.AppliedAt.Format(helpers.DateSQL)
+			} else if v.IsRunning() {
+				applied = "running"
+			}
+
+			fmt.Printf("%-40s %-6s %-20s %s\n", v.Migration.ID, v.Migration.Database, applied, v.Migration.Description)
+		}
+		return 0
+
+	case "up":
+		ran, err = migrations.Up(*dryRun)
+	case "down":
+		ran, err = migrations.Down(*steps, *dryRun)
+	default:
+		flag.Usage()
+		return 2
+	}
+
+	for _, v := range ran {
+		fmt.Println(v.ID)
+	}
+
+	if err != nil {
+		log.ErrS(err)
+		return 1
+	}
+
+	if len(ran) == 0 {
+		fmt.Println("Nothing to do")
+	}
+
+	return 0
+}
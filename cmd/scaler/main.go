@@ -11,6 +11,7 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/gamedb/gamedb/cmd/scaler/hosts"
 	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/health"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
@@ -36,6 +37,8 @@ func main() {
 	r.Get("/cycle", cycleHandler)
 	r.Get("/delete/{id}", deleteHandler)
 	r.Get("/health-check", healthCheckHandler)
+	r.Get("/health-check/live", health.LivenessHandler)
+	r.Get("/health-check/ready", health.ReadinessHandler(health.ReadyChecks...))
 
 	s := &http.Server{
 		Addr:              "0.0.0.0:4000",
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"strconv"
 	"sync"
@@ -19,6 +20,7 @@ import (
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql"
 	"github.com/gamedb/gamedb/pkg/steam"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	"go.uber.org/zap"
 )
 
@@ -46,6 +48,8 @@ func main() {
 		return
 	}
 
+	closeTracing := tracing.Init(log.LogNameSteam)
+
 	if config.C.SteamUsername == "" || config.C.SteamPassword == "" {
 		log.Err("Missing environment variables")
 	}
@@ -146,6 +150,7 @@ func main() {
 	}()
 
 	helpers.KeepAlive(
+		closeTracing,
 		mysql.Close,
 		mongo.Close,
 		memcache.Close,
@@ -225,7 +230,7 @@ func (ph packetHandler) handleProductInfo(packet *protocol.Packet) {
 				m = kv.ToMapOuter()
 			}
 
-			err = consumers.ProduceApp(consumers.AppMessage{ID: id, ChangeNumber: int(app.GetChangeNumber()), VDF: m})
+			err = consumers.ProduceApp(context.Background(), consumers.AppMessage{ID: id, ChangeNumber: int(app.GetChangeNumber()), VDF: m})
 			if err != nil {
 				log.Err("Produce app", zap.Error(err), zap.Int("app", id))
 			}
@@ -237,7 +242,7 @@ func (ph packetHandler) handleProductInfo(packet *protocol.Packet) {
 		for _, app := range unknownApps {
 
 			var id = int(app)
-			err := consumers.ProduceApp(consumers.AppMessage{ID: id})
+			err := consumers.ProduceApp(context.Background(), consumers.AppMessage{ID: id})
 			if err != nil {
 				log.Err("Produce app", zap.Error(err), zap.Int("app", id))
 			}
@@ -258,7 +263,7 @@ func (ph packetHandler) handleProductInfo(packet *protocol.Packet) {
 				m = kv.ToMapOuter()
 			}
 
-			err = consumers.ProducePackage(consumers.PackageMessage{ID: int(pack.GetPackageid()), ChangeNumber: int(pack.GetChangeNumber()), VDF: m})
+			err = consumers.ProducePackage(context.Background(), consumers.PackageMessage{ID: int(pack.GetPackageid()), ChangeNumber: int(pack.GetChangeNumber()), VDF: m})
 			if err != nil {
 				err = helpers.IgnoreErrors(err, mongo.ErrInvalidPackageID)
 				if err != nil {
@@ -273,7 +278,7 @@ func (ph packetHandler) handleProductInfo(packet *protocol.Packet) {
 		for _, pack := range unknownPackages {
 
 			var id = int(pack)
-			err := consumers.ProducePackage(consumers.PackageMessage{ID: id})
+			err := consumers.ProducePackage(context.Background(), consumers.PackageMessage{ID: id})
 			if err != nil {
 				log.Err("Produce app", zap.Error(err), zap.Int("sub", id))
 			}
@@ -347,7 +352,7 @@ func (ph packetHandler) handleChangesSince(packet *protocol.Packet) {
 	}))
 
 	// Save change
-	err := consumers.ProduceChanges(consumers.ChangesMessage{
+	err := consumers.ProduceChanges(context.Background(), consumers.ChangesMessage{
 		AppIDs:     appMap,
 		PackageIDs: packageMap,
 	})
@@ -370,7 +375,7 @@ func (ph packetHandler) handleProfileInfo(packet *protocol.Packet) {
 	packet.ReadProtoMsg(&body)
 
 	var id = int64(body.GetSteamidFriend())
-	err := consumers.ProducePlayer(consumers.PlayerMessage{ID: id}, "steam")
+	err := consumers.ProducePlayer(context.Background(), consumers.PlayerMessage{ID: id}, "steam")
 	if err != nil {
 		log.ErrS(err, id)
 	}
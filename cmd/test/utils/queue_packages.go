@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bufio"
+	"context"
 	"os"
 	"strconv"
 	"sync"
@@ -77,14 +78,14 @@ func (queuePackages) run() {
 
 		pack := mongo.Package{}
 
-		err = mongo.FindOne(mongo.CollectionPackages, bson.D{{Key: "_id", Value: packageID}}, nil, bson.M{"_id": 1}, &pack)
+		err = mongo.FindOne(context.Background(), mongo.CollectionPackages, bson.D{{Key: "_id", Value: packageID}}, nil, bson.M{"_id": 1}, &pack)
 		if err != nil && err != mongo.ErrNoDocuments {
 			log.ErrS(err)
 			continue
 		}
 
 		if err == mongo.ErrNoDocuments {
-			err = consumers.ProduceSteam(consumers.SteamMessage{PackageIDs: []int{packageID}})
+			err = consumers.ProduceSteam(context.Background(), consumers.SteamMessage{PackageIDs: []int{packageID}})
 			if err != nil {
 				log.ErrS(err)
 			} else {
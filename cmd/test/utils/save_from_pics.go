@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -21,7 +23,7 @@ func (saveFromPics) run() {
 
 		log.InfoS(offset)
 
-		apps, err := mongo.GetApps(offset, limit, bson.D{{Key: "_id", Value: 1}}, bson.D{{Key: "icon", Value: ""}}, bson.M{"common": 1})
+		apps, err := mongo.GetApps(context.Background(), offset, limit, bson.D{{Key: "_id", Value: 1}}, bson.D{{Key: "icon", Value: ""}}, bson.M{"common": 1})
 		if err != nil {
 			log.Err(err.Error())
 			return
@@ -32,7 +34,7 @@ func (saveFromPics) run() {
 			icon := app.Common.GetValue("icon")
 			if icon != "" {
 
-				_, err = mongo.UpdateOne(mongo.CollectionApps, bson.D{{Key: "_id", Value: app.ID}}, bson.D{{Key: "icon", Value: icon}})
+				_, err = mongo.UpdateOne(context.Background(), mongo.CollectionApps, bson.D{{Key: "_id", Value: app.ID}}, bson.D{{Key: "icon", Value: icon}})
 				if err != nil {
 					log.Err(err.Error())
 				}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"sort"
 
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -39,7 +40,7 @@ func syncSubAppInner(pack *mongo.Package) {
 			"icon":                1,
 		}
 
-		apps, err := mongo.GetAppsByID(pack.Apps, projection)
+		apps, err := mongo.GetAppsByID(context.Background(), pack.Apps, projection)
 		if err != nil {
 			log.ErrS(err)
 			return
@@ -72,7 +73,7 @@ func syncSubAppInner(pack *mongo.Package) {
 		}
 
 		if len(update) > 0 {
-			_, err = mongo.UpdateOne(mongo.CollectionPackages, bson.D{{Key: "_id", Value: pack.ID}}, update)
+			_, err = mongo.UpdateOne(context.Background(), mongo.CollectionPackages, bson.D{{Key: "_id", Value: pack.ID}}, update)
 			if err != nil {
 				log.ErrS(err)
 			}
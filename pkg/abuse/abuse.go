@@ -0,0 +1,469 @@
+package abuse
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/ratelimit"
+)
+
+// Layered limits for endpoints that let anyone queue Steam lookups.
+// Every layer has to pass, and clients that keep getting blocked have to pass a captcha.
+
+type Action string
+
+const (
+	ActionPlayerAdd    Action = "player-add"
+	ActionPlayerUpdate Action = "player-update"
+)
+
+type Reason string
+
+const (
+	ReasonNone    Reason = ""
+	ReasonIP      Reason = "ip"
+	ReasonUser    Reason = "user"
+	ReasonSession Reason = "session"
+	ReasonGlobal  Reason = "global"
+	ReasonCaptcha Reason = "captcha"
+)
+
+func (r Reason) Message() string {
+
+	switch r {
+	case ReasonGlobal:
+		return "The player queue is busy, please try again in a few minutes"
+	case ReasonCaptcha:
+		return "Please complete the captcha to continue"
+	default:
+		return "You are doing that too often, please slow down"
+	}
+}
+
+const (
+	StrikesForCaptcha = 3                // Blocks before a captcha is needed
+	strikeLifetime    = time.Hour        // How long blocks count towards a captcha, from the first one
+	captchaLifetime   = time.Hour        // How long a passed captcha lets a client skip the strike checks
+	requestersWindow  = time.Hour        // How long requests count towards the top requesters
+	requestersMax     = 1000             // Only the busiest are kept, the rest would never be shown
+	flushEvery        = time.Second * 10 // How often request counts are added to the shared totals
+	depthCache        = time.Second * 30
+
+	storeRequesters = "abuse-requesters"
+)
+
+var (
+	ipBucket      = ratelimit.Bucket{Name: "player-lookups-ip", Every: time.Second * 10, Burst: 10}
+	sessionBucket = ratelimit.Bucket{Name: "player-lookups-session", Every: time.Second * 20, Burst: 5}
+	userBucket    = ratelimit.Bucket{Name: "player-lookups-user", Every: time.Second * 5, Burst: 20}
+	globalBucket  = ratelimit.Bucket{Name: "player-lookups-global", Every: time.Millisecond * 100, Burst: 20} // With an empty queue
+)
+
+type Request struct {
+	Action    Action
+	IP        string
+	UserID    int    // Logged in users are limited per user instead of per session
+	SessionID string // Empty if the client didn't send back its session cookie
+	UserAgent string
+}
+
+func (r Request) keys() (keys []string) {
+
+	keys = append(keys, "ip:"+r.IP)
+	if client := r.clientKey(); client != "" {
+		keys = append(keys, client)
+	}
+	return keys
+}
+
+// The user or session, empty if there is neither
+func (r Request) clientKey() string {
+
+	if r.UserID > 0 {
+		return "user:" + strconv.Itoa(r.UserID)
+	} else if r.SessionID != "" {
+		return "session:" + r.SessionID
+	}
+	return ""
+}
+
+type Requester struct {
+	Key      string    `json:"k"`
+	Requests int       `json:"r"`
+	Blocked  int       `json:"b"`
+	Last     time.Time `json:"l"`
+}
+
+// The top requesters, as kept in the store
+type requesters struct {
+	Since      time.Time             `json:"s"`
+	Requesters map[string]*Requester `json:"r"`
+}
+
+type Guard struct {
+	ip      *ratelimit.Limiter
+	session *ratelimit.Limiter
+	user    *ratelimit.Limiter
+	global  map[float64]*ratelimit.Limiter // One per budget, all sharing a bucket
+	store   ratelimit.Store
+
+	// Requests since the last flush to the store
+	pending   map[string]*Requester
+	flushedAt time.Time
+	flushing  bool
+
+	depthFunc    func() (int, error)
+	depth        int
+	depthAt      time.Time
+	depthLoading bool
+
+	lock sync.Mutex // Never held while using the store
+}
+
+// New takes a func returning the length of the queue the requests feed into
+func New(store ratelimit.Store, depth func() (int, error)) *Guard {
+
+	g := &Guard{
+		ip:        ratelimit.New(ipBucket, store),
+		session:   ratelimit.New(sessionBucket, store),
+		user:      ratelimit.New(userBucket, store),
+		global:    map[float64]*ratelimit.Limiter{},
+		store:     store,
+		pending:   map[string]*Requester{},
+		flushedAt: time.Now(),
+		depthFunc: depth,
+	}
+
+	// Tokens come slower as the queue grows
+	for _, budget := range budgets {
+		bucket := globalBucket
+		bucket.Every = time.Duration(float64(bucket.Every) / budget)
+		g.global[budget] = ratelimit.New(bucket, store)
+	}
+
+	return g
+}
+
+var budgets = []float64{1, 0.5, 0.1}
+
+// Share of the global budget left when the queue is backed up
+func BudgetForDepth(depth int) float64 {
+
+	switch {
+	case depth < 1000:
+		return budgets[0]
+	case depth < 10000:
+		return budgets[1]
+	case depth < 50000:
+		return budgets[2]
+	default:
+		return 0
+	}
+}
+
+// Check returns ReasonNone if the request can go ahead
+func (g *Guard) Check(req Request) (reason Reason) {
+
+	now := time.Now()
+	keys := req.keys()
+
+	defer func() {
+		g.countRequest(keys, reason != ReasonNone, now)
+	}()
+
+	// Captcha escalation
+	if !g.PassedCaptcha(req) {
+
+		// Real browsers send both
+		if req.UserAgent == "" || req.SessionID == "" {
+			g.strike(keys[0])
+		}
+
+		for _, key := range keys {
+			if g.strikeCount(key) >= StrikesForCaptcha {
+				return ReasonCaptcha
+			}
+		}
+	}
+
+	// Per client
+	if !g.ip.Take("", req.IP).Allowed {
+		g.strike(keys[0])
+		return ReasonIP
+	}
+
+	if req.UserID > 0 {
+		if !g.user.Take("", strconv.Itoa(req.UserID)).Allowed {
+			g.strike(keys[1])
+			return ReasonUser
+		}
+	} else if req.SessionID != "" {
+		if !g.session.Take("", req.SessionID).Allowed {
+			g.strike(keys[1])
+			return ReasonSession
+		}
+	}
+
+	// Global
+	budget := BudgetForDepth(g.queueDepth(now))
+	if budget == 0 {
+		return ReasonGlobal
+	}
+
+	if !g.global[budget].Take("", "all").Allowed {
+		return ReasonGlobal
+	}
+
+	return ReasonNone
+}
+
+// Budget returns the last known queue depth and the share of the global budget it leaves
+func (g *Guard) Budget() (depth int, budget float64) {
+
+	depth = g.queueDepth(time.Now())
+	return depth, BudgetForDepth(depth)
+}
+
+// Top returns the clients with the most requests in the last hour, across every instance
+func (g *Guard) Top(limit int) (top []Requester) {
+
+	g.flush()
+
+	val, err := g.store.Get(storeRequesters)
+	if err != nil {
+		log.ErrS(err)
+		return nil
+	}
+
+	for _, v := range decodeRequesters(val, time.Now()).Requesters {
+		top = append(top, *v)
+	}
+
+	sortRequesters(top)
+
+	if limit > 0 && len(top) > limit {
+		top = top[:limit]
+	}
+
+	return top
+}
+
+func sortRequesters(requesters []Requester) {
+
+	sort.Slice(requesters, func(i, j int) bool {
+		if requesters[i].Requests == requesters[j].Requests {
+			return requesters[i].Key < requesters[j].Key
+		}
+		return requesters[i].Requests > requesters[j].Requests
+	})
+}
+
+// Counts in memory, they get added to the store every few seconds
+func (g *Guard) countRequest(keys []string, blocked bool, now time.Time) {
+
+	g.lock.Lock()
+
+	for _, key := range keys {
+
+		requester, ok := g.pending[key]
+		if !ok {
+			requester = &Requester{Key: key}
+			g.pending[key] = requester
+		}
+
+		requester.Requests++
+		requester.Last = now
+		if blocked {
+			requester.Blocked++
+		}
+	}
+
+	flush := !g.flushing && now.Sub(g.flushedAt) > flushEvery
+	if flush {
+		g.flushing = true
+	}
+
+	g.lock.Unlock()
+
+	if flush {
+		go g.flush()
+	}
+}
+
+func (g *Guard) flush() {
+
+	g.lock.Lock()
+	pending := g.pending
+	g.pending = map[string]*Requester{}
+	g.lock.Unlock()
+
+	defer func() {
+		g.lock.Lock()
+		g.flushing = false
+		g.flushedAt = time.Now()
+		g.lock.Unlock()
+	}()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	err := g.store.Update(storeRequesters, requestersWindow, func(val string) (string, error) {
+
+		shared := decodeRequesters(val, time.Now())
+
+		for key, v := range pending {
+			if existing, ok := shared.Requesters[key]; ok {
+				existing.Requests += v.Requests
+				existing.Blocked += v.Blocked
+				if v.Last.After(existing.Last) {
+					existing.Last = v.Last
+				}
+			} else {
+				v := *v
+				shared.Requesters[key] = &v
+			}
+		}
+
+		// Keep the busiest
+		if len(shared.Requesters) > requestersMax {
+
+			var all []Requester
+			for _, v := range shared.Requesters {
+				all = append(all, *v)
+			}
+
+			sortRequesters(all)
+
+			for _, v := range all[requestersMax:] {
+				delete(shared.Requesters, v.Key)
+			}
+		}
+
+		b, err := json.Marshal(shared)
+		return string(b), err
+	})
+	if err != nil {
+		log.ErrS(err)
+	}
+}
+
+// Starts again once the window has passed, or if the stored value can't be read
+func decodeRequesters(val string, now time.Time) (r requesters) {
+
+	if val != "" {
+		err := json.Unmarshal([]byte(val), &r)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+
+	if r.Requesters == nil || now.Sub(r.Since) > requestersWindow {
+		r = requesters{Since: now, Requesters: map[string]*Requester{}}
+	}
+
+	return r
+}
+
+func (g *Guard) strike(key string) {
+
+	_, err := g.store.Increment("abuse-strikes-"+key, strikeLifetime)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
+
+func (g *Guard) strikeCount(key string) int {
+
+	val, err := g.store.Get("abuse-strikes-" + key)
+	if err != nil {
+		log.ErrS(err)
+		return 0
+	}
+
+	i, _ := strconv.Atoi(val)
+	return i
+}
+
+func (g *Guard) clearStrikes(key string) {
+
+	err := g.store.Delete("abuse-strikes-" + key)
+	if err != nil {
+		log.ErrS(err)
+	}
+}
+
+// PassCaptcha lets the client skip the strike checks for a while and starts its strikes again.
+// Clients without a user or session can't keep a pass, so they have to send their cookie back.
+func (g *Guard) PassCaptcha(req Request) {
+
+	client := req.clientKey()
+	if client == "" {
+		return
+	}
+
+	err := g.store.Update("abuse-captcha-"+client, captchaLifetime, func(val string) (string, error) {
+		return "1", nil
+	})
+	if err != nil {
+		log.ErrS(err)
+		return
+	}
+
+	for _, key := range req.keys() {
+		g.clearStrikes(key)
+	}
+}
+
+func (g *Guard) PassedCaptcha(req Request) bool {
+
+	client := req.clientKey()
+	if client == "" {
+		return false
+	}
+
+	val, err := g.store.Get("abuse-captcha-" + client)
+	if err != nil {
+		log.ErrS(err)
+		return false
+	}
+
+	return val != ""
+}
+
+// Returns the last known value and refreshes it in the background, so checks don't wait on the queue
+func (g *Guard) queueDepth(now time.Time) int {
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.depthFunc == nil || g.depthLoading || now.Sub(g.depthAt) < depthCache {
+		return g.depth
+	}
+
+	g.depthLoading = true
+
+	go func() {
+
+		depth, err := g.depthFunc()
+
+		g.lock.Lock()
+		defer g.lock.Unlock()
+
+		g.depthLoading = false
+		g.depthAt = time.Now()
+
+		if err != nil {
+			log.ErrS(err)
+			return
+		}
+
+		g.depth = depth
+	}()
+
+	return g.depth
+}
@@ -0,0 +1,178 @@
+package abuse
+
+import (
+	"testing"
+
+	"github.com/gamedb/gamedb/pkg/ratelimit"
+)
+
+func TestBudgetForDepth(t *testing.T) {
+
+	tests := map[int]float64{
+		0:      1,
+		999:    1,
+		1000:   0.5,
+		9999:   0.5,
+		10000:  0.1,
+		50000:  0,
+		100000: 0,
+	}
+
+	for depth, budget := range tests {
+		if got := BudgetForDepth(depth); got != budget {
+			t.Errorf("depth %d: expected %v, got %v", depth, budget, got)
+		}
+	}
+}
+
+func TestCheckIP(t *testing.T) {
+
+	g := New(ratelimit.NewMemoryStore(), nil)
+	req := Request{Action: ActionPlayerAdd, IP: "1.1.1.1", SessionID: "a", UserAgent: "browser"}
+
+	// The IP burst is bigger than the session one, so use a new session each time
+	for i := 0; i < 10; i++ {
+		req.SessionID = string(rune('a' + i))
+		if reason := g.Check(req); reason != ReasonNone {
+			t.Fatalf("request %d: expected no block, got %s", i, reason)
+		}
+	}
+
+	req.SessionID = "z"
+	if reason := g.Check(req); reason != ReasonIP {
+		t.Errorf("expected %s, got %s", ReasonIP, reason)
+	}
+
+	// Other IPs are unaffected
+	req.IP = "2.2.2.2"
+	if reason := g.Check(req); reason != ReasonNone {
+		t.Errorf("expected no block, got %s", reason)
+	}
+}
+
+func TestCheckSessionAndUser(t *testing.T) {
+
+	g := New(ratelimit.NewMemoryStore(), nil)
+
+	anon := Request{IP: "1.1.1.1", SessionID: "a", UserAgent: "browser"}
+	for i := 0; i < 5; i++ {
+		g.Check(anon)
+	}
+
+	if reason := g.Check(anon); reason != ReasonSession {
+		t.Errorf("expected %s, got %s", ReasonSession, reason)
+	}
+
+	// Logged in users are limited per user, not per session
+	user := Request{IP: "2.2.2.2", SessionID: "b", UserAgent: "browser", UserID: 1}
+	for i := 0; i < 6; i++ {
+		if reason := g.Check(user); reason != ReasonNone {
+			t.Fatalf("request %d: expected no block, got %s", i, reason)
+		}
+	}
+}
+
+func TestCaptchaEscalation(t *testing.T) {
+
+	g := New(ratelimit.NewMemoryStore(), nil)
+
+	// No session cookie, each request is a strike
+	req := Request{IP: "1.1.1.1", UserAgent: "bot"}
+	for i := 1; i < StrikesForCaptcha; i++ {
+		if reason := g.Check(req); reason != ReasonNone {
+			t.Fatalf("request %d: expected no block, got %s", i, reason)
+		}
+	}
+
+	if reason := g.Check(req); reason != ReasonCaptcha {
+		t.Errorf("expected %s, got %s", ReasonCaptcha, reason)
+	}
+
+	// A pass needs a session to be kept against
+	g.PassCaptcha(req)
+	if g.PassedCaptcha(req) {
+		t.Error("expected no pass without a session")
+	}
+
+	// Passing the captcha clears the strikes
+	req.SessionID = "a"
+	g.PassCaptcha(req)
+
+	for i := 0; i < 2; i++ {
+		if reason := g.Check(req); reason != ReasonNone {
+			t.Errorf("request %d: expected no block, got %s", i, reason)
+		}
+	}
+
+	// Only for the session that passed it
+	other := Request{IP: "1.1.1.1", SessionID: "b", UserAgent: "browser"}
+	if g.PassedCaptcha(other) {
+		t.Error("expected the pass to be tied to the session")
+	}
+}
+
+func TestCheckGlobal(t *testing.T) {
+
+	g := New(ratelimit.NewMemoryStore(), nil)
+	g.depth = 50000
+
+	if reason := g.Check(Request{IP: "1.1.1.1", SessionID: "a", UserAgent: "browser"}); reason != ReasonGlobal {
+		t.Errorf("expected %s, got %s", ReasonGlobal, reason)
+	}
+
+	g.depth = 0
+
+	if reason := g.Check(Request{IP: "1.1.1.1", SessionID: "a", UserAgent: "browser"}); reason != ReasonNone {
+		t.Errorf("expected no block, got %s", reason)
+	}
+}
+
+func TestTop(t *testing.T) {
+
+	g := New(ratelimit.NewMemoryStore(), nil)
+
+	for i := 0; i < 3; i++ {
+		g.Check(Request{IP: "1.1.1.1", SessionID: "a", UserAgent: "browser"})
+	}
+	g.Check(Request{IP: "2.2.2.2", SessionID: "b", UserAgent: "browser"})
+
+	top := g.Top(2)
+	if len(top) != 2 {
+		t.Fatalf("expected 2 requesters, got %d", len(top))
+	}
+
+	if top[0].Key != "ip:1.1.1.1" || top[0].Requests != 3 {
+		t.Errorf("unexpected top requester %+v", top[0])
+	}
+}
+
+func TestSharedStore(t *testing.T) {
+
+	// Two instances behind the same store
+	store := ratelimit.NewMemoryStore()
+	a := New(store, nil)
+	b := New(store, nil)
+
+	req := Request{IP: "1.1.1.1", UserAgent: "bot"}
+	for i := 1; i < StrikesForCaptcha; i++ {
+		a.Check(req)
+	}
+
+	if reason := b.Check(req); reason != ReasonCaptcha {
+		t.Errorf("expected strikes from the other instance to count, got %s", reason)
+	}
+
+	a.Check(Request{IP: "2.2.2.2", SessionID: "a", UserAgent: "browser"})
+
+	// Instances add their counts every few seconds
+	a.flush()
+
+	top := b.Top(0)
+	if len(top) != 3 {
+		t.Fatalf("expected requesters from both instances, got %+v", top)
+	}
+
+	if top[0].Key != "ip:1.1.1.1" || top[0].Requests != 3 || top[0].Blocked != 1 {
+		t.Errorf("unexpected top requester %+v", top[0])
+	}
+}
@@ -85,7 +85,7 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 				},
 				"game-schema": {
 					Value: &openapi3.Schema{
-						Required: []string{"id", "name", "icon", "tags", "genres", "categories", "developers", "publishers", "prices", "players_max", "players_week_max", "players_week_avg", "release_date", "reviews_positive", "reviews_negative", "reviews_score", "metacritic_score"},
+						Required: []string{"id", "name", "icon", "tags", "genres", "categories", "developers", "publishers", "prices", "price_stats", "players_max", "players_week_max", "players_week_avg", "release_date", "reviews_positive", "reviews_negative", "reviews_score", "metacritic_score"},
 						Properties: map[string]*openapi3.SchemaRef{
 							"id":               {Value: openapi3.NewIntegerSchema()},
 							"name":             {Value: openapi3.NewStringSchema()},
@@ -96,6 +96,7 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 							"developers":       {Value: &openapi3.Schema{Type: "array", Items: &openapi3.SchemaRef{Ref: "#/components/schemas/stat-schema"}}},
 							"publishers":       {Value: &openapi3.Schema{Type: "array", Items: &openapi3.SchemaRef{Ref: "#/components/schemas/stat-schema"}}},
 							"prices":           {Value: &openapi3.Schema{Type: "object", AdditionalProperties: &openapi3.SchemaRef{Ref: "#/components/schemas/product-price-schema"}}},
+							"price_stats":      {Value: &openapi3.Schema{Type: "object", AdditionalProperties: &openapi3.SchemaRef{Ref: "#/components/schemas/product-price-stats-schema"}}},
 							"players_max":      {Value: openapi3.NewIntegerSchema()},
 							"players_week_max": {Value: openapi3.NewIntegerSchema()},
 							"release_date":     {Value: openapi3.NewInt64Schema()},
@@ -109,12 +110,26 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 				},
 				"similar-game-schema": {
 					Value: &openapi3.Schema{
-						Required: []string{"app_id", "count", "order", "owners"},
+						Required: []string{"app_id", "count", "order", "owners", "score"},
 						Properties: map[string]*openapi3.SchemaRef{
 							"app_id": {Value: openapi3.NewIntegerSchema()},
 							"count":  {Value: openapi3.NewIntegerSchema()},
 							"order":  {Value: openapi3.NewIntegerSchema()},
 							"owners": {Value: openapi3.NewIntegerSchema()},
+							"score":  {Value: openapi3.NewFloat64Schema().WithFormat("double")},
+						},
+					},
+				},
+				"recommended-game-schema": {
+					Value: &openapi3.Schema{
+						Required: []string{"app_id", "name", "score", "tags", "friends", "same_owners"},
+						Properties: map[string]*openapi3.SchemaRef{
+							"app_id":      {Value: openapi3.NewIntegerSchema()},
+							"name":        {Value: openapi3.NewStringSchema()},
+							"score":       {Value: openapi3.NewFloat64Schema().WithFormat("double")},
+							"tags":        {Value: openapi3.NewIntegerSchema()},
+							"friends":     {Value: openapi3.NewIntegerSchema()},
+							"same_owners": {Value: openapi3.NewIntegerSchema()},
 						},
 					},
 				},
@@ -160,7 +175,7 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 				},
 				"package-schema": {
 					Value: &openapi3.Schema{
-						Required: []string{"apps", "apps_count", "bundle", "billing_type", "change_id", "change_number_date", "coming_soon", "depot_ids", "icon", "id", "image_logo", "image_page", "license_type", "name", "platforms", "prices", "release_date", "release_date_unix", "status"},
+						Required: []string{"apps", "apps_count", "bundle", "billing_type", "change_id", "change_number_date", "coming_soon", "depot_ids", "icon", "id", "image_logo", "image_page", "license_type", "name", "platforms", "prices", "price_stats", "release_date", "release_date_unix", "status"},
 						Properties: map[string]*openapi3.SchemaRef{
 							"apps":               {Value: openapi3.NewArraySchema().WithItems(openapi3.NewInt32Schema())},
 							"apps_count":         {Value: openapi3.NewInt32Schema()},
@@ -178,6 +193,7 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 							"name":               {Value: openapi3.NewStringSchema()},
 							"platforms":          {Value: openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema())},
 							"prices":             {Value: &openapi3.Schema{Type: "object", AdditionalProperties: &openapi3.SchemaRef{Ref: "#/components/schemas/product-price-schema"}}},
+							"price_stats":        {Value: &openapi3.Schema{Type: "object", AdditionalProperties: &openapi3.SchemaRef{Ref: "#/components/schemas/product-price-stats-schema"}}},
 							"release_date":       {Value: openapi3.NewStringSchema()},
 							"release_date_unix":  {Value: openapi3.NewInt64Schema()},
 							"status":             {Value: openapi3.NewStringSchema()},
@@ -197,6 +213,19 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 						},
 					},
 				},
+				"product-price-stats-schema": {
+					Value: &openapi3.Schema{
+						Required: []string{"currency", "lowestEver", "lowestEverAt", "lowest12Months", "typicalSale", "sales"},
+						Properties: map[string]*openapi3.SchemaRef{
+							"currency":       {Value: openapi3.NewStringSchema()},
+							"lowestEver":     {Value: openapi3.NewInt32Schema()},
+							"lowestEverAt":   {Value: openapi3.NewInt64Schema()},
+							"lowest12Months": {Value: openapi3.NewInt32Schema()},
+							"typicalSale":    {Value: openapi3.NewInt32Schema()},
+							"sales":          {Value: openapi3.NewInt32Schema()},
+						},
+					},
+				},
 				"stat-schema": {
 					Value: &openapi3.Schema{
 						Required: []string{"id", "name"},
@@ -317,7 +346,7 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 				},
 				"similar-games-response": {
 					Value: &openapi3.Response{
-						Description: helpers.StringPointer("List of games with similar owners"),
+						Description: helpers.StringPointer("List of similar games"),
 						Content: openapi3.NewContentWithJSONSchema(&openapi3.Schema{
 							Description: "List of apps, with pagination",
 							Required:    []string{"pagination", "games", "error"},
@@ -328,6 +357,19 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 						}),
 					},
 				},
+				"recommended-games-response": {
+					Value: &openapi3.Response{
+						Description: helpers.StringPointer("List of recommended games"),
+						Content: openapi3.NewContentWithJSONSchema(&openapi3.Schema{
+							Description: "List of games the player does not own",
+							Required:    []string{"games", "error"},
+							Properties: map[string]*openapi3.SchemaRef{
+								"games": {Value: &openapi3.Schema{Type: "array", Items: &openapi3.SchemaRef{Ref: "#/components/schemas/recommended-game-schema"}}},
+								"error": {Value: openapi3.NewStringSchema()},
+							},
+						}),
+					},
+				},
 				"group-response": {
 					Value: &openapi3.Response{
 						Description: helpers.StringPointer("A group"),
@@ -507,7 +549,7 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 			"/games/{id}/similar": &openapi3.PathItem{
 				Get: &openapi3.Operation{
 					Tags:    []string{tagGames},
-					Summary: "List games with similar owners",
+					Summary: "List similar games, by tags, genres, developers, owners and reviews",
 					Parameters: openapi3.Parameters{
 						{Value: openapi3.NewPathParameter("id").WithRequired(true).WithSchema(openapi3.NewInt32Schema().WithMin(1))},
 					},
@@ -618,6 +660,22 @@ func GetGlobalSteam() (swagger *openapi3.Swagger) {
 					},
 				},
 			},
+			"/players/{id}/recommendations": &openapi3.PathItem{
+				Get: &openapi3.Operation{
+					Tags:    []string{tagPlayers},
+					Summary: "List recommended games, by tag affinity, friends, owners and reviews",
+					Parameters: openapi3.Parameters{
+						{Value: openapi3.NewPathParameter("id").WithRequired(true).WithSchema(openapi3.NewInt64Schema().WithMin(1))},
+					},
+					Responses: map[string]*openapi3.ResponseRef{
+						"200": {Ref: "#/components/responses/recommended-games-response"},
+						"400": {Ref: "#/components/responses/recommended-games-response"},
+						"401": {Ref: "#/components/responses/recommended-games-response"},
+						"404": {Ref: "#/components/responses/recommended-games-response"},
+						"500": {Ref: "#/components/responses/recommended-games-response"},
+					},
+				},
+			},
 			// "/app - players",
 			// "/app - price changes",
 			// "/bundles",
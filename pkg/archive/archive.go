@@ -0,0 +1,414 @@
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/influx"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.uber.org/zap"
+)
+
+const day = time.Hour * 24
+
+var ErrUnknownStore = errors.New("unknown store")
+
+// Archives up to maxDays days per store, oldest first.
+// Each day is exported, uploaded and recorded before it's pruned from the hot store,
+// so Restore can always find it. A failed prune is tried again on the next run.
+func Run(maxDays int) (err error) {
+
+	b, err := getBucket()
+	if err != nil {
+		return err
+	}
+
+	for _, store := range Stores {
+
+		err = archiveStore(b, store, maxDays)
+		if err != nil {
+			return errors.New(store.Name() + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+func archiveStore(b bucket, store Store, maxDays int) (err error) {
+
+	var start time.Time
+
+	latest, err := mongo.GetLatestArchive(store.Name())
+	if err == nil {
+
+		if latest.Pruning {
+			err = pruneDay(store, latest)
+			if err != nil {
+				return err
+			}
+		}
+
+		start = latest.Day.Add(day)
+
+	} else if err == mongo.ErrNoDocuments {
+		start, err = store.first()
+		if err != nil {
+			return err
+		}
+		if start.IsZero() {
+			return nil
+		}
+	} else {
+		return err
+	}
+
+	start = start.UTC().Truncate(day)
+	end := time.Now().UTC().Add(-store.Retention()).Truncate(day)
+
+	for i := 0; i < maxDays && start.Before(end); i++ {
+
+		err = archiveDay(b, store, start)
+		if err != nil {
+			return err
+		}
+
+		start = start.Add(day)
+	}
+
+	return nil
+}
+
+func archiveDay(b bucket, store Store, from time.Time) (err error) {
+
+	to := from.Add(day)
+
+	f, err := ioutil.TempFile("", "archive-*.ndjson.gz")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	gz := gzip.NewWriter(f)
+
+	count, err := store.export(from, to, gz)
+	if err != nil {
+		return err
+	}
+
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	file := filePath(store, from)
+
+	err = b.put(file, f)
+	if err != nil {
+		return err
+	}
+
+	archive := mongo.Archive{
+		Store:     store.Name(),
+		Day:       from,
+		Count:     count,
+		Path:      file,
+		CreatedAt: time.Now(),
+		Pruning:   true,
+	}
+
+	err = mongo.SaveArchive(archive)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Archived day", zap.String("store", store.Name()), zap.Time("day", from), zap.Int64("count", count))
+
+	return pruneDay(store, archive)
+}
+
+func pruneDay(store Store, archive mongo.Archive) (err error) {
+
+	err = store.prune(archive.Day, archive.Day.Add(day))
+	if err != nil {
+		return err
+	}
+
+	archive.Pruning = false
+
+	return mongo.SaveArchive(archive)
+}
+
+func filePath(store Store, day time.Time) string {
+	return store.Name() + "/" + day.Format("2006/01/02") + ".ndjson.gz"
+}
+
+// Loads archived days back into the hot store, both dates inclusive.
+// Anything already in the store is left as it is.
+func Restore(name string, from time.Time, to time.Time) (count int64, err error) {
+
+	store, ok := GetStore(name)
+	if !ok {
+		return 0, ErrUnknownStore
+	}
+
+	b, err := getBucket()
+	if err != nil {
+		return 0, err
+	}
+
+	archives, err := mongo.GetArchives(store.Name(), from.UTC().Truncate(day), to.UTC().Truncate(day).Add(day))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, archive := range archives {
+
+		n, err := restoreDay(b, store, archive)
+		count += n
+		if err != nil {
+			return count, errors.New(archive.Path + ": " + err.Error())
+		}
+
+		log.Info("Restored day", zap.String("store", store.Name()), zap.Time("day", archive.Day), zap.Int64("count", n))
+	}
+
+	return count, nil
+}
+
+func restoreDay(b bucket, store Store, archive mongo.Archive) (count int64, err error) {
+
+	r, err := b.get(archive.Path)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		err2 := r.Close()
+		if err2 != nil {
+			log.ErrS(err2)
+		}
+	}()
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		err2 := gz.Close()
+		if err2 != nil {
+			log.ErrS(err2)
+		}
+	}()
+
+	return store.restore(gz)
+}
+
+func openDay(b bucket, archive mongo.Archive) (io.ReadCloser, error) {
+
+	r, err := b.get(archive.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+
+	return readCloser{Reader: gz, close: func() error {
+		_ = gz.Close()
+		return r.Close()
+	}}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (rc readCloser) Close() error {
+	return rc.close()
+}
+
+// The archived days of the Influx store for a measurement
+func influxArchives(measurement influx.InfluxMeasurement) (archives []mongo.Archive, err error) {
+
+	for _, v := range Stores {
+		if s, ok := v.(influxStore); ok && s.measurement == measurement {
+			return mongo.GetArchives(s.Name(), time.Time{}, time.Now())
+		}
+	}
+
+	return nil, ErrUnknownStore
+}
+
+// Archived points with a tag value, for a user's data export
+func ExportTagPoints(measurement influx.InfluxMeasurement, tag string, value string, callback func(influx.ArchivedPoint) error) (err error) {
+
+	archives, err := influxArchives(measurement)
+	if err != nil || len(archives) == 0 {
+		return err
+	}
+
+	b, err := getBucket()
+	if err != nil {
+		return err
+	}
+
+	for _, archive := range archives {
+
+		err = func() error {
+
+			r, err := openDay(b, archive)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = r.Close() }()
+
+			return eachPoint(r, func(point influx.ArchivedPoint) error {
+				if point.Tags[tag] != value {
+					return nil
+				}
+				return callback(point)
+			})
+		}()
+		if err != nil {
+			return errors.New(archive.Path + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Rewrites the archived days that have points with a tag value without them, for when a user is deleted
+func DeleteTagPoints(measurement influx.InfluxMeasurement, tag string, value string) (err error) {
+
+	archives, err := influxArchives(measurement)
+	if err != nil || len(archives) == 0 {
+		return err
+	}
+
+	b, err := getBucket()
+	if err != nil {
+		return err
+	}
+
+	for _, archive := range archives {
+
+		err = deleteDayTagPoints(b, archive, tag, value)
+		if err != nil {
+			return errors.New(archive.Path + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+func deleteDayTagPoints(b bucket, archive mongo.Archive, tag string, value string) (err error) {
+
+	r, err := openDay(b, archive)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	f, err := ioutil.TempFile("", "archive-*.ndjson.gz")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	gz := gzip.NewWriter(f)
+
+	removed, err := filterPoints(r, gz, func(point influx.ArchivedPoint) bool {
+		return point.Tags[tag] != value
+	})
+	if err != nil {
+		return err
+	}
+
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		return nil
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	err = b.put(archive.Path, f)
+	if err != nil {
+		return err
+	}
+
+	archive.Count -= removed
+
+	return mongo.SaveArchive(archive)
+}
+
+func eachPoint(r io.Reader, callback func(influx.ArchivedPoint) error) (err error) {
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	for {
+
+		var point influx.ArchivedPoint
+		err = decoder.Decode(&point)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = callback(point)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Copies the points to keep from r to w, returns how many were dropped
+func filterPoints(r io.Reader, w io.Writer, keep func(influx.ArchivedPoint) bool) (removed int64, err error) {
+
+	encoder := json.NewEncoder(w)
+
+	err = eachPoint(r, func(point influx.ArchivedPoint) error {
+
+		if !keep(point) {
+			removed++
+			return nil
+		}
+
+		return encoder.Encode(point)
+	})
+
+	return removed, err
+}
@@ -0,0 +1,43 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gamedb/gamedb/pkg/influx"
+)
+
+func TestFilterPoints(t *testing.T) {
+
+	in := `{"measurement":"api_calls","tags":{"user_id":"1"},"time":"2019-01-01T00:00:00Z","fields":{"call":1},"types":{"call":"integer"}}
+{"measurement":"api_calls","tags":{"user_id":"2"},"time":"2019-01-01T00:00:01Z","fields":{"call":1},"types":{"call":"integer"}}
+{"measurement":"api_calls","tags":{"user_id":"1"},"time":"2019-01-01T00:00:02Z","fields":{"call":1},"types":{"call":"integer"}}
+`
+
+	var out bytes.Buffer
+	removed, err := filterPoints(strings.NewReader(in), &out, func(point influx.ArchivedPoint) bool {
+		return point.Tags["user_id"] != "1"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removed != 2 {
+		t.Errorf("expected 2 points removed, got %d", removed)
+	}
+
+	var kept []influx.ArchivedPoint
+	err = eachPoint(&out, func(point influx.ArchivedPoint) error {
+		kept = append(kept, point)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(kept) != 1 || kept[0].Tags["user_id"] != "2" || kept[0].Fields["call"].(json.Number).String() != "1" {
+		t.Errorf("unexpected points kept %v", kept)
+	}
+}
@@ -0,0 +1,175 @@
+package archive
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gamedb/gamedb/pkg/config"
+)
+
+var ErrNoPath = errors.New("ARCHIVE_PATH is not set")
+
+// Where archive files are kept
+type bucket interface {
+	put(file string, r io.ReadSeeker) error
+	get(file string) (io.ReadCloser, error)
+	delete(file string) error
+}
+
+// PutFile stores any file in the archive bucket, eg. user data exports
+func PutFile(file string, r io.ReadSeeker) error {
+
+	b, err := getBucket()
+	if err != nil {
+		return err
+	}
+
+	return b.put(file, r)
+}
+
+func GetFile(file string) (io.ReadCloser, error) {
+
+	b, err := getBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.get(file)
+}
+
+func DeleteFile(file string) error {
+
+	b, err := getBucket()
+	if err != nil {
+		return err
+	}
+
+	return b.delete(file)
+}
+
+func getBucket() (bucket, error) {
+
+	if config.C.ArchivePath == "" {
+		return nil, ErrNoPath
+	}
+
+	if !strings.HasPrefix(config.C.ArchivePath, "s3://") {
+		return localBucket{dir: config.C.ArchivePath}, nil
+	}
+
+	u, err := url.Parse(config.C.ArchivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:           aws.String(config.C.ArchiveS3Region),
+		Endpoint:         aws.String(config.C.ArchiveS3Endpoint),
+		S3ForcePathStyle: aws.Bool(config.C.ArchiveS3Endpoint != ""), // For Minio, Spaces etc
+		Credentials:      credentials.NewStaticCredentials(config.C.ArchiveS3Key, config.C.ArchiveS3Secret, ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s3Bucket{
+		client: s3.New(sess),
+		bucket: u.Host,
+		prefix: strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+type localBucket struct {
+	dir string
+}
+
+func (b localBucket) put(file string, r io.ReadSeeker) (err error) {
+
+	file = filepath.Join(b.dir, filepath.FromSlash(file))
+
+	err = os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file first so a failed copy doesn't leave half an archive
+	tmp := file + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
+}
+
+func (b localBucket) get(file string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(b.dir, filepath.FromSlash(file)))
+}
+
+func (b localBucket) delete(file string) error {
+
+	err := os.Remove(filepath.Join(b.dir, filepath.FromSlash(file)))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+type s3Bucket struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+func (b s3Bucket) put(file string, r io.ReadSeeker) (err error) {
+
+	_, err = b.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(path.Join(b.prefix, file)),
+		Body:   r,
+	})
+	return err
+}
+
+func (b s3Bucket) get(file string) (io.ReadCloser, error) {
+
+	resp, err := b.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(path.Join(b.prefix, file)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (b s3Bucket) delete(file string) error {
+
+	_, err := b.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(path.Join(b.prefix, file)),
+	})
+	return err
+}
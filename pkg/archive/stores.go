@@ -0,0 +1,238 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/influx"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const restoreBatch = 1000
+
+// A source of time series data that can be archived a day at a time
+type Store interface {
+	Name() string
+	Retention() time.Duration // How long to keep in the hot store
+	first() (time.Time, error)
+	export(from time.Time, to time.Time, w io.Writer) (count int64, err error)
+	prune(from time.Time, to time.Time) error
+	restore(r io.Reader) (count int64, err error)
+}
+
+var Stores = []Store{
+	mongoStore{
+		name:        "product_prices",
+		retention:   time.Hour * 24 * 365 * 2,
+		source:      mongo.ArchiveSource{Collection: mongo.CollectionProductPrices, DateField: "created_at"},
+		beforePrune: mongo.SaveProductPriceLows, // Keeps the lowest ever prices working
+	},
+	mongoStore{
+		name:      "changes",
+		retention: time.Hour * 24 * 365,
+		source:    mongo.ArchiveSource{Collection: mongo.CollectionChanges, DateField: "created_at"},
+	},
+	mongoStore{
+		name:      "player_aliases",
+		retention: time.Hour * 24 * 365,
+		source:    mongo.ArchiveSource{Collection: mongo.CollectionPlayerAliases, DateField: "time", UnixTime: true},
+	},
+	influxStore{
+		retention:   time.Hour * 24 * 13,
+		policy:      influx.InfluxRetentionPolicy14Day,
+		measurement: influx.InfluxMeasurementPlayerUpdates,
+		expires:     true,
+	},
+	influxStore{
+		retention:   time.Hour * 24 * 365,
+		policy:      influx.InfluxRetentionPolicyAllTime,
+		measurement: influx.InfluxMeasurementAPICalls,
+	},
+}
+
+func GetStore(name string) (Store, bool) {
+	for _, v := range Stores {
+		if v.Name() == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Mongo documents as canonical extended JSON, one per line
+type mongoStore struct {
+	name        string
+	retention   time.Duration
+	source      mongo.ArchiveSource
+	beforePrune func(from time.Time, to time.Time) error
+}
+
+func (s mongoStore) Name() string {
+	return "mongo-" + s.name
+}
+
+func (s mongoStore) Retention() time.Duration {
+	return s.retention
+}
+
+func (s mongoStore) first() (time.Time, error) {
+	return s.source.First()
+}
+
+func (s mongoStore) export(from time.Time, to time.Time, w io.Writer) (count int64, err error) {
+
+	err = s.source.Export(from, to, func(raw bson.Raw) error {
+
+		b, err := bson.MarshalExtJSON(raw, true, false)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(append(b, '\n'))
+		if err != nil {
+			return err
+		}
+
+		count++
+		return nil
+	})
+
+	return count, err
+}
+
+func (s mongoStore) prune(from time.Time, to time.Time) error {
+
+	if s.beforePrune != nil {
+		err := s.beforePrune(from, to)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.source.Delete(from, to)
+}
+
+func (s mongoStore) restore(r io.Reader) (count int64, err error) {
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024) // Documents can be up to 16MB
+
+	var batch []bson.D
+	for scanner.Scan() {
+
+		var doc bson.D
+		err = bson.UnmarshalExtJSON(scanner.Bytes(), true, &doc)
+		if err != nil {
+			return count, err
+		}
+
+		batch = append(batch, doc)
+
+		if len(batch) >= restoreBatch {
+			err = s.source.Restore(batch)
+			if err != nil {
+				return count, err
+			}
+			count += int64(len(batch))
+			batch = nil
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return count, err
+	}
+
+	if len(batch) > 0 {
+		err = s.source.Restore(batch)
+		if err != nil {
+			return count, err
+		}
+		count += int64(len(batch))
+	}
+
+	return count, nil
+}
+
+// Influx points as influx.ArchivedPoint JSON, one per line
+type influxStore struct {
+	retention   time.Duration
+	policy      influx.InfluxRetentionPolicy
+	measurement influx.InfluxMeasurement
+	expires     bool // The retention policy removes old points itself
+}
+
+func (s influxStore) Name() string {
+	return "influx-" + s.measurement.String()
+}
+
+func (s influxStore) Retention() time.Duration {
+	return s.retention
+}
+
+func (s influxStore) first() (time.Time, error) {
+	return influx.GetFirstTime(s.policy, s.measurement)
+}
+
+func (s influxStore) export(from time.Time, to time.Time, w io.Writer) (count int64, err error) {
+
+	encoder := json.NewEncoder(w)
+
+	err = influx.ExportPoints(s.policy, s.measurement, from, to, func(point influx.ArchivedPoint) error {
+		count++
+		return encoder.Encode(point)
+	})
+
+	return count, err
+}
+
+func (s influxStore) prune(from time.Time, to time.Time) error {
+
+	if s.expires {
+		return nil
+	}
+
+	return influx.DeletePoints(s.measurement, from, to)
+}
+
+func (s influxStore) restore(r io.Reader) (count int64, err error) {
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var batch []influx.ArchivedPoint
+	for {
+
+		var point influx.ArchivedPoint
+		err = decoder.Decode(&point)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+
+		batch = append(batch, point)
+
+		if len(batch) >= restoreBatch {
+			err = influx.RestorePoints(s.policy, batch)
+			if err != nil {
+				return count, err
+			}
+			count += int64(len(batch))
+			batch = nil
+		}
+	}
+
+	if len(batch) > 0 {
+		err = influx.RestorePoints(s.policy, batch)
+		if err != nil {
+			return count, err
+		}
+		count += int64(len(batch))
+	}
+
+	return count, nil
+}
@@ -13,17 +13,19 @@ import (
 	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
 	"github.com/gamedb/gamedb/pkg/backend/generated"
 	"github.com/gamedb/gamedb/pkg/config"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var (
 	conn *grpc.ClientConn
-	ctx  context.Context
 	lock sync.Mutex
 )
 
-func GetClient() (*grpc.ClientConn, context.Context, error) {
+// Pass the request context so traces carry through to the backend
+func GetClient(parent context.Context) (*grpc.ClientConn, context.Context, error) {
 
 	lock.Lock()
 	defer lock.Unlock()
@@ -59,16 +61,42 @@ func GetClient() (*grpc.ClientConn, context.Context, error) {
 			RootCAs:      certPool,
 		})
 
-		c, err := grpc.Dial(config.C.BackendClientPort, grpc.WithTransportCredentials(creds))
+		c, err := grpc.Dial(config.C.BackendClientPort,
+			grpc.WithTransportCredentials(creds),
+			grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+			grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+		)
 		if err != nil {
 			return nil, nil, err
 		}
 
 		conn = c
-		ctx = context.Background()
 	}
 
-	return conn, ctx, nil
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	return conn, parent, nil
+}
+
+func Ping(ctx context.Context) error {
+
+	conn, ctx, err := GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+
+	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return errors.New("backend is " + resp.GetStatus().String())
+	}
+
+	return nil
 }
 
 func MakePaginationRequest(query datatable.DataTablesQuery, cols map[string]string, limit int64) *generated.PaginationRequest {
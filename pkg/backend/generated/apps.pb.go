@@ -641,6 +641,7 @@ type AppMongoResponse struct {
 	PlayersMax      int32                  `protobuf:"varint,15,opt,name=playersMax,proto3" json:"playersMax,omitempty"`
 	PlayersWeekMax  int32                  `protobuf:"varint,16,opt,name=playersWeekMax,proto3" json:"playersWeekMax,omitempty"`
 	PlayersWeekAvg  float32                `protobuf:"fixed32,17,opt,name=playersWeekAvg,proto3" json:"playersWeekAvg,omitempty"`
+	PriceStats      map[string]*PriceStat  `protobuf:"bytes,19,rep,name=priceStats,proto3" json:"priceStats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *AppMongoResponse) Reset() {
@@ -801,6 +802,13 @@ func (x *AppMongoResponse) GetPlayersWeekAvg() float32 {
 	return 0
 }
 
+func (x *AppMongoResponse) GetPriceStats() map[string]*PriceStat {
+	if x != nil {
+		return x.PriceStats
+	}
+	return nil
+}
+
 // Similar
 type ListSimilarAppsRequest struct {
 	state         protoimpl.MessageState
@@ -808,6 +816,7 @@ type ListSimilarAppsRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	AppId int32 `protobuf:"varint,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
 func (x *ListSimilarAppsRequest) Reset() {
@@ -849,6 +858,13 @@ func (x *ListSimilarAppsRequest) GetAppId() int32 {
 	return 0
 }
 
+func (x *ListSimilarAppsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type SimilarAppsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -901,10 +917,11 @@ type SimilarAppResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppId  int32 `protobuf:"varint,1,opt,name=appId,proto3" json:"appId,omitempty"`
-	Count  int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
-	Order  int32 `protobuf:"varint,3,opt,name=order,proto3" json:"order,omitempty"`
-	Owners int32 `protobuf:"varint,4,opt,name=owners,proto3" json:"owners,omitempty"`
+	AppId  int32   `protobuf:"varint,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	Count  int32   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Order  int32   `protobuf:"varint,3,opt,name=order,proto3" json:"order,omitempty"`
+	Owners int32   `protobuf:"varint,4,opt,name=owners,proto3" json:"owners,omitempty"`
+	Score  float32 `protobuf:"fixed32,5,opt,name=score,proto3" json:"score,omitempty"`
 }
 
 func (x *SimilarAppResponse) Reset() {
@@ -967,6 +984,132 @@ func (x *SimilarAppResponse) GetOwners() int32 {
 	return 0
 }
 
+func (x *SimilarAppResponse) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// Watch players
+type WatchAppPlayersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []int32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *WatchAppPlayersRequest) Reset() {
+	*x = WatchAppPlayersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchAppPlayersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAppPlayersRequest) ProtoMessage() {}
+
+func (x *WatchAppPlayersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAppPlayersRequest.ProtoReflect.Descriptor instead.
+func (*WatchAppPlayersRequest) Descriptor() ([]byte, []int) {
+	return file_apps_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchAppPlayersRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type AppPlayersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Players       int32                  `protobuf:"varint,2,opt,name=players,proto3" json:"players,omitempty"`
+	TwitchViewers int32                  `protobuf:"varint,3,opt,name=twitchViewers,proto3" json:"twitchViewers,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (x *AppPlayersResponse) Reset() {
+	*x = AppPlayersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppPlayersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppPlayersResponse) ProtoMessage() {}
+
+func (x *AppPlayersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppPlayersResponse.ProtoReflect.Descriptor instead.
+func (*AppPlayersResponse) Descriptor() ([]byte, []int) {
+	return file_apps_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AppPlayersResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AppPlayersResponse) GetPlayers() int32 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+func (x *AppPlayersResponse) GetTwitchViewers() int32 {
+	if x != nil {
+		return x.TwitchViewers
+	}
+	return 0
+}
+
+func (x *AppPlayersResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 var File_apps_proto protoreflect.FileDescriptor
 
 var file_apps_proto_rawDesc = []byte{
@@ -1100,7 +1243,7 @@ var file_apps_proto_rawDesc = []byte{
 	0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x04, 0x61, 0x70, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
 	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x41, 0x70,
 	0x70, 0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04,
-	0x61, 0x70, 0x70, 0x73, 0x22, 0xd2, 0x05, 0x0a, 0x10, 0x41, 0x70, 0x70, 0x4d, 0x6f, 0x6e, 0x67,
+	0x61, 0x70, 0x70, 0x73, 0x22, 0xf4, 0x06, 0x0a, 0x10, 0x41, 0x70, 0x70, 0x4d, 0x6f, 0x6e, 0x67,
 	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
 	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
@@ -1140,45 +1283,76 @@ var file_apps_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x57, 0x65, 0x65,
 	0x6b, 0x4d, 0x61, 0x78, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x57,
 	0x65, 0x65, 0x6b, 0x41, 0x76, 0x67, 0x18, 0x11, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0e, 0x70, 0x6c,
-	0x61, 0x79, 0x65, 0x72, 0x73, 0x57, 0x65, 0x65, 0x6b, 0x41, 0x76, 0x67, 0x1a, 0x4b, 0x0a, 0x0b,
-	0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67,
-	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2e, 0x0a, 0x16, 0x4c, 0x69, 0x73,
-	0x74, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x22, 0x48, 0x0a, 0x13, 0x53, 0x69, 0x6d,
-	0x69, 0x6c, 0x61, 0x72, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x31, 0x0a, 0x04, 0x61, 0x70, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
-	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x53, 0x69, 0x6d, 0x69, 0x6c,
-	0x61, 0x72, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04, 0x61,
-	0x70, 0x70, 0x73, 0x22, 0x6e, 0x0a, 0x12, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x41, 0x70,
-	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70,
-	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f,
-	0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x77, 0x6e,
-	0x65, 0x72, 0x73, 0x32, 0xeb, 0x01, 0x0a, 0x0b, 0x41, 0x70, 0x70, 0x73, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1c, 0x2e,
-	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x57, 0x65, 0x65, 0x6b, 0x41, 0x76, 0x67, 0x12, 0x4b, 0x0a, 0x0a,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2b, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x41, 0x70, 0x70,
+	0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x1a, 0x4b, 0x0a, 0x0b, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x53, 0x0a, 0x0f, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x44, 0x0a, 0x16, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x22, 0x48, 0x0a, 0x13, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x41, 0x70, 0x70, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x61, 0x70, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x2e, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04, 0x61, 0x70, 0x70, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x12,
+	0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x22, 0x2a, 0x0a, 0x16, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x70, 0x70, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x9e,
+	0x01, 0x0a, 0x12, 0x41, 0x70, 0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x74, 0x77, 0x69, 0x74, 0x63, 0x68, 0x56, 0x69, 0x65, 0x77, 0x65, 0x72, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x74, 0x77, 0x69, 0x74, 0x63, 0x68, 0x56, 0x69,
+	0x65, 0x77, 0x65, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x32,
+	0xc4, 0x02, 0x0a, 0x0b, 0x41, 0x70, 0x70, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x48, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1c, 0x2e, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x70, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x64, 0x2e, 0x41, 0x70, 0x70, 0x73, 0x45, 0x6c, 0x61, 0x73, 0x74, 0x69, 0x63, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x04, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x1a, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x41, 0x70, 0x70, 0x73, 0x4d, 0x6f,
+	0x6e, 0x67, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a,
+	0x07, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x12, 0x21, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72,
 	0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x41, 0x70, 0x70, 0x73, 0x45, 0x6c, 0x61, 0x73,
-	0x74, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a,
-	0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1a, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x41, 0x70,
-	0x70, 0x73, 0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x4e, 0x0a, 0x07, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x12, 0x21, 0x2e, 0x67,
-	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x69, 0x6d,
-	0x69, 0x6c, 0x61, 0x72, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1e, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x53, 0x69, 0x6d, 0x69,
-	0x6c, 0x61, 0x72, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x67, 0x61, 0x6d, 0x65, 0x64, 0x62, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x64, 0x62, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x41,
+	0x70, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a,
+	0x0f, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x70, 0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73,
+	0x12, 0x21, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x41, 0x70, 0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e,
+	0x41, 0x70, 0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x64, 0x62, 0x2f, 0x67, 0x61, 0x6d, 0x65,
+	0x64, 0x62, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1193,7 +1367,7 @@ func file_apps_proto_rawDescGZIP() []byte {
 	return file_apps_proto_rawDescData
 }
 
-var file_apps_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_apps_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_apps_proto_goTypes = []interface{}{
 	(*SearchAppsRequest)(nil),      // 0: generated.SearchAppsRequest
 	(*AppsElasticResponse)(nil),    // 1: generated.AppsElasticResponse
@@ -1204,42 +1378,51 @@ var file_apps_proto_goTypes = []interface{}{
 	(*ListSimilarAppsRequest)(nil), // 6: generated.ListSimilarAppsRequest
 	(*SimilarAppsResponse)(nil),    // 7: generated.SimilarAppsResponse
 	(*SimilarAppResponse)(nil),     // 8: generated.SimilarAppResponse
-	nil,                            // 9: generated.AppElasticResponse.AchievementIconsEntry
-	nil,                            // 10: generated.AppElasticResponse.PricesEntry
-	nil,                            // 11: generated.AppMongoResponse.PricesEntry
-	(*PaginationRequest)(nil),      // 12: generated.PaginationRequest
-	(ProductCode)(0),               // 13: generated.ProductCode
-	(*PaginationResponse)(nil),     // 14: generated.PaginationResponse
-	(*timestamppb.Timestamp)(nil),  // 15: google.protobuf.Timestamp
-	(*Price)(nil),                  // 16: generated.Price
+	(*WatchAppPlayersRequest)(nil), // 9: generated.WatchAppPlayersRequest
+	(*AppPlayersResponse)(nil),     // 10: generated.AppPlayersResponse
+	nil,                            // 11: generated.AppElasticResponse.AchievementIconsEntry
+	nil,                            // 12: generated.AppElasticResponse.PricesEntry
+	nil,                            // 13: generated.AppMongoResponse.PricesEntry
+	nil,                            // 14: generated.AppMongoResponse.PriceStatsEntry
+	(*PaginationRequest)(nil),      // 15: generated.PaginationRequest
+	(ProductCode)(0),               // 16: generated.ProductCode
+	(*PaginationResponse)(nil),     // 17: generated.PaginationResponse
+	(*timestamppb.Timestamp)(nil),  // 18: google.protobuf.Timestamp
+	(*Price)(nil),                  // 19: generated.Price
+	(*PriceStat)(nil),              // 20: generated.PriceStat
 }
 var file_apps_proto_depIdxs = []int32{
-	12, // 0: generated.SearchAppsRequest.pagination:type_name -> generated.PaginationRequest
-	13, // 1: generated.SearchAppsRequest.currency:type_name -> generated.ProductCode
-	14, // 2: generated.AppsElasticResponse.pagination:type_name -> generated.PaginationResponse
+	15, // 0: generated.SearchAppsRequest.pagination:type_name -> generated.PaginationRequest
+	16, // 1: generated.SearchAppsRequest.currency:type_name -> generated.ProductCode
+	17, // 2: generated.AppsElasticResponse.pagination:type_name -> generated.PaginationResponse
 	2,  // 3: generated.AppsElasticResponse.apps:type_name -> generated.AppElasticResponse
-	9,  // 4: generated.AppElasticResponse.achievementIcons:type_name -> generated.AppElasticResponse.AchievementIconsEntry
-	10, // 5: generated.AppElasticResponse.prices:type_name -> generated.AppElasticResponse.PricesEntry
-	15, // 6: generated.AppElasticResponse.releaseDate:type_name -> google.protobuf.Timestamp
-	12, // 7: generated.ListAppsRequest.pagination:type_name -> generated.PaginationRequest
-	14, // 8: generated.AppsMongoResponse.pagination:type_name -> generated.PaginationResponse
+	11, // 4: generated.AppElasticResponse.achievementIcons:type_name -> generated.AppElasticResponse.AchievementIconsEntry
+	12, // 5: generated.AppElasticResponse.prices:type_name -> generated.AppElasticResponse.PricesEntry
+	18, // 6: generated.AppElasticResponse.releaseDate:type_name -> google.protobuf.Timestamp
+	15, // 7: generated.ListAppsRequest.pagination:type_name -> generated.PaginationRequest
+	17, // 8: generated.AppsMongoResponse.pagination:type_name -> generated.PaginationResponse
 	5,  // 9: generated.AppsMongoResponse.apps:type_name -> generated.AppMongoResponse
-	15, // 10: generated.AppMongoResponse.releaseDateUnix:type_name -> google.protobuf.Timestamp
-	11, // 11: generated.AppMongoResponse.prices:type_name -> generated.AppMongoResponse.PricesEntry
-	8,  // 12: generated.SimilarAppsResponse.apps:type_name -> generated.SimilarAppResponse
-	16, // 13: generated.AppElasticResponse.PricesEntry.value:type_name -> generated.Price
-	16, // 14: generated.AppMongoResponse.PricesEntry.value:type_name -> generated.Price
-	0,  // 15: generated.AppsService.Search:input_type -> generated.SearchAppsRequest
-	3,  // 16: generated.AppsService.List:input_type -> generated.ListAppsRequest
-	6,  // 17: generated.AppsService.Similar:input_type -> generated.ListSimilarAppsRequest
-	1,  // 18: generated.AppsService.Search:output_type -> generated.AppsElasticResponse
-	4,  // 19: generated.AppsService.List:output_type -> generated.AppsMongoResponse
-	7,  // 20: generated.AppsService.Similar:output_type -> generated.SimilarAppsResponse
-	18, // [18:21] is the sub-list for method output_type
-	15, // [15:18] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	18, // 10: generated.AppMongoResponse.releaseDateUnix:type_name -> google.protobuf.Timestamp
+	13, // 11: generated.AppMongoResponse.prices:type_name -> generated.AppMongoResponse.PricesEntry
+	14, // 12: generated.AppMongoResponse.priceStats:type_name -> generated.AppMongoResponse.PriceStatsEntry
+	8,  // 13: generated.SimilarAppsResponse.apps:type_name -> generated.SimilarAppResponse
+	18, // 14: generated.AppPlayersResponse.createdAt:type_name -> google.protobuf.Timestamp
+	19, // 15: generated.AppElasticResponse.PricesEntry.value:type_name -> generated.Price
+	19, // 16: generated.AppMongoResponse.PricesEntry.value:type_name -> generated.Price
+	20, // 17: generated.AppMongoResponse.PriceStatsEntry.value:type_name -> generated.PriceStat
+	0,  // 18: generated.AppsService.Search:input_type -> generated.SearchAppsRequest
+	3,  // 19: generated.AppsService.List:input_type -> generated.ListAppsRequest
+	6,  // 20: generated.AppsService.Similar:input_type -> generated.ListSimilarAppsRequest
+	9,  // 21: generated.AppsService.WatchAppPlayers:input_type -> generated.WatchAppPlayersRequest
+	1,  // 22: generated.AppsService.Search:output_type -> generated.AppsElasticResponse
+	4,  // 23: generated.AppsService.List:output_type -> generated.AppsMongoResponse
+	7,  // 24: generated.AppsService.Similar:output_type -> generated.SimilarAppsResponse
+	10, // 25: generated.AppsService.WatchAppPlayers:output_type -> generated.AppPlayersResponse
+	22, // [22:26] is the sub-list for method output_type
+	18, // [18:22] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_apps_proto_init() }
@@ -1358,6 +1541,30 @@ func file_apps_proto_init() {
 				return nil
 			}
 		}
+		file_apps_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchAppPlayersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppPlayersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1365,7 +1572,7 @@ func file_apps_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_apps_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,7 @@ type AppsServiceClient interface {
 	Search(ctx context.Context, in *SearchAppsRequest, opts ...grpc.CallOption) (*AppsElasticResponse, error)
 	List(ctx context.Context, in *ListAppsRequest, opts ...grpc.CallOption) (*AppsMongoResponse, error)
 	Similar(ctx context.Context, in *ListSimilarAppsRequest, opts ...grpc.CallOption) (*SimilarAppsResponse, error)
+	WatchAppPlayers(ctx context.Context, in *WatchAppPlayersRequest, opts ...grpc.CallOption) (AppsService_WatchAppPlayersClient, error)
 }
 
 type appsServiceClient struct {
@@ -58,6 +59,38 @@ func (c *appsServiceClient) Similar(ctx context.Context, in *ListSimilarAppsRequ
 	return out, nil
 }
 
+func (c *appsServiceClient) WatchAppPlayers(ctx context.Context, in *WatchAppPlayersRequest, opts ...grpc.CallOption) (AppsService_WatchAppPlayersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AppsService_ServiceDesc.Streams[0], "/generated.AppsService/WatchAppPlayers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &appsServiceWatchAppPlayersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AppsService_WatchAppPlayersClient interface {
+	Recv() (*AppPlayersResponse, error)
+	grpc.ClientStream
+}
+
+type appsServiceWatchAppPlayersClient struct {
+	grpc.ClientStream
+}
+
+func (x *appsServiceWatchAppPlayersClient) Recv() (*AppPlayersResponse, error) {
+	m := new(AppPlayersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AppsServiceServer is the server API for AppsService service.
 // All implementations must embed UnimplementedAppsServiceServer
 // for forward compatibility
@@ -65,6 +98,7 @@ type AppsServiceServer interface {
 	Search(context.Context, *SearchAppsRequest) (*AppsElasticResponse, error)
 	List(context.Context, *ListAppsRequest) (*AppsMongoResponse, error)
 	Similar(context.Context, *ListSimilarAppsRequest) (*SimilarAppsResponse, error)
+	WatchAppPlayers(*WatchAppPlayersRequest, AppsService_WatchAppPlayersServer) error
 	mustEmbedUnimplementedAppsServiceServer()
 }
 
@@ -81,6 +115,9 @@ func (UnimplementedAppsServiceServer) List(context.Context, *ListAppsRequest) (*
 func (UnimplementedAppsServiceServer) Similar(context.Context, *ListSimilarAppsRequest) (*SimilarAppsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Similar not implemented")
 }
+func (UnimplementedAppsServiceServer) WatchAppPlayers(*WatchAppPlayersRequest, AppsService_WatchAppPlayersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAppPlayers not implemented")
+}
 func (UnimplementedAppsServiceServer) mustEmbedUnimplementedAppsServiceServer() {}
 
 // UnsafeAppsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -148,6 +185,27 @@ func _AppsService_Similar_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AppsService_WatchAppPlayers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAppPlayersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AppsServiceServer).WatchAppPlayers(m, &appsServiceWatchAppPlayersServer{stream})
+}
+
+type AppsService_WatchAppPlayersServer interface {
+	Send(*AppPlayersResponse) error
+	grpc.ServerStream
+}
+
+type appsServiceWatchAppPlayersServer struct {
+	grpc.ServerStream
+}
+
+func (x *appsServiceWatchAppPlayersServer) Send(m *AppPlayersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // AppsService_ServiceDesc is the grpc.ServiceDesc for AppsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -168,6 +226,12 @@ var AppsService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _AppsService_Similar_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAppPlayers",
+			Handler:       _AppsService_WatchAppPlayers_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "apps.proto",
 }
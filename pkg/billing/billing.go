@@ -0,0 +1,280 @@
+package billing
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/oauth"
+	"go.uber.org/zap"
+	"golang.org/x/text/currency"
+)
+
+// Monthly prices of the levels on the donate page, in US cents
+const (
+	level1Cents = 100
+	level2Cents = 300
+	level3Cents = 1500
+)
+
+// LevelForMonthlyCents maps a monthly USD amount onto a level, the same for every provider
+func LevelForMonthlyCents(cents int) mysql.UserLevel {
+
+	switch {
+	case cents >= level3Cents:
+		return mysql.UserLevel3
+	case cents >= level2Cents:
+		return mysql.UserLevel2
+	case cents >= level1Cents:
+		return mysql.UserLevel1
+	default:
+		return mysql.UserLevelFree
+	}
+}
+
+// Update is a provider webhook boiled down to what we store.
+// A blank SubscriptionID means a one-off payment, a blank PaymentRef means nothing was charged.
+type Update struct {
+	Source string
+
+	// Who
+	Email      string
+	Provider   oauth.ProviderEnum // Linked account to look the user up by, before falling back to email
+	ProviderID string
+	Anon       bool
+
+	// Subscription
+	SubscriptionID string
+	Level          mysql.UserLevel // Guest keeps whatever level is stored
+	Cancelled      bool
+	ExpiresAt      *time.Time // Nil keeps whatever expiry is stored
+
+	// Payment
+	PaymentRef     string
+	Currency       string // ISO code
+	AmountCents    int
+	AmountUSDCents int // Converted, zero if the currency is unknown
+}
+
+// Apply saves the update and moves the user to the level their active subscriptions now give them.
+// Safe to call again with the same update, providers retry webhooks.
+func Apply(update Update) (err error) {
+
+	user, err := findUser(update)
+	if err != nil && err != mysql.ErrRecordNotFound {
+		return err
+	}
+
+	// Payment
+	if update.PaymentRef != "" {
+
+		exists, err := mysql.DonationExists(update.Source, update.PaymentRef)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			err = saveDonation(user, update)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Subscription
+	if update.SubscriptionID == "" || user.ID == 0 {
+		return nil
+	}
+
+	sub, err := mysql.GetUserSubscription(update.Source, update.SubscriptionID)
+	if err == mysql.ErrRecordNotFound {
+		sub = mysql.UserSubscription{
+			Source:     update.Source,
+			ExternalID: update.SubscriptionID,
+			Active:     true,
+		}
+	} else if err != nil {
+		return err
+	}
+
+	sub.UserID = user.ID
+	if update.Level != mysql.UserLevelGuest {
+		sub.Level = update.Level
+	}
+	if update.ExpiresAt != nil {
+		sub.ExpiresAt = update.ExpiresAt
+	}
+	if update.Cancelled {
+		sub.Active = false
+	} else if update.PaymentRef != "" {
+		sub.Active = true // A payment brings a lapsed subscription back
+	}
+
+	err = mysql.SaveUserSubscription(sub)
+	if err != nil {
+		return err
+	}
+
+	return Recalculate(user)
+}
+
+// Recalculate sets the user's level from their subscriptions, recording an event if it changed
+func Recalculate(user mysql.User) (err error) {
+
+	oldLevel, newLevel, err := mysql.RecalculateUserLevel(user.ID)
+	if err != nil || oldLevel == newLevel {
+		return err
+	}
+
+	err = mongo.NewEvent(nil, user.ID, mongo.EventSupporterLevel)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Supporter level changed", zap.Int("user", user.ID), zap.Int("old", int(oldLevel)), zap.Int("new", int(newLevel)))
+
+	// API level is cached by key
+	if user.APIKey != "" {
+		err = memcache.Client().Delete(memcache.ItemUserByAPIKey(user.APIKey).Key)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+
+	return nil
+}
+
+func findUser(update Update) (user mysql.User, err error) {
+
+	// Later webhooks for a subscription may not say who it belongs to
+	if update.SubscriptionID != "" {
+		sub, err := mysql.GetUserSubscription(update.Source, update.SubscriptionID)
+		if err == nil {
+			return mysql.GetUserByID(sub.UserID)
+		} else if err != mysql.ErrRecordNotFound {
+			return user, err
+		}
+	}
+
+	if update.Provider != "" && update.ProviderID != "" {
+		user, err = mysql.GetUserByProviderID(update.Provider, update.ProviderID)
+		if err != mysql.ErrRecordNotFound {
+			return user, err
+		}
+	}
+
+	if update.Email == "" {
+		return user, mysql.ErrRecordNotFound
+	}
+
+	return mysql.GetUserByEmail(update.Email)
+}
+
+func saveDonation(user mysql.User, update Update) (err error) {
+
+	var playerID int64
+
+	if user.ID > 0 {
+		steam, err := mysql.GetUserProviderByUserID(oauth.ProviderSteam, user.ID)
+		if err != nil && err != mysql.ErrRecordNotFound {
+			return err
+		} else if err == nil {
+			playerID, err = strconv.ParseInt(steam.ID, 10, 64)
+			if err != nil {
+				log.ErrS(err)
+			}
+		}
+	}
+
+	var email = user.Email
+	if email == "" {
+		email = update.Email
+	}
+
+	var code = strings.ToUpper(update.Currency)
+	if code == "" {
+		code = currency.USD.String()
+	}
+
+	db, err := mysql.GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	donation := mysql.Donation{
+		UserID:           user.ID,
+		PlayerID:         playerID,
+		Email:            email,
+		AmountUSD:        update.AmountUSDCents,
+		OriginalCurrency: code,
+		OriginalAmount:   update.AmountCents,
+		Source:           update.Source,
+		Anon:             update.Anon,
+		ExternalRef:      update.PaymentRef,
+	}
+
+	return db.Create(&donation).Error
+}
+
+// Rough US dollars per unit of each currency, only used to pick a level and total up donations.
+// Zero-decimal currencies like JPY are left out, their amounts aren't in cents.
+var usdRates = map[string]float64{
+	"USD": 1,
+	"EUR": 1.08,
+	"GBP": 1.26,
+	"CAD": 0.73,
+	"AUD": 0.66,
+	"NZD": 0.60,
+	"CHF": 1.12,
+	"SEK": 0.095,
+	"NOK": 0.093,
+	"DKK": 0.145,
+	"PLN": 0.25,
+	"CZK": 0.043,
+	"BRL": 0.19,
+	"MXN": 0.058,
+	"SGD": 0.74,
+	"HKD": 0.128,
+}
+
+// usdCents converts an amount into US cents, currencies we don't know come back as zero
+func usdCents(code string, cents int) int {
+
+	rate, ok := usdRates[strings.ToUpper(code)]
+	if !ok {
+		log.Warn("Unknown currency", zap.String("currency", code))
+		return 0
+	}
+
+	return int(float64(cents)*rate + 0.5)
+}
+
+var levelNameRegex = regexp.MustCompile(`(?i)^\s*(?:level\s*)?([1-3])\s*$`)
+
+// levelFromName reads a level from a tier name or price metadata, like "Level 2" or "2"
+func levelFromName(name string) (level mysql.UserLevel, ok bool) {
+
+	match := levelNameRegex.FindStringSubmatch(name)
+	if match == nil {
+		return level, false
+	}
+
+	i, _ := strconv.Atoi(match[1])
+	return mysql.UserLevelFree + mysql.UserLevel(i), true
+}
+
+// Parses "3.00" style amounts
+func parseCents(amount string) (int, error) {
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(f*100 + 0.5), nil
+}
@@ -0,0 +1,260 @@
+package billing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+func sign(secret string, parts ...string) string {
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	for _, part := range parts {
+		mac.Write([]byte(part))
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestLevelForMonthlyCents(t *testing.T) {
+
+	tests := map[int]mysql.UserLevel{
+		0:    mysql.UserLevelFree,
+		99:   mysql.UserLevelFree,
+		100:  mysql.UserLevel1,
+		299:  mysql.UserLevel1,
+		300:  mysql.UserLevel2,
+		1499: mysql.UserLevel2,
+		1500: mysql.UserLevel3,
+		5000: mysql.UserLevel3,
+	}
+
+	for cents, level := range tests {
+		if got := LevelForMonthlyCents(cents); got != level {
+			t.Errorf("%d cents: expected %d, got %d", cents, level, got)
+		}
+	}
+}
+
+func TestVerifyStripe(t *testing.T) {
+
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Unix(1600000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	valid := "t=" + ts + ",v1=" + sign("whsec", ts, ".", string(body))
+
+	tests := []struct {
+		name   string
+		header string
+		secret string
+		now    time.Time
+		err    error
+	}{
+		{"valid", valid, "whsec", now, nil},
+		{"valid with old secret alongside", "t=" + ts + ",v1=deadbeef,v1=" + sign("whsec", ts, ".", string(body)), "whsec", now, nil},
+		{"wrong secret", valid, "other", now, ErrInvalidSignature},
+		{"replayed", valid, "whsec", now.Add(time.Hour), ErrInvalidSignature},
+		{"no timestamp", "v1=" + sign("whsec", ts, ".", string(body)), "whsec", now, ErrInvalidSignature},
+		{"empty", "", "whsec", now, ErrInvalidSignature},
+		{"no secret configured", valid, "", now, ErrMissingSecret},
+	}
+
+	for _, test := range tests {
+		if err := VerifyStripe(body, test.header, test.secret, test.now); err != test.err {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestParseStripe(t *testing.T) {
+
+	body := []byte(`{
+		"id": "evt_1",
+		"type": "invoice.paid",
+		"data": {"object": {
+			"id": "in_1",
+			"customer_email": "a@example.com",
+			"subscription": "sub_1",
+			"amount_paid": 3600,
+			"currency": "usd",
+			"lines": {"data": [{"period": {"end": 1600000000}, "price": {"recurring": {"interval": "year", "interval_count": 1}}}]}
+		}}
+	}`)
+
+	event, updates, err := ParseStripe(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event != "invoice.paid" || len(updates) != 1 {
+		t.Fatalf("expected one update from invoice.paid, got %s %d", event, len(updates))
+	}
+
+	update := updates[0]
+	if update.SubscriptionID != "sub_1" || update.PaymentRef != "in_1" || update.Email != "a@example.com" {
+		t.Errorf("unexpected update %+v", update)
+	}
+	if update.Level != mysql.UserLevel2 {
+		t.Errorf("$36 a year is $3 a month, expected level 2, got %d", update.Level)
+	}
+	if update.AmountUSDCents != 3600 || update.ExpiresAt == nil || !update.ExpiresAt.After(time.Unix(1600000000, 0)) {
+		t.Errorf("unexpected amount or expiry %+v", update)
+	}
+
+	// Euros are converted for the level
+	_, updates, err = ParseStripe([]byte(`{"type": "invoice.paid", "data": {"object": {"id": "in_2", "subscription": "sub_2", "amount_paid": 1500, "currency": "eur", "lines": {"data": [{"price": {"recurring": {"interval": "month"}}}]}}}}`))
+	if err != nil || len(updates) != 1 || updates[0].AmountUSDCents != 1620 || updates[0].AmountCents != 1500 || updates[0].Level != mysql.UserLevel3 {
+		t.Errorf("unexpected euro update %+v %v", updates, err)
+	}
+
+	// The price's level wins over the amount
+	_, updates, err = ParseStripe([]byte(`{"type": "invoice.paid", "data": {"object": {"id": "in_3", "subscription": "sub_3", "amount_paid": 250, "currency": "gbp", "lines": {"data": [{"price": {"metadata": {"level": "2"}, "recurring": {"interval": "month"}}}]}}}}`))
+	if err != nil || len(updates) != 1 || updates[0].Level != mysql.UserLevel2 {
+		t.Errorf("unexpected price level update %+v %v", updates, err)
+	}
+
+	// Unknown currencies are still recorded
+	_, updates, err = ParseStripe([]byte(`{"type": "invoice.paid", "data": {"object": {"id": "in_4", "subscription": "sub_4", "amount_paid": 1500, "currency": "xyz", "lines": {"data": [{"price": {"recurring": {"interval": "month"}}}]}}}}`))
+	if err != nil || len(updates) != 1 || updates[0].AmountUSDCents != 0 || updates[0].AmountCents != 1500 || updates[0].Level != mysql.UserLevelFree {
+		t.Errorf("unexpected unknown currency update %+v %v", updates, err)
+	}
+
+	// Cancelled
+	_, updates, err = ParseStripe([]byte(`{"type": "customer.subscription.deleted", "data": {"object": {"id": "sub_1", "status": "canceled"}}}`))
+	if err != nil || len(updates) != 1 || !updates[0].Cancelled || updates[0].SubscriptionID != "sub_1" {
+		t.Errorf("unexpected cancellation %+v %v", updates, err)
+	}
+
+	// Still active, nothing to do
+	_, updates, err = ParseStripe([]byte(`{"type": "customer.subscription.updated", "data": {"object": {"id": "sub_1", "status": "active"}}}`))
+	if err != nil || len(updates) != 0 {
+		t.Errorf("expected no updates, got %+v %v", updates, err)
+	}
+}
+
+func TestParseKofi(t *testing.T) {
+
+	data := `{
+		"verification_token": "token",
+		"message_id": "msg_1",
+		"timestamp": "2020-09-13T12:26:40Z",
+		"type": "Subscription",
+		"is_public": false,
+		"email": "Supporter@Example.com",
+		"amount": "15.00",
+		"currency": "USD",
+		"is_subscription_payment": true,
+		"kofi_transaction_id": "txn_1"
+	}`
+
+	_, updates, err := ParseKofi(data, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected one update, got %d", len(updates))
+	}
+
+	update := updates[0]
+	if update.Level != mysql.UserLevel3 || update.AmountUSDCents != 1500 || update.PaymentRef != "txn_1" || !update.Anon {
+		t.Errorf("unexpected update %+v", update)
+	}
+	if update.SubscriptionID != "supporter@example.com" || update.ExpiresAt == nil {
+		t.Errorf("expected a subscription with an expiry, got %+v", update)
+	}
+
+	_, _, err = ParseKofi(data, "wrong")
+	if err != ErrInvalidSignature {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	_, _, err = ParseKofi(data, "")
+	if err != ErrMissingSecret {
+		t.Errorf("expected ErrMissingSecret, got %v", err)
+	}
+
+	// Pounds, with a tier named after a level
+	_, updates, err = ParseKofi(`{"verification_token": "token", "type": "Subscription", "email": "b@example.com", "amount": "3.00", "currency": "GBP", "is_subscription_payment": true, "tier_name": "Level 1"}`, "token")
+	if err != nil || len(updates) != 1 || updates[0].AmountUSDCents != 378 || updates[0].Level != mysql.UserLevel1 {
+		t.Errorf("unexpected pound update %+v %v", updates, err)
+	}
+
+	// Pounds, without a tier
+	_, updates, err = ParseKofi(`{"verification_token": "token", "type": "Subscription", "email": "b@example.com", "amount": "3.00", "currency": "GBP", "is_subscription_payment": true}`, "token")
+	if err != nil || len(updates) != 1 || updates[0].Level != mysql.UserLevel2 {
+		t.Errorf("unexpected pound update %+v %v", updates, err)
+	}
+
+	// Shop orders don't count
+	_, updates, err = ParseKofi(`{"verification_token": "token", "type": "Shop Order", "amount": "5.00"}`, "token")
+	if err != nil || len(updates) != 0 {
+		t.Errorf("expected no updates for a shop order, got %+v %v", updates, err)
+	}
+}
+
+func TestVerifyGitHub(t *testing.T) {
+
+	body := []byte(`{"action":"created"}`)
+	valid := "sha256=" + sign("secret", string(body))
+
+	if err := VerifyGitHub(body, valid, "secret"); err != nil {
+		t.Errorf("expected valid, got %v", err)
+	}
+	if err := VerifyGitHub(body, valid, "other"); err != ErrInvalidSignature {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+	if err := VerifyGitHub(body, "sha1=abc", "secret"); err != ErrInvalidSignature {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+	if err := VerifyGitHub(body, valid, ""); err != ErrMissingSecret {
+		t.Errorf("expected ErrMissingSecret, got %v", err)
+	}
+}
+
+func TestParseGitHub(t *testing.T) {
+
+	body := func(action string, cents int, oneTime bool) []byte {
+		return []byte(`{
+			"action": "` + action + `",
+			"sponsorship": {
+				"node_id": "S_1",
+				"privacy_level": "public",
+				"sponsor": {"id": 42, "login": "someone"},
+				"tier": {"monthly_price_in_cents": ` + strconv.Itoa(cents) + `, "is_one_time": ` + strconv.FormatBool(oneTime) + `}
+			}
+		}`)
+	}
+
+	_, updates, err := ParseGitHub("sponsorship", body("created", 500, false))
+	if err != nil || len(updates) != 1 {
+		t.Fatalf("expected one update, got %+v %v", updates, err)
+	}
+
+	update := updates[0]
+	if update.ProviderID != "42" || update.SubscriptionID != "S_1" || update.PaymentRef != "S_1" || update.Level != mysql.UserLevel2 {
+		t.Errorf("unexpected update %+v", update)
+	}
+
+	_, updates, _ = ParseGitHub("sponsorship", body("created", 2000, true))
+	if len(updates) != 1 || updates[0].SubscriptionID != "" || updates[0].AmountUSDCents != 2000 {
+		t.Errorf("one-time sponsorships are payments only, got %+v", updates)
+	}
+
+	_, updates, _ = ParseGitHub("sponsorship", body("cancelled", 500, false))
+	if len(updates) != 1 || !updates[0].Cancelled || updates[0].PaymentRef != "" {
+		t.Errorf("expected a cancellation, got %+v", updates)
+	}
+
+	_, updates, _ = ParseGitHub("sponsorship", body("pending_cancellation", 500, false))
+	if len(updates) != 0 {
+		t.Errorf("pending changes should wait, got %+v", updates)
+	}
+
+	_, updates, _ = ParseGitHub("ping", []byte(`{}`))
+	if len(updates) != 0 {
+		t.Errorf("expected no updates for a ping, got %+v", updates)
+	}
+}
@@ -0,0 +1,115 @@
+package billing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/oauth"
+)
+
+// VerifyGitHub checks an X-Hub-Signature-256 header, "sha256=<hex hmac-sha256 of body>"
+func VerifyGitHub(body []byte, header string, secret string) error {
+
+	if secret == "" {
+		return ErrMissingSecret
+	}
+
+	if !strings.HasPrefix(header, "sha256=") {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(header)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+type gitHubSponsorship struct {
+	Action      string `json:"action"`
+	Sponsorship struct {
+		NodeID       string `json:"node_id"`
+		PrivacyLevel string `json:"privacy_level"`
+		Sponsor      struct {
+			ID    int64  `json:"id"`
+			Login string `json:"login"`
+		} `json:"sponsor"`
+		Tier struct {
+			NodeID              string `json:"node_id"`
+			MonthlyPriceInCents int    `json:"monthly_price_in_cents"`
+			IsOneTime           bool   `json:"is_one_time"`
+		} `json:"tier"`
+	} `json:"sponsorship"`
+}
+
+// ParseGitHub reads a `sponsorship` event. GitHub only sends the first payment of a
+// monthly sponsorship, after that it stays active until a `cancelled` event.
+func ParseGitHub(event string, body []byte) (action string, updates []Update, err error) {
+
+	if event != "sponsorship" {
+		return "", nil, nil
+	}
+
+	payload := gitHubSponsorship{}
+	err = json.Unmarshal(body, &payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sponsorship := payload.Sponsorship
+
+	update := Update{
+		Source:     mysql.DonationSourceGitHub,
+		Provider:   oauth.ProviderGithub,
+		ProviderID: strconv.FormatInt(sponsorship.Sponsor.ID, 10),
+		Anon:       sponsorship.PrivacyLevel == "private",
+	}
+
+	if !sponsorship.Tier.IsOneTime {
+		update.SubscriptionID = sponsorship.NodeID
+	}
+
+	switch payload.Action {
+	case "created":
+
+		update.PaymentRef = sponsorship.NodeID
+		update.Currency = "USD"
+		update.AmountCents = sponsorship.Tier.MonthlyPriceInCents
+		update.AmountUSDCents = sponsorship.Tier.MonthlyPriceInCents
+
+		if !sponsorship.Tier.IsOneTime {
+			update.Level = LevelForMonthlyCents(sponsorship.Tier.MonthlyPriceInCents)
+		}
+
+	case "tier_changed":
+
+		if sponsorship.Tier.IsOneTime {
+			return payload.Action, nil, nil
+		}
+
+		update.Level = LevelForMonthlyCents(sponsorship.Tier.MonthlyPriceInCents)
+
+	case "cancelled":
+
+		if sponsorship.Tier.IsOneTime {
+			return payload.Action, nil, nil
+		}
+
+		update.Cancelled = true
+
+	default:
+		// Pending changes get a second event when they happen
+		return payload.Action, nil, nil
+	}
+
+	return payload.Action, []Update{update}, nil
+}
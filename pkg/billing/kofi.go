@@ -0,0 +1,95 @@
+package billing
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+// Ko-fi never tells us a membership has ended, so each payment buys a month and a few days
+const kofiPeriod = time.Hour * 24 * 35
+
+type kofiPayload struct {
+	VerificationToken          string    `json:"verification_token"`
+	MessageID                  string    `json:"message_id"`
+	Timestamp                  time.Time `json:"timestamp"`
+	Type                       string    `json:"type"`
+	IsPublic                   bool      `json:"is_public"`
+	Email                      string    `json:"email"`
+	Amount                     string    `json:"amount"`
+	Currency                   string    `json:"currency"`
+	IsSubscriptionPayment      bool      `json:"is_subscription_payment"`
+	IsFirstSubscriptionPayment bool      `json:"is_first_subscription_payment"`
+	KofiTransactionID          string    `json:"kofi_transaction_id"`
+	TierName                   string    `json:"tier_name"`
+}
+
+// ParseKofi reads the `data` form value Ko-fi posts. Ko-fi doesn't sign requests,
+// instead every payload carries the account's verification token.
+func ParseKofi(data string, token string) (event string, updates []Update, err error) {
+
+	if token == "" {
+		return "", nil, ErrMissingSecret
+	}
+
+	payload := kofiPayload{}
+	err = json.Unmarshal([]byte(data), &payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(payload.VerificationToken), []byte(token)) != 1 {
+		return payload.Type, nil, ErrInvalidSignature
+	}
+
+	// Shop orders and commissions are sales, not support
+	switch payload.Type {
+	case "Donation", "Subscription":
+	default:
+		return payload.Type, nil, nil
+	}
+
+	cents, err := parseCents(payload.Amount)
+	if err != nil {
+		return payload.Type, nil, err
+	}
+
+	ref := payload.KofiTransactionID
+	if ref == "" {
+		ref = payload.MessageID
+	}
+
+	update := Update{
+		Source:         mysql.DonationSourceKofi,
+		Email:          payload.Email,
+		Anon:           !payload.IsPublic,
+		PaymentRef:     ref,
+		Currency:       payload.Currency,
+		AmountCents:    cents,
+		AmountUSDCents: usdCents(payload.Currency, cents),
+	}
+
+	if payload.IsSubscriptionPayment && payload.Email != "" {
+
+		paid := payload.Timestamp
+		if paid.IsZero() {
+			paid = time.Now()
+		}
+		expires := paid.Add(kofiPeriod)
+
+		// Ko-fi has no membership ID, there is one membership per supporter
+		update.SubscriptionID = strings.ToLower(payload.Email)
+		update.Level = LevelForMonthlyCents(update.AmountUSDCents)
+
+		// Tiers named after a level win, they're priced in the supporter's currency
+		if level, ok := levelFromName(payload.TierName); ok {
+			update.Level = level
+		}
+		update.ExpiresAt = &expires
+	}
+
+	return payload.Type, []Update{update}, nil
+}
@@ -0,0 +1,198 @@
+package billing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+const (
+	stripeTolerance = time.Minute * 5
+	stripeGrace     = time.Hour * 24 * 3 // Stripe retries failed cards for a few days before giving up
+)
+
+var (
+	ErrMissingSecret    = errors.New("missing webhook secret")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// VerifyStripe checks a Stripe-Signature header, "t=<unix>,v1=<hex hmac-sha256 of t.body>"
+func VerifyStripe(body []byte, header string, secret string, now time.Time) error {
+
+	if secret == "" {
+		return ErrMissingSecret
+	}
+
+	var timestamp string
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	// Stops an old, captured request being replayed
+	if diff := now.Sub(time.Unix(unix, 0)); diff > stripeTolerance || diff < -stripeTolerance {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+type stripeEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+type stripeInvoice struct {
+	ID            string `json:"id"`
+	CustomerEmail string `json:"customer_email"`
+	Subscription  string `json:"subscription"`
+	AmountPaid    int    `json:"amount_paid"`
+	Currency      string `json:"currency"`
+	Lines         struct {
+		Data []struct {
+			Period struct {
+				End int64 `json:"end"`
+			} `json:"period"`
+			Price struct {
+				Metadata  map[string]string `json:"metadata"` // A "level" key picks the level, whatever the amount
+				Recurring struct {
+					Interval      string `json:"interval"`
+					IntervalCount int    `json:"interval_count"`
+				} `json:"recurring"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"lines"`
+}
+
+type stripeSubscription struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// ParseStripe returns the event type and what it changes, unhandled types return no updates
+func ParseStripe(body []byte) (event string, updates []Update, err error) {
+
+	e := stripeEvent{}
+	err = json.Unmarshal(body, &e)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch e.Type {
+	case "invoice.paid":
+
+		invoice := stripeInvoice{}
+		err = json.Unmarshal(e.Data.Object, &invoice)
+		if err != nil {
+			return e.Type, nil, err
+		}
+
+		if invoice.AmountPaid <= 0 {
+			return e.Type, nil, nil
+		}
+
+		update := Update{
+			Source:         mysql.DonationSourceStripe,
+			Email:          invoice.CustomerEmail,
+			SubscriptionID: invoice.Subscription,
+			PaymentRef:     invoice.ID,
+			Currency:       invoice.Currency,
+			AmountCents:    invoice.AmountPaid,
+			AmountUSDCents: usdCents(invoice.Currency, invoice.AmountPaid),
+		}
+
+		if invoice.Subscription != "" && len(invoice.Lines.Data) > 0 {
+
+			line := invoice.Lines.Data[0]
+
+			update.Level = LevelForMonthlyCents(update.AmountUSDCents / stripeMonths(line.Price.Recurring.Interval, line.Price.Recurring.IntervalCount))
+			if level, ok := levelFromName(line.Price.Metadata["level"]); ok {
+				update.Level = level
+			}
+
+			if line.Period.End > 0 {
+				expires := time.Unix(line.Period.End, 0).Add(stripeGrace)
+				update.ExpiresAt = &expires
+			}
+		}
+
+		return e.Type, []Update{update}, nil
+
+	case "customer.subscription.updated", "customer.subscription.deleted":
+
+		sub := stripeSubscription{}
+		err = json.Unmarshal(e.Data.Object, &sub)
+		if err != nil {
+			return e.Type, nil, err
+		}
+
+		// Renewals come through as paid invoices, only stopping matters here
+		switch sub.Status {
+		case "canceled", "unpaid", "incomplete_expired":
+		default:
+			if e.Type != "customer.subscription.deleted" {
+				return e.Type, nil, nil
+			}
+		}
+
+		return e.Type, []Update{{
+			Source:         mysql.DonationSourceStripe,
+			SubscriptionID: sub.ID,
+			Cancelled:      true,
+		}}, nil
+	}
+
+	return e.Type, nil, nil
+}
+
+func stripeMonths(interval string, count int) int {
+
+	if count < 1 {
+		count = 1
+	}
+
+	switch interval {
+	case "year":
+		return 12 * count
+	case "month":
+		return count
+	default:
+		return 1 // Weekly and daily plans just count as monthly
+	}
+}
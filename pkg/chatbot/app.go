@@ -1,6 +1,8 @@
 package chatbot
 
 import (
+	"context"
+
 	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/bwmarrin/discordgo"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
@@ -78,7 +80,7 @@ func (c CommandApp) Output(_ string, region steamapi.ProductCC, inputs map[strin
 		return message, nil
 	}
 
-	app, err := mongo.GetApp(apps[0].ID)
+	app, err := mongo.GetApp(context.Background(), apps[0].ID)
 	if err != nil {
 		return message, err
 	}
@@ -1,6 +1,8 @@
 package chatbot
 
 import (
+	"context"
+
 	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/bwmarrin/discordgo"
 	"github.com/dustin/go-humanize"
@@ -80,7 +82,7 @@ func (c CommandAppFollowers) Output(_ string, _ steamapi.ProductCC, inputs map[s
 		return message, nil
 	}
 
-	app, err := mongo.GetApp(apps[0].ID)
+	app, err := mongo.GetApp(context.Background(), apps[0].ID)
 	if err != nil {
 		return message, err
 	}
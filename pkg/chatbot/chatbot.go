@@ -1,6 +1,7 @@
 package chatbot
 
 import (
+	"context"
 	"regexp"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/steam"
 	"go.uber.org/zap"
 )
@@ -64,29 +66,30 @@ type Command interface {
 
 // These are the discord slash command names, if changed, the old one needs to be deleted
 const (
-	CApp            = "game"            //
-	CAppFollowers   = "followers"       //
-	CAppPlayers     = "players"         //
-	CAppPrice       = "price"           //
-	CAppsRandom     = "random"          //
-	CAppsNew        = "new"             //
-	CAppsPopular    = "top"             //
-	CAppsTrending   = "trending-games"  //
-	CGroup          = "group"           //
-	CGroupsTrending = "trending-groups" //
-	CPlayer         = "player"          //
-	CPlayerApps     = "games"           // Count
-	CPlayerLevel    = "level"           //
-	CPlayerPlaytime = "playtime"        //
-	CPlayerRecent   = "recent"          //
-	CPlayerUpdate   = "update"          //
-	CPlayerWishlist = "wishlist"        //
-	CPlayerLibrary  = "library"         //
-	CHelp           = "help"            //
-	CFeedback       = "feedback"        //
-	CInvite         = "invite"          //
-	CSettings       = "settings"        //
-	CSteamOnline    = "online"          //
+	CApp             = "game"            //
+	CAppFollowers    = "followers"       //
+	CAppPlayers      = "players"         //
+	CAppPrice        = "price"           //
+	CAppsRandom      = "random"          //
+	CAppsNew         = "new"             //
+	CAppsPopular     = "top"             //
+	CAppsTrending    = "trending-games"  //
+	CGroup           = "group"           //
+	CGroupsTrending  = "trending-groups" //
+	CPlayer          = "player"          //
+	CPlayerApps      = "games"           // Count
+	CPlayerLevel     = "level"           //
+	CPlayerPlaytime  = "playtime"        //
+	CPlayerRecommend = "recommend"       //
+	CPlayerRecent    = "recent"          //
+	CPlayerUpdate    = "update"          //
+	CPlayerWishlist  = "wishlist"        //
+	CPlayerLibrary   = "library"         //
+	CHelp            = "help"            //
+	CFeedback        = "feedback"        //
+	CInvite          = "invite"          //
+	CSettings        = "settings"        //
+	CSteamOnline     = "online"          //
 )
 
 var CommandRegister = []Command{
@@ -105,6 +108,7 @@ var CommandRegister = []Command{
 	&CommandPlayerApps{},
 	&CommandPlayerLevel{},
 	&CommandPlayerPlaytime{},
+	&CommandPlayerRecommend{},
 	&CommandPlayerRecent{},
 	&CommandPlayerLibrary{},
 	&CommandPlayerUpdate{},
@@ -241,7 +245,7 @@ func getAppEmbed(commandID string, app App, code steamapi.ProductCC) *discordgo.
 func searchForPlayer(search string) (player elasticsearch.Player, err error) {
 
 	// Check Elastic
-	players, _, err := elasticsearch.SearchPlayers(1, 0, search, nil, nil)
+	players, _, err := elasticsearch.SearchPlayers(context.Background(), 1, 0, search, nil, nil)
 	if err != nil {
 		return player, err
 	}
@@ -269,8 +273,14 @@ func searchForPlayer(search string) (player elasticsearch.Player, err error) {
 		// Friends:     tempPlayer.Friends,
 	}
 
+	// Players hidden from Elastic still get their playtime hidden
+	mongoPlayer, err := mongo.GetPlayer(context.Background(), player.ID)
+	if err == nil && mongoPlayer.Privacy.HidePlaytime {
+		player.PlayTime = 0
+	}
+
 	// Queue
-	err = consumers.ProducePlayer(consumers.PlayerMessage{ID: player.ID}, "chatbot-player")
+	err = consumers.ProducePlayer(context.Background(), consumers.PlayerMessage{ID: player.ID}, "chatbot-player")
 	err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 	if err != nil {
 		log.Err("Producing player", zap.Error(err))
@@ -1,6 +1,7 @@
 package chatbot
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -95,7 +96,7 @@ func (c CommandPlayer) Output(authorID string, _ steamapi.ProductCC, inputs map[
 			return message, nil
 		}
 
-		player, err = mongo.GetPlayer(i)
+		player, err = mongo.GetPlayer(context.Background(), i)
 		if err != nil {
 			message.Content = "We had trouble finding your profile on Global Steam"
 			return message, nil
@@ -1,6 +1,7 @@
 package chatbot
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -85,7 +86,7 @@ func (c CommandPlayerRecent) Output(authorID string, _ steamapi.ProductCC, input
 		return message, err
 	}
 
-	recent, err := mongo.GetRecentApps(player.ID, 0, 10, bson.D{{"playtime_2_weeks", -1}})
+	recent, err := mongo.GetRecentApps(context.Background(), player.ID, 0, 10, bson.D{{"playtime_2_weeks", -1}})
 	if err != nil {
 		return message, err
 	}
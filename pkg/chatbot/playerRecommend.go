@@ -0,0 +1,147 @@
+package chatbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jleagle/steam-go/steamapi"
+	"github.com/bwmarrin/discordgo"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/elasticsearch"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/oauth"
+)
+
+type CommandPlayerRecommend struct {
+}
+
+func (c CommandPlayerRecommend) ID() string {
+	return CPlayerRecommend
+}
+
+func (CommandPlayerRecommend) Regex() string {
+	return `^[.|!]recommend\s?(.+)?`
+}
+
+func (CommandPlayerRecommend) DisableCache() bool {
+	return false
+}
+
+func (CommandPlayerRecommend) PerProdCode() bool {
+	return false
+}
+
+func (CommandPlayerRecommend) AllowDM() bool {
+	return false
+}
+
+func (CommandPlayerRecommend) Example() string {
+	return ".recommend {player}?"
+}
+
+func (CommandPlayerRecommend) Description() string {
+	return "Recommends games a player does not own yet"
+}
+
+func (CommandPlayerRecommend) Type() CommandType {
+	return TypePlayer
+}
+
+func (c CommandPlayerRecommend) LegacyInputs(input string) map[string]string {
+
+	matches := RegexCache[c.Regex()].FindStringSubmatch(input)
+
+	return map[string]string{
+		"player": matches[1],
+	}
+}
+
+func (c CommandPlayerRecommend) Slash() []*discordgo.ApplicationCommandOption {
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Name:        "player",
+			Description: "The name or ID of the player, defaults to you",
+			Type:        discordgo.ApplicationCommandOptionString,
+			Required:    false,
+		},
+	}
+}
+
+func (c CommandPlayerRecommend) Output(authorID string, _ steamapi.ProductCC, inputs map[string]string) (message discordgo.MessageSend, err error) {
+
+	var player Player
+	var playerID int64
+
+	if inputs["player"] == "" {
+
+		provider, err := mysql.GetUserProviderByProviderID(oauth.ProviderDiscord, authorID)
+		if err != nil {
+			message.Content = "Please connect your Discord account first: <" + config.C.GlobalSteamDomain + "/oauth/out/discord?page=settings>"
+			return message, nil
+		}
+
+		provider, err = mysql.GetUserProviderByUserID(oauth.ProviderSteam, provider.UserID)
+		if err != nil {
+			message.Content = "Please connect your Steam account first: <" + config.C.GlobalSteamDomain + "/oauth/out/steam?page=settings>"
+			return message, nil
+		}
+
+		playerID, err = strconv.ParseInt(provider.ID, 10, 64)
+		if err != nil || playerID == 0 {
+			message.Content = "We had trouble finding your profile on Global Steam"
+			return message, nil
+		}
+
+		player, err = mongo.GetPlayer(context.Background(), playerID)
+		if err != nil {
+			message.Content = "We had trouble finding your profile on Global Steam"
+			return message, nil
+		}
+
+	} else {
+
+		esPlayer, err := searchForPlayer(inputs["player"])
+		if err == elasticsearch.ErrNoResult || err == steamapi.ErrProfileMissing {
+
+			message.Content = "Player **" + inputs["player"] + "** not found, they may be set to private, please enter a user's vanity URL"
+			return message, nil
+
+		} else if err != nil {
+			return message, err
+		}
+
+		player = esPlayer
+		playerID = esPlayer.ID
+	}
+
+	recs, err := mongo.GetPlayerRecommendations(playerID, 10)
+	if err != nil {
+		return message, err
+	}
+
+	if len(recs) > 0 {
+
+		var code []string
+		for k, rec := range recs {
+			code = append(code, fmt.Sprintf("%2d", k+1)+": "+rec.GetName())
+		}
+
+		message.Embed = &discordgo.MessageEmbed{
+			Title:       "Recommended Games",
+			URL:         player.GetPathAbsolute() + "#games",
+			Author:      getAuthor(authorID),
+			Color:       greenHexDec,
+			Thumbnail:   &discordgo.MessageEmbedThumbnail{URL: player.GetAvatarAbsolute(), Width: 184, Height: 184},
+			Description: "```" + strings.Join(code, "\n") + "```",
+		}
+
+	} else {
+		message.Content = player.GetName() + " has no recommendations yet, or a profile set to private"
+	}
+
+	return message, nil
+}
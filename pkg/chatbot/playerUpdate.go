@@ -1,6 +1,7 @@
 package chatbot
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/Jleagle/steam-go/steamapi"
@@ -85,7 +86,7 @@ func (c CommandPlayerUpdate) Output(authorID string, _ steamapi.ProductCC, input
 		playerID := mysql.GetUserSteamID(user.ID)
 		if playerID > 0 {
 
-			err = consumers.ProducePlayer(consumers.PlayerMessage{ID: playerID, ForceAchievementsRefresh: true}, "chatbot-player.update")
+			err = consumers.ProducePlayer(context.Background(), consumers.PlayerMessage{ID: playerID, ForceAchievementsRefresh: true}, "chatbot-player.update")
 			err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 			if err != nil {
 				log.ErrS(err)
@@ -108,7 +109,7 @@ func (c CommandPlayerUpdate) Output(authorID string, _ steamapi.ProductCC, input
 		return message, err
 	}
 
-	err = consumers.ProducePlayer(consumers.PlayerMessage{ID: player.ID, ForceAchievementsRefresh: true}, "chatbot-player.update")
+	err = consumers.ProducePlayer(context.Background(), consumers.PlayerMessage{ID: player.ID, ForceAchievementsRefresh: true}, "chatbot-player.update")
 	err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 	if err != nil {
 		log.ErrS(err)
@@ -1,6 +1,7 @@
 package chatbot
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -85,7 +86,7 @@ func (c CommandPlayerWishlist) Output(authorID string, _ steamapi.ProductCC, inp
 		return message, err
 	}
 
-	wishlistApps, err := mongo.GetPlayerWishlistAppsByPlayer(player.ID, 0, 10, bson.D{{"order", 1}}, nil)
+	wishlistApps, err := mongo.GetPlayerWishlistAppsByPlayer(context.Background(), player.ID, 0, 10, bson.D{{"order", 1}}, nil)
 	if err != nil {
 		return message, err
 	}
@@ -26,6 +26,13 @@ type Config struct {
 	AdminName  string `envconfig:"ADMIN_NAME"`
 	AdminEmail string `envconfig:"ADMIN_EMAIL"`
 
+	// Archive
+	ArchivePath       string `envconfig:"ARCHIVE_PATH"` // Local directory, or s3://bucket/prefix
+	ArchiveS3Endpoint string `envconfig:"ARCHIVE_S3_ENDPOINT"`
+	ArchiveS3Region   string `envconfig:"ARCHIVE_S3_REGION" default:"us-east-1"`
+	ArchiveS3Key      string `envconfig:"ARCHIVE_S3_KEY"`
+	ArchiveS3Secret   string `envconfig:"ARCHIVE_S3_SECRET"`
+
 	// Battlenet
 	BattlenetClient string `envconfig:"BATTLENET_CLIENT_ID"`     // OAuth
 	BattlenetSecret string `envconfig:"BATTLENET_CLIENT_SECRET"` // OAuth
@@ -56,6 +63,7 @@ type Config struct {
 	GitHubSecret        string `envconfig:"GITHUB_SECRET"`         // OAuth
 	GithubToken         string `envconfig:"GITHUB_TOKEN"`          // API
 	GithubWebhookSecret string `envconfig:"GITHUB_WEBHOOK_SECRET"` // Webhooks
+	GithubSponsorSecret string `envconfig:"GITHUB_SPONSOR_SECRET"` // Sponsors webhooks
 
 	// Google
 	GoogleOauthClientID     string `envconfig:"GOOGLE_OAUTH_CLIENT_ID"`     // OAuth
@@ -81,6 +89,9 @@ type Config struct {
 	InstagramUsername string `envconfig:"INSTAGRAM_USERNAME"`
 	InstagramPassword string `envconfig:"INSTAGRAM_PASSWORD"`
 
+	// Ko-fi
+	KofiVerificationToken string `envconfig:"KOFI_VERIFICATION_TOKEN"` // Webhooks
+
 	// Mailjet
 	MailjetPublic  string `envconfig:"MAILJET_PUBLIC"`  // API
 	MailjetPrivate string `envconfig:"MAILJET_PRIVATE"` // API
@@ -108,6 +119,8 @@ type Config struct {
 	PatreonSecret       string `envconfig:"PATREON_WEBOOK_SECRET"` // Webhooks
 	PatreonClientID     string `envconfig:"PATREON_CLIENT_ID"`     // OAuth
 	PatreonClientSecret string `envconfig:"PATREON_CLIENT_SECRET"` // OAuth
+	PatreonCampaignID   string `envconfig:"PATREON_CAMPAIGN_ID"`   // Reconciliation
+	PatreonCreatorToken string `envconfig:"PATREON_CREATOR_TOKEN"` // Reconciliation
 
 	// Rabbit
 	RabbitUsername      string `envconfig:"RABBIT_USER" required:"true"`
@@ -144,6 +157,13 @@ type Config struct {
 	SteamPassword string `envconfig:"PROXY_PASSWORD"`
 	SteamAPIKey   string
 
+	// Stripe
+	StripeWebhookSecret string `envconfig:"STRIPE_WEBHOOK_SECRET"` // Webhooks
+
+	// Tracing
+	TracingOTLPEndpoint string `envconfig:"TRACING_OTLP_ENDPOINT"` // host:port of an OTLP gRPC collector
+	TracingFilePath     string `envconfig:"TRACING_FILE_PATH"`     // Used when there is no collector
+
 	// Twitch
 	TwitchClientID     string `envconfig:"TWITCH_CLIENT_ID"`
 	TwitchClientSecret string `envconfig:"TWITCH_CLIENT_SECRET"`
@@ -163,11 +183,15 @@ type Config struct {
 	ChatbotPort  string `envconfig:"CHATBOT_PORT" default:"80"` // For slash commands
 	FrontendPort string `envconfig:"PORT" default:"80"`
 
-	BackendHostPort   string `envconfig:"BACKEND_HOST_PORT"`
-	BackendClientPort string `envconfig:"BACKEND_CLIENT_PORT"`
+	BackendHostPort        string   `envconfig:"BACKEND_HOST_PORT"`
+	BackendClientPort      string   `envconfig:"BACKEND_CLIENT_PORT"`
+	BackendInternalCallers []string `envconfig:"BACKEND_INTERNAL_CALLERS" default:"client"` // Certificate names our own services use, not rate limited
+
+	MetricsPort string `envconfig:"METRICS_PORT" default:"9090"` // Prometheus scrapes
 
 	// Other
 	GlobalSteamDomain   string `envconfig:"DOMAIN"` // With proto & port
+	APIDomain           string `envconfig:"API_DOMAIN" default:"https://api.globalsteam.online"`
 	Environment         string `envconfig:"ENV" required:"true"`
 	SlackGameDBWebhook  string `envconfig:"SLACK_GAMEDB_WEBHOOK"`
 	SlackPatreonWebhook string `envconfig:"SLACK_SOCIAL_WEBHOOK"`
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"sort"
 
 	"github.com/Jleagle/rabbit-go"
@@ -23,7 +24,7 @@ func (m AppAchievementsMessage) Queue() rabbit.QueueName {
 	return QueueAppsAchievements
 }
 
-func appAchievementsHandler(message *rabbit.Message) {
+func appAchievementsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppAchievementsMessage{}
 
@@ -102,7 +103,7 @@ func appAchievementsHandler(message *rabbit.Message) {
 	// 		{"achievement_complete", achievement.Completed},
 	// 	}
 	//
-	// 	_, err = mongo.UpdateManySet(mongo.CollectionPlayerAchievements, filter, update)
+	// 	_, err = mongo.UpdateManySet(ctx, mongo.CollectionPlayerAchievements, filter, update)
 	// 	if err != nil {
 	// 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 	// 	}
@@ -119,7 +120,7 @@ func appAchievementsHandler(message *rabbit.Message) {
 		return achievementsSlice[i].Completed > achievementsSlice[j].Completed
 	})
 
-	err = mongo.ReplaceAppAchievements(achievementsSlice)
+	err = mongo.ReplaceAppAchievements(ctx, achievementsSlice)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -128,7 +129,7 @@ func appAchievementsHandler(message *rabbit.Message) {
 
 	// Update in Elastic
 	for _, v := range achievementsSlice {
-		err = ProduceAchievementSearch(v, payload.AppName, payload.AppOwners)
+		err = ProduceAchievementSearch(ctx, v, payload.AppName, payload.AppOwners)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -172,7 +173,7 @@ func appAchievementsHandler(message *rabbit.Message) {
 		{"stats", stats},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, updateApp)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, updateApp)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -189,7 +190,7 @@ func appAchievementsHandler(message *rabbit.Message) {
 		filter = append(filter, bson.E{Key: "key", Value: bson.M{"$nin": keys}})
 	}
 
-	_, err = mongo.UpdateManySet(mongo.CollectionAppAchievements, filter, bson.D{{"deleted", true}})
+	_, err = mongo.UpdateManySet(ctx, mongo.CollectionAppAchievements, filter, bson.D{{"deleted", true}})
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -202,7 +203,7 @@ func appAchievementsHandler(message *rabbit.Message) {
 		memcache.ItemMongoCount(mongo.CollectionAppAchievements.String(), bson.D{{"app_id", payload.AppID}}).Key,
 	}
 
-	err = memcache.Client().Delete(items...)
+	err = memcache.Client().WithContext(ctx).Delete(items...)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -216,7 +217,7 @@ func appAchievementsHandler(message *rabbit.Message) {
 		"achievements_icons":  achievementsCol,
 	}
 
-	err = ProduceAppSearch(nil, payload.AppID, updateInElastic)
+	err = ProduceAppSearch(ctx, nil, payload.AppID, updateInElastic)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -16,7 +18,7 @@ type AppsAchievementsSearchMessage struct {
 	AppOwners      int64                `json:"app_owners"`
 }
 
-func appsAchievementsSearchHandler(message *rabbit.Message) {
+func appsAchievementsSearchHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppsAchievementsSearchMessage{}
 
@@ -44,12 +46,12 @@ func appsAchievementsSearchHandler(message *rabbit.Message) {
 	}
 
 	if payload.AppAchievement.Deleted {
-		err = elasticsearch.DeleteDocument(elasticsearch.IndexAchievements, achievement.GetKey())
+		err = elasticsearch.DeleteDocument(ctx, elasticsearch.IndexAchievements, achievement.GetKey())
 		if val, ok := err.(*elastic.Error); ok && val.Status == 404 {
 			err = nil
 		}
 	} else {
-		err = elasticsearch.IndexAchievement(achievement)
+		err = elasticsearch.IndexAchievement(ctx, achievement)
 	}
 	if err != nil {
 		log.ErrS(err)
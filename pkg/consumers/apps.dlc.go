@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -14,7 +16,7 @@ type DLCMessage struct {
 	DLCIDs []int `json:"dlc_ids"`
 }
 
-func appDLCHandler(message *rabbit.Message) {
+func appDLCHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := DLCMessage{}
 
@@ -25,7 +27,7 @@ func appDLCHandler(message *rabbit.Message) {
 		return
 	}
 
-	currentDLCs, err := mongo.GetDLCForApp(0, 0, bson.D{{"app_id", payload.AppID}}, nil, nil)
+	currentDLCs, err := mongo.GetDLCForApp(ctx, 0, 0, bson.D{{"app_id", payload.AppID}}, nil, nil)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
@@ -59,7 +61,7 @@ func appDLCHandler(message *rabbit.Message) {
 	}
 
 	//
-	apps, err := mongo.GetAppsByID(toAdd, bson.M{})
+	apps, err := mongo.GetAppsByID(ctx, toAdd, bson.M{})
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
@@ -78,14 +80,14 @@ func appDLCHandler(message *rabbit.Message) {
 		})
 	}
 
-	err = mongo.ReplaceAppDLCs(rows)
+	err = mongo.ReplaceAppDLCs(ctx, rows)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
 		return
 	}
 
-	err = mongo.DeleteAppDLC(payload.AppID, toRem)
+	err = mongo.DeleteAppDLC(ctx, payload.AppID, toRem)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
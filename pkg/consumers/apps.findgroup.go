@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"regexp"
 	"strconv"
 	"strings"
@@ -26,7 +27,7 @@ func (m FindGroupMessage) Queue() rabbit.QueueName {
 //noinspection RegExpRedundantEscape
 var regexpGroupID = regexp.MustCompile(`\(\s?\'(\d{18})\'\s?\)`)
 
-func appsFindGroupHandler(message *rabbit.Message) {
+func appsFindGroupHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := FindGroupMessage{}
 
@@ -61,7 +62,7 @@ func appsFindGroupHandler(message *rabbit.Message) {
 	filter := bson.D{{"_id", payload.AppID}}
 	update := bson.D{{"group_id", groupID}}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, filter, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, filter, update)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -69,7 +70,7 @@ func appsFindGroupHandler(message *rabbit.Message) {
 	}
 
 	// Clear cache
-	err = memcache.Client().Delete(memcache.ItemApp(payload.AppID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(payload.AppID).Key)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -81,7 +82,7 @@ func appsFindGroupHandler(message *rabbit.Message) {
 		"group_id": groupID,
 	}
 
-	err = ProduceAppSearch(nil, payload.AppID, updateInElastic)
+	err = ProduceAppSearch(ctx, nil, payload.AppID, updateInElastic)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"regexp"
 	"strconv"
 	"strings"
@@ -31,7 +32,7 @@ type AppMessage struct {
 	VDF          map[string]interface{} `json:"vdf"`
 }
 
-func appHandler(message *rabbit.Message) {
+func appHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppMessage{}
 
@@ -51,7 +52,7 @@ func appHandler(message *rabbit.Message) {
 	}
 
 	// Load current app
-	app, err := mongo.GetApp(id, true)
+	app, err := mongo.GetApp(ctx, id, true)
 	if err == mongo.ErrNoDocuments {
 		app = mongo.App{}
 		app.ID = id
@@ -98,14 +99,14 @@ func appHandler(message *rabbit.Message) {
 
 		var err error
 
-		err = updateAppDetails(&app)
+		err = updateAppDetails(ctx, &app)
 		if err != nil && err != steamapi.ErrAppNotFound {
 			steam.LogSteamError(err, zap.Int("app id", payload.ID))
 			sendToRetryQueue(message)
 			return
 		}
 
-		sales, err = scrapeApp(&app)
+		sales, err = scrapeApp(ctx, &app)
 		if err != nil {
 			steam.LogSteamError(err, zap.Int("app id", payload.ID))
 			sendToRetryQueue(message)
@@ -121,28 +122,28 @@ func appHandler(message *rabbit.Message) {
 
 		var err error
 
-		err = updateAppPlaytimeStats(&app)
+		err = updateAppPlaytimeStats(ctx, &app)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
 			return
 		}
 
-		err = updateAppOwners(&app)
+		err = updateAppOwners(ctx, &app)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
 			return
 		}
 
-		err = updateAppBadgeOwners(&app)
+		err = updateAppBadgeOwners(ctx, &app)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
 			return
 		}
 
-		err = updateAppCountries(&app)
+		err = updateAppCountries(ctx, &app)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -164,21 +165,28 @@ func appHandler(message *rabbit.Message) {
 
 		var err error
 
-		err = saveProductPricesToMongo(appBeforeUpdate, app)
+		err = saveProductPricesToMongo(ctx, appBeforeUpdate, app)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
 			return
 		}
 
-		err = saveSales(app, sales)
+		app.PriceStats, err = mongo.GetPriceStats(ctx, app.ID, helpers.ProductTypeApp, app.Prices)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
 			return
 		}
 
-		err = replaceAppRow(app)
+		err = saveSales(ctx, app, sales)
+		if err != nil {
+			log.ErrS(err, payload.ID)
+			sendToRetryQueue(message)
+			return
+		}
+
+		err = replaceAppRow(ctx, app)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -214,7 +222,7 @@ func appHandler(message *rabbit.Message) {
 			memcache.ItemMongoCount(mongo.CollectionAppSales.String(), bson.D{{"app_id", app.ID}}).Key,
 		}
 
-		err := memcache.Client().Delete(items...)
+		err := memcache.Client().WithContext(ctx).Delete(items...)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -233,7 +241,7 @@ func appHandler(message *rabbit.Message) {
 			var err error
 
 			wsPayload := IntPayload{ID: id}
-			err = ProduceWebsocket(wsPayload, websockets.PageApp)
+			err = ProduceWebsocket(ctx, wsPayload, websockets.PageApp)
 			if err != nil {
 				log.ErrS(err, id)
 			}
@@ -249,6 +257,11 @@ func appHandler(message *rabbit.Message) {
 	// Produce to sub queues
 	var produces = []QueueMessageInterface{
 		// AppsSearchMessage{App: app}, // Done in sub queues
+		AppsSearchMessage{AppID: app.ID, Fields: map[string]interface{}{
+			"prices":      app.Prices,
+			"price_stats": app.PriceStats,
+			"at_lowest":   app.PriceStats.AtLowest(app.Prices),
+		}},
 		AppAchievementsMessage{AppID: app.ID, AppName: app.Name, AppOwners: app.Owners},
 		AppMorelikeMessage{AppID: app.ID},
 		AppNewsMessage{AppID: app.ID},
@@ -266,7 +279,7 @@ func appHandler(message *rabbit.Message) {
 	}
 
 	for _, v := range produces {
-		err = produce(v.Queue(), v)
+		err = produce(ctx, v.Queue(), v)
 		if err != nil {
 			log.ErrS(err)
 			sendToRetryQueue(message)
@@ -471,7 +484,7 @@ func updateAppPICS(app *mongo.App, message *rabbit.Message, payload AppMessage)
 	return nil
 }
 
-func updateAppDetails(app *mongo.App) (err error) {
+func updateAppDetails(ctx context.Context, app *mongo.App) (err error) {
 
 	prices := helpers.ProductPrices{}
 
@@ -526,7 +539,7 @@ func updateAppDetails(app *mongo.App) (err error) {
 			// DLC
 			app.DLCCount = len(response.Data.DLC)
 
-			err = ProduceDLC(app.ID, response.Data.DLC)
+			err = ProduceDLC(ctx, app.ID, response.Data.DLC)
 			if err != nil {
 				log.ErrS(err)
 			}
@@ -535,13 +548,13 @@ func updateAppDetails(app *mongo.App) (err error) {
 			app.Packages = response.Data.Packages
 
 			// Publishers
-			app.Publishers, err = mongo.FindOrCreateStatsByName(mongo.StatsTypePublishers, response.Data.Publishers)
+			app.Publishers, err = mongo.FindOrCreateStatsByName(ctx, mongo.StatsTypePublishers, response.Data.Publishers)
 			if err != nil {
 				return err
 			}
 
 			// Developers
-			app.Developers, err = mongo.FindOrCreateStatsByName(mongo.StatsTypeDevelopers, response.Data.Developers)
+			app.Developers, err = mongo.FindOrCreateStatsByName(ctx, mongo.StatsTypeDevelopers, response.Data.Developers)
 			if err != nil {
 				return err
 			}
@@ -549,7 +562,7 @@ func updateAppDetails(app *mongo.App) (err error) {
 			// Genres
 			app.Genres = response.Data.Genres.IDs()
 
-			err = mongo.EnsureStat(mongo.StatsTypeGenres, response.Data.Genres.IDs(), response.Data.Genres.Names())
+			err = mongo.EnsureStat(ctx, mongo.StatsTypeGenres, response.Data.Genres.IDs(), response.Data.Genres.Names())
 			if err != nil {
 				return err
 			}
@@ -557,7 +570,7 @@ func updateAppDetails(app *mongo.App) (err error) {
 			// Categories
 			app.Categories = response.Data.Categories.IDs()
 
-			err = mongo.EnsureStat(mongo.StatsTypeCategories, response.Data.Categories.IDs(), response.Data.Categories.Names())
+			err = mongo.EnsureStat(ctx, mongo.StatsTypeCategories, response.Data.Categories.IDs(), response.Data.Categories.Names())
 			if err != nil {
 				return err
 			}
@@ -670,7 +683,7 @@ var (
 	appStorePageTags = regexp.MustCompile(`\{"tagid":([0-9]+),"name":"([a-zA-Z0-9-&'. ]+)","count":([0-9]+),"browseable":[a-z]{4,5}}`)
 )
 
-func scrapeApp(app *mongo.App) (sales []mongo.Sale, err error) {
+func scrapeApp(ctx context.Context, app *mongo.App) (sales []mongo.Sale, err error) {
 
 	// This app causes infinite redirects..
 	if app.ID == 12820 {
@@ -915,7 +928,7 @@ func scrapeApp(app *mongo.App) (sales []mongo.Sale, err error) {
 
 	for _, bundleID := range bundleIntIDs {
 
-		err = ProduceBundle(bundleID)
+		err = ProduceBundle(ctx, bundleID)
 		err = helpers.IgnoreErrors(err, ErrInQueue)
 		if err != nil {
 			log.ErrS(err)
@@ -926,10 +939,10 @@ func scrapeApp(app *mongo.App) (sales []mongo.Sale, err error) {
 	return sales, nil
 }
 
-func updateAppPlaytimeStats(app *mongo.App) (err error) {
+func updateAppPlaytimeStats(ctx context.Context, app *mongo.App) (err error) {
 
 	// Playtime
-	players, err := mongo.GetAppPlayTimes(app.ID)
+	players, err := mongo.GetAppPlayTimes(ctx, app.ID)
 	if err != nil {
 		return err
 	}
@@ -953,13 +966,13 @@ func updateAppPlaytimeStats(app *mongo.App) (err error) {
 	return nil
 }
 
-func updateAppOwners(app *mongo.App) (err error) {
+func updateAppOwners(ctx context.Context, app *mongo.App) (err error) {
 
-	app.Owners, err = mongo.CountDocuments(mongo.CollectionPlayerApps, bson.D{{"app_id", app.ID}}, 0)
+	app.Owners, err = mongo.CountDocuments(ctx, mongo.CollectionPlayerApps, bson.D{{"app_id", app.ID}}, 0)
 	return err
 }
 
-func updateAppBadgeOwners(app *mongo.App) (err error) {
+func updateAppBadgeOwners(ctx context.Context, app *mongo.App) (err error) {
 
 	filter := bson.D{
 		{"app_id", app.ID},
@@ -967,13 +980,13 @@ func updateAppBadgeOwners(app *mongo.App) (err error) {
 		{"badge_foil", false},
 	}
 
-	app.BadgeOwners, err = mongo.CountDocuments(mongo.CollectionPlayerBadges, filter, 0)
+	app.BadgeOwners, err = mongo.CountDocuments(ctx, mongo.CollectionPlayerBadges, filter, 0)
 	return err
 }
 
-func updateAppCountries(app *mongo.App) (err error) {
+func updateAppCountries(ctx context.Context, app *mongo.App) (err error) {
 
-	countries, err := mongo.GetAppPlayersByCountry(app.ID)
+	countries, err := mongo.GetAppPlayersByCountry(ctx, app.ID)
 	if err != nil {
 		return err
 	}
@@ -988,10 +1001,10 @@ func updateAppCountries(app *mongo.App) (err error) {
 	return nil
 }
 
-func saveSales(app mongo.App, newSales []mongo.Sale) (err error) {
+func saveSales(ctx context.Context, app mongo.App, newSales []mongo.Sale) (err error) {
 
 	// Get current app sales
-	oldSales, err := mongo.GetAppSales(app.ID)
+	oldSales, err := mongo.GetAppSales(ctx, app.ID)
 	if err != nil {
 		return err
 	}
@@ -1024,16 +1037,16 @@ func saveSales(app mongo.App, newSales []mongo.Sale) (err error) {
 		newSales[k].AppPrices = app.GetPrices().Map()
 	}
 
-	return mongo.ReplaceSales(newSales)
+	return mongo.ReplaceSales(ctx, newSales)
 }
 
-func replaceAppRow(app mongo.App) (err error) {
+func replaceAppRow(ctx context.Context, app mongo.App) (err error) {
 
-	_, err = mongo.ReplaceOne(mongo.CollectionApps, bson.D{{"_id", app.ID}}, app)
+	_, err = mongo.ReplaceOne(ctx, mongo.CollectionApps, bson.D{{"_id", app.ID}}, app)
 	if err != nil {
 		return err
 	}
 
 	// Cache cleared here to stop any race conditions with other queues
-	return memcache.Client().Delete(memcache.ItemApp(app.ID).Key)
+	return memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(app.ID).Key)
 }
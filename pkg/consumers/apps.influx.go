@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
@@ -23,7 +24,7 @@ func (m AppInfluxMessage) Queue() rabbit.QueueName {
 	return QueueAppsInflux
 }
 
-func appInfluxHandler(message *rabbit.Message) {
+func appInfluxHandler(ctx context.Context, message *rabbit.Message) {
 
 	// Sleep to not cause influx memory to spike too much
 	// time.Sleep(time.Second * 5)
@@ -116,7 +117,7 @@ func appInfluxHandler(message *rabbit.Message) {
 	// 	items = append(items, memcache.ItemApp(v).Key)
 	// }
 	//
-	// err = memcache.Client().Delete(items...)
+	// err = memcache.Client().WithContext(ctx).Delete(items...)
 	// if err != nil {
 	// 	log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 	// 	sendToRetryQueue(message)
@@ -136,7 +137,7 @@ func appInfluxHandler(message *rabbit.Message) {
 			doc["trend"] = val
 		}
 
-		err = ProduceAppSearch(nil, v, doc)
+		err = ProduceAppSearch(ctx, nil, v, doc)
 		if err != nil {
 			log.ErrS(err)
 		}
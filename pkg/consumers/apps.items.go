@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -20,7 +22,7 @@ func (m AppItemsMessage) Queue() rabbit.QueueName {
 	return QueueAppsItems
 }
 
-func appItemsHandler(message *rabbit.Message) {
+func appItemsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppItemsMessage{}
 
@@ -102,7 +104,7 @@ func appItemsHandler(message *rabbit.Message) {
 		filter = append(filter, bson.E{Key: "item_def_id", Value: bson.M{"$nin": keys}})
 	}
 
-	resp, err := mongo.GetAppItems(0, 0, filter, bson.M{"item_def_id": 1})
+	resp, err := mongo.GetAppItems(ctx, 0, 0, filter, bson.M{"item_def_id": 1})
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -114,7 +116,7 @@ func appItemsHandler(message *rabbit.Message) {
 		itemIDsToDelete = append(itemIDsToDelete, v.ItemDefID)
 	}
 
-	err = mongo.DeleteAppItems(payload.AppID, itemIDsToDelete)
+	err = mongo.DeleteAppItems(ctx, payload.AppID, itemIDsToDelete)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -123,7 +125,7 @@ func appItemsHandler(message *rabbit.Message) {
 
 	// Update all new items (must be after delete)
 	// Always save them all incase they change
-	err = mongo.ReplaceAppItems(newDocuments)
+	err = mongo.ReplaceAppItems(ctx, newDocuments)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -136,7 +138,7 @@ func appItemsHandler(message *rabbit.Message) {
 		{"items_digest", meta.Response.Digest},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, update)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -149,7 +151,7 @@ func appItemsHandler(message *rabbit.Message) {
 		memcache.ItemMongoCount(mongo.CollectionAppItems.String(), bson.D{{"app_id", payload.AppID}}).Key,
 	}
 
-	err = memcache.Client().Delete(items...)
+	err = memcache.Client().WithContext(ctx).Delete(items...)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
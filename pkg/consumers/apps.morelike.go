@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"regexp"
 	"strconv"
 
@@ -23,7 +24,7 @@ func (m AppMorelikeMessage) Queue() rabbit.QueueName {
 	return QueueAppsMorelike
 }
 
-func appMorelikeHandler(message *rabbit.Message) {
+func appMorelikeHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppMorelikeMessage{}
 
@@ -41,6 +42,7 @@ func appMorelikeHandler(message *rabbit.Message) {
 	)
 
 	var relatedAppIDs []int
+	var column = "related_app_ids"
 
 	c.OnHTML(".similar_grid_capsule", func(e *colly.HTMLElement) {
 		i, err := strconv.Atoi(e.Attr("data-ds-appid"))
@@ -51,9 +53,20 @@ func appMorelikeHandler(message *rabbit.Message) {
 
 	err = c.Visit("https://store.steampowered.com/recommended/morelike/app/" + strconv.Itoa(payload.AppID))
 	if err != nil {
+
 		steam.LogSteamError(err)
-		sendToRetryQueue(message)
-		return
+
+		// Fall back to our own similar apps, kept apart from the store's list
+		column = "related_similar_app_ids"
+		relatedAppIDs, err = getSimilarAppIDs(ctx, payload.AppID)
+		if err != nil {
+			log.ErrS(err, payload.AppID)
+		}
+
+		if len(relatedAppIDs) == 0 {
+			sendToRetryQueue(message)
+			return
+		}
 	}
 
 	if len(relatedAppIDs) == 0 {
@@ -63,9 +76,9 @@ func appMorelikeHandler(message *rabbit.Message) {
 
 	// Update app
 	filter := bson.D{{"_id", payload.AppID}}
-	update := bson.D{{"related_app_ids", relatedAppIDs}}
+	update := bson.D{{column, relatedAppIDs}}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, filter, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, filter, update)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -73,7 +86,7 @@ func appMorelikeHandler(message *rabbit.Message) {
 	}
 
 	// Clear cache
-	err = memcache.Client().Delete(memcache.ItemApp(payload.AppID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(payload.AppID).Key, memcache.ItemAppRelated(payload.AppID).Key)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -24,7 +25,7 @@ func (m AppNewsMessage) Queue() rabbit.QueueName {
 	return QueueAppsNews
 }
 
-func appNewsHandler(message *rabbit.Message) {
+func appNewsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppNewsMessage{}
 
@@ -48,7 +49,7 @@ func appNewsHandler(message *rabbit.Message) {
 		return
 	}
 
-	app, err := mongo.GetApp(payload.AppID, false)
+	app, err := mongo.GetApp(ctx, payload.AppID, false)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -109,18 +110,18 @@ func appNewsHandler(message *rabbit.Message) {
 			},
 		}
 
-		err = ProduceArticlesSearch(m)
+		err = ProduceArticlesSearch(ctx, m)
 		if err != nil {
 			log.ErrS(err)
 		}
 
-		err = ProduceWebsocket(NewsPayload{m.Elastic.OutputForJSON()}, websockets.PageNews)
+		err = ProduceWebsocket(ctx, NewsPayload{m.Elastic.OutputForJSON()}, websockets.PageNews)
 		if err != nil {
 			log.ErrS(err)
 		}
 	}
 
-	err = mongo.ReplaceArticles(articles)
+	err = mongo.ReplaceArticles(ctx, articles)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -130,7 +131,7 @@ func appNewsHandler(message *rabbit.Message) {
 	// Update app row
 	newsIDs = helpers.UniqueInt64(newsIDs)
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, bson.D{{"_id", app.ID}}, bson.D{{"news_ids", newsIDs}})
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, bson.D{{"_id", app.ID}}, bson.D{{"news_ids", newsIDs}})
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -138,7 +139,7 @@ func appNewsHandler(message *rabbit.Message) {
 	}
 
 	// Clear app cache
-	err = memcache.Client().Delete(memcache.ItemApp(app.ID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(app.ID).Key)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
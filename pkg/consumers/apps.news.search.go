@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -12,7 +14,7 @@ type AppsArticlesSearchMessage struct {
 	Elastic elasticsearch.Article `json:"elastic"`
 }
 
-func appsArticlesSearchHandler(message *rabbit.Message) {
+func appsArticlesSearchHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppsArticlesSearchMessage{}
 
@@ -27,7 +29,7 @@ func appsArticlesSearchHandler(message *rabbit.Message) {
 		payload.Elastic.ArticleIcon = payload.Elastic.GetArticleIcon()
 	}
 
-	err = elasticsearch.IndexArticle(payload.Elastic)
+	err = elasticsearch.IndexArticle(ctx, payload.Elastic)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
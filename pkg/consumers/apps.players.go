@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"strconv"
 	"sync"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/gamedb/gamedb/pkg/memcache"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/steam"
+	"github.com/gamedb/gamedb/pkg/streams"
 	influx "github.com/influxdata/influxdb1-client"
 	"github.com/nicklaw5/helix"
 	"go.mongodb.org/mongo-driver/bson"
@@ -24,7 +26,7 @@ type AppPlayerMessage struct {
 	IDs []int `json:"ids"`
 }
 
-func appPlayersHandler(message *rabbit.Message) {
+func appPlayersHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppPlayerMessage{}
 
@@ -36,7 +38,7 @@ func appPlayersHandler(message *rabbit.Message) {
 	}
 
 	// Also push to influx queue
-	err = ProduceAppsInflux(payload.IDs)
+	err = ProduceAppsInflux(ctx, payload.IDs)
 	if err != nil {
 		log.ErrS(err, payload.IDs)
 		sendToRetryQueue(message)
@@ -44,13 +46,15 @@ func appPlayersHandler(message *rabbit.Message) {
 	}
 
 	// Get apps
-	apps, err := mongo.GetAppsByID(payload.IDs, bson.M{"_id": 1, "twitch_id": 1, "player_peak_week": 1, "player_peak_alltime": 1})
+	apps, err := mongo.GetAppsByID(ctx, payload.IDs, bson.M{"_id": 1, "twitch_id": 1, "player_peak_week": 1, "player_peak_alltime": 1})
 	if err != nil {
 		log.ErrS(err, payload.IDs)
 		sendToRetryQueue(message)
 		return
 	}
 
+	var streamPayloads []AppPlayersStreamPayload
+
 	for _, app := range apps {
 
 		var wg sync.WaitGroup
@@ -120,7 +124,7 @@ func appPlayersHandler(message *rabbit.Message) {
 					{"player_peak_alltime_time", time.Now()},
 				}
 
-				_, err = mongo.UpdateOne(mongo.CollectionApps, filter, update)
+				_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, filter, update)
 				if err != nil {
 					log.ErrS(err, app.ID)
 					sendToRetryQueue(message)
@@ -128,7 +132,7 @@ func appPlayersHandler(message *rabbit.Message) {
 				}
 
 				// Clear cache
-				err = memcache.Client().Delete(memcache.ItemApp(app.ID).Key)
+				err = memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(app.ID).Key)
 				if err != nil {
 					log.ErrS(err, app.ID)
 					sendToRetryQueue(message)
@@ -144,6 +148,21 @@ func appPlayersHandler(message *rabbit.Message) {
 		if message.ActionTaken {
 			continue
 		}
+
+		streamPayloads = append(streamPayloads, AppPlayersStreamPayload{
+			AppID:         app.ID,
+			Players:       inGame,
+			TwitchViewers: twitchViewers,
+			CreatedAt:     time.Now(),
+		})
+	}
+
+	// Send to backend streams
+	if len(streamPayloads) > 0 {
+		err = ProduceStream(ctx, streamPayloads, streams.TopicAppPlayers)
+		if err != nil {
+			log.ErrS(err)
+		}
 	}
 
 	//
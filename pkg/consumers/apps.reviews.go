@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"sort"
 	"strconv"
 	"time"
@@ -27,7 +28,7 @@ func (m AppReviewsMessage) Queue() rabbit.QueueName {
 	return QueueAppsReviews
 }
 
-func appReviewsHandler(message *rabbit.Message) {
+func appReviewsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppReviewsMessage{}
 
@@ -68,7 +69,7 @@ func appReviewsHandler(message *rabbit.Message) {
 		reviewPlayersSlice = append(reviewPlayersSlice, int64(v.Author.SteamID))
 	}
 
-	players, err := mongo.GetPlayersByID(reviewPlayersSlice, bson.M{"_id": 1, "persona_name": 1})
+	players, err := mongo.GetPlayersByID(ctx, reviewPlayersSlice, bson.M{"_id": 1, "persona_name": 1})
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
@@ -100,7 +101,7 @@ func appReviewsHandler(message *rabbit.Message) {
 				SkipAchievements:   true,
 			}
 
-			err = ProducePlayer(producePayload, "queue-reviews")
+			err = ProducePlayer(ctx, producePayload, "queue-reviews")
 			err = helpers.IgnoreErrors(err, ErrInQueue)
 			if err != nil {
 				log.ErrS(err)
@@ -153,14 +154,14 @@ func appReviewsHandler(message *rabbit.Message) {
 		{"reviews_count", reviews.GetTotal()},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, update)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
 		return
 	}
 
-	err = memcache.Client().Delete(memcache.ItemApp(payload.AppID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(payload.AppID).Key)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -172,7 +173,7 @@ func appReviewsHandler(message *rabbit.Message) {
 		"reviews_count": reviews.GetTotal(),
 	}
 
-	err = ProduceAppSearch(nil, payload.AppID, updateInElastic)
+	err = ProduceAppSearch(ctx, nil, payload.AppID, updateInElastic)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"math"
 	"sort"
 	"time"
@@ -21,7 +22,7 @@ func (m AppSameownersMessage) Queue() rabbit.QueueName {
 	return QueueAppsSameowners
 }
 
-func appSameownersHandler(message *rabbit.Message) {
+func appSameownersHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppSameownersMessage{}
 
@@ -43,14 +44,14 @@ func appSameownersHandler(message *rabbit.Message) {
 		var filter = bson.D{{"_id", payload.AppID}}
 		var update = bson.D{{"related_owners_app_ids_date", time.Now()}}
 
-		_, err = mongo.UpdateOne(mongo.CollectionApps, filter, update)
+		_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, filter, update)
 		if err != nil {
 			log.Err("Updating app", zap.Error(err), zap.Int("app", payload.AppID))
 		}
 	}()
 
 	//
-	ownerRows, err := mongo.GetAppOwners(payload.AppID)
+	ownerRows, err := mongo.GetAppOwners(ctx, payload.AppID)
 	if err != nil {
 		log.Err(err.Error(), zap.Int("app", payload.AppID))
 		sendToRetryQueue(message)
@@ -73,7 +74,7 @@ func appSameownersHandler(message *rabbit.Message) {
 
 	for _, chunk := range helpers.ChunkInt64s(ownerPlayerIDs, batch1) {
 
-		ownerApps, err := mongo.GetPlayerAppsByPlayers(chunk, bson.M{"_id": -1, "app_id": 1})
+		ownerApps, err := mongo.GetPlayerAppsByPlayers(ctx, chunk, bson.M{"_id": -1, "app_id": 1})
 		if err != nil {
 			log.Err(err.Error(), zap.Int("app", payload.AppID))
 			sendToRetryQueue(message)
@@ -101,7 +102,7 @@ func appSameownersHandler(message *rabbit.Message) {
 
 	for _, chunk := range helpers.ChunkInts(appIDs, 1_000) {
 
-		apps, err := mongo.GetAppsByID(chunk, bson.M{"owners": 1})
+		apps, err := mongo.GetAppsByID(ctx, chunk, bson.M{"owners": 1})
 		if err != nil {
 			log.Err(err.Error(), zap.Int("app", payload.AppID))
 			sendToRetryQueue(message)
@@ -135,7 +136,7 @@ func appSameownersHandler(message *rabbit.Message) {
 	}
 
 	// Update same owners table
-	err = mongo.ReplaceAppSameOwners(payload.AppID, countSlice)
+	err = mongo.ReplaceAppSameOwners(ctx, payload.AppID, countSlice)
 	if err != nil {
 		log.Err(err.Error(), zap.Int("app", payload.AppID))
 		sendToRetryQueue(message)
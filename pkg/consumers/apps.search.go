@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"encoding/json"
 	"regexp"
 	"strconv"
@@ -27,7 +28,7 @@ func (m AppsSearchMessage) Queue() rabbit.QueueName {
 	return QueueAppsSearch
 }
 
-func appsSearchHandler(message *rabbit.Message) {
+func appsSearchHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppsSearchMessage{}
 
@@ -40,7 +41,7 @@ func appsSearchHandler(message *rabbit.Message) {
 
 	if len(payload.Fields) > 0 && payload.AppID > 0 {
 
-		err = elasticsearch.UpdateDocumentFields(elasticsearch.IndexApps, strconv.Itoa(payload.AppID), payload.Fields)
+		err = elasticsearch.UpdateDocumentFields(ctx, elasticsearch.IndexApps, strconv.Itoa(payload.AppID), payload.Fields)
 		if err != nil {
 
 			if val, ok := err.(*elastic.Error); ok {
@@ -70,7 +71,7 @@ func appsSearchHandler(message *rabbit.Message) {
 
 	if payload.AppID > 0 {
 
-		mongoApp, err = mongo.GetApp(payload.AppID)
+		mongoApp, err = mongo.GetApp(ctx, payload.AppID)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
@@ -107,6 +108,8 @@ func appsSearchHandler(message *rabbit.Message) {
 	app.Platforms = mongoApp.Platforms
 	app.PlayersCount = mongoApp.PlayerPeakWeek
 	app.Prices = mongoApp.Prices
+	app.PriceStats = mongoApp.PriceStats
+	app.AtLowest = mongoApp.PriceStats.AtLowest(mongoApp.Prices)
 	app.Publishers = mongoApp.Publishers
 	app.ReleaseDateOriginal = mongoApp.ReleaseDate
 	app.ReleaseDate = mongoApp.ReleaseDateUnix
@@ -127,7 +130,7 @@ func appsSearchHandler(message *rabbit.Message) {
 	app.Screenshots = string(b)
 	app.ScreenshotsCount = len(mongoApp.Screenshots)
 
-	err = elasticsearch.IndexApp(app)
+	err = elasticsearch.IndexApp(ctx, app)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
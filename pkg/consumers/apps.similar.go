@@ -0,0 +1,301 @@
+package consumers
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Jleagle/rabbit-go"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// Weights for each signal, should add up to 1
+const (
+	similarWeightTags       = 0.45
+	similarWeightGenres     = 0.15
+	similarWeightDevelopers = 0.10
+	similarWeightSameOwners = 0.20
+	similarWeightReviews    = 0.10
+
+	similarCandidates = 1_000
+	similarKeep       = 100
+	similarFallback   = 12 // Same as Steam's more like page
+)
+
+type AppSimilarMessage struct {
+	AppID int `json:"app_id"`
+}
+
+func (m AppSimilarMessage) Queue() rabbit.QueueName {
+	return QueueAppsSimilar
+}
+
+func appSimilarHandler(ctx context.Context, message *rabbit.Message) {
+
+	payload := AppSimilarMessage{}
+
+	err := helpers.Unmarshal(message.Message.Body, &payload)
+	if err != nil {
+		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
+		sendToFailQueue(message)
+		return
+	}
+
+	// Mark app as done
+	success := false
+	defer func() {
+
+		if !success {
+			return
+		}
+
+		var filter = bson.D{{"_id", payload.AppID}}
+		var update = bson.D{{"related_similar_date", time.Now()}}
+
+		_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, filter, update)
+		if err != nil {
+			log.Err("Updating app", zap.Error(err), zap.Int("app", payload.AppID))
+		}
+	}()
+
+	var projection = bson.M{"_id": 1, "type": 1, "tags": 1, "tag_counts": 1, "genres": 1, "developers": 1, "reviews_score": 1, "owners": 1}
+
+	app := mongo.App{}
+	err = mongo.FindOne(ctx, mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, nil, projection, &app)
+	if err == mongo.ErrNoDocuments {
+		success = true
+		message.Ack()
+		return
+	}
+	if err != nil {
+		log.Err(err.Error(), zap.Int("app", payload.AppID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	// Get candidates that share a tag, genre or developer
+	var or = bson.A{}
+	if len(app.Tags) > 0 {
+		or = append(or, bson.M{"tags": bson.M{"$in": app.Tags}})
+	}
+	if len(app.Developers) > 0 {
+		or = append(or, bson.M{"developers": bson.M{"$in": app.Developers}})
+	}
+	if len(app.Genres) > 0 {
+		or = append(or, bson.M{"genres": bson.M{"$in": app.Genres}})
+	}
+
+	var candidates = map[int]mongo.App{}
+
+	if len(or) > 0 {
+
+		filter := bson.D{
+			{"_id", bson.M{"$ne": app.ID}},
+			{"type", app.Type},
+			{"$or", or},
+		}
+
+		apps, err := mongo.GetApps(ctx, 0, similarCandidates, bson.D{{"player_peak_week", -1}}, filter, projection)
+		if err != nil {
+			log.Err(err.Error(), zap.Int("app", payload.AppID))
+			sendToRetryQueue(message)
+			return
+		}
+
+		for _, v := range apps {
+			candidates[v.ID] = v
+		}
+	}
+
+	// Add apps with the same owners
+	sameOwners, err := mongo.GetAppSameOwners(ctx, app.ID, similarKeep)
+	if err != nil {
+		log.Err(err.Error(), zap.Int("app", payload.AppID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	var sameOwnersMap = map[int]mongo.AppSameOwners{}
+	var sameOwnersMax float64
+	var missing []int
+
+	for _, v := range sameOwners {
+
+		sameOwnersMap[v.SameAppID] = v
+		sameOwnersMax = math.Max(sameOwnersMax, v.Order)
+
+		if _, ok := candidates[v.SameAppID]; !ok {
+			missing = append(missing, v.SameAppID)
+		}
+	}
+
+	if len(missing) > 0 {
+
+		apps, err := mongo.GetAppsByID(ctx, missing, projection)
+		if err != nil {
+			log.Err(err.Error(), zap.Int("app", payload.AppID))
+			sendToRetryQueue(message)
+			return
+		}
+
+		for _, v := range apps {
+			if v.Type == app.Type {
+				candidates[v.ID] = v
+			}
+		}
+	}
+
+	// Score candidates
+	var similar []mongo.AppSimilar
+	var appTags = similarTagVector(app)
+
+	for _, candidate := range candidates {
+
+		row := mongo.AppSimilar{
+			AppID:        app.ID,
+			SimilarAppID: candidate.ID,
+			Tags:         similarOverlap(app.Tags, candidate.Tags),
+			Genres:       similarOverlap(app.Genres, candidate.Genres),
+			Developers:   similarOverlap(app.Developers, candidate.Developers),
+			Owners:       int(candidate.Owners),
+		}
+
+		var sameOwnersScore float64
+		if val, ok := sameOwnersMap[candidate.ID]; ok {
+			row.SameOwners = val.Count
+			if sameOwnersMax > 0 {
+				sameOwnersScore = val.Order / sameOwnersMax
+			}
+		}
+
+		var developersScore float64
+		if row.Developers > 0 {
+			developersScore = 1
+		}
+
+		var reviewsScore float64
+		if app.ReviewsScore > 0 && candidate.ReviewsScore > 0 {
+			reviewsScore = 1 - (math.Abs(app.ReviewsScore-candidate.ReviewsScore) / 100)
+		}
+
+		row.Score = (similarCosine(appTags, similarTagVector(candidate)) * similarWeightTags) +
+			(similarJaccard(app.Genres, candidate.Genres) * similarWeightGenres) +
+			(developersScore * similarWeightDevelopers) +
+			(sameOwnersScore * similarWeightSameOwners) +
+			(reviewsScore * similarWeightReviews)
+
+		if row.Score > 0 {
+			similar = append(similar, row)
+		}
+	}
+
+	sort.Slice(similar, func(i, j int) bool {
+		return similar[i].Score > similar[j].Score
+	})
+
+	if len(similar) > similarKeep {
+		similar = similar[0:similarKeep]
+	}
+
+	// Update similar table
+	err = mongo.ReplaceAppSimilar(ctx, app.ID, similar)
+	if err != nil {
+		log.Err(err.Error(), zap.Int("app", payload.AppID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	// Clear cache
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemAppSimilar(app.ID).Key)
+	if err != nil {
+		log.Err(err.Error(), zap.Int("app", payload.AppID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	//
+	success = true
+	message.Ack()
+}
+
+// Used when Steam's more like page can't be scraped
+func getSimilarAppIDs(ctx context.Context, appID int) (appIDs []int, err error) {
+
+	similar, err := mongo.GetAppSimilar(ctx, appID, similarFallback)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range similar {
+		appIDs = append(appIDs, v.SimilarAppID)
+	}
+
+	return appIDs, nil
+}
+
+// Tag votes, falling back to current tags if there are no counts
+func similarTagVector(app mongo.App) map[int]float64 {
+
+	var vector = map[int]float64{}
+
+	for _, v := range app.TagCounts {
+		if helpers.SliceHasInt(app.Tags, v.ID) && v.Count > 0 {
+			vector[v.ID] = float64(v.Count)
+		}
+	}
+
+	if len(vector) == 0 {
+		for _, v := range app.Tags {
+			vector[v] = 1
+		}
+	}
+
+	return vector
+}
+
+func similarCosine(a, b map[int]float64) float64 {
+
+	var dot, magA, magB float64
+
+	for k, v := range a {
+		dot += v * b[k]
+		magA += v * v
+	}
+
+	for _, v := range b {
+		magB += v * v
+	}
+
+	if magA == 0 || magB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(magA) * math.Sqrt(magB))
+}
+
+func similarJaccard(a, b []int) float64 {
+
+	union := len(helpers.UniqueInt(append(append([]int{}, a...), b...)))
+	if union == 0 {
+		return 0
+	}
+
+	return float64(similarOverlap(a, b)) / float64(union)
+}
+
+func similarOverlap(a, b []int) (count int) {
+
+	for _, v := range helpers.UniqueInt(a) {
+		if helpers.SliceHasInt(b, v) {
+			count++
+		}
+	}
+
+	return count
+}
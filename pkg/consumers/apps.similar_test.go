@@ -0,0 +1,70 @@
+package consumers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimilarCosine(t *testing.T) {
+
+	tests := []struct {
+		a, b     map[int]float64
+		expected float64
+	}{
+		{map[int]float64{1: 1, 2: 1}, map[int]float64{1: 1, 2: 1}, 1},
+		{map[int]float64{1: 2, 2: 4}, map[int]float64{1: 1, 2: 2}, 1}, // Only the direction counts
+		{map[int]float64{1: 1}, map[int]float64{2: 1}, 0},
+		{map[int]float64{1: 1, 2: 1}, map[int]float64{1: 1}, 1 / math.Sqrt(2)},
+		{map[int]float64{}, map[int]float64{1: 1}, 0},
+		{nil, nil, 0},
+	}
+
+	for k, v := range tests {
+		if got := similarCosine(v.a, v.b); math.Abs(got-v.expected) > 1e-9 {
+			t.Errorf("%d: expected %f, got %f", k, v.expected, got)
+		}
+		if got := similarCosine(v.b, v.a); math.Abs(got-v.expected) > 1e-9 {
+			t.Errorf("%d reversed: expected %f, got %f", k, v.expected, got)
+		}
+	}
+}
+
+func TestSimilarJaccard(t *testing.T) {
+
+	tests := []struct {
+		a, b     []int
+		expected float64
+	}{
+		{[]int{1, 2}, []int{1, 2}, 1},
+		{[]int{1, 2}, []int{2, 3}, 1.0 / 3},
+		{[]int{1, 1, 2}, []int{1}, 0.5}, // Duplicates only count once
+		{[]int{1}, []int{2}, 0},
+		{nil, []int{1}, 0},
+		{nil, nil, 0},
+	}
+
+	for k, v := range tests {
+		if got := similarJaccard(v.a, v.b); math.Abs(got-v.expected) > 1e-9 {
+			t.Errorf("%d: expected %f, got %f", k, v.expected, got)
+		}
+	}
+}
+
+func TestSimilarOverlap(t *testing.T) {
+
+	tests := []struct {
+		a, b     []int
+		expected int
+	}{
+		{[]int{1, 2, 3}, []int{2, 3, 4}, 2},
+		{[]int{1, 1, 2}, []int{1, 2}, 2},
+		{[]int{1}, []int{2}, 0},
+		{nil, []int{1}, 0},
+	}
+
+	for k, v := range tests {
+		if got := similarOverlap(v.a, v.b); got != v.expected {
+			t.Errorf("%d: expected %d, got %d", k, v.expected, got)
+		}
+	}
+}
@@ -32,7 +32,7 @@ var (
 	steamspyLimiterApp    = rate.New(time.Hour * 2)
 )
 
-func appSteamspyHandler(message *rabbit.Message) {
+func appSteamspyHandler(ctx context.Context, message *rabbit.Message) {
 
 	attempt := time.Duration(message.Attempt())
 
@@ -121,7 +121,7 @@ func appSteamspyHandler(message *rabbit.Message) {
 	filter := bson.D{{"_id", payload.AppID}}
 	update := bson.D{{"steam_spy", ss}}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, filter, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, filter, update)
 	if err != nil {
 		log.ErrS(err, payload.AppID, u)
 		sendToRetryQueue(message)
@@ -129,7 +129,7 @@ func appSteamspyHandler(message *rabbit.Message) {
 	}
 
 	// Clear cache
-	err = memcache.Client().Delete(memcache.ItemApp(payload.AppID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(payload.AppID).Key)
 	if err != nil {
 		log.ErrS(err, payload.AppID, u)
 		sendToRetryQueue(message)
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/Jleagle/rabbit-go"
@@ -22,7 +23,7 @@ func (m AppTwitchMessage) Queue() rabbit.QueueName {
 	return QueueAppsTwitch
 }
 
-func appTwitchHandler(message *rabbit.Message) {
+func appTwitchHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppTwitchMessage{}
 
@@ -33,7 +34,7 @@ func appTwitchHandler(message *rabbit.Message) {
 		return
 	}
 
-	app, err := mongo.GetApp(payload.AppID)
+	app, err := mongo.GetApp(ctx, payload.AppID)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -76,14 +77,14 @@ func appTwitchHandler(message *rabbit.Message) {
 		{"twitch_url", resp.Data.Games[0].Name},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, update)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
 		return
 	}
 
-	err = memcache.Client().Delete(memcache.ItemApp(payload.AppID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(payload.AppID).Key)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -19,7 +20,7 @@ type AppWishlistsMessage struct {
 	AppID int `json:"id"`
 }
 
-func appWishlistsHandler(message *rabbit.Message) {
+func appWishlistsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := AppWishlistsMessage{}
 
@@ -30,7 +31,7 @@ func appWishlistsHandler(message *rabbit.Message) {
 		return
 	}
 
-	playerWishlists, err := mongo.GetPlayerWishlistAppsByApp(payload.AppID)
+	playerWishlists, err := mongo.GetPlayerWishlistAppsByApp(ctx, payload.AppID)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -66,7 +67,7 @@ func appWishlistsHandler(message *rabbit.Message) {
 	}
 
 	// Get percent of players
-	wishlistPlayers, err := mongo.CountDocuments(mongo.CollectionPlayers, nil, 60*60)
+	wishlistPlayers, err := mongo.CountDocuments(ctx, mongo.CollectionPlayers, nil, 60*60)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -110,7 +111,7 @@ func appWishlistsHandler(message *rabbit.Message) {
 		{"wishlist_firsts", wishlistFirsts},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, bson.D{{"_id", payload.AppID}}, update)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -118,7 +119,7 @@ func appWishlistsHandler(message *rabbit.Message) {
 	}
 
 	// Clear app memcache
-	err = memcache.Client().Delete(memcache.ItemApp(payload.AppID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemApp(payload.AppID).Key)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
@@ -131,7 +132,7 @@ func appWishlistsHandler(message *rabbit.Message) {
 		"wishlist_avg":   wishlistAverage,
 	}
 
-	err = ProduceAppSearch(nil, payload.AppID, updateInElastic)
+	err = ProduceAppSearch(ctx, nil, payload.AppID, updateInElastic)
 	if err != nil {
 		log.ErrS(err, payload.AppID)
 		sendToRetryQueue(message)
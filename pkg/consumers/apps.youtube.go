@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"time"
@@ -23,7 +24,7 @@ type AppYoutubeMessage struct {
 	Name string `json:"name"`
 }
 
-func appYoutubeHandler(message *rabbit.Message) {
+func appYoutubeHandler(ctx context.Context, message *rabbit.Message) {
 
 	if time.Since(youtubeOverLimitAt) < time.Hour {
 		message.Ack()
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"encoding/json"
 	"math"
 	"net/url"
@@ -29,7 +30,7 @@ type BundleMessage struct {
 	ID int `json:"id"`
 }
 
-func bundleHandler(message *rabbit.Message) {
+func bundleHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := BundleMessage{}
 
@@ -43,7 +44,7 @@ func bundleHandler(message *rabbit.Message) {
 	// Load current bundle
 	var newBundle bool
 
-	bundle, err := mongo.GetBundle(payload.ID)
+	bundle, err := mongo.GetBundle(ctx, payload.ID)
 	if err == mongo.ErrNoDocuments {
 
 		bundle = mongo.Bundle{}
@@ -77,7 +78,7 @@ func bundleHandler(message *rabbit.Message) {
 		defer wg.Done()
 
 		var err error
-		_, err = mongo.ReplaceOne(mongo.CollectionBundles, bson.D{{"_id", bundle.ID}}, bundle)
+		_, err = mongo.ReplaceOne(ctx, mongo.CollectionBundles, bson.D{{"_id", bundle.ID}}, bundle)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -91,7 +92,7 @@ func bundleHandler(message *rabbit.Message) {
 
 		defer wg.Done()
 
-		var err = saveBundlePriceToMongo(bundle, oldBundle)
+		var err = saveBundlePriceToMongo(ctx, bundle, oldBundle)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -111,7 +112,7 @@ func bundleHandler(message *rabbit.Message) {
 		memcache.ItemBundle(bundle.ID).Key,
 	}
 
-	err = memcache.Client().Delete(items...)
+	err = memcache.Client().WithContext(ctx).Delete(items...)
 	if err != nil {
 		log.ErrS(err, payload.ID)
 		sendToRetryQueue(message)
@@ -122,7 +123,7 @@ func bundleHandler(message *rabbit.Message) {
 	if newBundle {
 
 		wsPayload := IntPayload(payload)
-		err = ProduceWebsocket(wsPayload, websockets.PageBundle, websockets.PageBundles)
+		err = ProduceWebsocket(ctx, wsPayload, websockets.PageBundle, websockets.PageBundles)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -131,7 +132,7 @@ func bundleHandler(message *rabbit.Message) {
 	}
 
 	// Elastic
-	err = ProduceBundleSearch(bundle)
+	err = ProduceBundleSearch(ctx, bundle)
 	if err != nil {
 		log.Err("Producing bundle search", zap.Error(err), zap.Int("bundle", payload.ID))
 		sendToRetryQueue(message)
@@ -267,7 +268,7 @@ type bundleData struct {
 
 var bundlePriceLock sync.Mutex
 
-func saveBundlePriceToMongo(bundle mongo.Bundle, oldBundle mongo.Bundle) (err error) {
+func saveBundlePriceToMongo(ctx context.Context, bundle mongo.Bundle, oldBundle mongo.Bundle) (err error) {
 
 	bundlePriceLock.Lock()
 	defer bundlePriceLock.Unlock()
@@ -281,7 +282,7 @@ func saveBundlePriceToMongo(bundle mongo.Bundle, oldBundle mongo.Bundle) (err er
 		}
 
 		// Does a replace, as sometimes doing a InsertOne would error on key already existing
-		_, err = mongo.ReplaceOne(mongo.CollectionBundlePrices, bson.D{{"_id", doc.GetKey()}}, doc)
+		_, err = mongo.ReplaceOne(ctx, mongo.CollectionBundlePrices, bson.D{{"_id", doc.GetKey()}}, doc)
 	}
 
 	return err
@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -17,7 +19,7 @@ func (m BundlesSearchMessage) Queue() rabbit.QueueName {
 	return QueueBundlesSearch
 }
 
-func bundleSearchHandler(message *rabbit.Message) {
+func bundleSearchHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := BundlesSearchMessage{}
 
@@ -50,7 +52,7 @@ func bundleSearchHandler(message *rabbit.Message) {
 		Score:           0,
 	}
 
-	err = elasticsearch.IndexBundle(bundle)
+	err = elasticsearch.IndexBundle(ctx, bundle)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
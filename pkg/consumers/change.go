@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"sort"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	influxHelper "github.com/gamedb/gamedb/pkg/influx"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/streams"
 	"github.com/gamedb/gamedb/pkg/websockets"
 	influx "github.com/influxdata/influxdb1-client"
 	"go.mongodb.org/mongo-driver/bson"
@@ -21,7 +23,7 @@ type ChangesMessage struct {
 	PackageIDs map[uint32]uint32 `json:"package_ids"`
 }
 
-func changesHandler(message *rabbit.Message) {
+func changesHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := ChangesMessage{}
 
@@ -70,7 +72,7 @@ func changesHandler(message *rabbit.Message) {
 	})
 
 	// Save to Mongo
-	err = saveChangesToMongo(changeSlice)
+	err = saveChangesToMongo(ctx, changeSlice)
 	if err != nil && !strings.Contains(err.Error(), "duplicate key error collection") {
 		log.ErrS(err)
 		sendToRetryQueue(message)
@@ -86,7 +88,7 @@ func changesHandler(message *rabbit.Message) {
 	}
 
 	// Get apps and packages for all changes in message
-	appMap, packageMap, err := getChangesAppsAndPackages(changeSlice)
+	appMap, packageMap, err := getChangesAppsAndPackages(ctx, changeSlice)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
@@ -94,7 +96,13 @@ func changesHandler(message *rabbit.Message) {
 	}
 
 	// Send websocket
-	err = sendChangesWebsocket(changeSlice, appMap, packageMap)
+	err = sendChangesWebsocket(ctx, changeSlice, appMap, packageMap)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	// Send to backend streams
+	err = ProduceStream(ctx, ChangesStreamPayload{Changes: changeSlice, Apps: appMap, Packages: packageMap}, streams.TopicChanges)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -108,7 +116,7 @@ func changesHandler(message *rabbit.Message) {
 	message.Ack()
 }
 
-func saveChangesToMongo(changes []*mongo.Change) (err error) {
+func saveChangesToMongo(ctx context.Context, changes []*mongo.Change) (err error) {
 
 	if len(changes) == 0 {
 		return nil
@@ -125,11 +133,11 @@ func saveChangesToMongo(changes []*mongo.Change) (err error) {
 		})
 	}
 
-	_, err = mongo.InsertMany(mongo.CollectionChanges, changesDocuments)
+	_, err = mongo.InsertMany(ctx, mongo.CollectionChanges, changesDocuments)
 	return err
 }
 
-func getChangesAppsAndPackages(changes []*mongo.Change) (appMap map[int]string, packageMap map[int]string, err error) {
+func getChangesAppsAndPackages(ctx context.Context, changes []*mongo.Change) (appMap map[int]string, packageMap map[int]string, err error) {
 
 	appMap = map[int]string{}
 	packageMap = map[int]string{}
@@ -143,7 +151,7 @@ func getChangesAppsAndPackages(changes []*mongo.Change) (appMap map[int]string,
 	}
 
 	// Apps & packages for all changes
-	apps, err := mongo.GetAppsByID(appIDs, bson.M{"_id": 1, "name": 1})
+	apps, err := mongo.GetAppsByID(ctx, appIDs, bson.M{"_id": 1, "name": 1})
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -152,7 +160,7 @@ func getChangesAppsAndPackages(changes []*mongo.Change) (appMap map[int]string,
 		appMap[v.ID] = v.GetName()
 	}
 
-	packages, err := mongo.GetPackagesByID(packageIDs, bson.M{"id": 1, "name": 1})
+	packages, err := mongo.GetPackagesByID(ctx, packageIDs, bson.M{"id": 1, "name": 1})
 	if err != nil {
 		return appMap, packageMap, err
 	}
@@ -164,7 +172,7 @@ func getChangesAppsAndPackages(changes []*mongo.Change) (appMap map[int]string,
 	return appMap, packageMap, err
 }
 
-func sendChangesWebsocket(changes []*mongo.Change, appMap map[int]string, packageMap map[int]string) (err error) {
+func sendChangesWebsocket(ctx context.Context, changes []*mongo.Change, appMap map[int]string, packageMap map[int]string) (err error) {
 
 	var ws [][]interface{}
 	for _, v := range changes {
@@ -173,7 +181,7 @@ func sendChangesWebsocket(changes []*mongo.Change, appMap map[int]string, packag
 	}
 
 	wsPayload := ChangesPayload{Data: ws}
-	return ProduceWebsocket(wsPayload, websockets.PageChanges)
+	return ProduceWebsocket(ctx, wsPayload, websockets.PageChanges)
 }
 
 // func sendChangeToDiscord(changes []*mongo.Change, appMap map[int]string, packageMap map[int]string) (err error) {
@@ -1,9 +1,11 @@
 package consumers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/Jleagle/rabbit-go"
@@ -13,10 +15,14 @@ import (
 	influxHelpers "github.com/gamedb/gamedb/pkg/influx"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/metrics"
 	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/streams"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	"github.com/gamedb/gamedb/pkg/websockets"
 	influx "github.com/influxdata/influxdb1-client"
 	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -40,6 +46,7 @@ const (
 	QueueAppsInflux             rabbit.QueueName = "GDB_Apps.Influx"
 	QueueAppsDLC                rabbit.QueueName = "GDB_Apps.DLC"
 	QueueAppsSameowners         rabbit.QueueName = "GDB_Apps.Sameowners"
+	QueueAppsSimilar            rabbit.QueueName = "GDB_Apps.Similar"
 	QueueAppsNews               rabbit.QueueName = "GDB_Apps.News"
 	QueueAppsFindGroup          rabbit.QueueName = "GDB_Apps.FindGroup"
 	QueueAppsReviews            rabbit.QueueName = "GDB_Apps.Reviews"
@@ -81,9 +88,12 @@ const (
 	QueueDelay       rabbit.QueueName = "GDB_Delay"
 	QueueFailed      rabbit.QueueName = "GDB_Failed"
 	QueuePlayerRanks rabbit.QueueName = "GDB_Player_Ranks"
+	QueueSavedSearch rabbit.QueueName = "GDB_Saved_Search"
 	QueueStats       rabbit.QueueName = "GDB_Stats"
 	QueueSteam       rabbit.QueueName = "GDB_Steam"
+	QueueStreams     rabbit.QueueName = "GDB_Streams"
 	QueueTest        rabbit.QueueName = "GDB_Test"
+	QueueUserData    rabbit.QueueName = "GDB_User_Data"
 	QueueWebsockets  rabbit.QueueName = "GDB_Websockets"
 )
 
@@ -105,6 +115,7 @@ var (
 		{Name: QueueAppsReviews},
 		{Name: QueueAppsSameowners},
 		{Name: QueueAppsSearch, prefetchSize: 1_000},
+		{Name: QueueAppsSimilar},
 		{Name: QueueBundlesSearch, prefetchSize: 1_000},
 		{Name: QueueAppsSteamspy},
 		{Name: QueueAppsTwitch},
@@ -130,9 +141,12 @@ var (
 		{Name: QueuePlayersSearch, prefetchSize: 1_000},
 		{Name: QueuePlayersWishlist},
 		{Name: QueuePlayers},
+		{Name: QueueSavedSearch},
 		{Name: QueueStats},
 		{Name: QueueSteam},
+		{Name: QueueStreams},
 		{Name: QueueTest},
+		{Name: QueueUserData},
 		{Name: QueueWebsockets},
 	}
 
@@ -152,6 +166,7 @@ var (
 		{Name: QueueAppsReviews, consumer: appReviewsHandler},
 		{Name: QueueAppsSameowners, consumer: appSameownersHandler},
 		{Name: QueueAppsSearch, consumer: appsSearchHandler, prefetchSize: 1_000},
+		{Name: QueueAppsSimilar, consumer: appSimilarHandler},
 		{Name: QueueAppsSteamspy, consumer: appSteamspyHandler},
 		{Name: QueueAppsTwitch, consumer: appTwitchHandler},
 		{Name: QueueAppsWishlists, consumer: appWishlistsHandler, prefetchSize: 1_000},
@@ -176,9 +191,12 @@ var (
 		{Name: QueuePlayersGroups, consumer: playersGroupsHandler},
 		{Name: QueuePlayersSearch, consumer: appsPlayersHandler, prefetchSize: 1_000},
 		{Name: QueuePlayersWishlist, consumer: playersWishlistHandler},
+		{Name: QueueSavedSearch},
 		{Name: QueueStats, consumer: statsHandler},
 		{Name: QueueSteam},
+		{Name: QueueStreams},
 		{Name: QueueTest, consumer: testHandler},
+		{Name: QueueUserData},
 		{Name: QueueWebsockets},
 	}
 
@@ -212,9 +230,11 @@ var (
 		{Name: QueuePlayersSearch, prefetchSize: 1_000},
 		{Name: QueuePlayersWishlist},
 		{Name: QueuePlayers},
+		{Name: QueueSavedSearch, consumer: savedSearchHandler},
 		{Name: QueueStats},
 		{Name: QueueSteam},
 		{Name: QueueTest},
+		{Name: QueueUserData, consumer: userDataHandler},
 		{Name: QueueWebsockets, consumer: websocketHandler},
 	}
 
@@ -240,6 +260,7 @@ var (
 		{Name: QueueAppsWishlists, prefetchSize: 1_000},
 		{Name: QueueAppsYoutube},
 		{Name: QueueAppsSameowners},
+		{Name: QueueAppsSimilar},
 		{Name: QueueApps},
 		{Name: QueueBundlesSearch, prefetchSize: 1_000},
 		{Name: QueueDelay, skipHeaders: true},
@@ -251,8 +272,10 @@ var (
 		{Name: QueuePlayersGroups},
 		{Name: QueuePlayersSearch, prefetchSize: 1_000},
 		{Name: QueuePlayers},
+		{Name: QueueSavedSearch},
 		{Name: QueueStats},
 		{Name: QueueSteam},
+		{Name: QueueUserData},
 		{Name: QueueWebsockets},
 	}
 
@@ -260,11 +283,15 @@ var (
 		{Name: QueuePlayers},
 		{Name: QueueWebsockets},
 	}
+
+	BackendDefinitions = []QueueDefinition{
+		{Name: QueueStreams, consumer: streamsHandler},
+	}
 )
 
 type QueueDefinition struct {
 	Name         rabbit.QueueName
-	consumer     rabbit.Handler
+	consumer     handler
 	skipHeaders  bool
 	prefetchSize int
 }
@@ -305,8 +332,10 @@ func Init(definitions []QueueDefinition) {
 
 	for k, queue := range definitions {
 
+		var consumer rabbit.Handler
 		if queue.consumer != nil {
 			consume = true
+			consumer = instrumentHandler(queue.Name, queue.consumer)
 		}
 
 		prefetchSize := 50
@@ -319,7 +348,7 @@ func Init(definitions []QueueDefinition) {
 			QueueName:     queue.Name,
 			ConsumerName:  config.C.Environment + "-" + strconv.Itoa(k),
 			PrefetchCount: prefetchSize,
-			Handler:       queue.consumer,
+			Handler:       consumer,
 			UpdateHeaders: !queue.skipHeaders,
 			AutoDelete:    false,
 			QueueArgs: amqp.Table{
@@ -376,7 +405,7 @@ func Init(definitions []QueueDefinition) {
 						QueueName:     queue.Name,
 						ConsumerName:  config.C.Environment + "-" + strconv.Itoa(k),
 						PrefetchCount: prefetchSize,
-						Handler:       queue.consumer,
+						Handler:       instrumentHandler(queue.Name, queue.consumer),
 						UpdateHeaders: !queue.skipHeaders,
 						AutoDelete:    false,
 						QueueArgs: amqp.Table{
@@ -397,9 +426,42 @@ func Init(definitions []QueueDefinition) {
 	}
 }
 
+// Consumers get the context of the message's trace, for the spans they start
+type handler func(ctx context.Context, message *rabbit.Message)
+
+// Continues the trace from the producer's headers and records how the message was dealt with
+func instrumentHandler(q rabbit.QueueName, handler handler) rabbit.Handler {
+
+	return func(message *rabbit.Message) {
+
+		ctx := tracing.Extract(context.Background(), message.Message.Headers)
+
+		ctx, span := tracing.StartSpan(ctx, "consume "+string(q), trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		start := time.Now()
+
+		handler(ctx, message)
+
+		result := metrics.QueueResultUnacked
+		if val, ok := messageResults.LoadAndDelete(message); ok {
+			result = val.(string)
+		} else if message.ActionTaken {
+			result = metrics.QueueResultAck
+		}
+
+		metrics.ObserveQueueMessage(string(q), result, time.Since(start))
+	}
+}
+
+// Set by the helpers below, so a plain ack can be told apart from a retry or failure
+var messageResults sync.Map
+
 // Message helpers
 func sendToFailQueue(message *rabbit.Message) {
 
+	messageResults.Store(message, metrics.QueueResultFail)
+
 	err := message.SendToQueueAndAck(ProducerChannels[QueueFailed], nil)
 	if err != nil {
 		log.ErrS(err)
@@ -413,6 +475,8 @@ func sendToRetryQueue(message *rabbit.Message) {
 
 func sendToRetryQueueWithDelay(message *rabbit.Message, delay time.Duration) {
 
+	messageResults.Store(message, metrics.QueueResultNack)
+
 	var po rabbit.ProduceOptions
 	if delay > 0 {
 		po = func(p amqp.Publishing) amqp.Publishing {
@@ -435,6 +499,12 @@ func sendToLastQueue(message *rabbit.Message) {
 		queue = QueueFailed
 	}
 
+	if queue == QueueFailed {
+		messageResults.Store(message, metrics.QueueResultFail)
+	} else {
+		messageResults.Store(message, metrics.QueueResultNack)
+	}
+
 	err := message.SendToQueueAndAck(ProducerChannels[queue], nil)
 	if err != nil {
 		log.ErrS(err)
@@ -442,7 +512,7 @@ func sendToLastQueue(message *rabbit.Message) {
 }
 
 // Producers
-func ProduceApp(payload AppMessage) (err error) {
+func ProduceApp(ctx context.Context, payload AppMessage) (err error) {
 
 	if !helpers.IsValidAppID(payload.ID) {
 		return mongo.ErrInvalidAppID
@@ -451,7 +521,7 @@ func ProduceApp(payload AppMessage) (err error) {
 	item := memcache.ItemAppInQueue(payload.ID)
 
 	if payload.ChangeNumber == 0 {
-		exists, err := memcache.Client().Exists(item.Key)
+		exists, err := memcache.Client().WithContext(ctx).Exists(item.Key)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -460,55 +530,55 @@ func ProduceApp(payload AppMessage) (err error) {
 		}
 	}
 
-	err = produce(QueueApps, payload)
+	err = produce(ctx, QueueApps, payload)
 	if err == nil {
-		err = memcache.Client().Set(item.Key, item.Value, item.Expiration)
+		err = memcache.Client().WithContext(ctx).Set(item.Key, item.Value, item.Expiration)
 	}
 
 	return err
 }
 
-func ProduceAppsInflux(appIDs []int) (err error) {
+func ProduceAppsInflux(ctx context.Context, appIDs []int) (err error) {
 	m := AppInfluxMessage{AppIDs: appIDs}
-	return produce(m.Queue(), m)
+	return produce(ctx, m.Queue(), m)
 }
 
-func ProduceAppsReviews(id int, skipMissingPlayers bool) (err error) {
+func ProduceAppsReviews(ctx context.Context, id int, skipMissingPlayers bool) (err error) {
 	m := AppReviewsMessage{AppID: id, SkipMissingPlayers: skipMissingPlayers}
-	return produce(m.Queue(), m)
+	return produce(ctx, m.Queue(), m)
 }
 
-func ProduceAppsYoutube(id int, name string) (err error) {
-	return produce(QueueAppsYoutube, AppYoutubeMessage{ID: id, Name: name})
+func ProduceAppsYoutube(ctx context.Context, id int, name string) (err error) {
+	return produce(ctx, QueueAppsYoutube, AppYoutubeMessage{ID: id, Name: name})
 }
 
-func ProduceAppsWishlists(id int) (err error) {
-	return produce(QueueAppsWishlists, AppWishlistsMessage{AppID: id})
+func ProduceAppsWishlists(ctx context.Context, id int) (err error) {
+	return produce(ctx, QueueAppsWishlists, AppWishlistsMessage{AppID: id})
 }
 
-func ProduceAppPlayers(appIDs []int) (err error) {
+func ProduceAppPlayers(ctx context.Context, appIDs []int) (err error) {
 
 	if len(appIDs) == 0 {
 		return nil
 	}
 
-	return produce(QueueAppPlayers, AppPlayerMessage{IDs: appIDs})
+	return produce(ctx, QueueAppPlayers, AppPlayerMessage{IDs: appIDs})
 }
 
-func ProduceAppPlayersTop(appIDs []int) (err error) {
+func ProduceAppPlayersTop(ctx context.Context, appIDs []int) (err error) {
 
 	if len(appIDs) == 0 {
 		return nil
 	}
 
-	return produce(QueueAppPlayersTop, AppPlayerMessage{IDs: appIDs})
+	return produce(ctx, QueueAppPlayersTop, AppPlayerMessage{IDs: appIDs})
 }
 
-func ProduceBundle(id int) (err error) {
+func ProduceBundle(ctx context.Context, id int) (err error) {
 
 	item := memcache.ItemBundleInQueue(id)
 
-	exists, err := memcache.Client().Exists(item.Key)
+	exists, err := memcache.Client().WithContext(ctx).Exists(item.Key)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -516,27 +586,27 @@ func ProduceBundle(id int) (err error) {
 		return ErrInQueue
 	}
 
-	err = produce(QueueBundles, BundleMessage{ID: id})
+	err = produce(ctx, QueueBundles, BundleMessage{ID: id})
 	if err == nil {
-		err = memcache.Client().Set(item.Key, item.Value, item.Expiration)
+		err = memcache.Client().WithContext(ctx).Set(item.Key, item.Value, item.Expiration)
 	}
 
 	return err
 }
 
-func ProduceChanges(payload ChangesMessage) (err error) {
+func ProduceChanges(ctx context.Context, payload ChangesMessage) (err error) {
 
-	return produce(QueueChanges, payload)
+	return produce(ctx, QueueChanges, payload)
 }
 
-func ProduceDLC(appID int, DLCIDs []int) (err error) {
+func ProduceDLC(ctx context.Context, appID int, DLCIDs []int) (err error) {
 
-	return produce(QueueAppsDLC, DLCMessage{AppID: appID, DLCIDs: DLCIDs})
+	return produce(ctx, QueueAppsDLC, DLCMessage{AppID: appID, DLCIDs: DLCIDs})
 }
 
-func ProducePlayerAchievements(playerID int64, appID int, force bool, oldCount, oldCount100, oldCountApps int) (err error) {
+func ProducePlayerAchievements(ctx context.Context, playerID int64, appID int, force bool, oldCount, oldCount100, oldCountApps int) (err error) {
 
-	return produce(QueuePlayersAchievements, PlayerAchievementsMessage{
+	return produce(ctx, QueuePlayersAchievements, PlayerAchievementsMessage{
 		PlayerID:     playerID,
 		AppID:        appID,
 		Force:        force,
@@ -546,7 +616,7 @@ func ProducePlayerAchievements(playerID int64, appID int, force bool, oldCount,
 	})
 }
 
-func ProduceGroup(payload GroupMessage) (err error) {
+func ProduceGroup(ctx context.Context, payload GroupMessage) (err error) {
 
 	if payload.UserAgent != nil && helpers.IsBot(*payload.UserAgent) {
 		return ErrIsBot
@@ -554,7 +624,7 @@ func ProduceGroup(payload GroupMessage) (err error) {
 
 	item := memcache.ItemGroupInQueue(payload.ID)
 
-	exists, err := memcache.Client().Exists(item.Key)
+	exists, err := memcache.Client().WithContext(ctx).Exists(item.Key)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -562,15 +632,15 @@ func ProduceGroup(payload GroupMessage) (err error) {
 		return ErrInQueue
 	}
 
-	err = produce(QueueGroups, payload)
+	err = produce(ctx, QueueGroups, payload)
 	if err == nil {
-		err = memcache.Client().Set(item.Key, item.Value, item.Expiration)
+		err = memcache.Client().WithContext(ctx).Set(item.Key, item.Value, item.Expiration)
 	}
 
 	return err
 }
 
-func ProducePackage(payload PackageMessage) (err error) {
+func ProducePackage(ctx context.Context, payload PackageMessage) (err error) {
 
 	if !helpers.IsValidPackageID(payload.ID) {
 		return mongo.ErrInvalidPackageID
@@ -579,7 +649,7 @@ func ProducePackage(payload PackageMessage) (err error) {
 	item := memcache.ItemPackageInQueue(payload.ID)
 
 	if payload.ChangeNumber == 0 {
-		exists, err := memcache.Client().Exists(item.Key)
+		exists, err := memcache.Client().WithContext(ctx).Exists(item.Key)
 		if err != nil {
 			log.ErrS(err)
 		}
@@ -588,21 +658,21 @@ func ProducePackage(payload PackageMessage) (err error) {
 		}
 	}
 
-	err = produce(QueuePackages, payload)
+	err = produce(ctx, QueuePackages, payload)
 	if err == nil {
-		err = memcache.Client().Set(item.Key, item.Value, item.Expiration)
+		err = memcache.Client().WithContext(ctx).Set(item.Key, item.Value, item.Expiration)
 	}
 
 	return err
 }
 
-func producePackagePrice(payload PackagePriceMessage) (err error) {
-	return produce(QueuePackagesPrices, payload)
+func producePackagePrice(ctx context.Context, payload PackagePriceMessage) (err error) {
+	return produce(ctx, QueuePackagesPrices, payload)
 }
 
 var ErrIsBot = errors.New("bots can't update players")
 
-func ProducePlayer(payload PlayerMessage, event string) (err error) {
+func ProducePlayer(ctx context.Context, payload PlayerMessage, event string) (err error) {
 
 	if payload.UserAgent != nil && helpers.IsBot(*payload.UserAgent) {
 		return ErrIsBot
@@ -615,7 +685,7 @@ func ProducePlayer(payload PlayerMessage, event string) (err error) {
 
 	item := memcache.ItemPlayerInQueue(payload.ID)
 
-	exists, err := memcache.Client().Exists(item.Key)
+	exists, err := memcache.Client().WithContext(ctx).Exists(item.Key)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -623,11 +693,11 @@ func ProducePlayer(payload PlayerMessage, event string) (err error) {
 		return ErrInQueue
 	}
 
-	err = produce(QueuePlayers, payload)
+	err = produce(ctx, QueuePlayers, payload)
 	if err == nil {
 
 		go func() {
-			if err := memcache.Client().Set(item.Key, item.Value, item.Expiration); err != nil {
+			if err := memcache.Client().WithContext(ctx).Set(item.Key, item.Value, item.Expiration); err != nil {
 				log.ErrS(err)
 			}
 		}()
@@ -661,73 +731,91 @@ func ProducePlayer(payload PlayerMessage, event string) (err error) {
 	return err
 }
 
-func ProducePlayerRank(payload PlayerRanksMessage) (err error) {
+func ProducePlayerRank(ctx context.Context, payload PlayerRanksMessage) (err error) {
 
-	return produce(QueuePlayerRanks, payload)
+	return produce(ctx, QueuePlayerRanks, payload)
 }
 
-func ProduceGroupSearch(group *mongo.Group, groupID string, groupType string) (err error) {
+func ProduceGroupSearch(ctx context.Context, group *mongo.Group, groupID string, groupType string) (err error) {
 
-	return produce(QueueGroupsSearch, GroupSearchMessage{Group: group, GroupID: groupID, GroupType: groupType})
+	return produce(ctx, QueueGroupsSearch, GroupSearchMessage{Group: group, GroupID: groupID, GroupType: groupType})
 }
 
-func ProduceBundleSearch(bundle mongo.Bundle) (err error) {
+func ProduceBundleSearch(ctx context.Context, bundle mongo.Bundle) (err error) {
 
-	return produce(QueueBundlesSearch, BundlesSearchMessage{Bundle: bundle})
+	return produce(ctx, QueueBundlesSearch, BundlesSearchMessage{Bundle: bundle})
 }
 
-func ProduceGroupPrimaries(groupID string, groupType string, prims int) (err error) {
+func ProduceGroupPrimaries(ctx context.Context, groupID string, groupType string, prims int) (err error) {
 
 	m := GroupPrimariesMessage{GroupID: groupID, GroupType: groupType, CurrentPrimaries: prims}
-	return produce(m.Queue(), m)
+	return produce(ctx, m.Queue(), m)
 }
 
-func ProduceSameOwners(appID int) (err error) {
+func ProduceSameOwners(ctx context.Context, appID int) (err error) {
 
 	m := AppSameownersMessage{AppID: appID}
-	return produce(m.Queue(), m)
+	return produce(ctx, m.Queue(), m)
 }
 
-func ProduceAchievementSearch(achievement mongo.AppAchievement, appName string, appOwners int64) (err error) {
+func ProduceSimilar(ctx context.Context, appID int) (err error) {
 
-	return produce(QueueAppsAchievementsSearch, AppsAchievementsSearchMessage{
+	m := AppSimilarMessage{AppID: appID}
+	return produce(ctx, m.Queue(), m)
+}
+
+func ProduceSavedSearch(ctx context.Context, id string) (err error) {
+
+	m := SavedSearchMessage{ID: id}
+	return produce(ctx, m.Queue(), m)
+}
+
+func ProduceUserData(ctx context.Context, requestID int) (err error) {
+
+	m := UserDataMessage{RequestID: requestID}
+	return produce(ctx, m.Queue(), m)
+}
+
+func ProduceAchievementSearch(ctx context.Context, achievement mongo.AppAchievement, appName string, appOwners int64) (err error) {
+
+	return produce(ctx, QueueAppsAchievementsSearch, AppsAchievementsSearchMessage{
 		AppAchievement: achievement,
 		AppName:        appName,
 		AppOwners:      appOwners,
 	})
 }
 
-func ProduceArticlesSearch(payload AppsArticlesSearchMessage) (err error) {
+func ProduceArticlesSearch(ctx context.Context, payload AppsArticlesSearchMessage) (err error) {
 
-	return produce(QueueAppsArticlesSearch, payload)
+	return produce(ctx, QueueAppsArticlesSearch, payload)
 }
 
 //goland:noinspection GoUnusedExportedFunction
-func ProducePlayerAlias(id int64, removed bool) (err error) {
+func ProducePlayerAlias(ctx context.Context, id int64, removed bool) (err error) {
 
-	return produce(QueuePlayersAliases, PlayersAliasesMessage{PlayerID: id, PlayerRemoved: removed})
+	return produce(ctx, QueuePlayersAliases, PlayersAliasesMessage{PlayerID: id, PlayerRemoved: removed})
 }
 
-func ProduceAppAchievement(appID int, appName string, appOwners int64) (err error) {
+func ProduceAppAchievement(ctx context.Context, appID int, appName string, appOwners int64) (err error) {
 
-	return produce(QueueAppsAchievements, AppAchievementsMessage{AppID: appID, AppName: appName, AppOwners: appOwners})
+	return produce(ctx, QueueAppsAchievements, AppAchievementsMessage{AppID: appID, AppName: appName, AppOwners: appOwners})
 }
 
-func ProduceSteam(payload SteamMessage) (err error) {
+func ProduceSteam(ctx context.Context, payload SteamMessage) (err error) {
 
 	if len(payload.AppIDs) == 0 && len(payload.PackageIDs) == 0 {
 		return nil
 	}
 
-	return produce(QueueSteam, payload)
+	return produce(ctx, QueueSteam, payload)
 }
 
-func ProduceTest(id int) (err error) {
+func ProduceTest(ctx context.Context, id int) (err error) {
 
-	return produce(QueueTest, TestMessage{ID: id})
+	return produce(ctx, QueueTest, TestMessage{ID: id})
 }
 
-func ProduceStats(typex mongo.StatsType, ID int, appsCount int64) (err error) {
+func ProduceStats(ctx context.Context, typex mongo.StatsType, ID int, appsCount int64) (err error) {
 
 	m := StatsMessage{
 		Type:      typex,
@@ -735,62 +823,80 @@ func ProduceStats(typex mongo.StatsType, ID int, appsCount int64) (err error) {
 		AppsCount: appsCount,
 	}
 
-	return produce(m.Queue(), m)
+	return produce(ctx, m.Queue(), m)
 }
 
-func ProducePlayerGroup(player mongo.Player, skipGroupUpdate bool, force bool) (err error) {
+func ProducePlayerGroup(ctx context.Context, player mongo.Player, skipGroupUpdate bool, force bool) (err error) {
 
-	return produce(QueuePlayersGroups, PlayersGroupsMessage{
+	return produce(ctx, QueuePlayersGroups, PlayersGroupsMessage{
 		Player:                    player,
 		SkipGroupUpdate:           skipGroupUpdate,
 		ForceResavingPlayerGroups: force,
 	})
 }
 
-func ProduceAppSearch(app *mongo.App, appID int, fields map[string]interface{}) (err error) {
+func ProduceAppSearch(ctx context.Context, app *mongo.App, appID int, fields map[string]interface{}) (err error) {
 
 	m := AppsSearchMessage{App: app, AppID: appID, Fields: fields}
-	return produce(m.Queue(), m)
+	return produce(ctx, m.Queue(), m)
 }
 
-func ProduceAppSteamSpy(appID int) (err error) {
+func ProduceAppSteamSpy(ctx context.Context, appID int) (err error) {
 
 	m := AppSteamspyMessage{AppID: appID}
-	return produce(m.Queue(), m)
+	return produce(ctx, m.Queue(), m)
 }
 
-func ProduceAppNews(appID int) (err error) {
+func ProduceAppNews(ctx context.Context, appID int) (err error) {
 
 	m := AppNewsMessage{AppID: appID}
-	return produce(m.Queue(), m)
+	return produce(ctx, m.Queue(), m)
 }
 
-func ProducePlayerSearch(player *mongo.Player, playerID int64) (err error) {
+func ProducePlayerSearch(ctx context.Context, player *mongo.Player, playerID int64) (err error) {
 
-	return produce(QueuePlayersSearch, PlayersSearchMessage{Player: player, PlayerID: playerID})
+	return produce(ctx, QueuePlayersSearch, PlayersSearchMessage{Player: player, PlayerID: playerID})
 }
 
-func ProduceWebsocket(payload interface{}, pages ...websockets.WebsocketPage) (err error) {
+func ProduceWebsocket(ctx context.Context, payload interface{}, pages ...websockets.WebsocketPage) (err error) {
 
 	b, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
 
-	return produce(QueueWebsockets, WebsocketMessage{
+	return produce(ctx, QueueWebsockets, WebsocketMessage{
 		Pages:   pages,
 		Message: b,
 	})
 }
 
-func produce(q rabbit.QueueName, payload interface{}) error {
+func ProduceStream(ctx context.Context, payload interface{}, topic streams.StreamTopic) (err error) {
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	m := StreamMessage{Topic: topic, Message: b}
+	return produce(ctx, m.Queue(), m)
+}
+
+func produce(ctx context.Context, q rabbit.QueueName, payload interface{}) error {
 
 	if !config.IsLocal() {
 		time.Sleep(time.Second / 1_000)
 	}
 
 	if val, ok := ProducerChannels[q]; ok {
-		return val.Produce(payload, nil)
+
+		ctx, span := tracing.StartSpan(ctx, "produce "+string(q), trace.WithSpanKind(trace.SpanKindProducer))
+		defer span.End()
+
+		return val.Produce(payload, func(p amqp.Publishing) amqp.Publishing {
+			tracing.Inject(ctx, p.Headers)
+			return p
+		})
 	}
 
 	return errors.New("channel not in register")
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"math"
 	"time"
 
@@ -12,7 +13,7 @@ const (
 	minDelay = time.Second * 10
 )
 
-func delayHandler(message *rabbit.Message) {
+func delayHandler(ctx context.Context, message *rabbit.Message) {
 
 	time.Sleep(time.Second / 10)
 
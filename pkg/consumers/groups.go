@@ -39,7 +39,7 @@ func (m GroupMessage) Queue() rabbit.QueueName {
 	return QueueGroups
 }
 
-func groupsHandler(message *rabbit.Message) {
+func groupsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := GroupMessage{}
 
@@ -68,7 +68,7 @@ func groupsHandler(message *rabbit.Message) {
 	}
 
 	//
-	group, err := mongo.GetGroup(payload.ID)
+	group, err := mongo.GetGroup(ctx, payload.ID)
 	if err == mongo.ErrNoDocuments {
 
 		group = mongo.Group{
@@ -108,7 +108,7 @@ func groupsHandler(message *rabbit.Message) {
 	// Update group
 	var found bool
 	if group.Type == helpers.GroupTypeGame {
-		found, err = updateGameGroup(payload.ID, &group)
+		found, err = updateGameGroup(ctx, payload.ID, &group)
 	} else {
 		found, err = updateRegularGroup(payload.ID, &group)
 	}
@@ -150,7 +150,7 @@ func groupsHandler(message *rabbit.Message) {
 
 		var err error
 
-		app, err = getAppFromGroup(group)
+		app, err = getAppFromGroup(ctx, group)
 		err = helpers.IgnoreErrors(err, mysql.ErrRecordNotFound)
 		if err != nil {
 			log.ErrS(err, payload.ID)
@@ -172,14 +172,14 @@ func groupsHandler(message *rabbit.Message) {
 
 		defer wg.Done()
 
-		err = updateApp(app, group)
+		err = updateApp(ctx, app, group)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
 			return
 		}
 
-		err = saveGroup(group)
+		err = saveGroup(ctx, group)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -212,7 +212,7 @@ func groupsHandler(message *rabbit.Message) {
 		memcache.ItemGroupInQueue(payload.ID).Key,
 	}
 
-	err = memcache.Client().Delete(items...)
+	err = memcache.Client().WithContext(ctx).Delete(items...)
 	if err != nil {
 		log.ErrS(err, payload.ID)
 		sendToRetryQueue(message)
@@ -220,7 +220,7 @@ func groupsHandler(message *rabbit.Message) {
 	}
 
 	// Send websocket
-	err = sendGroupWebsocket(payload.ID)
+	err = sendGroupWebsocket(ctx, payload.ID)
 	if err != nil {
 		log.ErrS(err, payload.ID)
 		sendToRetryQueue(message)
@@ -238,7 +238,7 @@ func groupsHandler(message *rabbit.Message) {
 	}
 
 	for _, v := range produces {
-		err = produce(v.Queue(), v)
+		err = produce(ctx, v.Queue(), v)
 		if err != nil {
 			log.ErrS(err)
 			sendToRetryQueue(message)
@@ -253,7 +253,7 @@ func groupsHandler(message *rabbit.Message) {
 	//
 	message.Ack()
 }
-func updateGameGroup(id string, group *mongo.Group) (foundNumbers bool, err error) {
+func updateGameGroup(ctx context.Context, id string, group *mongo.Group) (foundNumbers bool, err error) {
 
 	group.Abbr = "" // Game groups don't have abbr's
 
@@ -325,10 +325,10 @@ func updateGameGroup(id string, group *mongo.Group) (foundNumbers bool, err erro
 	if group.Icon == "" && group.URL != "" {
 		i, err := strconv.Atoi(group.URL)
 		if err == nil && i > 0 {
-			app, err := mongo.GetApp(i)
+			app, err := mongo.GetApp(ctx, i)
 			if err == mongo.ErrNoDocuments {
 				log.WarnS(err, group.URL, "missing app has been queued")
-				err = ProduceSteam(SteamMessage{AppIDs: []int{i}})
+				err = ProduceSteam(ctx, SteamMessage{AppIDs: []int{i}})
 				if err != nil {
 					log.ErrS(err)
 				}
@@ -505,9 +505,9 @@ func getGroupTrending(group mongo.Group) (trend float64, err error) {
 	return influxHelper.GetInfluxTrendFromResponse(builder, 28)
 }
 
-func saveGroup(group mongo.Group) (err error) {
+func saveGroup(ctx context.Context, group mongo.Group) (err error) {
 
-	_, err = mongo.ReplaceOne(mongo.CollectionGroups, bson.D{{"_id", group.ID}}, group)
+	_, err = mongo.ReplaceOne(ctx, mongo.CollectionGroups, bson.D{{"_id", group.ID}}, group)
 	return err
 
 	// This uses too much CPU
@@ -523,23 +523,23 @@ func saveGroup(group mongo.Group) (err error) {
 	// 	{"group_url", group.URL},
 	// }
 	//
-	// _, err = mongo.UpdateManySet(mongo.CollectionPlayerGroups, filter, update)
+	// _, err = mongo.UpdateManySet(ctx, mongo.CollectionPlayerGroups, filter, update)
 	// return err
 }
 
-func getAppFromGroup(group mongo.Group) (app mongo.App, err error) {
+func getAppFromGroup(ctx context.Context, group mongo.Group) (app mongo.App, err error) {
 
 	if group.Type == helpers.GroupTypeGame && group.AppID > 0 {
-		app, err = mongo.GetApp(group.AppID)
+		app, err = mongo.GetApp(ctx, group.AppID)
 		if err == mongo.ErrNoDocuments {
-			err = ProduceSteam(SteamMessage{AppIDs: []int{group.AppID}})
+			err = ProduceSteam(ctx, SteamMessage{AppIDs: []int{group.AppID}})
 		}
 	}
 
 	return app, err
 }
 
-func updateApp(app mongo.App, group mongo.Group) (err error) {
+func updateApp(ctx context.Context, app mongo.App, group mongo.Group) (err error) {
 
 	if app.ID == 0 || group.ID == "" || group.Type != helpers.GroupTypeGame {
 		return nil
@@ -550,7 +550,7 @@ func updateApp(app mongo.App, group mongo.Group) (err error) {
 		{"group_followers", group.Members},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionApps, bson.D{{"_id", app.ID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionApps, bson.D{{"_id", app.ID}}, update)
 	return err
 }
 
@@ -577,10 +577,10 @@ func saveGroupToInflux(group mongo.Group) (err error) {
 	return err
 }
 
-func sendGroupWebsocket(id string) (err error) {
+func sendGroupWebsocket(ctx context.Context, id string) (err error) {
 
 	wsPayload := StringPayload{String: id}
-	return ProduceWebsocket(wsPayload, websockets.PageGroup)
+	return ProduceWebsocket(ctx, wsPayload, websockets.PageGroup)
 }
 
 func getGroupType(id string) (groupType string, groupURL string, err error) {
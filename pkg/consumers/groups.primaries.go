@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -20,7 +22,7 @@ func (m GroupPrimariesMessage) Queue() rabbit.QueueName {
 	return QueueGroupsPrimaries
 }
 
-func groupPrimariesHandler(message *rabbit.Message) {
+func groupPrimariesHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := GroupPrimariesMessage{}
 
@@ -31,7 +33,7 @@ func groupPrimariesHandler(message *rabbit.Message) {
 		return
 	}
 
-	prims, err := mongo.CountDocuments(mongo.CollectionPlayers, bson.D{{"primary_clan_id_string", payload.GroupID}}, 0)
+	prims, err := mongo.CountDocuments(ctx, mongo.CollectionPlayers, bson.D{{"primary_clan_id_string", payload.GroupID}}, 0)
 	if err != nil {
 		log.ErrS(err, payload.GroupID)
 		sendToRetryQueue(message)
@@ -51,7 +53,7 @@ func groupPrimariesHandler(message *rabbit.Message) {
 		{"primaries", int(prims)},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionGroups, filter, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionGroups, filter, update)
 	if err != nil {
 		log.ErrS(err, payload.GroupID)
 		sendToRetryQueue(message)
@@ -59,7 +61,7 @@ func groupPrimariesHandler(message *rabbit.Message) {
 	}
 
 	// Clear group cache
-	err = memcache.Client().Delete(memcache.ItemGroup(payload.GroupID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemGroup(payload.GroupID).Key)
 	if err != nil {
 		log.ErrS(err, payload.GroupID)
 		sendToRetryQueue(message)
@@ -67,7 +69,7 @@ func groupPrimariesHandler(message *rabbit.Message) {
 	}
 
 	// Update Elastic
-	err = ProduceGroupSearch(nil, payload.GroupID, payload.GroupType)
+	err = ProduceGroupSearch(ctx, nil, payload.GroupID, payload.GroupType)
 	if err != nil {
 		log.ErrS(err, payload.GroupID)
 		sendToRetryQueue(message)
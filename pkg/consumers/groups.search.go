@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -19,7 +21,7 @@ func (m GroupSearchMessage) Queue() rabbit.QueueName {
 	return QueueGroupsSearch
 }
 
-func groupsSearchHandler(message *rabbit.Message) {
+func groupsSearchHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := GroupSearchMessage{}
 	err := helpers.Unmarshal(message.Message.Body, &payload)
@@ -38,7 +40,7 @@ func groupsSearchHandler(message *rabbit.Message) {
 
 	if payload.GroupID != "" {
 
-		groupMongo, err = mongo.GetGroup(payload.GroupID)
+		groupMongo, err = mongo.GetGroup(ctx, payload.GroupID)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
@@ -73,7 +75,7 @@ func groupsSearchHandler(message *rabbit.Message) {
 		Primaries:    groupMongo.Primaries,
 	}
 
-	err = elasticsearch.IndexGroup(group)
+	err = elasticsearch.IndexGroup(ctx, group)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"regexp"
 	"sort"
 	"strconv"
@@ -31,7 +32,7 @@ type PackageMessage struct {
 	VDF          map[string]interface{} `json:"vdf"`
 }
 
-func packageHandler(message *rabbit.Message) {
+func packageHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PackageMessage{}
 
@@ -48,7 +49,7 @@ func packageHandler(message *rabbit.Message) {
 	}
 
 	// Load current package
-	pack, err := mongo.GetPackage(payload.ID)
+	pack, err := mongo.GetPackage(ctx, payload.ID)
 	if err == mongo.ErrNoDocuments {
 		pack = mongo.Package{}
 		pack.ID = payload.ID
@@ -91,7 +92,7 @@ func packageHandler(message *rabbit.Message) {
 
 				payload2.BeforePrice = &price.Final
 
-				err = producePackagePrice(payload2)
+				err = producePackagePrice(ctx, payload2)
 				if err != nil {
 					log.ErrS(err)
 				}
@@ -155,7 +156,7 @@ func packageHandler(message *rabbit.Message) {
 
 		defer wg.Done()
 
-		var err = updatePackageNameFromApp(&pack)
+		var err = updatePackageNameFromApp(ctx, &pack)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -169,27 +170,27 @@ func packageHandler(message *rabbit.Message) {
 		return
 	}
 
-	// Save price changes
+	// Save price changes and package
 	wg.Add(1)
 	go func() {
 
 		defer wg.Done()
 
-		var err = saveProductPricesToMongo(packageBeforeUpdate, pack)
+		var err = saveProductPricesToMongo(ctx, packageBeforeUpdate, pack)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
 			return
 		}
-	}()
 
-	// Save package
-	wg.Add(1)
-	go func() {
-
-		defer wg.Done()
+		pack.PriceStats, err = mongo.GetPriceStats(ctx, pack.ID, helpers.ProductTypePackage, pack.Prices)
+		if err != nil {
+			log.ErrS(err, payload.ID)
+			sendToRetryQueue(message)
+			return
+		}
 
-		var err = pack.Save()
+		err = pack.Save()
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -214,7 +215,7 @@ func packageHandler(message *rabbit.Message) {
 			var err error
 
 			wsPayload := IntPayload{ID: payload.ID}
-			err = ProduceWebsocket(wsPayload, websockets.PagePackage, websockets.PagePackages)
+			err = ProduceWebsocket(ctx, wsPayload, websockets.PagePackage, websockets.PagePackages)
 			if err != nil {
 				log.ErrS(err, payload.ID)
 			}
@@ -233,7 +234,7 @@ func packageHandler(message *rabbit.Message) {
 			memcache.ItemPackageBundles(pack.ID).Key,
 		}
 
-		err := memcache.Client().Delete(items...)
+		err := memcache.Client().WithContext(ctx).Delete(items...)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -249,7 +250,7 @@ func packageHandler(message *rabbit.Message) {
 	//
 	//	if payload.ChangeNumber > 0 {
 	//
-	//		err := ProduceSteam(SteamMessage{AppIDs: pack.Apps})
+	//		err := ProduceSteam(ctx, SteamMessage{AppIDs: pack.Apps})
 	//		if err != nil {
 	//			log.ErrS(err)
 	//		}
@@ -265,11 +266,11 @@ func packageHandler(message *rabbit.Message) {
 	//
 	message.Ack()
 }
-func updatePackageNameFromApp(pack *mongo.Package) (err error) {
+func updatePackageNameFromApp(ctx context.Context, pack *mongo.Package) (err error) {
 
 	if pack.HasEmptyName() || pack.HasEmptyIcon() || pack.ImageLogo == "" || pack.ImagePage == "" {
 
-		apps, err := mongo.GetAppsByID(pack.Apps, bson.M{"_id": 1, "player_peak_alltime": 1})
+		apps, err := mongo.GetAppsByID(ctx, pack.Apps, bson.M{"_id": 1, "player_peak_alltime": 1})
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -9,8 +10,10 @@ import (
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/i18n"
 	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/steam"
+	"github.com/gamedb/gamedb/pkg/streams"
 	"github.com/gamedb/gamedb/pkg/websockets"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -27,7 +30,7 @@ type PackagePriceMessage struct {
 	LowestPrice *int               `json:"lowest_price"`
 }
 
-func packagePriceHandler(message *rabbit.Message) {
+func packagePriceHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PackagePriceMessage{}
 
@@ -73,7 +76,7 @@ func packagePriceHandler(message *rabbit.Message) {
 			},
 		}
 
-		_, err = mongo.UpdateOne(mongo.CollectionPackages, bson.D{{"_id", payload.PackageID}}, update)
+		_, err = mongo.UpdateOne(ctx, mongo.CollectionPackages, bson.D{{"_id", payload.PackageID}}, update)
 		if err != nil {
 			log.ErrS(err)
 			sendToRetryQueue(message)
@@ -107,18 +110,23 @@ func packagePriceHandler(message *rabbit.Message) {
 					price.DifferencePercent = (float64(newPrice-oldPrice) / float64(oldPrice)) * 100
 				}
 
-				result, err := mongo.InsertOne(mongo.CollectionProductPrices, price)
+				result, err := mongo.InsertOne(ctx, mongo.CollectionProductPrices, price)
 				if err != nil {
 					log.ErrS(err)
 					return
 				}
 
-				// Send websockets to prices page
+				// Send websockets to prices page, and to backend streams
 				if result != nil {
 					if insertedID, ok := result.InsertedID.(primitive.ObjectID); ok {
 
 						wsPayload := StringsPayload{IDs: []string{insertedID.Hex()}}
-						err2 := ProduceWebsocket(wsPayload, websockets.PagePrices)
+						err2 := ProduceWebsocket(ctx, wsPayload, websockets.PagePrices)
+						if err2 != nil {
+							log.ErrS(err2)
+						}
+
+						err2 = ProduceStream(ctx, wsPayload, streams.TopicPrices)
 						if err2 != nil {
 							log.ErrS(err2)
 						}
@@ -149,6 +157,36 @@ func packagePriceHandler(message *rabbit.Message) {
 
 	wg.Wait()
 
+	if message.ActionTaken {
+		return
+	}
+
+	// Update price stats, now the new price and change are saved
+	var prices = helpers.ProductPrices{}
+	prices.AddPriceFromPackage(productCC.ProductCode, response)
+
+	stats, err := mongo.GetPriceStats(ctx, int(payload.PackageID), helpers.ProductTypePackage, prices)
+	if err != nil {
+		log.ErrS(err)
+		sendToRetryQueue(message)
+		return
+	}
+
+	if stat, ok := stats[productCC.ProductCode]; ok {
+
+		_, err = mongo.UpdateOne(ctx, mongo.CollectionPackages, bson.D{{"_id", payload.PackageID}}, bson.D{{"price_stats." + string(productCC.ProductCode), stat}})
+		if err != nil {
+			log.ErrS(err)
+			sendToRetryQueue(message)
+			return
+		}
+
+		err = memcache.Client().WithContext(ctx).Delete(memcache.ItemPackage(int(payload.PackageID)).Key)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+
 	//
 	message.Ack()
 }
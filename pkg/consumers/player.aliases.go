@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"time"
 
 	"github.com/Jleagle/rabbit-go"
@@ -23,7 +24,7 @@ func (m PlayersAliasesMessage) Queue() rabbit.QueueName {
 	return QueuePlayersAliases
 }
 
-func playerAliasesHandler(message *rabbit.Message) {
+func playerAliasesHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayersAliasesMessage{}
 
@@ -35,7 +36,7 @@ func playerAliasesHandler(message *rabbit.Message) {
 	}
 
 	// Websocket
-	defer sendPlayerWebsocket(payload.PlayerID, "alias", message)
+	defer sendPlayerWebsocket(ctx, payload.PlayerID, "alias", message)
 
 	//
 	if payload.PlayerRemoved {
@@ -85,7 +86,7 @@ func playerAliasesHandler(message *rabbit.Message) {
 		playerAliasStrings = append(playerAliasStrings, v.Alias)
 	}
 
-	err = mongo.ReplacePlayerAliases(playerAliases)
+	err = mongo.ReplacePlayerAliases(ctx, playerAliases)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -95,7 +96,7 @@ func playerAliasesHandler(message *rabbit.Message) {
 	// Update player row
 	update := bson.D{{"aliases", playerAliasStrings}}
 
-	_, err = mongo.UpdateOne(mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -103,7 +104,7 @@ func playerAliasesHandler(message *rabbit.Message) {
 	}
 
 	// Clear player cache
-	err = memcache.Client().Delete(memcache.ItemPlayer(payload.PlayerID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemPlayer(payload.PlayerID).Key)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -111,7 +112,7 @@ func playerAliasesHandler(message *rabbit.Message) {
 	}
 
 	// Update Elastic
-	err = ProducePlayerSearch(nil, payload.PlayerID)
+	err = ProducePlayerSearch(ctx, nil, payload.PlayerID)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -27,7 +28,7 @@ type PlayerAchievementsMessage struct {
 	OldCountApps int   `json:"old_count_apps"`
 }
 
-func playerAchievementsHandler(message *rabbit.Message) {
+func playerAchievementsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayerAchievementsMessage{}
 
@@ -51,28 +52,28 @@ func playerAchievementsHandler(message *rabbit.Message) {
 				Queue: "achievement",
 			}
 
-			err = ProduceWebsocket(wsPayload, websockets.PagePlayer)
+			err = ProduceWebsocket(ctx, wsPayload, websockets.PagePlayer)
 			if err != nil {
 				log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			}
 		}()
 
 		// Total achievements
-		count, err := mongo.CountDocuments(mongo.CollectionPlayerAchievements, bson.D{{"player_id", payload.PlayerID}}, 0)
+		count, err := mongo.CountDocuments(ctx, mongo.CollectionPlayerAchievements, bson.D{{"player_id", payload.PlayerID}}, 0)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
 			return
 		}
 
-		count100, err := mongo.CountDocuments(mongo.CollectionPlayerApps, bson.D{{"player_id", payload.PlayerID}, {"app_achievements_percent", 100}}, 0)
+		count100, err := mongo.CountDocuments(ctx, mongo.CollectionPlayerApps, bson.D{{"player_id", payload.PlayerID}, {"app_achievements_percent", 100}}, 0)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
 			return
 		}
 
-		countApps, err := mongo.CountDocuments(mongo.CollectionPlayerApps, bson.D{{"player_id", payload.PlayerID}, {"app_achievements_have", bson.M{"$gt": 0}}}, 0)
+		countApps, err := mongo.CountDocuments(ctx, mongo.CollectionPlayerApps, bson.D{{"player_id", payload.PlayerID}, {"app_achievements_have", bson.M{"$gt": 0}}}, 0)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
@@ -96,7 +97,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 			{"achievement_count_apps", int(countApps)},
 		}
 
-		_, err = mongo.UpdateOne(mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
+		_, err = mongo.UpdateOne(ctx, mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
@@ -110,7 +111,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 			schemas.InfPlayersAchievementsApps: countApps,
 		}
 
-		err = savePlayerStatsToInflux(payload.PlayerID, fields)
+		err = savePlayerStatsToInflux(ctx, payload.PlayerID, fields)
 		if err != nil {
 			log.ErrS(err, payload.PlayerID)
 			sendToRetryQueue(message)
@@ -125,7 +126,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 			memcache.ItemPlayerAchievementsInflux(payload.PlayerID).Key,
 		}
 
-		err = memcache.Client().Delete(items...)
+		err = memcache.Client().WithContext(ctx).Delete(items...)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
@@ -133,7 +134,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 		}
 
 		// Update Elastic
-		err = ProducePlayerSearch(nil, payload.PlayerID)
+		err = ProducePlayerSearch(ctx, nil, payload.PlayerID)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
@@ -147,7 +148,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 
 	item := memcache.ItemAppNoAchievements(payload.AppID) // Cache if this app has no achievements
 
-	exists, err := memcache.Client().Exists(item.Key)
+	exists, err := memcache.Client().WithContext(ctx).Exists(item.Key)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -157,7 +158,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 	}
 
 	// Get app
-	app, err := mongo.GetApp(payload.AppID, false)
+	app, err := mongo.GetApp(ctx, payload.AppID, false)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -165,14 +166,14 @@ func playerAchievementsHandler(message *rabbit.Message) {
 	}
 
 	if app.AchievementsCount == 0 {
-		err = memcache.Client().Set(item.Key, item.Value, item.Expiration)
+		err = memcache.Client().WithContext(ctx).Set(item.Key, item.Value, item.Expiration)
 		if err != nil {
 			log.ErrS(err)
 		}
 	}
 
 	// Get player
-	player, err := mongo.GetPlayer(payload.PlayerID)
+	player, err := mongo.GetPlayer(ctx, payload.PlayerID)
 	if err != nil {
 
 		// ErrNoDocuments can be returned on new signups as the player hasnt been created yet
@@ -204,7 +205,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 	if !resp.Success {
 
 		if resp.Error == "Requested app has no stats" {
-			err = memcache.Client().Set(item.Key, item.Value, item.Expiration)
+			err = memcache.Client().WithContext(ctx).Set(item.Key, item.Value, item.Expiration)
 			if err != nil {
 				log.ErrS(err)
 			}
@@ -217,7 +218,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 	// Get the last saved achievement
 	var timestamp int64
 	if !config.IsLocal() && !payload.Force {
-		timestamp, err = mongo.FindLatestPlayerAchievement(payload.PlayerID, payload.AppID)
+		timestamp, err = mongo.FindLatestPlayerAchievement(ctx, payload.PlayerID, payload.AppID)
 		if err != nil {
 			log.ErrS(err)
 			sendToRetryQueue(message)
@@ -242,7 +243,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 			{"key", bson.M{"$in": a}},
 		}
 
-		appAchievements, err := mongo.GetAppAchievements(0, 0, filter, nil)
+		appAchievements, err := mongo.GetAppAchievements(ctx, 0, 0, filter, nil)
 		if err != nil {
 			log.ErrS(err)
 			sendToRetryQueue(message)
@@ -279,7 +280,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 		}
 	}
 
-	err = mongo.ReplacePlayerAchievements(rows)
+	err = mongo.ReplacePlayerAchievements(ctx, rows)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
@@ -309,7 +310,7 @@ func playerAchievementsHandler(message *rabbit.Message) {
 		{"app_achievements_percent", percent},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionPlayerApps, bson.D{{"_id", playerApp.GetKey()}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionPlayerApps, bson.D{{"_id", playerApp.GetKey()}}, update)
 	if err != nil {
 		log.ErrS(err)
 		sendToRetryQueue(message)
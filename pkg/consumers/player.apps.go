@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"sort"
 	"time"
 
@@ -31,7 +32,7 @@ func (m PlayerGamesMessage) Queue() rabbit.QueueName {
 }
 
 // Always updates player_app rows as the playtime will change
-func playerGamesHandler(message *rabbit.Message) {
+func playerGamesHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayerGamesMessage{}
 
@@ -43,7 +44,7 @@ func playerGamesHandler(message *rabbit.Message) {
 	}
 
 	// Websocket
-	defer sendPlayerWebsocket(payload.PlayerID, "game", message)
+	defer sendPlayerWebsocket(ctx, payload.PlayerID, "game", message)
 
 	//
 	if message.Attempt() > 10 {
@@ -105,7 +106,7 @@ func playerGamesHandler(message *rabbit.Message) {
 	)
 
 	// Getting missing price info from Mongo
-	gameRows, err := mongo.GetAppsByID(appIDs, bson.M{"_id": 1, "prices": 1, "type": 1, "game_id": 1, "dlc_count": 1})
+	gameRows, err := mongo.GetAppsByID(ctx, appIDs, bson.M{"_id": 1, "prices": 1, "type": 1, "game_id": 1, "dlc_count": 1})
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -149,7 +150,7 @@ func playerGamesHandler(message *rabbit.Message) {
 	updatePlayer = append(updatePlayer, bson.E{Key: "games_by_type", Value: gamesByType})
 
 	// Save playerApps to Mongo
-	err = mongo.UpdatePlayerApps(playerApps)
+	err = mongo.UpdatePlayerApps(ctx, playerApps)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -182,7 +183,7 @@ func playerGamesHandler(message *rabbit.Message) {
 		if payload.PlayerUpdated.Before(time.Now().Add(time.Hour*24*13*-1)) || payload.ForceAchievementsRefresh { // If updated over 13 days ago
 			for _, v := range resp.Games {
 				if v.PlaytimeForever > 0 {
-					err = ProducePlayerAchievements(
+					err = ProducePlayerAchievements(ctx,
 						payload.PlayerID, v.AppID, payload.ForceAchievementsRefresh,
 						payload.OldAchievementCount, payload.OldAchievementCount100, payload.OldAchievementCountApps,
 					)
@@ -191,7 +192,7 @@ func playerGamesHandler(message *rabbit.Message) {
 					}
 				}
 			}
-			err = ProducePlayerAchievements(
+			err = ProducePlayerAchievements(ctx,
 				payload.PlayerID, 0, false,
 				payload.OldAchievementCount, payload.OldAchievementCount100, payload.OldAchievementCountApps,
 			)
@@ -202,7 +203,7 @@ func playerGamesHandler(message *rabbit.Message) {
 	}
 
 	// Update player row
-	_, err = mongo.UpdateOne(mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, updatePlayer)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, updatePlayer)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -215,7 +216,7 @@ func playerGamesHandler(message *rabbit.Message) {
 		schemas.InfPlayersPlaytime: playtime,
 	}
 
-	err = savePlayerStatsToInflux(payload.PlayerID, fields)
+	err = savePlayerStatsToInflux(ctx, payload.PlayerID, fields)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -223,7 +224,7 @@ func playerGamesHandler(message *rabbit.Message) {
 	}
 
 	// Clear player cache
-	err = memcache.Client().Delete(memcache.ItemPlayer(payload.PlayerID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemPlayer(payload.PlayerID).Key)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -231,7 +232,7 @@ func playerGamesHandler(message *rabbit.Message) {
 	}
 
 	// Update Elastic
-	err = ProducePlayerSearch(nil, payload.PlayerID)
+	err = ProducePlayerSearch(ctx, nil, payload.PlayerID)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -24,7 +25,7 @@ func (m PlayersAwardsMessage) Queue() rabbit.QueueName {
 	return QueuePlayersAwards
 }
 
-func playerAwardsHandler(message *rabbit.Message) {
+func playerAwardsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayersAwardsMessage{}
 
@@ -36,7 +37,7 @@ func playerAwardsHandler(message *rabbit.Message) {
 	}
 
 	// Websocket
-	defer sendPlayerWebsocket(payload.PlayerID, "award", message)
+	defer sendPlayerWebsocket(ctx, payload.PlayerID, "award", message)
 
 	c := colly.NewCollector(
 		colly.AllowURLRevisit(),
@@ -93,14 +94,14 @@ func playerAwardsHandler(message *rabbit.Message) {
 		{"awards_received_points", awardsReceivedPoints},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
 	if err != nil {
 		log.ErrS(err, payload.PlayerID)
 		sendToRetryQueue(message)
 		return
 	}
 
-	err = memcache.Client().Delete(memcache.ItemPlayer(payload.PlayerID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemPlayer(payload.PlayerID).Key)
 	if err != nil {
 		log.ErrS(err, payload.PlayerID)
 		sendToRetryQueue(message)
@@ -108,7 +109,7 @@ func playerAwardsHandler(message *rabbit.Message) {
 	}
 
 	// Update in Elastic
-	err = ProducePlayerSearch(nil, payload.PlayerID)
+	err = ProducePlayerSearch(ctx, nil, payload.PlayerID)
 	if err != nil {
 		log.ErrS(err, payload.PlayerID)
 		sendToRetryQueue(message)
@@ -123,7 +124,7 @@ func playerAwardsHandler(message *rabbit.Message) {
 		schemas.InfPlayersAwardsReceivedPoints: awardsReceivedPoints,
 	}
 
-	err = savePlayerStatsToInflux(payload.PlayerID, fields)
+	err = savePlayerStatsToInflux(ctx, payload.PlayerID, fields)
 	if err != nil {
 		log.ErrS(err, payload.PlayerID)
 		sendToRetryQueue(message)
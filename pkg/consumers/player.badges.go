@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"time"
 
 	"github.com/Jleagle/rabbit-go"
@@ -27,7 +28,7 @@ func (m PlayerBadgesMessage) Queue() rabbit.QueueName {
 }
 
 // Always updates player_app rows as the playtime will change
-func playerBadgesHandler(message *rabbit.Message) {
+func playerBadgesHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayerBadgesMessage{}
 
@@ -39,7 +40,7 @@ func playerBadgesHandler(message *rabbit.Message) {
 	}
 
 	// Websocket
-	defer sendPlayerWebsocket(payload.PlayerID, "badge", message)
+	defer sendPlayerWebsocket(ctx, payload.PlayerID, "badge", message)
 
 	//
 	if message.Attempt() > 10 {
@@ -86,7 +87,7 @@ func playerBadgesHandler(message *rabbit.Message) {
 
 	// Make map of app rows
 	var appRowsMap = map[int]mongo.App{}
-	appRows, err := mongo.GetAppsByID(appIDSlice, bson.M{"_id": 1, "name": 1, "icon": 1})
+	appRows, err := mongo.GetAppsByID(ctx, appIDSlice, bson.M{"_id": 1, "name": 1, "icon": 1})
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -124,7 +125,7 @@ func playerBadgesHandler(message *rabbit.Message) {
 	}
 
 	// Save to Mongo
-	err = mongo.ReplacePlayerBadges(playerBadgeSlice)
+	err = mongo.ReplacePlayerBadges(ctx, playerBadgeSlice)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -144,7 +145,7 @@ func playerBadgesHandler(message *rabbit.Message) {
 		}},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -157,7 +158,7 @@ func playerBadgesHandler(message *rabbit.Message) {
 		schemas.InfPlayersBadgesFoil: foilBadgeCount,
 	}
 
-	err = savePlayerStatsToInflux(payload.PlayerID, fields)
+	err = savePlayerStatsToInflux(ctx, payload.PlayerID, fields)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -165,7 +166,7 @@ func playerBadgesHandler(message *rabbit.Message) {
 	}
 
 	// Clear player cache
-	err = memcache.Client().Delete(memcache.ItemPlayer(payload.PlayerID).Key)
+	err = memcache.Client().WithContext(ctx).Delete(memcache.ItemPlayer(payload.PlayerID).Key)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -173,7 +174,7 @@ func playerBadgesHandler(message *rabbit.Message) {
 	}
 
 	// Update Elastic
-	err = ProducePlayerSearch(nil, payload.PlayerID)
+	err = ProducePlayerSearch(ctx, nil, payload.PlayerID)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
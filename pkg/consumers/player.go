@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"path"
 	"strconv"
 	"strings"
@@ -35,7 +36,7 @@ type PlayerMessage struct {
 	UserAgent                *string `json:"user_agent"`
 }
 
-func playerHandler(message *rabbit.Message) {
+func playerHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayerMessage{}
 
@@ -63,7 +64,7 @@ func playerHandler(message *rabbit.Message) {
 	}
 
 	// Update player
-	player, err := mongo.GetPlayer(payload.ID)
+	player, err := mongo.GetPlayer(ctx, payload.ID)
 	if err == nil && payload.SkipExistingPlayer {
 		message.Ack()
 		return
@@ -104,7 +105,7 @@ func playerHandler(message *rabbit.Message) {
 			New:           newPlayer,
 		}
 
-		err = ProduceWebsocket(wsPayload, websockets.PagePlayer)
+		err = ProduceWebsocket(ctx, wsPayload, websockets.PagePlayer)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 		}
@@ -137,14 +138,14 @@ func playerHandler(message *rabbit.Message) {
 			return
 		}
 
-		err = updatePlayerRecentGames(&player, payload)
+		err = updatePlayerRecentGames(ctx, &player, payload)
 		if err != nil {
 			steam.LogSteamError(err, zap.Int64("player id", payload.ID))
 			sendToRetryQueue(message)
 			return
 		}
 
-		err = updatePlayerFriends(&player)
+		err = updatePlayerFriends(ctx, &player)
 		if err != nil {
 			steam.LogSteamError(err, zap.Int64("player id", payload.ID))
 			sendToRetryQueue(message)
@@ -192,7 +193,7 @@ func playerHandler(message *rabbit.Message) {
 
 		defer wg.Done()
 
-		apps, err := mongo.GetPlayerWishlistAppsByPlayer(player.ID, 0, 0, nil, bson.M{"app_prices": 1})
+		apps, err := mongo.GetPlayerWishlistAppsByPlayer(ctx, player.ID, 0, 0, nil, bson.M{"app_prices": 1})
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -216,13 +217,21 @@ func playerHandler(message *rabbit.Message) {
 		return
 	}
 
+	// The player may have changed their privacy settings since it was cached
+	player.Privacy, err = mongo.GetPlayerPrivacy(ctx, player.ID)
+	if err != nil {
+		log.ErrS(err, payload.ID)
+		sendToRetryQueue(message)
+		return
+	}
+
 	// Write to databases
 	wg.Add(1)
 	go func() {
 
 		defer wg.Done()
 
-		err = savePlayerRow(player)
+		err = savePlayerRow(ctx, player)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -236,7 +245,7 @@ func playerHandler(message *rabbit.Message) {
 
 			defer wg.Done()
 
-			err = updatePlayerFriendRows(player)
+			err = updatePlayerFriendRows(ctx, player)
 			if err != nil {
 				log.ErrS(err, payload.ID)
 				sendToRetryQueue(message)
@@ -298,7 +307,7 @@ func playerHandler(message *rabbit.Message) {
 			memcache.ItemPlayerInQueue(player.ID).Key,
 		}
 
-		err = memcache.Client().Delete(items...)
+		err = memcache.Client().WithContext(ctx).Delete(items...)
 		if err != nil {
 			log.ErrS(err, payload.ID)
 			sendToRetryQueue(message)
@@ -357,7 +366,7 @@ func playerHandler(message *rabbit.Message) {
 	}
 
 	for _, v := range produces {
-		err = produce(v.Queue(), v)
+		err = produce(ctx, v.Queue(), v)
 		if err != nil {
 			log.ErrS(err)
 			sendToRetryQueue(message)
@@ -402,10 +411,10 @@ func updatePlayerSummary(player *mongo.Player) error {
 	return err
 }
 
-func updatePlayerRecentGames(player *mongo.Player, payload PlayerMessage) error {
+func updatePlayerRecentGames(ctx context.Context, player *mongo.Player, payload PlayerMessage) error {
 
 	// Get data
-	oldAppsSlice, err := mongo.GetRecentApps(player.ID, 0, 0, nil)
+	oldAppsSlice, err := mongo.GetRecentApps(ctx, player.ID, 0, 0, nil)
 	if err != nil {
 		return err
 	}
@@ -446,12 +455,12 @@ func updatePlayerRecentGames(player *mongo.Player, payload PlayerMessage) error
 	}
 
 	// Update DB
-	err = mongo.DeleteRecentApps(player.ID, appsToRem)
+	err = mongo.DeleteRecentApps(ctx, player.ID, appsToRem)
 	if err != nil {
 		return err
 	}
 
-	err = mongo.ReplaceRecentApps(appsToAdd)
+	err = mongo.ReplaceRecentApps(ctx, appsToAdd)
 	if err != nil {
 		return err
 	}
@@ -460,7 +469,7 @@ func updatePlayerRecentGames(player *mongo.Player, payload PlayerMessage) error
 	if !payload.SkipAchievements {
 		if player.UpdatedAt.After(time.Now().Add(time.Hour*24*13*-1)) && !payload.ForceAchievementsRefresh { // If updated in the last 13 days
 			for _, v := range newAppsSlice {
-				err = ProducePlayerAchievements(
+				err = ProducePlayerAchievements(ctx,
 					player.ID, v.AppID, false,
 					player.AchievementCount, player.AchievementCount100, player.AchievementCountApps,
 				)
@@ -468,7 +477,7 @@ func updatePlayerRecentGames(player *mongo.Player, payload PlayerMessage) error
 					log.ErrS(err)
 				}
 			}
-			err = ProducePlayerAchievements(
+			err = ProducePlayerAchievements(ctx,
 				player.ID, 0, false,
 				player.AchievementCount, player.AchievementCount100, player.AchievementCountApps,
 			)
@@ -481,7 +490,7 @@ func updatePlayerRecentGames(player *mongo.Player, payload PlayerMessage) error
 	return nil
 }
 
-func updatePlayerFriends(player *mongo.Player) error {
+func updatePlayerFriends(ctx context.Context, player *mongo.Player) error {
 
 	newFriendsSlice, err := steam.GetSteam().GetFriendList(player.ID)
 	err = steam.AllowSteamCodes(err, 401, 404)
@@ -493,7 +502,7 @@ func updatePlayerFriends(player *mongo.Player) error {
 	player.FriendsCount = len(newFriendsSlice)
 
 	// Get data
-	oldFriendsSlice, err := mongo.GetFriends(player.ID, 0, 0, nil, nil)
+	oldFriendsSlice, err := mongo.GetFriends(ctx, player.ID, 0, 0, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -525,12 +534,13 @@ func updatePlayerFriends(player *mongo.Player) error {
 	}
 
 	// Fill in missing map the map
-	friendRows, err := mongo.GetPlayersByID(friendIDsToAdd, bson.M{
+	friendRows, err := mongo.GetPlayersByID(ctx, friendIDsToAdd, bson.M{
 		"_id":          1,
 		"avatar":       1,
 		"games_count":  1,
 		"persona_name": 1,
 		"level":        1,
+		"privacy":      1,
 	})
 	if err != nil {
 		return err
@@ -543,11 +553,12 @@ func updatePlayerFriends(player *mongo.Player) error {
 			friendsToAdd[friend.ID].Games = friend.GamesCount
 			friendsToAdd[friend.ID].Name = friend.GetName()
 			friendsToAdd[friend.ID].Level = friend.Level
+			friendsToAdd[friend.ID].Hidden = friend.Privacy.HideFriends
 		}
 	}
 
 	// Update DB
-	err = mongo.DeleteFriends(player.ID, friendsToRem)
+	err = mongo.DeleteFriends(ctx, player.ID, friendsToRem)
 	if err != nil {
 		return err
 	}
@@ -557,7 +568,7 @@ func updatePlayerFriends(player *mongo.Player) error {
 		friendsToAddSlice = append(friendsToAddSlice, v)
 	}
 
-	return mongo.ReplacePlayerFriends(friendsToAddSlice)
+	return mongo.ReplacePlayerFriends(ctx, friendsToAddSlice)
 }
 
 func updatePlayerLevel(player *mongo.Player) error {
@@ -621,13 +632,24 @@ func updatePlayerComments(player *mongo.Player) ([]byte, error) {
 	return b, nil
 }
 
-func savePlayerRow(player mongo.Player) error {
+// The player sets private and privacy in settings, and ranks come from the ranks cron,
+// so they are left alone in case the cached player is behind
+func savePlayerRow(ctx context.Context, player mongo.Player) error {
+
+	var skip = map[string]bool{"_id": true, "private": true, "privacy": true, "ranks": true}
 
-	_, err := mongo.ReplaceOne(mongo.CollectionPlayers, bson.D{{"_id", player.ID}}, player)
+	var update bson.D
+	for _, v := range player.BSON() {
+		if !skip[v.Key] {
+			update = append(update, v)
+		}
+	}
+
+	_, err := mongo.UpsertOneSet(ctx, mongo.CollectionPlayers, bson.D{{"_id", player.ID}}, update)
 	return err
 }
 
-func updatePlayerFriendRows(player mongo.Player) error {
+func updatePlayerFriendRows(ctx context.Context, player mongo.Player) error {
 
 	update := bson.D{
 		{"avatar", player.Avatar},
@@ -636,12 +658,16 @@ func updatePlayerFriendRows(player mongo.Player) error {
 		{"level", player.Level},
 	}
 
-	_, err := mongo.UpdateManySet(mongo.CollectionPlayerFriends, bson.D{{"friend_id", player.ID}}, update)
+	_, err := mongo.UpdateManySet(ctx, mongo.CollectionPlayerFriends, bson.D{{"friend_id", player.ID}}, update)
 	return err
 }
 
 func savePlayerToInflux(player mongo.Player) (err error) {
 
+	if player.Privacy.NoHistory {
+		return nil
+	}
+
 	fields := map[schemas.PlayerField]interface{}{
 		schemas.InfPlayersComments: player.CommentsCount,
 		schemas.InfPlayersFriends:  player.FriendsCount,
@@ -649,11 +675,27 @@ func savePlayerToInflux(player mongo.Player) (err error) {
 		// Others stored in sub queues
 	}
 
-	return savePlayerStatsToInflux(player.ID, fields)
+	return writePlayerStatsToInflux(player.ID, fields)
 }
 
 // Helper used in other consumers
-func savePlayerStatsToInflux(playerId int64, fields map[schemas.PlayerField]interface{}) error {
+func savePlayerStatsToInflux(ctx context.Context, playerId int64, fields map[schemas.PlayerField]interface{}) error {
+
+	// Sub queues only have the ID, the player is usually cached from the main queue
+	player, err := mongo.GetPlayer(ctx, playerId)
+	if err == nil && player.Privacy.NoHistory {
+		return nil
+	}
+
+	err = helpers.IgnoreErrors(err, mongo.ErrNoDocuments)
+	if err != nil {
+		return err
+	}
+
+	return writePlayerStatsToInflux(playerId, fields)
+}
+
+func writePlayerStatsToInflux(playerId int64, fields map[schemas.PlayerField]interface{}) error {
 
 	interfaceFields := map[string]interface{}{}
 	for k, v := range fields {
@@ -675,14 +717,14 @@ func savePlayerStatsToInflux(playerId int64, fields map[schemas.PlayerField]inte
 }
 
 // Helper used in other consumers
-func sendPlayerWebsocket(playerID int64, key string, message *rabbit.Message) {
+func sendPlayerWebsocket(ctx context.Context, playerID int64, key string, message *rabbit.Message) {
 
 	wsPayload := PlayerPayload{
 		ID:    strconv.FormatInt(playerID, 10),
 		Queue: key,
 	}
 
-	err := ProduceWebsocket(wsPayload, websockets.PagePlayer)
+	err := ProduceWebsocket(ctx, wsPayload, websockets.PagePlayer)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 	}
@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -23,7 +25,7 @@ func (m PlayersGroupsMessage) Queue() rabbit.QueueName {
 	return QueuePlayersGroups
 }
 
-func playersGroupsHandler(message *rabbit.Message) {
+func playersGroupsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayersGroupsMessage{}
 
@@ -35,7 +37,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 	}
 
 	// Websocket
-	defer sendPlayerWebsocket(payload.Player.ID, "group", message)
+	defer sendPlayerWebsocket(ctx, payload.Player.ID, "group", message)
 
 	if payload.Player.Removed {
 		message.Ack()
@@ -43,7 +45,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 	}
 
 	// Old groups
-	oldGroupsSlice, err := mongo.GetPlayerGroups(payload.Player.ID, 0, 0, nil)
+	oldGroupsSlice, err := mongo.GetPlayerGroups(ctx, payload.Player.ID, 0, 0, nil)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -89,7 +91,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 	if len(toAddIDs) > 0 {
 
 		// Find new groups
-		toAdd, err := mongo.GetGroupsByID(toAddIDs, nil)
+		toAdd, err := mongo.GetGroupsByID(ctx, toAddIDs, nil)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
@@ -116,7 +118,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 			})
 		}
 
-		err = mongo.ReplacePlayerGroups(newPlayerGroupSlice)
+		err = mongo.ReplacePlayerGroups(ctx, newPlayerGroupSlice)
 		if err != nil {
 			log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 			sendToRetryQueue(message)
@@ -132,7 +134,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 		}
 	}
 
-	err = mongo.DeletePlayerGroups(payload.Player.ID, toDeleteIDs)
+	err = mongo.DeletePlayerGroups(ctx, payload.Player.ID, toDeleteIDs)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -142,7 +144,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 	// Queue groups for update
 	if !payload.SkipGroupUpdate {
 		for id := range newGroupsMap {
-			err = ProduceGroup(GroupMessage{ID: id, UserAgent: payload.UserAgent})
+			err = ProduceGroup(ctx, GroupMessage{ID: id, UserAgent: payload.UserAgent})
 			err = helpers.IgnoreErrors(err, ErrInQueue, ErrIsBot)
 			if err != nil {
 				log.ErrS(err)
@@ -155,7 +157,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 		{"groups_count", len(newGroupsMap)},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionPlayers, bson.D{{"_id", payload.Player.ID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionPlayers, bson.D{{"_id", payload.Player.ID}}, update)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -167,7 +169,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 		memcache.ItemPlayer(payload.Player.ID).Key,
 	}
 
-	err = memcache.Client().Delete(items...)
+	err = memcache.Client().WithContext(ctx).Delete(items...)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -175,7 +177,7 @@ func playersGroupsHandler(message *rabbit.Message) {
 	}
 
 	// Update Elastic
-	err = ProducePlayerSearch(nil, payload.Player.ID)
+	err = ProducePlayerSearch(ctx, nil, payload.Player.ID)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
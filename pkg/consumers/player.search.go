@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+	"strconv"
 	"time"
 
 	"github.com/Jleagle/rabbit-go"
@@ -9,6 +11,7 @@ import (
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/olivere/elastic/v7"
 	"go.uber.org/zap"
 )
 
@@ -21,7 +24,7 @@ func (m PlayersSearchMessage) Queue() rabbit.QueueName {
 	return QueuePlayersSearch
 }
 
-func appsPlayersHandler(message *rabbit.Message) {
+func appsPlayersHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayersSearchMessage{}
 
@@ -36,7 +39,7 @@ func appsPlayersHandler(message *rabbit.Message) {
 
 	if payload.PlayerID > 0 {
 
-		mongoPlayer, err = mongo.GetPlayer(payload.PlayerID)
+		mongoPlayer, err = mongo.GetPlayer(ctx, payload.PlayerID)
 		if err == mongo.ErrNoDocuments || err == steamid.ErrInvalidPlayerID {
 			message.Ack()
 			return
@@ -56,6 +59,24 @@ func appsPlayersHandler(message *rabbit.Message) {
 		return
 	}
 
+	if mongoPlayer.Privacy.HideSearch {
+
+		err = elasticsearch.DeleteDocument(ctx, elasticsearch.IndexPlayers, strconv.FormatInt(mongoPlayer.ID, 10))
+		if val, ok := err.(*elastic.Error); ok && val.Status == 404 {
+			err = nil
+		}
+		if err != nil {
+			log.Err("Deleting player", zap.Error(err), zap.Int64("app", mongoPlayer.ID))
+			sendToRetryQueue(message)
+			return
+		}
+
+		message.Ack()
+		return
+	}
+
+	mongoPlayer.ApplyPrivacy()
+
 	player := elasticsearch.Player{}
 	player.ID = mongoPlayer.ID
 	player.PersonaName = mongoPlayer.PersonaName
@@ -79,11 +100,12 @@ func appsPlayersHandler(message *rabbit.Message) {
 	player.AwardsReceivedCount = mongoPlayer.AwardsReceivedCount
 	player.AwardsReceivedPoints = mongoPlayer.AwardsReceivedPoints
 	player.Ranks = mongoPlayer.Ranks
+	player.HideRanks = mongoPlayer.Privacy.HideRanks
 
 	// Add aliases
 	sixMonthsAgo := time.Now().AddDate(0, -6, 0).Unix()
 
-	aliases, err := mongo.GetPlayerAliases(mongoPlayer.ID, 5, sixMonthsAgo)
+	aliases, err := mongo.GetPlayerAliases(ctx, mongoPlayer.ID, 5, sixMonthsAgo)
 	if err != nil {
 		log.Err("retrieve aliases", zap.Error(err), zap.String("body", string(message.Message.Body)))
 		sendToFailQueue(message)
@@ -95,7 +117,7 @@ func appsPlayersHandler(message *rabbit.Message) {
 	}
 
 	// Send to Elastic
-	err = elasticsearch.IndexPlayer(player)
+	err = elasticsearch.IndexPlayer(ctx, player)
 	if err != nil {
 		log.Err("Indexing player", zap.Error(err), zap.Int64("app", mongoPlayer.ID))
 		sendToRetryQueue(message)
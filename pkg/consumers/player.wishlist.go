@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"time"
 
 	"github.com/Jleagle/rabbit-go"
@@ -22,7 +23,7 @@ func (m PlayersWishlistMessage) Queue() rabbit.QueueName {
 	return QueuePlayersWishlist
 }
 
-func playersWishlistHandler(message *rabbit.Message) {
+func playersWishlistHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayersWishlistMessage{}
 
@@ -34,7 +35,7 @@ func playersWishlistHandler(message *rabbit.Message) {
 	}
 
 	// Websocket
-	defer sendPlayerWebsocket(payload.PlayerID, "wishlist", message)
+	defer sendPlayerWebsocket(ctx, payload.PlayerID, "wishlist", message)
 
 	//
 	resp, err := steam.GetSteam().GetWishlist(payload.PlayerID)
@@ -58,7 +59,7 @@ func playersWishlistHandler(message *rabbit.Message) {
 	}
 
 	// Old
-	oldAppsSlice, err := mongo.GetPlayerWishlistAppsByPlayer(payload.PlayerID, 0, 0, nil, nil)
+	oldAppsSlice, err := mongo.GetPlayerWishlistAppsByPlayer(ctx, payload.PlayerID, 0, 0, nil, nil)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -78,7 +79,7 @@ func playersWishlistHandler(message *rabbit.Message) {
 		}
 	}
 
-	err = mongo.DeletePlayerWishlistApps(payload.PlayerID, toDelete)
+	err = mongo.DeletePlayerWishlistApps(ctx, payload.PlayerID, toDelete)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -100,7 +101,7 @@ func playersWishlistHandler(message *rabbit.Message) {
 	}
 
 	// Fill in data from SQL
-	apps, err := mongo.GetAppsByID(toAddIDs, bson.M{"_id": 1, "name": 1, "icon": 1, "release_state": 1, "release_date": 1, "release_date_unix": 1, "prices": 1})
+	apps, err := mongo.GetAppsByID(ctx, toAddIDs, bson.M{"_id": 1, "name": 1, "icon": 1, "release_state": 1, "release_date": 1, "release_date_unix": 1, "prices": 1})
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -121,7 +122,7 @@ func playersWishlistHandler(message *rabbit.Message) {
 		toAdd[k].AppReleaseDateNice = appsMap[v.AppID].ReleaseDate
 	}
 
-	err = mongo.ReplacePlayerWishlistApps(toAdd)
+	err = mongo.ReplacePlayerWishlistApps(ctx, toAdd)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -133,7 +134,7 @@ func playersWishlistHandler(message *rabbit.Message) {
 		{"wishlist_apps_count", len(resp.Items)},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionPlayers, bson.D{{"_id", payload.PlayerID}}, update)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -145,7 +146,7 @@ func playersWishlistHandler(message *rabbit.Message) {
 		memcache.ItemPlayer(payload.PlayerID).Key,
 	}
 
-	err = memcache.Client().Delete(items...)
+	err = memcache.Client().WithContext(ctx).Delete(items...)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -153,7 +154,7 @@ func playersWishlistHandler(message *rabbit.Message) {
 	}
 
 	// Update Elastic
-	// err = ProducePlayerSearch(nil, payload.PlayerID)
+	// err = ProducePlayerSearch(ctx, nil, payload.PlayerID)
 	// if err != nil {
 	// 	log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 	// 	sendToRetryQueue(message)
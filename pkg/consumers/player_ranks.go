@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -25,7 +26,7 @@ type PlayerRanksMessage struct {
 	State      *string `json:"state"`
 }
 
-func playerRanksHandler(message *rabbit.Message) {
+func playerRanksHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := PlayerRanksMessage{}
 
@@ -53,6 +54,7 @@ func playerRanksHandler(message *rabbit.Message) {
 		filter = append(filter, bson.E{Key: "status_code", Value: *payload.State})
 	}
 	filter = append(filter, bson.E{Key: payload.SortColumn, Value: bson.M{"$gt": 0}})
+	filter = append(filter, bson.E{Key: "privacy.hide_ranks", Value: bson.M{"$ne": true}})
 
 	// Batched to use less memory consumer memory
 	var offset int64
@@ -63,7 +65,7 @@ func playerRanksHandler(message *rabbit.Message) {
 		err := func() error {
 
 			// Get players
-			players, err = mongo.GetPlayers(offset, batchSize, bson.D{{payload.SortColumn, -1}}, filter, bson.M{"_id": 1})
+			players, err = mongo.GetPlayers(ctx, offset, batchSize, bson.D{{payload.SortColumn, -1}}, filter, bson.M{"_id": 1, "privacy": 1})
 			if err != nil {
 				return err
 			}
@@ -80,7 +82,7 @@ func playerRanksHandler(message *rabbit.Message) {
 				writes = append(writes, write)
 			}
 
-			err = mongo.BulkUpdatePlayers(writes)
+			err = mongo.BulkUpdatePlayers(ctx, writes)
 			if err != nil {
 				return err
 			}
@@ -90,6 +92,9 @@ func playerRanksHandler(message *rabbit.Message) {
 
 				var points []influx.Point
 				for position, player := range players {
+					if player.Privacy.NoHistory {
+						continue
+					}
 					if val, ok := helpers.PlayerRankFieldsInflux[helpers.RankMetric(payload.ObjectKey)]; ok {
 						points = append(points, influx.Point{
 							Measurement: string(influxHelper.InfluxMeasurementPlayers),
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/mysql/pics"
+	"github.com/gamedb/gamedb/pkg/streams"
 	"github.com/gamedb/gamedb/pkg/websockets"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
@@ -247,7 +249,7 @@ func setAppLaunchItem(kv steamvdf.KeyValue, launchItem *pics.PICSAppConfigLaunch
 	}
 }
 
-func saveProductPricesToMongo(before helpers.ProductInterface, after helpers.ProductInterface) (err error) {
+func saveProductPricesToMongo(ctx context.Context, before helpers.ProductInterface, after helpers.ProductInterface) (err error) {
 
 	var prices helpers.ProductPrices
 	var price helpers.ProductPrice
@@ -343,9 +345,9 @@ func saveProductPricesToMongo(before helpers.ProductInterface, after helpers.Pro
 		// }
 	}
 
-	result, err := mongo.InsertMany(mongo.CollectionProductPrices, documents)
+	result, err := mongo.InsertMany(ctx, mongo.CollectionProductPrices, documents)
 
-	// Send websockets to prices page
+	// Send websockets to prices page, and to backend streams
 	if err == nil && result != nil {
 
 		var priceIDs []string
@@ -359,7 +361,12 @@ func saveProductPricesToMongo(before helpers.ProductInterface, after helpers.Pro
 		if len(priceIDs) > 0 {
 
 			wsPayload := StringsPayload{IDs: priceIDs}
-			err2 := ProduceWebsocket(wsPayload, websockets.PagePrices)
+			err2 := ProduceWebsocket(ctx, wsPayload, websockets.PagePrices)
+			if err2 != nil {
+				log.ErrS(err2)
+			}
+
+			err2 = ProduceStream(ctx, wsPayload, streams.TopicPrices)
 			if err2 != nil {
 				log.ErrS(err2)
 			}
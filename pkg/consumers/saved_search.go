@@ -0,0 +1,259 @@
+package consumers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jleagle/rabbit-go"
+	"github.com/bwmarrin/discordgo"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/datatable"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/email"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/filters"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/elasticsearch"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/oauth"
+	"github.com/olivere/elastic/v7"
+	"go.uber.org/zap"
+)
+
+const (
+	savedSearchResults = 100 // The first page of the datatable
+	savedSearchNotify  = 10  // Max items listed in a notification
+)
+
+type SavedSearchMessage struct {
+	ID string `json:"id"`
+}
+
+func (m SavedSearchMessage) Queue() rabbit.QueueName {
+	return QueueSavedSearch
+}
+
+// Runs in the frontend, as it needs the email templates
+func savedSearchHandler(ctx context.Context, message *rabbit.Message) {
+
+	payload := SavedSearchMessage{}
+
+	err := helpers.Unmarshal(message.Message.Body, &payload)
+	if err != nil {
+		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
+		sendToFailQueue(message)
+		return
+	}
+
+	search, err := mongo.GetSavedSearch(ctx, payload.ID)
+	if err == mongo.ErrNoDocuments {
+		message.Ack()
+		return
+	} else if err != nil {
+		log.Err(err.Error(), zap.String("id", payload.ID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	results, err := runSavedSearch(ctx, search)
+	if err != nil {
+		log.Err(err.Error(), zap.String("id", payload.ID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	// Diff against what we have seen before
+	var seen = map[string]bool{}
+	for _, v := range search.SeenIDs {
+		seen[v] = true
+	}
+
+	var newItems []mongo.SavedSearchItem
+	var newSeen []string
+
+	for _, v := range results {
+		if !seen[v.ID] {
+			newItems = append(newItems, v)
+			newSeen = append(newSeen, v.ID)
+		}
+	}
+
+	// The first run only records the current results
+	firstRun := search.CheckedAt.IsZero()
+
+	var items = search.Items
+	if !firstRun {
+		items = append(newItems, items...)
+	}
+
+	err = mongo.UpdateSavedSearchResults(ctx, search.ID, append(newSeen, search.SeenIDs...), items)
+	if err != nil {
+		log.Err(err.Error(), zap.String("id", payload.ID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	if !firstRun && len(newItems) > 0 {
+
+		if len(newItems) > savedSearchNotify {
+			newItems = newItems[0:savedSearchNotify]
+		}
+
+		if search.NotifyEmail {
+			err = notifySavedSearchEmail(search, newItems)
+			if err != nil {
+				log.Err(err.Error(), zap.String("id", payload.ID))
+			}
+		}
+
+		if search.NotifyDiscord {
+			err = notifySavedSearchDiscord(search, newItems)
+			if err != nil {
+				log.Err(err.Error(), zap.String("id", payload.ID))
+			}
+		}
+	}
+
+	message.Ack()
+}
+
+func runSavedSearch(ctx context.Context, search mongo.SavedSearch) (items []mongo.SavedSearchItem, err error) {
+
+	query, err := datatable.NewDataTableQueryFromString(search.Query)
+	if err != nil {
+		return items, err
+	}
+
+	now := time.Now()
+
+	switch search.Type {
+	case mongo.SavedSearchTypeGames:
+
+		appFilters := filters.AppsFilters(query, search.ProductCC, search.PlayerID)
+		order := filters.AppsOrder(query, search.ProductCC)
+
+		apps, _, err := elasticsearch.SearchAppsAdvanced(ctx, 0, savedSearchResults, query.GetSearchString("search"), order, elastic.NewBoolQuery().Filter(appFilters...))
+		if err != nil {
+			return items, err
+		}
+
+		for _, app := range apps {
+			items = append(items, mongo.SavedSearchItem{
+				ID:      strconv.Itoa(app.ID),
+				Name:    app.GetName(),
+				Path:    app.GetPath(),
+				Icon:    app.GetIcon(),
+				FoundAt: now,
+			})
+		}
+
+	case mongo.SavedSearchTypeSales:
+
+		sales, err := mongo.GetAllSales(ctx, 0, savedSearchResults, filters.SalesFilter(query, search.ProductCC), filters.SalesOrder(query, search.ProductCC))
+		if err != nil {
+			return items, err
+		}
+
+		for _, sale := range sales {
+			items = append(items, mongo.SavedSearchItem{
+				ID:      sale.GetKey(),
+				Name:    helpers.GetAppName(sale.AppID, sale.AppName) + " - " + sale.GetOfferName(),
+				Path:    helpers.GetAppPath(sale.AppID, sale.AppName),
+				Icon:    helpers.GetAppIcon(sale.AppID, sale.AppIcon),
+				FoundAt: now,
+			})
+		}
+
+	case mongo.SavedSearchTypePlayers:
+
+		players, _, err := elasticsearch.SearchPlayers(ctx, savedSearchResults, 0, query.GetSearchString("search"), filters.PlayersOrder(query), filters.PlayersFilters(query))
+		if err != nil {
+			return items, err
+		}
+
+		for _, player := range players {
+			items = append(items, mongo.SavedSearchItem{
+				ID:      strconv.FormatInt(player.ID, 10),
+				Name:    player.GetName(),
+				Path:    player.GetPath(),
+				Icon:    player.GetAvatar(),
+				FoundAt: now,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+func notifySavedSearchEmail(search mongo.SavedSearch, items []mongo.SavedSearchItem) error {
+
+	user, err := mysql.GetUserByID(search.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !user.EmailVerified {
+		return nil
+	}
+
+	var templateItems []email.SavedSearchTemplateItem
+	for _, v := range items {
+		templateItems = append(templateItems, email.SavedSearchTemplateItem{Name: v.Name, Path: v.Path})
+	}
+
+	return email.GetProvider().Send(
+		user.Email,
+		"",
+		"",
+		"New results for "+search.Name,
+		email.SavedSearchTemplate{
+			Domain: config.C.GlobalSteamDomain,
+			Name:   search.Name,
+			Path:   search.Type.GetPath(),
+			Items:  templateItems,
+		},
+	)
+}
+
+func notifySavedSearchDiscord(search mongo.SavedSearch, items []mongo.SavedSearchItem) error {
+
+	provider, err := mysql.GetUserProviderByUserID(oauth.ProviderDiscord, search.UserID)
+	if err == mysql.ErrRecordNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	discord, err := discordgo.New("Bot " + config.C.DiscordChatBotToken)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = discord.Close()
+		if err != nil {
+			log.ErrS(err)
+		}
+	}()
+
+	channel, err := discord.UserChannelCreate(provider.ID)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, v := range items {
+		lines = append(lines, "["+v.Name+"]("+config.C.GlobalSteamDomain+v.Path+")")
+	}
+
+	_, err = discord.ChannelMessageSendEmbed(channel.ID, &discordgo.MessageEmbed{
+		Title:       "New results for " + search.Name,
+		URL:         config.C.GlobalSteamDomain + search.Type.GetPath(),
+		Description: strings.Join(lines, "\n"),
+		Footer:      &discordgo.MessageEmbedFooter{Text: "Manage saved searches at " + config.C.GlobalSteamDomain + "/settings"},
+	})
+
+	return err
+}
@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"math"
 	"time"
 
@@ -26,7 +27,7 @@ func (m StatsMessage) Queue() rabbit.QueueName {
 	return QueueStats
 }
 
-func statsHandler(message *rabbit.Message) {
+func statsHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := StatsMessage{}
 
@@ -84,7 +85,7 @@ func statsHandler(message *rabbit.Message) {
 		}
 	}
 
-	err = mongo.BatchApps(filter, projection, callback)
+	err = mongo.BatchApps(ctx, filter, projection, callback)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
@@ -143,7 +144,7 @@ func statsHandler(message *rabbit.Message) {
 		{Key: "max_discount", Value: maxDiscount},
 	}
 
-	_, err = mongo.UpdateOne(mongo.CollectionStats, bson.D{{"_id", stat.GetKey()}}, update)
+	_, err = mongo.UpdateOne(ctx, mongo.CollectionStats, bson.D{{"_id", stat.GetKey()}}, update)
 	if err != nil {
 		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
 		sendToRetryQueue(message)
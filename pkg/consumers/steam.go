@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"errors"
 
 	"github.com/Jleagle/rabbit-go"
@@ -24,7 +25,7 @@ type SteamMessage struct {
 	PackageIDs []int `json:"package_ids"`
 }
 
-func steamHandler(message *rabbit.Message) {
+func steamHandler(ctx context.Context, message *rabbit.Message) {
 
 	false := false
 
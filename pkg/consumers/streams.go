@@ -0,0 +1,56 @@
+package consumers
+
+import (
+	"context"
+	"time"
+
+	"github.com/Jleagle/rabbit-go"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/streams"
+	"go.uber.org/zap"
+)
+
+type StreamMessage struct {
+	Topic   streams.StreamTopic `json:"topic"`
+	Message []byte              `json:"message"`
+}
+
+func (m StreamMessage) Queue() rabbit.QueueName {
+	return QueueStreams
+}
+
+func streamsHandler(ctx context.Context, message *rabbit.Message) {
+
+	payload := StreamMessage{}
+
+	err := helpers.Unmarshal(message.Message.Body, &payload)
+	if err != nil {
+		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
+		sendToFailQueue(message)
+		return
+	}
+
+	topic := streams.GetTopic(payload.Topic)
+	if topic == nil {
+		log.Err("no handler for topic", zap.String("topic", string(payload.Topic)))
+	} else if topic.CountSubscribers() > 0 {
+		topic.Send(payload.Message)
+	}
+
+	message.Ack()
+}
+
+type ChangesStreamPayload struct {
+	Changes  []*mongo.Change `json:"changes"`
+	Apps     map[int]string  `json:"apps"`
+	Packages map[int]string  `json:"packages"`
+}
+
+type AppPlayersStreamPayload struct {
+	AppID         int       `json:"id"`
+	Players       int       `json:"players"`
+	TwitchViewers int       `json:"twitch_viewers"`
+	CreatedAt     time.Time `json:"created_at"`
+}
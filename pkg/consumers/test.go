@@ -1,6 +1,7 @@
 package consumers
 
 import (
+	"context"
 	"time"
 
 	"github.com/Jleagle/rabbit-go"
@@ -13,7 +14,7 @@ type TestMessage struct {
 	ID int `json:"id"`
 }
 
-func testHandler(message *rabbit.Message) {
+func testHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := TestMessage{}
 
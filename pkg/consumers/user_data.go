@@ -0,0 +1,140 @@
+package consumers
+
+import (
+	"context"
+	"time"
+
+	"github.com/Jleagle/rabbit-go"
+	"github.com/gamedb/gamedb/cmd/frontend/helpers/email"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/userdata"
+	"go.uber.org/zap"
+)
+
+type UserDataMessage struct {
+	RequestID int `json:"request_id"`
+}
+
+func (m UserDataMessage) Queue() rabbit.QueueName {
+	return QueueUserData
+}
+
+// Runs in the frontend, as it needs the email templates
+func userDataHandler(ctx context.Context, message *rabbit.Message) {
+
+	payload := UserDataMessage{}
+
+	err := helpers.Unmarshal(message.Message.Body, &payload)
+	if err != nil {
+		log.Err(err.Error(), zap.String("body", string(message.Message.Body)))
+		sendToFailQueue(message)
+		return
+	}
+
+	request, err := mysql.GetUserDataRequest(payload.RequestID)
+	if err == mysql.ErrRecordNotFound {
+		message.Ack()
+		return
+	} else if err != nil {
+		log.Err(err.Error(), zap.Int("id", payload.RequestID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	// Cancelled, or already done by another message
+	if request.Status != mysql.UserDataPending {
+		message.Ack()
+		return
+	}
+
+	switch request.Type {
+	case mysql.UserDataExport:
+		err = userDataExport(request)
+	case mysql.UserDataDelete:
+
+		// Still in the cancel window
+		if request.DueAt.After(time.Now()) {
+			message.Ack()
+			return
+		}
+
+		err = userDataDelete(request)
+	}
+
+	if err != nil {
+		log.Err(err.Error(), zap.Int("id", payload.RequestID))
+		sendToRetryQueue(message)
+		return
+	}
+
+	message.Ack()
+}
+
+func userDataExport(request mysql.UserDataRequest) (err error) {
+
+	user, err := mysql.GetUserByID(request.UserID)
+	if err == mysql.ErrRecordNotFound {
+		return mysql.SetUserDataRequestStatus(request, mysql.UserDataExpired)
+	} else if err != nil {
+		return err
+	}
+
+	file, err := userdata.Export(user, request.ID)
+	if err != nil {
+		return err
+	}
+
+	token, err := mysql.SetUserDataExportReady(request, file, userdata.ExportLifetime)
+	if err != nil {
+		return err
+	}
+
+	return email.GetProvider().Send(
+		user.Email,
+		"",
+		"",
+		"Your Global Steam data export",
+		email.UserDataExportTemplate{
+			Domain:  config.C.GlobalSteamDomain,
+			Token:   token,
+			Expires: time.Now().Add(userdata.ExportLifetime).Format(helpers.DateYearTime),
+		},
+	)
+}
+
+func userDataDelete(request mysql.UserDataRequest) (err error) {
+
+	user, err := mysql.GetUserByID(request.UserID)
+	if err == mysql.ErrRecordNotFound {
+		return mysql.SetUserDataRequestStatus(request, mysql.UserDataDone)
+	} else if err != nil {
+		return err
+	}
+
+	err = userdata.Delete(user)
+	if err != nil {
+		return err
+	}
+
+	err = mysql.SetUserDataRequestStatus(request, mysql.UserDataDone)
+	if err != nil {
+		return err
+	}
+
+	// The account is gone, so only log a failed email
+	err = email.GetProvider().Send(
+		user.Email,
+		"",
+		"",
+		"Your Global Steam account has been deleted",
+		email.UserDeletedTemplate{},
+	)
+	if err != nil {
+		log.ErrS(err)
+	}
+
+	return nil
+}
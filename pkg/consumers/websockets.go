@@ -1,6 +1,8 @@
 package consumers
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -15,7 +17,7 @@ type WebsocketMessage struct {
 	Message []byte                     `json:"message"`
 }
 
-func websocketHandler(message *rabbit.Message) {
+func websocketHandler(ctx context.Context, message *rabbit.Message) {
 
 	payload := WebsocketMessage{}
 
@@ -132,7 +134,7 @@ func websocketHandler(message *rabbit.Message) {
 				continue
 			}
 
-			pack, err := mongo.GetPackage(idPayload.ID)
+			pack, err := mongo.GetPackage(ctx, idPayload.ID)
 			if err != nil {
 				log.ErrS(err)
 				continue
@@ -151,7 +153,7 @@ func websocketHandler(message *rabbit.Message) {
 			}
 
 			var bundle helpers.Bundle
-			bundle, err = mongo.GetBundle(idPayload.ID)
+			bundle, err = mongo.GetBundle(ctx, idPayload.ID)
 			if err != nil {
 				log.ErrS(err)
 				continue
@@ -169,7 +171,7 @@ func websocketHandler(message *rabbit.Message) {
 				continue
 			}
 
-			prices, err := mongo.GetPricesByID(idsPayload.IDs)
+			prices, err := mongo.GetPricesByID(ctx, idsPayload.IDs)
 			if err != nil {
 				log.ErrS(err)
 				continue
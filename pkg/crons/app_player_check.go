@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/Jleagle/rabbit-go"
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/crons/helpers/rabbitweb"
@@ -30,7 +32,7 @@ func (c AppsPlayerCheck) Cron() TaskTime {
 	return CronTimeAppPlayers
 }
 
-func (c AppsPlayerCheck) work() (err error) {
+func (c AppsPlayerCheck) work(ctx context.Context) (err error) {
 
 	// Skip if queues have activity
 	limits := map[rabbit.QueueName]int{
@@ -55,7 +57,7 @@ func (c AppsPlayerCheck) work() (err error) {
 
 	var projection = bson.M{"_id": 1}
 
-	return mongo.BatchApps(filter, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, filter, projection, func(apps []mongo.App) {
 
 		var ids []int
 		for _, v := range apps {
@@ -67,7 +69,7 @@ func (c AppsPlayerCheck) work() (err error) {
 		var chunks = helpers.ChunkInts(ids, 20)
 
 		for _, chunk := range chunks {
-			err = consumers.ProduceAppPlayers(chunk)
+			err = consumers.ProduceAppPlayers(ctx, chunk)
 			if err != nil {
 				log.ErrS(err)
 				return
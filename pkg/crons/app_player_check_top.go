@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -33,7 +35,7 @@ const (
 	topGroupFollowers = 4000 // And up are top apps
 )
 
-func (c AppsPlayerCheckTop) work() (err error) {
+func (c AppsPlayerCheckTop) work(ctx context.Context) (err error) {
 
 	var filter = bson.D{{"$or", bson.A{
 		bson.D{{"player_peak_week", bson.M{"$gte": topAppPlayers}}},
@@ -42,7 +44,7 @@ func (c AppsPlayerCheckTop) work() (err error) {
 
 	var projection = bson.M{"_id": 1}
 
-	return mongo.BatchApps(filter, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, filter, projection, func(apps []mongo.App) {
 
 		var ids []int
 		for _, v := range apps {
@@ -54,7 +56,7 @@ func (c AppsPlayerCheckTop) work() (err error) {
 		var chunks = helpers.ChunkInts(ids, 20)
 
 		for _, chunk := range chunks {
-			err = consumers.ProduceAppPlayersTop(chunk)
+			err = consumers.ProduceAppPlayersTop(ctx, chunk)
 			if err != nil {
 				log.ErrS(err)
 				return
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -27,15 +29,15 @@ func (c AppsAchievementsQueueAll) Cron() TaskTime {
 	return ""
 }
 
-func (c AppsAchievementsQueueAll) work() (err error) {
+func (c AppsAchievementsQueueAll) work(ctx context.Context) (err error) {
 
 	var projection = bson.M{"_id": 1, "name": 1, "owners": 1}
 
-	return mongo.BatchApps(nil, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, nil, projection, func(apps []mongo.App) {
 
 		for _, app := range apps {
 
-			err = consumers.ProduceAppAchievement(app.ID, app.Name, app.Owners)
+			err = consumers.ProduceAppAchievement(ctx, app.ID, app.Name, app.Owners)
 			if err != nil {
 				log.ErrS(err)
 				return
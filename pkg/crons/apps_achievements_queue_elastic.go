@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,7 +28,7 @@ func (c AppsAchievementsQueueElastic) Cron() TaskTime {
 	return ""
 }
 
-func (c AppsAchievementsQueueElastic) work() (err error) {
+func (c AppsAchievementsQueueElastic) work(ctx context.Context) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
@@ -36,7 +38,7 @@ func (c AppsAchievementsQueueElastic) work() (err error) {
 	for {
 
 		// Sort by app id so we only need to cache one app at a time
-		appAchievements, err := mongo.GetAppAchievements(offset, limit, nil, bson.D{{"app_id", 1}})
+		appAchievements, err := mongo.GetAppAchievements(ctx, offset, limit, nil, bson.D{{"app_id", 1}})
 		if err != nil {
 			return err
 		}
@@ -44,13 +46,13 @@ func (c AppsAchievementsQueueElastic) work() (err error) {
 		for _, appAchievement := range appAchievements {
 
 			if appCache.ID != appAchievement.AppID {
-				appCache, err = mongo.GetApp(appAchievement.AppID)
+				appCache, err = mongo.GetApp(ctx, appAchievement.AppID)
 				if err != nil {
 					return err
 				}
 			}
 
-			err = consumers.ProduceAchievementSearch(appAchievement, appCache.Name, appCache.Owners)
+			err = consumers.ProduceAchievementSearch(ctx, appAchievement, appCache.Name, appCache.Owners)
 			if err != nil {
 				return err
 			}
@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -32,7 +33,7 @@ func (c AppsAddTagCountsToInflux) Cron() TaskTime {
 	return CronTimeAddAppTagsToInflux
 }
 
-func (c AppsAddTagCountsToInflux) work() (err error) {
+func (c AppsAddTagCountsToInflux) work(ctx context.Context) (err error) {
 
 	var projection = bson.M{
 		"_id":        1,
@@ -45,7 +46,7 @@ func (c AppsAddTagCountsToInflux) work() (err error) {
 	}
 
 	var i = 1
-	return mongo.BatchApps(filter, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, filter, projection, func(apps []mongo.App) {
 
 		if config.IsLocal() {
 			log.InfoS(i)
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/elasticsearch"
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -28,7 +30,7 @@ func (c AppsArticlesQueueElastic) Cron() TaskTime {
 	return ""
 }
 
-func (c AppsArticlesQueueElastic) work() (err error) {
+func (c AppsArticlesQueueElastic) work(ctx context.Context) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
@@ -42,7 +44,7 @@ func (c AppsArticlesQueueElastic) work() (err error) {
 
 		for _, article := range articles {
 
-			err = consumers.ProduceArticlesSearch(consumers.AppsArticlesSearchMessage{
+			err = consumers.ProduceArticlesSearch(ctx, consumers.AppsArticlesSearchMessage{
 				Elastic: elasticsearch.Article{
 					ID:          article.ID,
 					Title:       article.Title,
@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gamedb/gamedb/pkg/consumers"
@@ -28,7 +29,7 @@ func (c AppsQueueAll) Cron() TaskTime {
 	return ""
 }
 
-func (c AppsQueueAll) work() (err error) {
+func (c AppsQueueAll) work(ctx context.Context) (err error) {
 
 	var last = 0
 	var keepGoing = true
@@ -46,7 +47,7 @@ func (c AppsQueueAll) work() (err error) {
 
 		for _, v := range apps.Apps {
 
-			err = consumers.ProduceApp(consumers.AppMessage{ID: v.AppID})
+			err = consumers.ProduceApp(ctx, consumers.AppMessage{ID: v.AppID})
 			if err != nil {
 				return err
 			}
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -27,7 +29,7 @@ func (c AppsQueueElastic) Cron() TaskTime {
 	return ""
 }
 
-func (c AppsQueueElastic) work() (err error) {
+func (c AppsQueueElastic) work(ctx context.Context) (err error) {
 
 	var projection = bson.M{
 		"common":       0,
@@ -40,11 +42,11 @@ func (c AppsQueueElastic) work() (err error) {
 		"ufs":          0,
 	}
 
-	return mongo.BatchApps(nil, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, nil, projection, func(apps []mongo.App) {
 
 		for _, app := range apps {
 
-			err = consumers.ProduceAppSearch(&app, 0, nil)
+			err = consumers.ProduceAppSearch(ctx, &app, 0, nil)
 			if err != nil {
 				log.ErrS(err)
 				return
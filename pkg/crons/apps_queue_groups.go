@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -28,16 +30,16 @@ func (c AppsQueueGroups) Cron() TaskTime {
 	return CronTimeQueueAppGroups
 }
 
-func (c AppsQueueGroups) work() (err error) {
+func (c AppsQueueGroups) work(ctx context.Context) (err error) {
 
 	var filter = bson.D{{"group_id", bson.M{"$ne": ""}}}
 	var projection = bson.M{"group_id": 1}
 
-	return mongo.BatchApps(filter, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, filter, projection, func(apps []mongo.App) {
 
 		for _, app := range apps {
 
-			err = consumers.ProduceGroup(consumers.GroupMessage{ID: app.GroupID})
+			err = consumers.ProduceGroup(ctx, consumers.GroupMessage{ID: app.GroupID})
 			err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 			if err != nil {
 				log.ErrS(err)
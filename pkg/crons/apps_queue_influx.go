@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
@@ -28,11 +30,11 @@ func (c AppsQueueInflux) Cron() TaskTime {
 	return CronTimeAppsInflux
 }
 
-func (c AppsQueueInflux) work() (err error) {
+func (c AppsQueueInflux) work(ctx context.Context) (err error) {
 
 	var projection = bson.M{"_id": 1}
 
-	return mongo.BatchApps(nil, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, nil, projection, func(apps []mongo.App) {
 
 		var ids []int
 		for _, v := range apps {
@@ -42,7 +44,7 @@ func (c AppsQueueInflux) work() (err error) {
 		var chunks = helpers.ChunkInts(ids, 20)
 
 		for _, chunk := range chunks {
-			err = consumers.ProduceAppsInflux(chunk)
+			err = consumers.ProduceAppsInflux(ctx, chunk)
 			if err != nil {
 				log.ErrS(err)
 				return
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,7 +28,7 @@ func (c AppsQueuePackages) Cron() TaskTime {
 	return ""
 }
 
-func (c AppsQueuePackages) work() (err error) {
+func (c AppsQueuePackages) work(ctx context.Context) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
@@ -40,7 +42,7 @@ func (c AppsQueuePackages) work() (err error) {
 			{"packages", bson.M{"$ne": bson.A{}}},
 		}
 
-		apps, err := mongo.GetApps(offset, limit, sort, filter, projection)
+		apps, err := mongo.GetApps(ctx, offset, limit, sort, filter, projection)
 		if err != nil {
 			return err
 		}
@@ -58,7 +60,7 @@ func (c AppsQueuePackages) work() (err error) {
 			packageSlice = append(packageSlice, k)
 		}
 
-		err = consumers.ProduceSteam(consumers.SteamMessage{PackageIDs: packageSlice})
+		err = consumers.ProduceSteam(ctx, consumers.SteamMessage{PackageIDs: packageSlice})
 		if err != nil {
 			return err
 		}
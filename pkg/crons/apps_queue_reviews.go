@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -27,16 +29,16 @@ func (c AppsQueueReviews) Cron() TaskTime {
 	return CronTimeAppsReviews
 }
 
-func (c AppsQueueReviews) work() (err error) {
+func (c AppsQueueReviews) work(ctx context.Context) (err error) {
 
 	var filter = bson.D{{"reviews_count", bson.M{"$gt": 0}}}
 	var projection = bson.M{"_id": 1}
 
-	return mongo.BatchApps(filter, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, filter, projection, func(apps []mongo.App) {
 
 		for _, app := range apps {
 
-			err = consumers.ProduceAppsReviews(app.ID, false)
+			err = consumers.ProduceAppsReviews(ctx, app.ID, false)
 			if err != nil {
 				log.ErrS(err)
 				return
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -28,7 +30,7 @@ func (c AppsQueueSteamSpy) Cron() TaskTime {
 	return CronTimeSteamSpy
 }
 
-func (c AppsQueueSteamSpy) work() (err error) {
+func (c AppsQueueSteamSpy) work(ctx context.Context) (err error) {
 
 	var filter = bson.D{
 		{"owners", bson.M{"$gt": 0}}, // Just to keep requests to SteamSpy down a bit
@@ -37,11 +39,11 @@ func (c AppsQueueSteamSpy) work() (err error) {
 
 	var count int
 
-	err = mongo.BatchApps(filter, projection, func(apps []mongo.App) {
+	err = mongo.BatchApps(ctx, filter, projection, func(apps []mongo.App) {
 
 		for _, app := range apps {
 
-			err = consumers.ProduceAppSteamSpy(app.ID)
+			err = consumers.ProduceAppSteamSpy(ctx, app.ID)
 			if err != nil {
 				log.ErrS(err)
 				return
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -27,15 +29,15 @@ func (c AppsQueueWishlists) Cron() TaskTime {
 	return CronTimeAppsWishlists
 }
 
-func (c AppsQueueWishlists) work() (err error) {
+func (c AppsQueueWishlists) work(ctx context.Context) (err error) {
 
 	var projection = bson.M{"_id": 1}
 
-	return mongo.BatchApps(nil, projection, func(apps []mongo.App) {
+	return mongo.BatchApps(ctx, nil, projection, func(apps []mongo.App) {
 
 		for _, app := range apps {
 
-			err = consumers.ProduceAppsWishlists(app.ID)
+			err = consumers.ProduceAppsWishlists(ctx, app.ID)
 			if err != nil {
 				log.ErrS(err)
 				return
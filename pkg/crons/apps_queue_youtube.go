@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,16 +28,16 @@ func (c AppsQueueYoutube) Cron() TaskTime {
 	return CronTimeAppsYoutube
 }
 
-func (c AppsQueueYoutube) work() (err error) {
+func (c AppsQueueYoutube) work(ctx context.Context) (err error) {
 
-	apps, err := mongo.GetApps(0, 8500, bson.D{{"player_peak_week", -1}}, nil, bson.M{"_id": 1, "name": 1})
+	apps, err := mongo.GetApps(ctx, 0, 8500, bson.D{{"player_peak_week", -1}}, nil, bson.M{"_id": 1, "name": 1})
 	if err != nil {
 		return err
 	}
 
 	for _, app := range apps {
 
-		err = consumers.ProduceAppsYoutube(app.ID, app.Name)
+		err = consumers.ProduceAppsYoutube(ctx, app.ID, app.Name)
 		if err != nil {
 			return err
 		}
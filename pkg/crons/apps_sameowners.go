@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/crons/helpers/rabbitweb"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -27,7 +29,7 @@ func (c AppsSameOwners) Cron() TaskTime {
 	return CronTimeAppsSameowners
 }
 
-func (c AppsSameOwners) work() (err error) {
+func (c AppsSameOwners) work(ctx context.Context) (err error) {
 
 	queues, err := rabbitweb.GetRabbitWebClient().GetQueues()
 	if err != nil {
@@ -56,14 +58,14 @@ func (c AppsSameOwners) work() (err error) {
 		{"group_followers", 1},
 	}
 
-	apps, err := mongo.GetApps(0, int64(free), sort, filter, bson.M{"_id": 1})
+	apps, err := mongo.GetApps(ctx, 0, int64(free), sort, filter, bson.M{"_id": 1})
 	if err != nil {
 		return err
 	}
 
 	for _, v := range apps {
 
-		err = consumers.ProduceSameOwners(v.ID)
+		err = consumers.ProduceSameOwners(ctx, v.ID)
 		if err != nil {
 			return err
 		}
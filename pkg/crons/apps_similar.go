@@ -0,0 +1,74 @@
+package crons
+
+import (
+	"context"
+
+	"github.com/gamedb/gamedb/pkg/consumers"
+	"github.com/gamedb/gamedb/pkg/crons/helpers/rabbitweb"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type AppsSimilar struct {
+	BaseTask
+}
+
+func (c AppsSimilar) ID() string {
+	return "apps-similar"
+}
+
+func (c AppsSimilar) Name() string {
+	return "Queue a game to calculate similar games"
+}
+
+func (c AppsSimilar) Group() TaskGroup {
+	return TaskGroupApps
+}
+
+func (c AppsSimilar) Cron() TaskTime {
+	return CronTimeAppsSimilar
+}
+
+func (c AppsSimilar) work(ctx context.Context) (err error) {
+
+	queues, err := rabbitweb.GetRabbitWebClient().GetQueues()
+	if err != nil {
+		return err
+	}
+
+	var free int
+	for _, v := range queues {
+		if v.Name == string(consumers.QueueAppsSimilar) {
+			free = int(float64(v.Consumers)/float64(consumers.ConsumersPerProcess)) - v.Messages
+			break
+		}
+	}
+
+	if free <= 0 {
+		return nil
+	}
+
+	filter := bson.D{
+		{"tags.0", bson.M{"$exists": true}},
+	}
+
+	sort := bson.D{
+		{"related_similar_date", 1},
+		{"player_peak_week", -1},
+	}
+
+	apps, err := mongo.GetApps(ctx, 0, int64(free), sort, filter, bson.M{"_id": 1})
+	if err != nil {
+		return err
+	}
+
+	for _, v := range apps {
+
+		err = consumers.ProduceSimilar(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package crons
+
+import (
+	"context"
+
+	"github.com/gamedb/gamedb/pkg/archive"
+	"github.com/gamedb/gamedb/pkg/config"
+)
+
+type Archive struct {
+	BaseTask
+}
+
+func (c Archive) ID() string {
+	return "archive"
+}
+
+func (c Archive) Name() string {
+	return "Archive old time series data"
+}
+
+func (c Archive) Group() TaskGroup {
+	return ""
+}
+
+func (c Archive) Cron() TaskTime {
+	return CronTimeArchive
+}
+
+func (c Archive) work(ctx context.Context) (err error) {
+
+	if config.C.ArchivePath == "" {
+		return nil
+	}
+
+	// Limit the days per run so the first run over a large backlog doesn't take all night
+	return archive.Run(30)
+}
@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -39,7 +40,7 @@ var (
 	articlesLatestCache = cache.New(time.Hour*24*7, time.Minute*30)
 )
 
-func (c ArticlesLatest) work() (err error) {
+func (c ArticlesLatest) work(ctx context.Context) (err error) {
 
 	col := colly.NewCollector(
 		steam.WithTimeout(30),
@@ -58,7 +59,7 @@ func (c ArticlesLatest) work() (err error) {
 			if len(sub) == 2 {
 				i, err := strconv.Atoi(sub[1])
 				if err == nil {
-					err = consumers.ProduceAppNews(i)
+					err = consumers.ProduceAppNews(ctx, i)
 					if err != nil {
 						log.ErrS(err)
 					}
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -26,7 +28,7 @@ func (c BadgesUpdateRandom) Cron() TaskTime {
 	return CronTimeSetBadgeCache
 }
 
-func (c BadgesUpdateRandom) work() (err error) {
+func (c BadgesUpdateRandom) work(ctx context.Context) (err error) {
 
 	for k := range helpers.BuiltInSpecialBadges {
 
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -27,13 +29,13 @@ func (c BundlesQueueAll) Cron() TaskTime {
 	return ""
 }
 
-func (c BundlesQueueAll) work() (err error) {
+func (c BundlesQueueAll) work(ctx context.Context) (err error) {
 
 	return mongo.BatchBundles(nil, bson.M{"_id": 1}, func(bundles []mongo.Bundle) {
 
 		for _, bundle := range bundles {
 
-			err = consumers.ProduceBundle(bundle.ID)
+			err = consumers.ProduceBundle(ctx, bundle.ID)
 			if err != nil && err != consumers.ErrInQueue {
 				log.ErrS(err)
 				return
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,7 +28,7 @@ func (c BundlesQueueElastic) Cron() TaskTime {
 	return ""
 }
 
-func (c BundlesQueueElastic) work() (err error) {
+func (c BundlesQueueElastic) work(ctx context.Context) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
@@ -40,7 +42,7 @@ func (c BundlesQueueElastic) work() (err error) {
 
 		for _, bundle := range bundles {
 
-			err = consumers.ProduceBundleSearch(bundle)
+			err = consumers.ProduceBundleSearch(ctx, bundle)
 			if err != nil {
 				return err
 			}
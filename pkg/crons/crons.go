@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	"github.com/gamedb/gamedb/pkg/websockets"
 	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
@@ -19,10 +21,15 @@ const ( //                                       min  hour
 	CronTimeUpdateLastUpdatedPlayers TaskTime = "*    *"
 	CronTimeNewsLatest               TaskTime = "*    *"
 	CronTimeUpdateDiscordGuild       TaskTime = "*    *"
+	CronTimeStatusChecks             TaskTime = "*/5  *"
 	CronTimeSteamClientPlayers       TaskTime = "*/10 *"
 	CronTimeAppPlayers               TaskTime = "*/10 *"
 	CronTimeAppPlayersTop            TaskTime = "*/10 *"
 	CronTimeAppsSameowners           TaskTime = "*/10 *"
+	CronTimeAppsSimilar              TaskTime = "*/10 *"
+	CronTimeSavedSearches            TaskTime = "0    *"
+	CronTimeSubscriptionsExpire      TaskTime = "15   *"
+	CronTimeUserData                 TaskTime = "30   *"
 	CronTimeAutoPlayerRefreshes      TaskTime = "0    */6"
 	CronTimeGameDBStats              TaskTime = "0    */6"
 	CronTimeAppsReviews              TaskTime = "0    0"
@@ -35,6 +42,9 @@ const ( //                                       min  hour
 	CronTimeStats                    TaskTime = "35   0"
 	CronTimeAppsWishlists            TaskTime = "40   0"
 	CronTimeAddAppTagsToInflux       TaskTime = "45   0"
+	CronTimeFreshnessReport          TaskTime = "50   0"
+	CronTimeArchive                  TaskTime = "55   0"
+	CronTimePatreonReconcile         TaskTime = "0    1"
 	CronTimeAppsInflux               TaskTime = ""
 	CronTimeSteamSpy                 TaskTime = ""
 	CronTimeInstagram                TaskTime = ""
@@ -73,26 +83,34 @@ var (
 		&AppsQueueWishlists{},
 		&AppsQueueYoutube{},
 		&AppsSameOwners{},
+		&AppsSimilar{},
+		&Archive{},
 		&ArticlesLatest{},
 		&AutoPlayerRefreshes{},
 		&BadgesUpdateRandom{},
 		&BundlesQueueAll{},
 		&BundlesQueueElastic{},
 		&DiscordUpdateGuild{},
+		&FreshnessReport{},
 		&GlobalSteamStats{},
 		&GroupsQueueElastic{},
 		&GroupsQueuePrimaries{},
 		&GroupsUpdateTop{},
 		&InstagramPost{},
 		&MemcacheClearAll{},
+		&PatreonReconcile{},
 		&PlayersQueueAll{},
 		&PlayersQueueElastic{},
 		&PlayersQueueGroups{},
 		&PlayersQueueLastUpdated{},
 		&PlayersUpdateRanks{},
 		&ProductsUpdateKeys{},
+		&SavedSearches{},
 		&StatsTask{},
+		&StatusChecks{},
 		&SteamOnline{},
+		&SubscriptionsExpire{},
+		&UserData{},
 	}
 )
 
@@ -107,7 +125,7 @@ type TaskInterface interface {
 	Name() string
 	Group() TaskGroup
 	Cron() TaskTime
-	work() error
+	work(ctx context.Context) error
 }
 
 type BaseTask struct {
@@ -157,9 +175,12 @@ func Run(task TaskInterface) {
 
 	// log.InfoS("Cron started: " + task.ID())
 
+	ctx, span := tracing.StartSpan(context.Background(), "cron "+task.ID())
+	defer span.End()
+
 	// Send start websocket
 	wsPayload := consumers.AdminPayload{TaskID: task.ID(), Action: "started"}
-	err := consumers.ProduceWebsocket(wsPayload, websockets.PageAdmin)
+	err := consumers.ProduceWebsocket(ctx, wsPayload, websockets.PageAdmin)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -171,7 +192,11 @@ func Run(task TaskInterface) {
 		log.Info("Cron retry failed", zap.String("cron id", task.ID()), zap.Error(err))
 	}
 
-	err = backoff.RetryNotify(task.work, backoff.WithMaxRetries(policy, 10), notify)
+	work := func() error {
+		return task.work(ctx)
+	}
+
+	err = backoff.RetryNotify(work, backoff.WithMaxRetries(policy, 10), notify)
 	if err != nil {
 
 		if val, ok := err.(TaskError); ok && val.Okay {
@@ -189,7 +214,7 @@ func Run(task TaskInterface) {
 
 		// Send end websocket
 		wsPayload = consumers.AdminPayload{TaskID: task.ID(), Action: "finished", Time: Next(task).Unix()}
-		err = consumers.ProduceWebsocket(wsPayload, websockets.PageAdmin)
+		err = consumers.ProduceWebsocket(ctx, wsPayload, websockets.PageAdmin)
 		if err != nil {
 			log.ErrS(err)
 		}
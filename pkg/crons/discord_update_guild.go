@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"errors"
 
 	"github.com/bwmarrin/discordgo"
@@ -29,7 +30,7 @@ func (c DiscordUpdateGuild) Cron() TaskTime {
 	return CronTimeUpdateDiscordGuild
 }
 
-func (c DiscordUpdateGuild) work() (err error) {
+func (c DiscordUpdateGuild) work(ctx context.Context) (err error) {
 
 	guilds, err := mongo.GetGuilds(0, 1, bson.D{{"update_at", 1}}, nil)
 	if err != nil {
@@ -51,7 +52,7 @@ func (c DiscordUpdateGuild) work() (err error) {
 
 			if val, ok := err.(*discordgo.RESTError); ok {
 				if val.Response.StatusCode == 404 {
-					_, err = mongo.ReplaceOne(mongo.CollectionDiscordGuilds, bson.D{{"_id", guild.ID}}, guild)
+					_, err = mongo.ReplaceOne(ctx, mongo.CollectionDiscordGuilds, bson.D{{"_id", guild.ID}}, guild)
 					if err != nil {
 						return err
 					}
@@ -73,7 +74,7 @@ func (c DiscordUpdateGuild) work() (err error) {
 		guild.Icon = fullGuild.Icon
 		guild.Members = resp.ApproximateMemberCount
 
-		_, err = mongo.ReplaceOne(mongo.CollectionDiscordGuilds, bson.D{{"_id", resp.ID}}, guild)
+		_, err = mongo.ReplaceOne(ctx, mongo.CollectionDiscordGuilds, bson.D{{"_id", resp.ID}}, guild)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,45 @@
+package crons
+
+import (
+	"context"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/mongo"
+)
+
+type FreshnessReport struct {
+	BaseTask
+}
+
+func (c FreshnessReport) ID() string {
+	return "freshness-report"
+}
+
+func (c FreshnessReport) Name() string {
+	return "Build the data freshness report"
+}
+
+func (c FreshnessReport) Group() TaskGroup {
+	return ""
+}
+
+func (c FreshnessReport) Cron() TaskTime {
+	return CronTimeFreshnessReport
+}
+
+func (c FreshnessReport) work(ctx context.Context) (err error) {
+
+	report := mongo.FreshnessReport{CreatedAt: time.Now()}
+
+	for _, tier := range mongo.FreshnessTiers {
+
+		row, err := mongo.NewFreshnessReportRow(tier)
+		if err != nil {
+			return err
+		}
+
+		report.Rows = append(report.Rows, row)
+	}
+
+	return mongo.InsertFreshnessReport(report)
+}
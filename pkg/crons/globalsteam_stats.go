@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"time"
 
 	influxHelper "github.com/gamedb/gamedb/pkg/influx"
@@ -29,34 +30,34 @@ func (c GlobalSteamStats) Cron() TaskTime {
 	return CronTimeGameDBStats
 }
 
-func (c GlobalSteamStats) work() (err error) {
+func (c GlobalSteamStats) work(ctx context.Context) (err error) {
 
-	apps, err := mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+	apps, err := mongo.CountDocuments(ctx, mongo.CollectionApps, nil, 0)
 	if err != nil {
 		return err
 	}
 
-	changes, err := mongo.CountDocuments(mongo.CollectionChanges, nil, 0)
+	changes, err := mongo.CountDocuments(ctx, mongo.CollectionChanges, nil, 0)
 	if err != nil {
 		return err
 	}
 
-	groups, err := mongo.CountDocuments(mongo.CollectionGroups, nil, 0)
+	groups, err := mongo.CountDocuments(ctx, mongo.CollectionGroups, nil, 0)
 	if err != nil {
 		return err
 	}
 
-	packages, err := mongo.CountDocuments(mongo.CollectionPackages, nil, 0)
+	packages, err := mongo.CountDocuments(ctx, mongo.CollectionPackages, nil, 0)
 	if err != nil {
 		return err
 	}
 
-	webhooks, err := mongo.CountDocuments(mongo.CollectionWebhooks, nil, 0)
+	webhooks, err := mongo.CountDocuments(ctx, mongo.CollectionWebhooks, nil, 0)
 	if err != nil {
 		return err
 	}
 
-	players, err := mongo.CountDocuments(mongo.CollectionPlayers, nil, 0)
+	players, err := mongo.CountDocuments(ctx, mongo.CollectionPlayers, nil, 0)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -27,7 +29,7 @@ func (c GroupsQueueElastic) Cron() TaskTime {
 	return ""
 }
 
-func (c GroupsQueueElastic) work() (err error) {
+func (c GroupsQueueElastic) work(ctx context.Context) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
@@ -43,7 +45,7 @@ func (c GroupsQueueElastic) work() (err error) {
 
 		for _, group := range groups {
 
-			err = consumers.ProduceGroupSearch(&group, "", "")
+			err = consumers.ProduceGroupSearch(ctx, &group, "", "")
 			if err != nil {
 				return err
 			}
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/backend"
 	"github.com/gamedb/gamedb/pkg/backend/generated"
 	"github.com/gamedb/gamedb/pkg/consumers"
@@ -27,9 +29,9 @@ func (c GroupsQueuePrimaries) Cron() TaskTime {
 	return ""
 }
 
-func (c GroupsQueuePrimaries) work() (err error) {
+func (c GroupsQueuePrimaries) work(ctx context.Context) (err error) {
 
-	conn, ctx, err := backend.GetClient()
+	conn, ctx, err := backend.GetClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -55,7 +57,7 @@ func (c GroupsQueuePrimaries) work() (err error) {
 		groups := resp.GetGroups()
 		for _, group := range groups {
 
-			err = consumers.ProduceGroupPrimaries(group.GetID(), helpers.GroupTypeGroup, int(group.GetPrimaries()))
+			err = consumers.ProduceGroupPrimaries(ctx, group.GetID(), helpers.GroupTypeGroup, int(group.GetPrimaries()))
 			if err != nil {
 				return err
 			}
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -27,7 +29,7 @@ func (c GroupsUpdateTop) Cron() TaskTime {
 	return CronTimeQueuePlayerGroups
 }
 
-func (c GroupsUpdateTop) work() (err error) {
+func (c GroupsUpdateTop) work(ctx context.Context) (err error) {
 
 	var filter = bson.D{
 		{Key: "type", Value: helpers.GroupTypeGroup},
@@ -55,7 +57,7 @@ func (c GroupsUpdateTop) work() (err error) {
 
 	for groupID := range groupMap {
 
-		err = consumers.ProduceGroup(consumers.GroupMessage{ID: groupID})
+		err = consumers.ProduceGroup(ctx, consumers.GroupMessage{ID: groupID})
 		err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 		if err != nil {
 			return err
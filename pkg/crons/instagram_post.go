@@ -1,5 +1,7 @@
 package crons
 
+import "context"
+
 type InstagramPost struct {
 	BaseTask
 }
@@ -20,7 +22,7 @@ func (c InstagramPost) Cron() TaskTime {
 	return CronTimeInstagram
 }
 
-func (c InstagramPost) work() (err error) {
+func (c InstagramPost) work(ctx context.Context) (err error) {
 
 	// filter := bson.D{
 	// 	{"type", "game"},
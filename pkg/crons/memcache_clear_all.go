@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/memcache"
 )
 
@@ -24,7 +26,7 @@ func (c MemcacheClearAll) Cron() TaskTime {
 	return ""
 }
 
-func (c MemcacheClearAll) work() (err error) {
+func (c MemcacheClearAll) work(ctx context.Context) (err error) {
 
 	return memcache.Client().DeleteAll()
 }
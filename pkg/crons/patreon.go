@@ -0,0 +1,51 @@
+package crons
+
+import (
+	"context"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/patreon"
+	"go.uber.org/zap"
+)
+
+type PatreonReconcile struct {
+	BaseTask
+}
+
+func (c PatreonReconcile) ID() string {
+	return "patreon-reconcile"
+}
+
+func (c PatreonReconcile) Name() string {
+	return "Reconcile Patreon pledges"
+}
+
+func (c PatreonReconcile) Group() TaskGroup {
+	return ""
+}
+
+func (c PatreonReconcile) Cron() TaskTime {
+	return CronTimePatreonReconcile
+}
+
+func (c PatreonReconcile) work(ctx context.Context) (err error) {
+
+	if config.C.PatreonCampaignID == "" || config.C.PatreonCreatorToken == "" {
+		return nil
+	}
+
+	members, err := patreon.NewClient().Members()
+	if err != nil {
+		return err
+	}
+
+	changes, err := patreon.Reconcile(members)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Patreon reconciled", zap.Int("members", len(members)), zap.Int("changes", len(changes)))
+
+	return nil
+}
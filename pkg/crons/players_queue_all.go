@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,21 +28,21 @@ func (c PlayersQueueAll) Cron() TaskTime {
 	return ""
 }
 
-func (c PlayersQueueAll) work() (err error) {
+func (c PlayersQueueAll) work(ctx context.Context) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
 
 	for {
 
-		players, err := mongo.GetPlayers(offset, limit, bson.D{{"_id", 1}}, nil, bson.M{"_id": 1})
+		players, err := mongo.GetPlayers(ctx, offset, limit, bson.D{{"_id", 1}}, nil, bson.M{"_id": 1})
 		if err != nil {
 			return err
 		}
 
 		for _, player := range players {
 
-			err = consumers.ProducePlayer(consumers.PlayerMessage{ID: player.ID}, "crons-queue-all")
+			err = consumers.ProducePlayer(ctx, consumers.PlayerMessage{ID: player.ID}, "crons-queue-all")
 			if err != nil {
 				return err
 			}
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/mongo"
@@ -28,7 +30,7 @@ func (c AutoPlayerRefreshes) Cron() TaskTime {
 	return CronTimeAutoPlayerRefreshes
 }
 
-func (c AutoPlayerRefreshes) work() (err error) {
+func (c AutoPlayerRefreshes) work(ctx context.Context) (err error) {
 
 	// Get users
 	db, err := mysql.GetMySQLClient()
@@ -53,7 +55,7 @@ func (c AutoPlayerRefreshes) work() (err error) {
 
 			playerIDs = append(playerIDs, playerID)
 
-			err = consumers.ProducePlayer(consumers.PlayerMessage{ID: playerID, ForceAchievementsRefresh: true}, "crons-donators")
+			err = consumers.ProducePlayer(ctx, consumers.PlayerMessage{ID: playerID, ForceAchievementsRefresh: true}, "crons-donators")
 			err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 			if err != nil {
 				return err
@@ -62,14 +64,14 @@ func (c AutoPlayerRefreshes) work() (err error) {
 	}
 
 	// Update groups
-	players, err := mongo.GetPlayersByID(playerIDs, bson.M{"primary_clan_id_string": 1})
+	players, err := mongo.GetPlayersByID(ctx, playerIDs, bson.M{"primary_clan_id_string": 1})
 	if err != nil {
 		return err
 	}
 
 	for _, v := range players {
 		if v.PrimaryGroupID != "" {
-			err = consumers.ProduceGroup(consumers.GroupMessage{ID: v.PrimaryGroupID})
+			err = consumers.ProduceGroup(ctx, consumers.GroupMessage{ID: v.PrimaryGroupID})
 			err = helpers.IgnoreErrors(err, consumers.ErrIsBot, consumers.ErrInQueue)
 			if err != nil {
 				return err
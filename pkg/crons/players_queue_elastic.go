@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,21 +28,21 @@ func (c PlayersQueueElastic) Cron() TaskTime {
 	return ""
 }
 
-func (c PlayersQueueElastic) work() (err error) {
+func (c PlayersQueueElastic) work(ctx context.Context) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
 
 	for {
 
-		players, err := mongo.GetPlayers(offset, limit, bson.D{{"_id", 1}}, nil, nil)
+		players, err := mongo.GetPlayers(ctx, offset, limit, bson.D{{"_id", 1}}, nil, nil)
 		if err != nil {
 			return err
 		}
 
 		for _, player := range players {
 
-			err = consumers.ProducePlayerSearch(&player, 0)
+			err = consumers.ProducePlayerSearch(ctx, &player, 0)
 			if err != nil {
 				return err
 			}
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,7 +28,7 @@ func (c PlayersQueueGroups) Cron() TaskTime {
 	return ""
 }
 
-func (c PlayersQueueGroups) work() (err error) {
+func (c PlayersQueueGroups) work(ctx context.Context) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
@@ -44,14 +46,14 @@ func (c PlayersQueueGroups) work() (err error) {
 			"ufs":          0,
 		}
 
-		players, err := mongo.GetPlayers(offset, limit, nil, nil, projection)
+		players, err := mongo.GetPlayers(ctx, offset, limit, nil, nil, projection)
 		if err != nil {
 			return err
 		}
 
 		for _, player := range players {
 
-			err = consumers.ProducePlayerGroup(player, true, false)
+			err = consumers.ProducePlayerGroup(ctx, player, true, false)
 			if err != nil {
 				return err
 			}
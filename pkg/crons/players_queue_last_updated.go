@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"time"
 
 	"github.com/Jleagle/rabbit-go"
@@ -36,7 +37,7 @@ func (c PlayersQueueLastUpdated) Cron() TaskTime {
 const toQueue = 10
 const cronTime = time.Minute
 
-func (c PlayersQueueLastUpdated) work() (err error) {
+func (c PlayersQueueLastUpdated) work(ctx context.Context) (err error) {
 
 	// Skip if queues have activity
 	limits := map[rabbit.QueueName]int{
@@ -70,12 +71,34 @@ func (c PlayersQueueLastUpdated) work() (err error) {
 		}
 	}
 
+	limit := int64(toQueue * consumerCount)
+
+	// Popular players that are past their SLO get half the slots, a limit of 0 would return everyone
+	var players []mongo.Player
+	if popularLimit := limit / 2; popularLimit > 0 {
+		players, err = mongo.GetPlayers(ctx, 0, popularLimit, bson.D{{"updated_at", 1}}, mongo.FreshnessPlayersPopular.StaleFilter(), bson.M{"_id": 1})
+		if err != nil {
+			return err
+		}
+	}
+
 	// Queue last updated players
-	players, err := mongo.GetPlayers(0, int64(toQueue*consumerCount), bson.D{{"updated_at", 1}}, helpers.LastUpdatedQuery, bson.M{"_id": 1})
+	lastUpdated, err := mongo.GetPlayers(ctx, 0, limit-int64(len(players)), bson.D{{"updated_at", 1}}, helpers.LastUpdatedQuery, bson.M{"_id": 1})
 	if err != nil {
 		return err
 	}
 
+	var queued = map[int64]bool{}
+	for _, player := range players {
+		queued[player.ID] = true
+	}
+
+	for _, player := range lastUpdated {
+		if !queued[player.ID] {
+			players = append(players, player)
+		}
+	}
+
 	for _, player := range players {
 
 		m := consumers.PlayerMessage{
@@ -84,7 +107,7 @@ func (c PlayersQueueLastUpdated) work() (err error) {
 			SkipAchievements: true,
 		}
 
-		err = consumers.ProducePlayer(m, "crons-last-updated")
+		err = consumers.ProducePlayer(ctx, m, "crons-last-updated")
 		err = helpers.IgnoreErrors(err, consumers.ErrInQueue)
 		if err != nil {
 			return err
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/i18n"
@@ -26,11 +28,11 @@ func (c PlayersUpdateRanks) Cron() TaskTime {
 	return CronTimePlayerRanks
 }
 
-func (c PlayersUpdateRanks) work() (err error) {
+func (c PlayersUpdateRanks) work(ctx context.Context) (err error) {
 
 	// Global
 	for read, write := range helpers.PlayerRankFields {
-		err = consumers.ProducePlayerRank(consumers.PlayerRanksMessage{
+		err = consumers.ProducePlayerRank(ctx, consumers.PlayerRanksMessage{
 			SortColumn: read,
 			ObjectKey:  string(write),
 		})
@@ -42,7 +44,7 @@ func (c PlayersUpdateRanks) work() (err error) {
 	// By continent
 	for _, continent := range i18n.Continents {
 		for read, write := range helpers.PlayerRankFields {
-			err = consumers.ProducePlayerRank(consumers.PlayerRanksMessage{
+			err = consumers.ProducePlayerRank(ctx, consumers.PlayerRanksMessage{
 				SortColumn: read,
 				ObjectKey:  string(write) + "_continent-" + continent.Key,
 				Continent:  &continent.Key,
@@ -57,7 +59,7 @@ func (c PlayersUpdateRanks) work() (err error) {
 	for cc, states := range i18n.States {
 
 		for read, write := range helpers.PlayerRankFields {
-			err = consumers.ProducePlayerRank(consumers.PlayerRanksMessage{
+			err = consumers.ProducePlayerRank(ctx, consumers.PlayerRanksMessage{
 				SortColumn: read,
 				ObjectKey:  string(write) + "_country-" + cc,
 				Country:    &cc,
@@ -70,7 +72,7 @@ func (c PlayersUpdateRanks) work() (err error) {
 		// By state
 		for state := range states {
 			for read, write := range helpers.PlayerRankFields {
-				err = consumers.ProducePlayerRank(consumers.PlayerRanksMessage{
+				err = consumers.ProducePlayerRank(ctx, consumers.PlayerRanksMessage{
 					SortColumn: read,
 					ObjectKey:  string(write) + "_state-" + state,
 					Country:    &cc,
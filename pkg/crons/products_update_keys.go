@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -31,7 +32,7 @@ func (c ProductsUpdateKeys) Cron() TaskTime {
 	return CronTimeScanProductQueues
 }
 
-func (c ProductsUpdateKeys) work() (err error) {
+func (c ProductsUpdateKeys) work(ctx context.Context) (err error) {
 
 	var addedKeys []string
 	var limit int64 = 10_000
@@ -52,7 +53,7 @@ func (c ProductsUpdateKeys) work() (err error) {
 
 	for {
 
-		apps, err := mongo.GetApps(offset, limit, nil, filter, projection)
+		apps, err := mongo.GetApps(ctx, offset, limit, nil, filter, projection)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,47 @@
+package crons
+
+import (
+	"context"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/consumers"
+	"github.com/gamedb/gamedb/pkg/mongo"
+)
+
+type SavedSearches struct {
+	BaseTask
+}
+
+func (c SavedSearches) ID() string {
+	return "saved-searches"
+}
+
+func (c SavedSearches) Name() string {
+	return "Queue saved searches to check for new results"
+}
+
+func (c SavedSearches) Group() TaskGroup {
+	return TaskGroupPlayers
+}
+
+func (c SavedSearches) Cron() TaskTime {
+	return CronTimeSavedSearches
+}
+
+func (c SavedSearches) work(ctx context.Context) (err error) {
+
+	searches, err := mongo.GetSavedSearchesToCheck(time.Now().Add(time.Minute*-50), 10_000)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range searches {
+
+		err = consumers.ProduceSavedSearch(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package crons
 
 import (
+	"context"
+
 	"github.com/gamedb/gamedb/pkg/consumers"
 	"github.com/gamedb/gamedb/pkg/mongo"
 	"github.com/gamedb/gamedb/pkg/steam"
@@ -26,9 +28,9 @@ func (c StatsTask) Cron() TaskTime {
 	return CronTimeStats
 }
 
-func (c StatsTask) work() (err error) {
+func (c StatsTask) work(ctx context.Context) (err error) {
 
-	appsCount, err := mongo.CountDocuments(mongo.CollectionApps, nil, 0)
+	appsCount, err := mongo.CountDocuments(ctx, mongo.CollectionApps, nil, 0)
 	if err != nil {
 		return err
 	}
@@ -44,7 +46,7 @@ func (c StatsTask) work() (err error) {
 	for _, t := range types {
 		err := mongo.BatchStats(t, func(stats []mongo.Stat) {
 			for _, stat := range stats {
-				err = consumers.ProduceStats(stat.Type, stat.ID, appsCount)
+				err = consumers.ProduceStats(ctx, stat.Type, stat.ID, appsCount)
 			}
 		})
 		if err != nil {
@@ -68,6 +70,6 @@ func (c StatsTask) work() (err error) {
 		})
 	}
 
-	_, err = mongo.InsertMany(mongo.CollectionStats, tags)
+	_, err = mongo.InsertMany(ctx, mongo.CollectionStats, tags)
 	return err
 }
@@ -0,0 +1,165 @@
+package crons
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jleagle/rabbit-go"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/consumers"
+	"github.com/gamedb/gamedb/pkg/crons/helpers/rabbitweb"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	statusChangesMaxAge = time.Minute * 30 // Steam always has changes coming through
+	statusQueueMaxSize  = 100_000
+)
+
+// Queues that must always have something consuming them
+var statusQueues = []rabbit.QueueName{
+	consumers.QueueApps,
+	consumers.QueuePackages,
+	consumers.QueuePlayers,
+	consumers.QueueGroups,
+	consumers.QueueBundles,
+	consumers.QueueChanges,
+}
+
+type StatusChecks struct {
+	BaseTask
+}
+
+func (c StatusChecks) ID() string {
+	return "status-checks"
+}
+
+func (c StatusChecks) Name() string {
+	return "Check components for the status page"
+}
+
+func (c StatusChecks) Group() TaskGroup {
+	return ""
+}
+
+func (c StatusChecks) Cron() TaskTime {
+	return CronTimeStatusChecks
+}
+
+func (c StatusChecks) work(ctx context.Context) (err error) {
+
+	checks := map[mongo.StatusComponent]func() error{
+		mongo.StatusComponentSite:    func() error { return statusCheckReady(config.C.GlobalSteamDomain) },
+		mongo.StatusComponentAPI:     func() error { return statusCheckReady(config.C.APIDomain) },
+		mongo.StatusComponentChatbot: statusCheckChatbot,
+		mongo.StatusComponentPICS:    statusCheckPICS,
+		mongo.StatusComponentQueues:  statusCheckQueues,
+		mongo.StatusComponentData:    func() error { return statusCheckData(ctx) },
+	}
+
+	var results []mongo.StatusCheck
+	for _, component := range mongo.StatusComponents {
+
+		err := checks[component]()
+
+		result := mongo.StatusCheck{
+			Component: component,
+			Healthy:   err == nil,
+			CreatedAt: time.Now(),
+		}
+		if err != nil {
+			result.Message = err.Error()
+		}
+
+		results = append(results, result)
+	}
+
+	return mongo.InsertStatusChecks(results)
+}
+
+func statusCheckReady(domain string) error {
+
+	_, code, err := helpers.Get(strings.TrimSuffix(domain, "/")+"/health-check/ready", time.Second*10, nil)
+	if err == helpers.ErrNon200 {
+		return errors.New("readiness check returned " + strconv.Itoa(code))
+	}
+
+	return err
+}
+
+// The chatbot sets a heartbeat while it's connected to Discord
+func statusCheckChatbot() error {
+
+	var last int64
+	err := memcache.Client().Get(memcache.ItemChatbotHeartbeat.Key, &last)
+	if err != nil {
+		return errors.New("no heartbeat from the Discord bot")
+	}
+
+	return nil
+}
+
+func statusCheckPICS() error {
+
+	change, err := mongo.GetLatestChange()
+	if err != nil {
+		return err
+	}
+
+	if time.Since(change.CreatedAt) > statusChangesMaxAge {
+		return errors.New("no changes since " + change.CreatedAt.Format(helpers.DateSQL))
+	}
+
+	return nil
+}
+
+func statusCheckQueues() error {
+
+	queues, err := rabbitweb.GetRabbitWebClient().GetQueues()
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for _, q := range queues {
+		for _, name := range statusQueues {
+			if q.Name == string(name) {
+
+				if q.Messages > 0 && q.Consumers == 0 {
+					problems = append(problems, q.Name+" has no consumers")
+				} else if q.Messages > statusQueueMaxSize {
+					problems = append(problems, q.Name+" has "+strconv.Itoa(q.Messages)+" messages")
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// Public players should always be getting updated
+func statusCheckData(ctx context.Context) error {
+
+	filter := append(bson.D{{"updated_at", bson.M{"$gte": time.Now().Add(time.Hour * -1)}}}, helpers.LastUpdatedQuery...)
+
+	count, err := mongo.CountDocuments(ctx, mongo.CollectionPlayers, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return errors.New("no players updated in the last hour")
+	}
+
+	return nil
+}
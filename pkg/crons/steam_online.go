@@ -1,6 +1,7 @@
 package crons
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"time"
@@ -30,7 +31,7 @@ func (c SteamOnline) Cron() TaskTime {
 	return CronTimeSteamClientPlayers
 }
 
-func (c SteamOnline) work() (err error) {
+func (c SteamOnline) work(ctx context.Context) (err error) {
 
 	body, _, err := helpers.Get("https://www.valvesoftware.com/en/about/stats", 0, nil)
 	if err != nil {
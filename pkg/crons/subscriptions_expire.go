@@ -0,0 +1,54 @@
+package crons
+
+import (
+	"context"
+
+	"github.com/gamedb/gamedb/pkg/billing"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+type SubscriptionsExpire struct {
+	BaseTask
+}
+
+func (c SubscriptionsExpire) ID() string {
+	return "subscriptions-expire"
+}
+
+func (c SubscriptionsExpire) Name() string {
+	return "Downgrade users whose paid period has ended"
+}
+
+func (c SubscriptionsExpire) Group() TaskGroup {
+	return ""
+}
+
+func (c SubscriptionsExpire) Cron() TaskTime {
+	return CronTimeSubscriptionsExpire
+}
+
+func (c SubscriptionsExpire) work(ctx context.Context) (err error) {
+
+	userIDs, err := mysql.GetExpiredUserSubscriptionUserIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+
+		user, err := mysql.GetUserByID(userID)
+		if err == mysql.ErrRecordNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		err = billing.Recalculate(user)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package crons
+
+import (
+	"context"
+
+	"github.com/gamedb/gamedb/pkg/consumers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/userdata"
+)
+
+type UserData struct {
+	BaseTask
+}
+
+func (c UserData) ID() string {
+	return "user-data"
+}
+
+func (c UserData) Name() string {
+	return "Queue account deletions and remove old data exports"
+}
+
+func (c UserData) Group() TaskGroup {
+	return ""
+}
+
+func (c UserData) Cron() TaskTime {
+	return CronTimeUserData
+}
+
+func (c UserData) work(ctx context.Context) (err error) {
+
+	// Deletions whose cancel window has passed
+	deletes, err := mysql.GetDueUserDataDeletes()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range deletes {
+
+		err = consumers.ProduceUserData(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Exports that can no longer be downloaded
+	exports, err := mysql.GetExpiredUserDataExports()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range exports {
+
+		err = userdata.RemoveExport(v)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+
+	return nil
+}
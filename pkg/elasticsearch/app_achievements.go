@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
@@ -43,8 +44,8 @@ func (achievement Achievement) GetAppPath() string {
 	return helpers.GetAppPath(achievement.AppID, achievement.AppName) + "#achievements"
 }
 
-func IndexAchievement(achievement Achievement) error {
-	return indexDocument(IndexAchievements, achievement.GetKey(), achievement)
+func IndexAchievement(ctx context.Context, achievement Achievement) error {
+	return indexDocument(ctx, IndexAchievements, achievement.GetKey(), achievement)
 }
 
 func IndexAchievementBulk(achievements map[string]Achievement) error {
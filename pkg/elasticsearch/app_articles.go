@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -110,8 +111,8 @@ func (article Article) OutputForJSON() []interface{} {
 	}
 }
 
-func IndexArticle(article Article) error {
-	return indexDocument(IndexArticles, strconv.FormatInt(article.ID, 10), article)
+func IndexArticle(ctx context.Context, article Article) error {
+	return indexDocument(ctx, IndexArticles, strconv.FormatInt(article.ID, 10), article)
 }
 
 func IndexArticlesBulk(articles map[string]Article) error {
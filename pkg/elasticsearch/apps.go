@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"html/template"
 	"math"
@@ -16,41 +17,43 @@ import (
 )
 
 type App struct {
-	AchievementsAvg     float64               `json:"achievements_avg"`
-	AchievementsCount   int                   `json:"achievements_counts"`
-	AchievementsIcons   []helpers.Tuple       `json:"achievements_icons"`
-	Aliases             []string              `json:"aliases"`
-	Background          string                `json:"background"`
-	Categories          []int                 `json:"categories"`
-	Developers          []int                 `json:"developers"`
-	FollowersCount      int                   `json:"followers"`
-	Genres              []int                 `json:"genres"`
-	GroupID             string                `json:"group_id"`
-	Icon                string                `json:"icon"`
-	ID                  int                   `json:"id"`
-	MicroTrailor        string                `json:"micro_trailor"`
-	Movies              string                `json:"movies"`
-	MoviesCount         int                   `json:"movies_count"`
-	Name                string                `json:"name"`
-	NameLC              string                `json:"name_lc"`
-	Platforms           []string              `json:"platforms"`
-	PlayersCount        int                   `json:"players"` // Peak week
-	Prices              helpers.ProductPrices `json:"prices"`
-	Publishers          []int                 `json:"publishers"`
-	ReleaseDateOriginal string                `json:"release_date_original"`
-	ReleaseDate         int64                 `json:"release_date"`
-	ReleaseDateRounded  int64                 `json:"release_date_rounded"`
-	ReviewScore         float64               `json:"score"`
-	ReviewsCount        int                   `json:"reviews_count"`
-	Screenshots         string                `json:"screenshots"`
-	ScreenshotsCount    int                   `json:"screenshots_count"`
-	Tags                []int                 `json:"tags"`
-	Trend               float64               `json:"trend"`
-	Type                string                `json:"type"`
-	WishlistAvg         float64               `json:"wishlist_avg"`
-	WishlistCount       int                   `json:"wishlist_count"`
-	NameMarked          string                `json:"-"`
-	Score               float64               `json:"-"`
+	AchievementsAvg     float64                   `json:"achievements_avg"`
+	AchievementsCount   int                       `json:"achievements_counts"`
+	AchievementsIcons   []helpers.Tuple           `json:"achievements_icons"`
+	Aliases             []string                  `json:"aliases"`
+	Background          string                    `json:"background"`
+	Categories          []int                     `json:"categories"`
+	Developers          []int                     `json:"developers"`
+	FollowersCount      int                       `json:"followers"`
+	Genres              []int                     `json:"genres"`
+	GroupID             string                    `json:"group_id"`
+	Icon                string                    `json:"icon"`
+	ID                  int                       `json:"id"`
+	MicroTrailor        string                    `json:"micro_trailor"`
+	Movies              string                    `json:"movies"`
+	MoviesCount         int                       `json:"movies_count"`
+	Name                string                    `json:"name"`
+	NameLC              string                    `json:"name_lc"`
+	Platforms           []string                  `json:"platforms"`
+	PlayersCount        int                       `json:"players"` // Peak week
+	Prices              helpers.ProductPrices     `json:"prices"`
+	PriceStats          helpers.ProductPriceStats `json:"price_stats"`
+	AtLowest            []steamapi.ProductCC      `json:"at_lowest"` // Regions where the current price is the lowest ever
+	Publishers          []int                     `json:"publishers"`
+	ReleaseDateOriginal string                    `json:"release_date_original"`
+	ReleaseDate         int64                     `json:"release_date"`
+	ReleaseDateRounded  int64                     `json:"release_date_rounded"`
+	ReviewScore         float64                   `json:"score"`
+	ReviewsCount        int                       `json:"reviews_count"`
+	Screenshots         string                    `json:"screenshots"`
+	ScreenshotsCount    int                       `json:"screenshots_count"`
+	Tags                []int                     `json:"tags"`
+	Trend               float64                   `json:"trend"`
+	Type                string                    `json:"type"`
+	WishlistAvg         float64                   `json:"wishlist_avg"`
+	WishlistCount       int                       `json:"wishlist_count"`
+	NameMarked          string                    `json:"-"`
+	Score               float64                   `json:"-"`
 }
 
 func (app App) GetID() int {
@@ -168,20 +171,20 @@ func (app App) GetPlayLink() template.URL {
 
 func SearchAppsSimple(limit int, search string) (apps []App, err error) {
 
-	apps, _, err = searchApps(limit, 0, search, false, false, nil, nil, false)
+	apps, _, err = searchApps(context.TODO(), limit, 0, search, false, false, nil, nil, false)
 	return apps, err
 }
 
-func SearchAppsAdvanced(offset int, limit int, search string, sorters []elastic.Sorter, boolQuery *elastic.BoolQuery) (apps []App, total int64, err error) {
+func SearchAppsAdvanced(ctx context.Context, offset int, limit int, search string, sorters []elastic.Sorter, boolQuery *elastic.BoolQuery) (apps []App, total int64, err error) {
 
-	return searchApps(limit, offset, search, true, true, sorters, boolQuery, false)
+	return searchApps(ctx, limit, offset, search, true, true, sorters, boolQuery, false)
 }
 
 func SearchAppsRandom(filters []elastic.Query) (app App, count int64, err error) {
 
 	boolQuery := elastic.NewBoolQuery().Filter(filters...)
 
-	apps, count, err := searchApps(1, 0, "", true, true, nil, boolQuery, true)
+	apps, count, err := searchApps(context.TODO(), 1, 0, "", true, true, nil, boolQuery, true)
 	if err != nil {
 		return app, count, err
 	}
@@ -239,7 +242,7 @@ func GetUpcomingGames() (apps []App, err error) {
 	item := memcache.ItemHomeUpcoming
 	err = memcache.Client().GetSet(item.Key, item.Expiration, &apps, func() (interface{}, error) {
 
-		apps, _, err := SearchAppsAdvanced(
+		apps, _, err := SearchAppsAdvanced(context.TODO(),
 			0,
 			10,
 			"",
@@ -255,9 +258,9 @@ func GetUpcomingGames() (apps []App, err error) {
 	return apps, err
 }
 
-func searchApps(limit int, offset int, search string, totals bool, highlights bool, sorters []elastic.Sorter, boolQuery *elastic.BoolQuery, random bool) (apps []App, total int64, err error) {
+func searchApps(ctx context.Context, limit int, offset int, search string, totals bool, highlights bool, sorters []elastic.Sorter, boolQuery *elastic.BoolQuery, random bool) (apps []App, total int64, err error) {
 
-	client, ctx, err := client()
+	client, _, err := client()
 	if err != nil {
 		return apps, 0, err
 	}
@@ -343,8 +346,8 @@ func searchApps(limit int, offset int, search string, totals bool, highlights bo
 	return apps, searchResult.TotalHits(), err
 }
 
-func IndexApp(a App) error {
-	return indexDocument(IndexApps, strconv.Itoa(a.ID), a)
+func IndexApp(ctx context.Context, a App) error {
+	return indexDocument(ctx, IndexApps, strconv.Itoa(a.ID), a)
 }
 
 //noinspection GoUnusedExportedFunction
@@ -365,6 +368,22 @@ func DeleteAndRebuildAppsIndex() {
 		}
 	}
 
+	var priceStatsProperties = map[string]interface{}{}
+	for _, v := range steamapi.ProductCCs {
+		priceStatsProperties[string(v)] = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"currency":         fieldTypeKeyword,
+				"lowest_ever":      fieldTypeInt32,
+				"lowest_ever_at":   fieldTypeDisabled,
+				"lowest_12_months": fieldTypeInt32,
+				"typical_sale":     fieldTypeInt32,
+				"sales":            fieldTypeInt32,
+				"updated_at":       fieldTypeDisabled,
+			},
+		}
+	}
+
 	var mapping = map[string]interface{}{
 		"settings": settings,
 		"mappings": map[string]interface{}{
@@ -372,6 +391,7 @@ func DeleteAndRebuildAppsIndex() {
 				"achievements_counts":   fieldTypeInt32,
 				"achievements_avg":      fieldTypeFloat16,
 				"achievements_icons":    fieldTypeDisabled,
+				"at_lowest":             fieldTypeKeyword,
 				"aliases":               fieldTypeText, // Used for searching
 				"background":            fieldTypeDisabled,
 				"categories":            fieldTypeKeyword,
@@ -389,6 +409,7 @@ func DeleteAndRebuildAppsIndex() {
 				"platforms":             fieldTypeKeyword,
 				"players":               fieldTypeInt32,
 				"prices":                map[string]interface{}{"type": "object", "properties": priceProperties},
+				"price_stats":           map[string]interface{}{"type": "object", "properties": priceStatsProperties},
 				"publishers":            fieldTypeKeyword,
 				"release_date_original": map[string]interface{}{"type": "text", "fields": map[string]interface{}{"raw": fieldTypeKeyword}}, /* type:text allows search, type:keyword allows sorting*/
 				"release_date":          fieldTypeInt64,
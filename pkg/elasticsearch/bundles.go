@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 	"strings"
@@ -177,8 +178,8 @@ func SearchBundles(offset int, limit int, search string, sorters []elastic.Sorte
 	return bundles, searchResult.TotalHits(), err
 }
 
-func IndexBundle(bundle Bundle) error {
-	return indexDocument(IndexBundles, strconv.Itoa(bundle.ID), bundle)
+func IndexBundle(ctx context.Context, bundle Bundle) error {
+	return indexDocument(ctx, IndexBundles, strconv.Itoa(bundle.ID), bundle)
 }
 
 //noinspection GoUnusedExportedFunction
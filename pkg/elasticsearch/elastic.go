@@ -3,11 +3,13 @@ package elasticsearch
 import (
 	"context"
 	"errors"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/tracing"
 	"github.com/olivere/elastic/v7"
 )
 
@@ -65,6 +67,7 @@ func client() (*elastic.Client, context.Context, error) {
 			elastic.SetSniff(false),
 			elastic.SetHealthcheck(true),
 			elastic.SetBasicAuth(config.C.ElasticUsername, config.C.ElasticPassword),
+			elastic.SetHttpClient(&http.Client{Transport: tracing.Transport(http.DefaultTransport)}),
 		}
 
 		var err error
@@ -79,9 +82,29 @@ func client() (*elastic.Client, context.Context, error) {
 	return clientStruct, clientContext, nil
 }
 
-func UpdateDocumentFields(index string, key string, doc map[string]interface{}) error {
+// Errors if the cluster is red
+func Ping(ctx context.Context) error {
 
-	client, ctx, err := client()
+	client, _, err := client()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ClusterHealth().Do(ctx)
+	if err != nil {
+		return err
+	}
+
+	if resp.Status == "red" {
+		return errors.New("cluster status is red")
+	}
+
+	return nil
+}
+
+func UpdateDocumentFields(ctx context.Context, index string, key string, doc map[string]interface{}) error {
+
+	client, _, err := client()
 	if err != nil {
 		return err
 	}
@@ -90,9 +113,9 @@ func UpdateDocumentFields(index string, key string, doc map[string]interface{})
 	return err
 }
 
-func indexDocument(index string, key string, doc interface{}) error {
+func indexDocument(ctx context.Context, index string, key string, doc interface{}) error {
 
-	client, ctx, err := client()
+	client, _, err := client()
 	if err != nil {
 		return err
 	}
@@ -126,9 +149,9 @@ func indexDocuments(index string, docs map[string]interface{}) error {
 	return nil
 }
 
-func DeleteDocument(index string, key string) error {
+func DeleteDocument(ctx context.Context, index string, key string) error {
 
-	client, ctx, err := client()
+	client, _, err := client()
 	if err != nil {
 		return err
 	}
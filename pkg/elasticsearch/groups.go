@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -65,8 +66,8 @@ func (group Group) GetGameLink() string {
 	return helpers.GetGroupLink(helpers.GroupTypeGame, group.URL)
 }
 
-func IndexGroup(g Group) error {
-	return indexDocument(IndexGroups, g.ID, g)
+func IndexGroup(ctx context.Context, g Group) error {
+	return indexDocument(ctx, IndexGroups, g.ID, g)
 }
 
 func SearchGroups(offset int, limit int, sorters []elastic.Sorter, search string, errors string) (groups []Group, aggregations map[string]map[string]int64, total int64, err error) {
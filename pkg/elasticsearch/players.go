@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"path"
 	"strconv"
@@ -39,6 +40,7 @@ type Player struct {
 	AwardsReceivedCount  int                        `json:"awards_received_count"`
 	AwardsReceivedPoints int                        `json:"awards_received_points"`
 	Ranks                map[helpers.RankMetric]int `json:"ranks"`
+	HideRanks            bool                       `json:"hide_ranks"`
 	PersonaNameMarked    string                     `json:"-"`
 	Score                float64                    `json:"-"`
 }
@@ -123,13 +125,13 @@ func (player Player) GetLastBan() time.Time {
 	return time.Unix(player.LastBan, 0)
 }
 
-func IndexPlayer(p Player) error {
-	return indexDocument(IndexPlayers, strconv.FormatInt(p.ID, 10), p)
+func IndexPlayer(ctx context.Context, p Player) error {
+	return indexDocument(ctx, IndexPlayers, strconv.FormatInt(p.ID, 10), p)
 }
 
-func SearchPlayers(limit int, offset int, search string, sorters []elastic.Sorter, filters []elastic.Query) (players []Player, total int64, err error) {
+func SearchPlayers(ctx context.Context, limit int, offset int, search string, sorters []elastic.Sorter, filters []elastic.Query) (players []Player, total int64, err error) {
 
-	client, ctx, err := client()
+	client, _, err := client()
 	if err != nil {
 		return players, 0, err
 	}
@@ -299,6 +301,7 @@ func DeleteAndRebuildPlayersIndex() {
 				"awards_received_count":  fieldTypeInt32,
 				"awards_received_points": fieldTypeInt32,
 				"ranks":                  fieldTypeDisabled,
+				"hide_ranks":             fieldTypeBool,
 			},
 		},
 	}
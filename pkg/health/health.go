@@ -0,0 +1,180 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/backend"
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/elasticsearch"
+	influxHelpers "github.com/gamedb/gamedb/pkg/influx"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+)
+
+const (
+	checkTimeout = time.Second * 3 // Each dependency gets this long before it counts as down
+	reportTTL    = time.Second * 5
+)
+
+type Check struct {
+	Name string
+	Ping func(ctx context.Context) error
+}
+
+var (
+	CheckMySQL    = Check{Name: "mysql", Ping: mysql.Ping}
+	CheckMongo    = Check{Name: "mongo", Ping: mongo.Ping}
+	CheckMemcache = Check{Name: "memcache", Ping: pingMemcache}
+	CheckElastic  = Check{Name: "elasticsearch", Ping: elasticsearch.Ping}
+	CheckInflux   = Check{Name: "influx", Ping: pingInflux}
+	CheckRabbit   = Check{Name: "rabbitmq", Ping: pingRabbit}
+	CheckBackend  = Check{Name: "backend", Ping: backend.Ping}
+
+	AllChecks = []Check{CheckMySQL, CheckMongo, CheckMemcache, CheckElastic, CheckInflux, CheckRabbit, CheckBackend}
+
+	// Pages can't be served without these, the rest only take out charts, search or queueing
+	ReadyChecks = []Check{CheckMySQL, CheckMongo, CheckMemcache}
+)
+
+type Result struct {
+	Name      string  `json:"name"`
+	Healthy   bool    `json:"healthy"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Healthy   bool      `json:"healthy"`
+	CheckedAt time.Time `json:"checked_at"`
+	Results   []Result  `json:"results"`
+}
+
+// Runs the checks in parallel, results keep the order of the checks
+func Run(ctx context.Context, checks ...Check) (report Report) {
+
+	report.CheckedAt = time.Now()
+	report.Results = make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for k, check := range checks {
+
+		wg.Add(1)
+		go func(k int, check Check) {
+
+			defer wg.Done()
+
+			report.Results[k] = run(ctx, check)
+		}(k, check)
+	}
+	wg.Wait()
+
+	report.Healthy = true
+	for _, v := range report.Results {
+		if !v.Healthy {
+			report.Healthy = false
+		}
+	}
+
+	return report
+}
+
+func run(ctx context.Context, check Check) (result Result) {
+
+	result.Name = check.Name
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// Some clients ignore the context, or retry forever while connecting
+	c := make(chan error, 1)
+	go func() {
+		c <- check.Ping(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-c:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result.LatencyMS = float64(time.Since(start)) / float64(time.Millisecond)
+	result.Healthy = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// These clients don't take a context, the timeout in run() still applies
+func pingMemcache(context.Context) error {
+	return memcache.Ping()
+}
+
+func pingInflux(context.Context) error {
+	return influxHelpers.Ping()
+}
+
+func pingRabbit(context.Context) error {
+
+	conn, err := amqp.DialConfig(config.RabbitDSN(), amqp.Config{Dial: amqp.DefaultDial(checkTimeout)})
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// The process is up, nothing else is checked
+func LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]bool{"healthy": true})
+}
+
+// 503 if any of the checks fail, so load balancers stop sending traffic.
+// Reports are reused for a few seconds so the endpoint can't be used to hammer the dependencies.
+func ReadinessHandler(checks ...Check) http.HandlerFunc {
+
+	var report Report
+	var lock sync.Mutex
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		lock.Lock()
+		if time.Since(report.CheckedAt) > reportTTL {
+			// Not the request context, a client hanging up shouldn't get cached as an outage
+			report = Run(context.Background(), checks...)
+		}
+		current := report
+		lock.Unlock()
+
+		code := http.StatusOK
+		if !current.Healthy {
+			code = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, code, current)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, i interface{}) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	err := json.NewEncoder(w).Encode(i)
+	if err != nil {
+		log.Err("writing health response", zap.Error(err))
+	}
+}
@@ -0,0 +1,175 @@
+package helpers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Jleagle/steam-go/steamapi"
+	"github.com/gamedb/gamedb/pkg/i18n"
+)
+
+//
+type ProductPriceStats map[steamapi.ProductCC]ProductPriceStat
+
+func (s ProductPriceStats) Get(code steamapi.ProductCC) (stat ProductPriceStat) {
+
+	if val, ok := s[code]; ok {
+		return val
+	}
+
+	// Fallback
+	if code == "eu" {
+		return s.Get("de")
+	}
+
+	return stat
+}
+
+// Regions where the current price matches the lowest ever price
+func (s ProductPriceStats) AtLowest(prices ProductPrices) (codes []steamapi.ProductCC) {
+
+	for k, v := range s {
+		if v.IsLowest(prices.Get(k)) {
+			codes = append(codes, k)
+		}
+	}
+
+	return codes
+}
+
+//
+type ProductPriceStat struct {
+	Currency       steamapi.CurrencyCode `json:"currency" bson:"currency"`
+	LowestEver     int                   `json:"lowest_ever" bson:"lowest_ever"`
+	LowestEverAt   time.Time             `json:"lowest_ever_at" bson:"lowest_ever_at"`
+	Lowest12Months int                   `json:"lowest_12_months" bson:"lowest_12_months"`
+	TypicalSale    int                   `json:"typical_sale" bson:"typical_sale"` // Median price of all discounts that ended, or are running now
+	Sales          int                   `json:"sales" bson:"sales"`
+	UpdatedAt      time.Time             `json:"updated_at" bson:"updated_at"`
+}
+
+// A single price change, oldest first
+type ProductPriceChange struct {
+	Time   time.Time
+	Before int
+	After  int
+}
+
+// Free products and products with no history have no stats
+func NewProductPriceStat(current ProductPrice, changes []ProductPriceChange) (stat ProductPriceStat) {
+
+	if !current.Exists || current.Free || current.Final == 0 {
+		return stat
+	}
+
+	var now = time.Now()
+	var yearAgo = now.AddDate(-1, 0, 0)
+
+	stat.Currency = current.Currency
+	stat.UpdatedAt = now
+	stat.LowestEver = current.Final
+	stat.LowestEverAt = now
+	stat.Lowest12Months = current.Final
+
+	var check = func(price int, t time.Time) {
+
+		// Zero prices are usually products being removed from the store
+		if price <= 0 {
+			return
+		}
+
+		if price < stat.LowestEver || (price == stat.LowestEver && t.Before(stat.LowestEverAt)) {
+			stat.LowestEver = price
+			stat.LowestEverAt = t
+		}
+
+		if !t.Before(yearAgo) && price < stat.Lowest12Months {
+			stat.Lowest12Months = price
+		}
+	}
+
+	var sales []int
+
+	for k, change := range changes {
+
+		// The price before the first change has been around since we started tracking
+		if k == 0 {
+			check(change.Before, change.Time)
+		}
+
+		// The previous price was still active at the start of the 12 month window
+		if change.Time.After(yearAgo) && (k == 0 || changes[k-1].Time.Before(yearAgo)) {
+			check(change.Before, yearAgo)
+		}
+
+		check(change.After, change.Time)
+
+		if change.After > 0 && change.After < change.Before && isSale(current, changes[k+1:], change.After) {
+			sales = append(sales, change.After)
+		}
+	}
+
+	if len(sales) > 0 {
+
+		sort.Ints(sales)
+
+		stat.Sales = len(sales)
+		stat.TypicalSale = sales[len(sales)/2]
+	}
+
+	return stat
+}
+
+// A drop is a sale if the price goes back up after it, or if it's below the current full price.
+// Drops that never go back up are permanent price cuts.
+func isSale(current ProductPrice, later []ProductPriceChange, price int) bool {
+
+	for _, v := range later {
+		if v.After > price {
+			return true
+		}
+	}
+
+	return price < current.Initial
+}
+
+func (s ProductPriceStat) Exists() bool {
+	return !s.UpdatedAt.IsZero() && s.Currency != ""
+}
+
+func (s ProductPriceStat) GetLowestEver() string {
+	return s.format(s.LowestEver)
+}
+
+func (s ProductPriceStat) GetLowestEverDate() string {
+
+	if !s.Exists() {
+		return "-"
+	}
+	return s.LowestEverAt.Format(DateYear)
+}
+
+func (s ProductPriceStat) GetLowest12Months() string {
+	return s.format(s.Lowest12Months)
+}
+
+func (s ProductPriceStat) GetTypicalSale() string {
+
+	if s.Sales == 0 {
+		return "-"
+	}
+	return s.format(s.TypicalSale)
+}
+
+// Whether the current price matches the lowest ever price
+func (s ProductPriceStat) IsLowest(current ProductPrice) bool {
+	return s.Exists() && current.Exists && current.Final > 0 && current.Final <= s.LowestEver
+}
+
+func (s ProductPriceStat) format(value int) string {
+
+	if !s.Exists() {
+		return "-"
+	}
+	return i18n.FormatPrice(s.Currency, value)
+}
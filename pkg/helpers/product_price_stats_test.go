@@ -0,0 +1,120 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewProductPriceStat(t *testing.T) {
+
+	var now = time.Now()
+	var ago = func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name           string
+		current        ProductPrice
+		changes        []ProductPriceChange
+		lowestEver     int
+		lowestEverAt   time.Time // Zero to skip, for when it's set to now
+		lowest12Months int
+		typicalSale    int
+		sales          int
+	}{
+		{
+			name:    "missing",
+			current: ProductPrice{},
+		},
+		{
+			name:    "free",
+			current: ProductPrice{Exists: true, Free: true},
+		},
+		{
+			name:           "no history",
+			current:        ProductPrice{Exists: true, Initial: 2000, Final: 2000},
+			lowestEver:     2000,
+			lowest12Months: 2000,
+		},
+		{
+			name:    "sale that ended",
+			current: ProductPrice{Exists: true, Initial: 2000, Final: 2000},
+			changes: []ProductPriceChange{
+				{Time: ago(730), Before: 2000, After: 1500},
+				{Time: ago(723), Before: 1500, After: 2000},
+			},
+			lowestEver:     1500,
+			lowestEverAt:   ago(730),
+			lowest12Months: 2000,
+			typicalSale:    1500,
+			sales:          1,
+		},
+		{
+			name:    "permanent cut",
+			current: ProductPrice{Exists: true, Initial: 1500, Final: 1500},
+			changes: []ProductPriceChange{
+				{Time: ago(730), Before: 2000, After: 1500},
+			},
+			lowestEver:     1500,
+			lowestEverAt:   ago(730),
+			lowest12Months: 1500,
+		},
+		{
+			name:    "sales after a permanent cut",
+			current: ProductPrice{Exists: true, Initial: 1500, Final: 1500},
+			changes: []ProductPriceChange{
+				{Time: ago(1095), Before: 2000, After: 1500},
+				{Time: ago(730), Before: 1500, After: 1000},
+				{Time: ago(723), Before: 1000, After: 1500},
+				{Time: ago(180), Before: 1500, After: 750},
+				{Time: ago(173), Before: 750, After: 1500},
+			},
+			lowestEver:     750,
+			lowestEverAt:   ago(180),
+			lowest12Months: 750,
+			typicalSale:    1000,
+			sales:          2,
+		},
+		{
+			name:    "sale running now",
+			current: ProductPrice{Exists: true, Initial: 2000, Final: 1000},
+			changes: []ProductPriceChange{
+				{Time: ago(1), Before: 2000, After: 1000},
+			},
+			lowestEver:     1000,
+			lowestEverAt:   ago(1),
+			lowest12Months: 1000,
+			typicalSale:    1000,
+			sales:          1,
+		},
+		{
+			name:    "removed from the store",
+			current: ProductPrice{Exists: true, Initial: 2000, Final: 2000},
+			changes: []ProductPriceChange{
+				{Time: ago(30), Before: 2000, After: 0},
+				{Time: ago(29), Before: 0, After: 2000},
+			},
+			lowestEver:     2000,
+			lowest12Months: 2000,
+		},
+	}
+
+	for _, test := range tests {
+
+		stat := NewProductPriceStat(test.current, test.changes)
+
+		if stat.LowestEver != test.lowestEver {
+			t.Errorf("%s: lowest ever expected %d, got %d", test.name, test.lowestEver, stat.LowestEver)
+		}
+		if !test.lowestEverAt.IsZero() && !stat.LowestEverAt.Equal(test.lowestEverAt) {
+			t.Errorf("%s: lowest ever at expected %s, got %s", test.name, test.lowestEverAt, stat.LowestEverAt)
+		}
+		if stat.Lowest12Months != test.lowest12Months {
+			t.Errorf("%s: lowest 12 months expected %d, got %d", test.name, test.lowest12Months, stat.Lowest12Months)
+		}
+		if stat.TypicalSale != test.typicalSale {
+			t.Errorf("%s: typical sale expected %d, got %d", test.name, test.typicalSale, stat.TypicalSale)
+		}
+		if stat.Sales != test.sales {
+			t.Errorf("%s: sales expected %d, got %d", test.name, test.sales, stat.Sales)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package influx
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	influx "github.com/influxdata/influxdb1-client"
+)
+
+// A point as it's stored in the archive.
+// Influx returns every number the same way, so the field types are kept to write them back correctly.
+// Decode with json.Decoder.UseNumber.
+type ArchivedPoint struct {
+	Measurement string                 `json:"measurement"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Time        time.Time              `json:"time"`
+	Fields      map[string]interface{} `json:"fields"`
+	Types       map[string]string      `json:"types"`
+}
+
+func (p ArchivedPoint) Point() (point influx.Point, err error) {
+
+	point = influx.Point{
+		Measurement: p.Measurement,
+		Tags:        p.Tags,
+		Time:        p.Time,
+		Fields:      map[string]interface{}{},
+		Precision:   "ns",
+	}
+
+	for k, v := range p.Fields {
+
+		number, ok := v.(json.Number)
+		if !ok {
+			point.Fields[k] = v
+			continue
+		}
+
+		switch p.Types[k] {
+		case "integer":
+			point.Fields[k], err = number.Int64()
+		case "float":
+			point.Fields[k], err = number.Float64()
+		default:
+			point.Fields[k] = number.String()
+		}
+		if err != nil {
+			return point, err
+		}
+	}
+
+	return point, nil
+}
+
+func query(retention InfluxRetentionPolicy, command string) (resp *influx.Response, err error) {
+
+	client, err := getInfluxClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err = client.Query(influx.Query{
+		Command:         command,
+		Database:        InfluxGameDB,
+		RetentionPolicy: string(retention),
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, resp.Error()
+}
+
+func timeRange(from time.Time, to time.Time) string {
+	return "time >= '" + from.UTC().Format(time.RFC3339Nano) + "' AND time < '" + to.UTC().Format(time.RFC3339Nano) + "'"
+}
+
+func fieldTypes(retention InfluxRetentionPolicy, measurement InfluxMeasurement) (types map[string]string, err error) {
+
+	resp, err := query(retention, `SHOW FIELD KEYS FROM "`+measurement.String()+`"`)
+	if err != nil {
+		return nil, err
+	}
+
+	types = map[string]string{}
+	for _, result := range resp.Results {
+		for _, series := range result.Series {
+			for _, row := range series.Values {
+				if len(row) == 2 {
+					key, _ := row[0].(string)
+					typ, _ := row[1].(string)
+					types[key] = typ
+				}
+			}
+		}
+	}
+
+	return types, nil
+}
+
+func ExportPoints(retention InfluxRetentionPolicy, measurement InfluxMeasurement, from time.Time, to time.Time, callback func(ArchivedPoint) error) (err error) {
+
+	types, err := fieldTypes(retention, measurement)
+	if err != nil {
+		return err
+	}
+
+	// Group by tags so they are returned separately from the fields
+	command := `SELECT * FROM "` + InfluxGameDB + `"."` + retention.String() + `"."` + measurement.String() + `" WHERE ` + timeRange(from, to) + ` GROUP BY *`
+
+	resp, err := query(retention, command)
+	if err != nil {
+		return err
+	}
+
+	return eachPoint(resp, measurement, types, callback)
+}
+
+func eachPoint(resp *influx.Response, measurement InfluxMeasurement, types map[string]string, callback func(ArchivedPoint) error) (err error) {
+
+	for _, result := range resp.Results {
+		for _, series := range result.Series {
+			for _, row := range series.Values {
+
+				point := ArchivedPoint{
+					Measurement: measurement.String(),
+					Tags:        series.Tags,
+					Fields:      map[string]interface{}{},
+					Types:       types,
+				}
+
+				for k, col := range series.Columns {
+
+					if col == "time" {
+						s, ok := row[k].(string)
+						if !ok {
+							return errors.New("unexpected time format")
+						}
+						point.Time, err = time.Parse(time.RFC3339Nano, s)
+						if err != nil {
+							return err
+						}
+						continue
+					}
+
+					if row[k] != nil {
+						point.Fields[col] = row[k]
+					}
+				}
+
+				err = callback(point)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func DeletePoints(measurement InfluxMeasurement, from time.Time, to time.Time) (err error) {
+
+	_, err = query("", `DELETE FROM "`+measurement.String()+`" WHERE `+timeRange(from, to))
+	return err
+}
+
+func RestorePoints(retention InfluxRetentionPolicy, points []ArchivedPoint) (err error) {
+
+	var batch influx.BatchPoints
+	for _, v := range points {
+
+		point, err := v.Point()
+		if err != nil {
+			return err
+		}
+
+		batch.Points = append(batch.Points, point)
+	}
+
+	_, err = InfluxWriteMany(retention, batch)
+	return err
+}
+
+// Oldest point in a measurement
+func GetFirstTime(retention InfluxRetentionPolicy, measurement InfluxMeasurement) (t time.Time, err error) {
+
+	resp, err := query(retention, `SELECT * FROM "`+InfluxGameDB+`"."`+retention.String()+`"."`+measurement.String()+`" ORDER BY time ASC LIMIT 1`)
+	if err != nil {
+		return t, err
+	}
+
+	for _, result := range resp.Results {
+		for _, series := range result.Series {
+			for _, row := range series.Values {
+				if s, ok := row[0].(string); ok {
+					return time.Parse(time.RFC3339Nano, s)
+				}
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// Points with a tag value, for a user's data export
+func ExportTagPoints(retention InfluxRetentionPolicy, measurement InfluxMeasurement, tag string, value string, callback func(ArchivedPoint) error) (err error) {
+
+	types, err := fieldTypes(retention, measurement)
+	if err != nil {
+		return err
+	}
+
+	command := `SELECT * FROM "` + InfluxGameDB + `"."` + retention.String() + `"."` + measurement.String() + `" WHERE ` + tagEquals(tag, value) + ` GROUP BY *`
+
+	resp, err := query(retention, command)
+	if err != nil {
+		return err
+	}
+
+	return eachPoint(resp, measurement, types, callback)
+}
+
+func DeleteTagPoints(measurement InfluxMeasurement, tag string, value string) (err error) {
+
+	_, err = query("", `DELETE FROM "`+measurement.String()+`" WHERE `+tagEquals(tag, value))
+	return err
+}
+
+func tagEquals(tag string, value string) string {
+	return `"` + tag + `" = '` + strings.ReplaceAll(value, `'`, `\'`) + `'`
+}
@@ -43,6 +43,17 @@ func getInfluxClient() (*influx.Client, error) {
 	return client, err
 }
 
+func Ping() error {
+
+	client, err := getInfluxClient()
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.Ping()
+	return err
+}
+
 var (
 	client2 influxdb2.Client
 	mutex2  sync.Mutex
@@ -29,7 +29,9 @@ const (
 	InfluxMeasurementChanges       InfluxMeasurement = "changes"
 	InfluxMeasurementChatBot       InfluxMeasurement = "chat_bot"
 	InfluxMeasurementGameDBStats   InfluxMeasurement = "gamedb-stats"
+	InfluxMeasurementGRPCCalls     InfluxMeasurement = "grpc_calls"
 	InfluxMeasurementGroups        InfluxMeasurement = "groups"
+	InfluxMeasurementPlayerAbuse   InfluxMeasurement = "player_abuse"
 	InfluxMeasurementPlayers       InfluxMeasurement = "players"
 	InfluxMeasurementPlayerUpdates InfluxMeasurement = "player_updates"
 	InfluxMeasurementRabbitQueue   InfluxMeasurement = "rabbitmq_queue"
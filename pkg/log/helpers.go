@@ -17,6 +17,8 @@ const (
 	LogNameTest      = "test"
 	LogNameScaler    = "scaler"
 	LogNameDevenv    = "devenv"
+	LogNameMigrate   = "migrate"
+	LogNameArchive   = "archive"
 
 	// Webhooks
 	LogNameTwitter  = "twitter" // Zapier
@@ -1,6 +1,7 @@
 package memcache
 
 import (
+	"context"
 	"encoding/json"
 	"sort"
 	"strconv"
@@ -12,7 +13,12 @@ import (
 	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/helpers"
 	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/metrics"
+	"github.com/gamedb/gamedb/pkg/tracing"
+	"github.com/memcachier/mc/v3"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Item struct {
@@ -30,6 +36,7 @@ var (
 	ItemAppStats              = func(typex string, appID int) Item { return Item{Key: "app-stats-" + typex + "-" + strconv.Itoa(appID), Expiration: 0} }
 	ItemAppDemos              = func(appID int) Item { return Item{Key: "app-demos-" + strconv.Itoa(appID), Expiration: 0} }
 	ItemAppRelated            = func(appID int) Item { return Item{Key: "app-related-" + strconv.Itoa(appID), Expiration: 0} }
+	ItemAppSimilar            = func(appID int) Item { return Item{Key: "app-similar-" + strconv.Itoa(appID), Expiration: 60 * 60 * 24} }
 	ItemAppBundles            = func(appID int) Item { return Item{Key: "app-bundles-" + strconv.Itoa(appID), Expiration: 0} }
 	ItemAppPackages           = func(appID int) Item { return Item{Key: "app-packages-" + strconv.Itoa(appID), Expiration: 0} }
 	ItemAppNoAchievements     = func(appID int) Item { return Item{Key: "app-no-stats-" + strconv.Itoa(appID), Expiration: 60 * 60, Value: "1"} }
@@ -87,15 +94,20 @@ var (
 	ItemStatsForSelect = func(t string) Item { return Item{Key: "stats-select-" + t, Expiration: 60 * 60 * 24} }
 
 	// User
-	ItemUserEvents    = func(userID int) Item { return Item{Key: "user-event-counts" + strconv.Itoa(userID), Expiration: 0} }
-	ItemUserByAPIKey  = func(key string) Item { return Item{Key: "user-level-by-key-" + key, Expiration: 10 * 60} }
-	ItemUserInDiscord = func(discordID string) Item { return Item{Key: "discord-id-" + discordID, Expiration: 60 * 60 * 24} }
+	ItemUserEvents     = func(userID int) Item { return Item{Key: "user-event-counts" + strconv.Itoa(userID), Expiration: 0} }
+	ItemUserByAPIKey   = func(key string) Item { return Item{Key: "user-level-by-key-" + key, Expiration: 10 * 60} }
+	ItemUserInDiscord  = func(discordID string) Item { return Item{Key: "discord-id-" + discordID, Expiration: 60 * 60 * 24} }
+	ItemUserSession    = func(id string) Item { return Item{Key: "user-session-" + id, Expiration: 10 * 60} }
+	ItemUserTeamAccess = func(userID int) Item { return Item{Key: "user-team-access-" + strconv.Itoa(userID), Expiration: 10 * 60} }
+	ItemTeamKey        = func(key string) Item { return Item{Key: "team-key-" + key, Expiration: 10 * 60} }
+	ItemTwoFactorFails = func(userID int) Item { return Item{Key: "two-factor-fails-" + strconv.Itoa(userID), Expiration: 10 * 60} }
 
 	// Player
 	ItemPlayer                   = func(playerID int64) Item { return Item{Key: "player-" + strconv.FormatInt(playerID, 10), Expiration: 0} }
 	ItemPlayerAchievementsDays   = func(playerID int64) Item { return Item{Key: "player-ach-days-" + strconv.FormatInt(playerID, 10), Expiration: 0} }
 	ItemPlayerAchievementsInflux = func(playerID int64) Item { return Item{Key: "player-ach-influx-" + strconv.FormatInt(playerID, 10), Expiration: 0} }
 	ItemPlayerFriends            = func(playerID int64, appID int) Item { return Item{Key: "player-friends-" + strconv.FormatInt(playerID, 10) + "-" + strconv.Itoa(appID), Expiration: 60 * 60 * 24} }
+	ItemPlayerRecommendations    = func(playerID int64) Item { return Item{Key: "player-recommendations-" + strconv.FormatInt(playerID, 10), Expiration: 60 * 60 * 6} }
 	ItemPlayerLevels             = Item{Key: "player-levels", Expiration: 60 * 60 * 24}
 	ItemPlayerLevelsRounded      = Item{Key: "player-levels-rounded", Expiration: 60 * 60 * 24}
 	ItemPlayerLocationAggs       = Item{Key: "player-location-aggs", Expiration: 60 * 60 * 2}
@@ -108,16 +120,27 @@ var (
 	ItemChange               = func(changeID int64) Item { return Item{Key: "change-" + strconv.FormatInt(changeID, 10), Expiration: 0} }
 	ItemCommitsPage          = func(page int) Item { return Item{Key: "commits-page-" + strconv.Itoa(page), Expiration: 60 * 60} }
 	ItemConfigItem           = func(configID string) Item { return Item{Key: "config-item-" + configID, Expiration: 0} }
+	ItemFreshnessReport      = Item{Key: "freshness-report", Expiration: 60 * 60 * 24}
 	ItemFirstAppBadge        = func(appID int) Item { return Item{Key: "first-app-badge-" + strconv.Itoa(appID), Expiration: 0} }
 	ItemMongoCount           = func(collection string, filter bson.D) Item { return Item{Key: "mongo-count-" + collection + "-" + FilterToString(filter), Expiration: 60 * 60} }
 	ItemUniqueSaleTypes      = Item{Key: "unique-sale-types", Expiration: 60 * 60 * 1}
 	ItemChatbotCalls         = Item{Key: "chatbot-calls", Expiration: 60 * 10}
+	ItemChatbotHeartbeat     = Item{Key: "chatbot-heartbeat", Expiration: 60 * 5}
+	ItemRateLimit            = func(bucket string, key string) Item { return Item{Key: "rate-limit-" + bucket + "-" + key} }
+	ItemRateLimitValue       = func(key string) Item { return Item{Key: "rate-limit-value-" + key} }
+)
+
+const namespace = "gs_"
+
+var (
+	ErrNotFound    = mc.ErrNotFound
+	ErrCASConflict = mc.ErrKeyExists // Someone else wrote the key first
 )
 
 var lock sync.Mutex
-var client *memcache.Client
+var client *CacheClient
 
-func Client() *memcache.Client {
+func Client() *CacheClient {
 
 	lock.Lock()
 	defer lock.Unlock()
@@ -130,19 +153,154 @@ func Client() *memcache.Client {
 
 		options := []memcache.Option{
 			memcache.WithAuth(config.C.MemcacheUsername, config.C.MemcachePassword),
-			memcache.WithNamespace("gs_"),
+			memcache.WithNamespace(namespace),
 		}
 
 		if config.IsLocal() {
 			options = append(options, memcache.WithTypeChecks(true))
 		}
 
-		client = memcache.NewClient(config.C.MemcacheDSN, options...)
+		client = &CacheClient{Client: memcache.NewClient(config.C.MemcacheDSN, options...)}
 	}
 
 	return client
 }
 
+// Counts hits and misses on reads, everything else goes straight to the library
+type CacheClient struct {
+	*memcache.Client
+	ctx context.Context
+}
+
+// Calls through the returned client are traced as part of ctx
+func (c CacheClient) WithContext(ctx context.Context) *CacheClient {
+	c.ctx = ctx
+	return &c
+}
+
+// Only traced when there is a context to join, a span for every untraced call would be noise
+func (c CacheClient) startSpan(operation string, key string) trace.Span {
+
+	if c.ctx == nil {
+		return trace.SpanFromContext(context.Background())
+	}
+
+	_, span := tracing.StartSpan(c.ctx, "memcache "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "memcached"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", key),
+		),
+	)
+
+	return span
+}
+
+func (c CacheClient) Get(key string, out interface{}) (err error) {
+
+	span := c.startSpan("get", key)
+	defer span.End()
+
+	err = c.Client.Get(key, out)
+	if err == nil {
+		metrics.CacheHit()
+	} else if err == mc.ErrNotFound {
+		metrics.CacheMiss()
+	}
+
+	return err
+}
+
+func (c CacheClient) GetSet(key string, seconds uint32, out interface{}, callback func() (interface{}, error)) (err error) {
+
+	span := c.startSpan("getset", key)
+	defer span.End()
+
+	var miss bool
+
+	err = c.Client.GetSet(key, seconds, out, func() (interface{}, error) {
+		miss = true
+		return callback()
+	})
+
+	if miss {
+		metrics.CacheMiss()
+	} else if err == nil {
+		metrics.CacheHit()
+	}
+
+	return err
+}
+
+func (c CacheClient) Set(key string, value interface{}, seconds uint32) (err error) {
+
+	span := c.startSpan("set", key)
+	defer span.End()
+
+	return c.Client.Set(key, value, seconds)
+}
+
+func (c CacheClient) Exists(key string) (exists bool, err error) {
+
+	span := c.startSpan("exists", key)
+	defer span.End()
+
+	return c.Client.Exists(key)
+}
+
+func (c CacheClient) Delete(keys ...string) (err error) {
+
+	span := c.startSpan("delete", strings.Join(keys, " "))
+	defer span.End()
+
+	return c.Client.Delete(keys...)
+}
+
+// GetCAS skips the encoder, for raw values that get updated with SetCAS
+func (c CacheClient) GetCAS(key string) (val string, cas uint64, err error) {
+
+	val, _, cas, err = c.Client.Client().Get(namespace + key)
+	return val, cas, err
+}
+
+// SetCAS only writes if the key hasn't changed since GetCAS, or doesn't exist yet when cas is 0
+func (c CacheClient) SetCAS(key string, val string, seconds uint32, cas uint64) (err error) {
+
+	if cas == 0 {
+		_, err = c.Client.Client().Add(namespace+key, val, 0, seconds)
+	} else {
+		_, err = c.Client.Client().Set(namespace+key, val, 0, seconds, cas)
+	}
+
+	return err
+}
+
+// Increment adds one to a raw counter, creating it at 1 if it doesn't exist. The expiry is only set on creation.
+func (c CacheClient) Increment(key string, seconds uint32) (n uint64, err error) {
+
+	n, _, err = c.Client.Client().Incr(namespace+key, 1, 1, seconds, 0)
+	return n, err
+}
+
+// Counter reads a value written by Increment, missing counters are zero
+func (c CacheClient) Counter(key string) (n uint64, err error) {
+
+	val, _, err := c.GetCAS(key)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(val, 10, 64)
+}
+
+func Ping() error {
+	return Client().Client.Client().NoOp()
+}
+
 func Close() {
 	Client().Close()
 }
@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const namespace = "gamedb"
+
+// Queue message results
+const (
+	QueueResultAck     = "ack"     // Handled
+	QueueResultNack    = "nack"    // Sent back round to be retried
+	QueueResultFail    = "fail"    // Sent to the failed queue
+	QueueResultUnacked = "unacked" // Handler returned without doing either
+)
+
+var (
+	httpRequests = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request durations by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+
+	grpcCalls = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_handler_duration_seconds",
+		Help:      "Backend gRPC handler durations by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	queueMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_messages_total",
+		Help:      "Consumed Rabbit messages by queue and result.",
+	}, []string{"queue", "result"})
+
+	queueDurations = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "queue_handler_duration_seconds",
+		Help:      "Rabbit consumer handler durations by queue.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"queue"})
+
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "memcache_requests_total",
+		Help:      "Memcache reads by result, hit or miss.",
+	}, []string{"result"})
+
+	steamCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "steam_calls_total",
+		Help:      "Steam API, store and community calls by endpoint.",
+	}, []string{"endpoint"})
+
+	steamErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "steam_call_errors_total",
+		Help:      "Failed Steam calls by endpoint and status code, or \"error\" if there was no response.",
+	}, []string{"endpoint", "code"})
+)
+
+// Serve starts the /metrics listener on its own port, away from public routes
+func Serve() (shutdown func()) {
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	s := &http.Server{
+		Addr:              "0.0.0.0:" + config.C.MetricsPort,
+		Handler:           mux,
+		ReadTimeout:       5 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+
+	go func() {
+		err := s.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			log.Err("metrics server", zap.Error(err))
+		}
+	}()
+
+	return func() {
+		err := s.Shutdown(context.Background())
+		if err != nil {
+			log.Err("shutting down metrics server", zap.Error(err))
+		}
+	}
+}
+
+// Chi middleware, labeled by the matched route so IDs in paths don't explode the series
+func Middleware(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		start := time.Now()
+		ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		code := ww.Status()
+		if code == 0 {
+			code = http.StatusOK
+		}
+
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(code)).Observe(time.Since(start).Seconds())
+	})
+}
+
+func ObserveGRPC(method string, code string, duration time.Duration) {
+	grpcCalls.WithLabelValues(method, code).Observe(duration.Seconds())
+}
+
+func ObserveQueueMessage(queue string, result string, duration time.Duration) {
+	queueMessages.WithLabelValues(queue, result).Inc()
+	queueDurations.WithLabelValues(queue).Observe(duration.Seconds())
+}
+
+func CacheHit() {
+	cacheRequests.WithLabelValues("hit").Inc()
+}
+
+func CacheMiss() {
+	cacheRequests.WithLabelValues("miss").Inc()
+}
+
+// Takes the full request URL
+func SteamCall(rawURL string) {
+	steamCalls.WithLabelValues(steamEndpoint(rawURL)).Inc()
+}
+
+// Code is the HTTP status, or zero if the call didn't get a response
+func SteamError(rawURL string, code int) {
+
+	var label = "error"
+	if code > 0 {
+		label = strconv.Itoa(code)
+	}
+
+	steamErrors.WithLabelValues(steamEndpoint(rawURL), label).Inc()
+}
+
+// The route the URL was made from, without IDs, names or the query string
+func steamEndpoint(rawURL string) string {
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "unknown"
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	// Community paths have vanity names in them, so only keep the section
+	if u.Host == "steamcommunity.com" {
+		parts = parts[:1]
+	}
+
+	// Store pages are like /app/440/Team_Fortress_2, anything after the ID is a slug
+	for k, v := range parts {
+		if v != "" && v[0] >= '0' && v[0] <= '9' {
+			parts = append(parts[:k], ":id")
+			break
+		}
+	}
+
+	return u.Host + "/" + strings.Join(parts, "/")
+}
@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"testing"
+)
+
+func TestSteamEndpoint(t *testing.T) {
+
+	tests := map[string]string{
+		"https://api.steampowered.com/ISteamUser/GetPlayerSummaries/v2/?key=x&steamids=76561197960287930": "api.steampowered.com/ISteamUser/GetPlayerSummaries/v2",
+		"https://store.steampowered.com/app/440/Team_Fortress_2/":                                         "store.steampowered.com/app/:id",
+		"https://store.steampowered.com/bundle/232/Valve_Complete_Pack/?cc=us":                            "store.steampowered.com/bundle/:id",
+		"https://store.steampowered.com/curator/33-PC-Gamer/":                                             "store.steampowered.com/curator/:id",
+		"https://store.steampowered.com/api/appdetails?appids=440":                                        "store.steampowered.com/api/appdetails",
+		"https://steamcommunity.com/id/some-vanity-name/inventory":                                        "steamcommunity.com/id",
+		"not a url": "unknown",
+	}
+
+	for in, expected := range tests {
+		if got := steamEndpoint(in); got != expected {
+			t.Errorf("%s: expected %s, got %s", in, expected, got)
+		}
+	}
+}
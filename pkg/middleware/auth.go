@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 
+	"github.com/gamedb/gamedb/pkg/mysql"
 	"github.com/gamedb/gamedb/pkg/session"
 )
 
@@ -15,7 +16,12 @@ func MiddlewareAuthCheck(next http.Handler) http.Handler {
 		}
 
 		// session.SetFlash(r, session.SessionBad, "Please login")
-		// session.Save(w, r)
+
+		// Come back here after logging in, eg. from a link in an email
+		if r.Method == http.MethodGet {
+			session.Set(r, session.SessionLastPage, r.URL.Path)
+			session.Save(w, r)
+		}
 
 		http.Redirect(w, r, "/login", http.StatusFound)
 	})
@@ -34,3 +40,18 @@ func MiddlewareAdminCheck(errorHandler http.HandlerFunc) func(http.Handler) http
 		})
 	}
 }
+
+// For admins whose role doesn't include the permission, use after MiddlewareAdminCheck
+func MiddlewareAdminPermission(permission mysql.AdminPermission, errorHandler http.HandlerFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if session.HasAdminPermission(r, permission) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			errorHandler(w, r)
+		})
+	}
+}
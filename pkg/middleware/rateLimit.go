@@ -1,34 +1,72 @@
 package middleware
 
 import (
-	"context"
 	"fmt"
+	"math"
 	"net/http"
+	"strings"
+	"time"
 
-	"github.com/Jleagle/rate-limit-go"
-	"github.com/gamedb/gamedb/pkg/helpers"
-	"github.com/gamedb/gamedb/pkg/log"
-	xrate "golang.org/x/time/rate"
+	"github.com/gamedb/gamedb/pkg/ratelimit"
 )
 
-func SetRateLimitHeaders(w http.ResponseWriter, limiters *rate.Limiters, reservation *xrate.Reservation) {
+// Longest RateLimiterWait will hold a request before giving up
+const rateLimitMaxWait = time.Second * 10
 
-	w.Header().Set("X-RateLimit-Every", limiters.GetMinInterval().String())
-	w.Header().Set("X-RateLimit-Burst", fmt.Sprint(limiters.GetBurst()))
-	w.Header().Set("X-RateLimit-Wait", reservation.Delay().String())
-	w.Header().Set("X-RateLimit-Bucket", "global")
+func SetRateLimitHeaders(w http.ResponseWriter, limiter *ratelimit.Limiter, res ratelimit.Result) {
+
+	w.Header().Set("X-RateLimit-Every", limiter.Bucket().Every.String())
+	w.Header().Set("X-RateLimit-Burst", fmt.Sprint(res.Limit))
+	w.Header().Set("X-RateLimit-Limit", fmt.Sprint(res.Limit))
+	w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(res.Remaining))
+	w.Header().Set("X-RateLimit-Reset", fmt.Sprint(ceilSeconds(res.Reset)))
+	w.Header().Set("X-RateLimit-Wait", res.Wait.String())
+	w.Header().Set("X-RateLimit-Bucket", res.Bucket)
+
+	if !res.Allowed {
+		w.Header().Set("Retry-After", fmt.Sprint(ceilSeconds(res.Wait)))
+	}
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// RouteGroups have their own bucket, any other path shares one, so paths can't make up new buckets
+type RouteGroups map[string]bool
+
+const routeGroupOther = "other"
+
+func NewRouteGroups(groups ...string) RouteGroups {
+
+	m := RouteGroups{}
+	for _, v := range groups {
+		m[v] = true
+	}
+	return m
 }
 
-func RateLimiterBlock(limiters *rate.Limiters, handler http.HandlerFunc) func(http.Handler) http.Handler {
+// Group is the first part of the path if it's in the list, or "other"
+func (groups RouteGroups) Group(r *http.Request) string {
+
+	group := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[0]
+	group = strings.TrimSuffix(group, ".json")
+	if groups[group] {
+		return group
+	}
+	return routeGroupOther
+}
+
+func RateLimiterBlock(limiter *ratelimit.Limiter, groups RouteGroups, handler http.HandlerFunc) func(http.Handler) http.Handler {
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-			reservation := limiters.GetLimiter(r.RemoteAddr).Reserve()
+			res := limiter.Take(groups.Group(r), r.RemoteAddr)
 
-			SetRateLimitHeaders(w, limiters, reservation)
+			SetRateLimitHeaders(w, limiter, res)
 
-			if !reservation.OK() {
+			if !res.Allowed {
 				handler(w, r)
 				return
 			}
@@ -38,22 +76,35 @@ func RateLimiterBlock(limiters *rate.Limiters, handler http.HandlerFunc) func(ht
 	}
 }
 
-func RateLimiterWait(limiters *rate.Limiters) func(http.Handler) http.Handler {
+func RateLimiterWait(limiter *ratelimit.Limiter, groups RouteGroups) func(http.Handler) http.Handler {
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-			err := limiters.GetLimiter(r.RemoteAddr).Wait(r.Context())
-			if err != nil {
-				err = helpers.IgnoreErrors(err, context.Canceled)
-				if err != nil {
-					log.ErrS(err)
+			group := groups.Group(r)
+			deadline := time.Now().Add(rateLimitMaxWait)
+
+			for {
+				res := limiter.Take(group, r.RemoteAddr)
+				if res.Allowed {
+					SetRateLimitHeaders(w, limiter, res)
+					next.ServeHTTP(w, r)
+					return
 				}
-				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
-				return
-			}
 
-			next.ServeHTTP(w, r)
+				if time.Now().Add(res.Wait).After(deadline) {
+					SetRateLimitHeaders(w, limiter, res)
+					http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+					return
+				}
+
+				select {
+				case <-r.Context().Done():
+					http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+					return
+				case <-time.After(res.Wait):
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/session"
+)
+
+// Paths that can't trust the cookie alone, in case it was revoked while Mongo is down
+var userSessionRequiredPaths = []string{"/admin", "/settings", "/teams"}
+
+// Logs out cookies whose server side session has been revoked or has expired
+func MiddlewareUserSession(unavailableHandler http.HandlerFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if session.IsLoggedIn(r) {
+
+				userSession, err := mongo.GetUserSession(session.Get(r, session.SessionUserSessionID))
+				if err == nil && userSession.UserID == session.GetUserIDFromSesion(r) && userSession.ExpiresAt.After(time.Now()) {
+
+					if time.Since(userSession.LastSeen) > mongo.UserSessionTouch {
+						go func(ip, userAgent string) {
+							err := mongo.TouchUserSession(userSession.ID, ip, userAgent)
+							if err != nil {
+								log.ErrS(err)
+							}
+						}(r.RemoteAddr, r.UserAgent())
+					}
+
+				} else if err == nil || err == mongo.ErrNoDocuments {
+
+					session.DeleteAll(r)
+					session.SetFlash(r, session.SessionBad, "You have been logged out")
+					session.Save(w, r)
+
+				} else {
+
+					log.ErrS(err)
+
+					// Let them through if Mongo is down, except where a revoked session could do damage
+					for _, path := range userSessionRequiredPaths {
+						if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path+"/") {
+							unavailableHandler(w, r)
+							return
+						}
+					}
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
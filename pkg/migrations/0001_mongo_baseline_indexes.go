@@ -0,0 +1,230 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/i18n"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The indexes that used to be created on every backend boot, as they were.
+// This includes the player app and stat indexes that were put on the wrong collections.
+var mongoBaselineIndexes = Migration{
+	ID:          "0001-mongo-baseline-indexes",
+	Database:    DatabaseMongo,
+	Description: "Indexes previously created by mongo.EnsureIndexes",
+	Up: func() error {
+		return mongo.CreateIndexes(baselineIndexes()...)
+	},
+	Down: func() error {
+		return mongo.DropIndexes(baselineIndexes()...)
+	},
+}
+
+func baselineIndexes() []mongo.IndexSet {
+
+	return []mongo.IndexSet{
+		mongo.NewIndexSet(mongo.CollectionApps, baselineAppIndexes),
+		mongo.NewIndexSet(mongo.CollectionGroups, baselineGroupIndexes),
+		mongo.NewIndexSet(mongo.CollectionPackages, baselinePackageIndexes()),
+		mongo.NewIndexSet(mongo.CollectionPlayers, baselinePlayerIndexes()),
+		mongo.NewIndexSet(mongo.CollectionPlayerAchievements, baselinePlayerAchievementIndexes),
+		mongo.NewIndexSet(mongo.CollectionGroups, baselinePlayerAppIndexes),
+		mongo.NewIndexSet(mongo.CollectionPlayerFriends, baselinePlayerFriendIndexes),
+		mongo.NewIndexSet(mongo.CollectionAppSales, baselineSaleIndexes()),
+		mongo.NewIndexSet(mongo.CollectionPlayers, baselineStatIndexes),
+		mongo.NewIndexSet(mongo.CollectionAppSameOwners, baselineAppSameOwnersIndexes),
+		mongo.NewIndexSet(mongo.CollectionAppSimilar, baselineAppSimilarIndexes),
+		mongo.NewIndexSet(mongo.CollectionPlayerIgnoredApps, baselinePlayerIgnoredAppIndexes),
+		mongo.NewIndexSet(mongo.CollectionSavedSearches, baselineSavedSearchIndexes),
+		mongo.NewIndexSet(mongo.CollectionAppArticles, baselineArticleIndexes),
+		mongo.NewIndexSet(mongo.CollectionProductPrices, baselineProductPriceIndexes),
+		mongo.NewIndexSet(mongo.CollectionStatusChecks, baselineStatusCheckIndexes),
+		mongo.NewIndexSet(mongo.CollectionStatusIncidents, baselineStatusIncidentIndexes),
+		mongo.NewIndexSet(mongo.CollectionFreshnessReports, baselineFreshnessIndexes),
+	}
+}
+
+var caseInsensitive = &options.Collation{
+	Locale:   "en",
+	Strength: 2,
+}
+
+var baselineAppIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"achievements_average_completion", -1}}},
+	{Keys: bson.D{{"achievements_count", -1}, {"achievements_average_completion", -1}}},
+	{Keys: bson.D{{"categories", 1}}},
+	{Keys: bson.D{{"developers", 1}}},
+	{Keys: bson.D{{"genres", 1}}},
+	{Keys: bson.D{{"genres", -1}}},
+	{Keys: bson.D{{"group_followers", -1}}},
+	{Keys: bson.D{{"player_peak_week", -1}}},
+	{Keys: bson.D{{"player_trend", -1}}},
+	{Keys: bson.D{{"publishers", 1}}},
+	{Keys: bson.D{{"release_date_unix", 1}}},
+	{Keys: bson.D{{"release_date_unix", -1}}},
+	{Keys: bson.D{{"reviews_score", -1}}},
+	{Keys: bson.D{{"tags", 1}}},
+	{Keys: bson.D{{"tags", -1}}},
+	{Keys: bson.D{{"type", 1}}},
+	{Keys: bson.D{{"wishlist_avg_position", 1}}},
+	{Keys: bson.D{{"wishlist_count", -1}}},
+
+	{Keys: bson.D{{"common.$**", 1}}, Options: options.Index().SetName("common.wildcard_1")},
+	{Keys: bson.D{{"config.$**", 1}}, Options: options.Index().SetName("config.wildcard_1")},
+	{Keys: bson.D{{"extended.$**", 1}}, Options: options.Index().SetName("extended.wildcard_1")},
+	{Keys: bson.D{{"ufs.$**", 1}}, Options: options.Index().SetName("ufs.wildcard_1")},
+}
+
+var baselineGroupIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"type", 1}, {"_id", 1}}},
+	{Keys: bson.D{{"type", 1}, {"members", -1}}},
+	{Keys: bson.D{{"type", 1}, {"trending", 1}}},
+	{Keys: bson.D{{"type", 1}, {"trending", -1}}},
+	{Keys: bson.D{{"type", 1}, {"primaries", -1}}},
+}
+
+func baselinePackageIndexes() (indexes []mongodb.IndexModel) {
+
+	var cols = []string{
+		"apps_count",
+		"billing_type",
+		"change_number_date",
+		"license_type",
+		"platforms",
+		"status",
+	}
+
+	for _, v := range i18n.GetProdCCs(true) {
+		cols = append(cols, "prices."+string(v.ProductCode)+".final")
+		cols = append(cols, "prices."+string(v.ProductCode)+".discount_percent")
+	}
+
+	for _, v := range cols {
+		indexes = append(indexes,
+			mongodb.IndexModel{Keys: bson.D{{v, 1}}},
+			mongodb.IndexModel{Keys: bson.D{{v, -1}}},
+		)
+	}
+
+	return append(indexes,
+		mongodb.IndexModel{Keys: bson.D{{"extended.$**", 1}}, Options: options.Index().SetName("extended.wildcard_1")},
+	)
+}
+
+func baselinePlayerIndexes() (indexes []mongodb.IndexModel) {
+
+	// For the ranking cron
+	for col := range helpers.PlayerRankFields {
+		indexes = append(indexes,
+			mongodb.IndexModel{Keys: bson.D{{col, -1}}},
+			mongodb.IndexModel{Keys: bson.D{{"continent_code", 1}, {col, -1}}},
+			mongodb.IndexModel{Keys: bson.D{{"country_code", 1}, {col, -1}}},
+			mongodb.IndexModel{Keys: bson.D{{"country_code", 1}, {"status_code", 1}, {col, -1}}},
+		)
+	}
+
+	return append(indexes,
+
+		// For the last updated cron
+		mongodb.IndexModel{Keys: bson.D{{"community_visibility_state", 1}, {"removed", 1}, {"updated_at", 1}}},
+
+		// For admin stats
+		mongodb.IndexModel{Keys: bson.D{{"community_visibility_state", 1}}},
+		mongodb.IndexModel{Keys: bson.D{{"removed", 1}}},
+
+		mongodb.IndexModel{Keys: bson.D{{"primary_clan_id_string", 1}}},
+		mongodb.IndexModel{Keys: bson.D{{"achievement_count_100", -1}}},
+		mongodb.IndexModel{Keys: bson.D{{"bans_cav", -1}}},
+		mongodb.IndexModel{Keys: bson.D{{"bans_game", -1}}},
+		mongodb.IndexModel{Keys: bson.D{{"created_at", -1}}},
+	)
+}
+
+var baselinePlayerAchievementIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"player_id", 1}, {"app_id", 1}, {"achievement_date", -1}}}, // FindLatestPlayerAchievement
+}
+
+var baselinePlayerAppIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"app_id", 1}, {"app_time", -1}, {"player_country", 1}}},
+	{Keys: bson.D{{"player_id", 1}, {"app_achievements_have", 1}}},
+}
+
+var baselinePlayerFriendIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"player_id", 1}, {"name", 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+	{Keys: bson.D{{"friend_id", 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+}
+
+func baselineSaleIndexes() (indexes []mongodb.IndexModel) {
+
+	cols := []string{
+		"app_date",
+		"app_rating",
+		"offer_end",
+		"offer_name",
+		"offer_percent",
+	}
+
+	for _, v := range i18n.GetProdCCs(true) {
+		cols = append(cols, "app_prices."+string(v.ProductCode))
+		cols = append(cols, "app_lowest_price."+string(v.ProductCode))
+	}
+
+	for _, col := range cols {
+		indexes = append(indexes,
+			mongodb.IndexModel{Keys: bson.D{{col, 1}}},
+			mongodb.IndexModel{Keys: bson.D{{col, -1}}},
+		)
+	}
+
+	return indexes
+}
+
+var baselineStatIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"type", 1}, {"id", 1}}},
+	{Keys: bson.D{{"type", 1}, {"name", 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+}
+
+var baselineAppSameOwnersIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"app_id", 1}, {"order", -1}}},
+}
+
+var baselineAppSimilarIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"app_id", 1}, {"score", -1}}},
+}
+
+var baselinePlayerIgnoredAppIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"player_id", 1}}},
+}
+
+var baselineSavedSearchIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"user_id", 1}, {"created_at", -1}}},
+	{Keys: bson.D{{"checked_at", 1}}},
+}
+
+var baselineArticleIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"date", -1}}},
+	{Keys: bson.D{{"app_id", 1}, {"date", -1}}},
+}
+
+var baselineProductPriceIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"app_id", 1}, {"prod_cc", 1}, {"created_at", 1}}},
+	{Keys: bson.D{{"package_id", 1}, {"prod_cc", 1}, {"created_at", 1}}},
+}
+
+var baselineStatusCheckIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"component", 1}, {"created_at", -1}}},
+	{Keys: bson.D{{"created_at", 1}}, Options: options.Index().SetExpireAfterSeconds(int32((time.Hour * 24 * 90).Seconds()))},
+}
+
+var baselineStatusIncidentIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"created_at", -1}}},
+}
+
+var baselineFreshnessIndexes = []mongodb.IndexModel{
+	{Keys: bson.D{{"created_at", 1}}, Options: options.Index().SetExpireAfterSeconds(int32((time.Hour * 24 * 90).Seconds()))},
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+// Creates any missing tables and columns, existing ones are left alone.
+// Nothing to roll back to, the tables were created by hand before this.
+var mysqlBaselineTables = Migration{
+	ID:          "0002-mysql-baseline-tables",
+	Database:    DatabaseMySQL,
+	Description: "Tables for the current gorm models",
+	Up: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		db = db.AutoMigrate(
+			&mysql.ChatBotSetting{},
+			&mysql.Config{},
+			&mysql.Consumer{},
+			&mysql.Donation{},
+			&mysql.ProductKey{},
+			&mysql.User{},
+			&mysql.UserProvider{},
+			&mysql.UserVerification{},
+		)
+		return db.Error
+	},
+}
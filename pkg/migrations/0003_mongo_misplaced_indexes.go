@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mongo"
+)
+
+// The player app indexes were being created on groups, and the stat indexes on players
+var mongoMisplacedIndexes = Migration{
+	ID:          "0003-mongo-misplaced-indexes",
+	Database:    DatabaseMongo,
+	Description: "Move the player app and stat indexes to their own collections",
+	Up: func() error {
+
+		err := mongo.CreateIndexes(
+			mongo.NewIndexSet(mongo.CollectionPlayerApps, baselinePlayerAppIndexes),
+			mongo.NewIndexSet(mongo.CollectionStats, baselineStatIndexes),
+		)
+		if err != nil {
+			return err
+		}
+
+		return mongo.DropIndexes(
+			mongo.NewIndexSet(mongo.CollectionGroups, baselinePlayerAppIndexes),
+			mongo.NewIndexSet(mongo.CollectionPlayers, baselineStatIndexes),
+		)
+	},
+	Down: func() error {
+
+		err := mongo.CreateIndexes(
+			mongo.NewIndexSet(mongo.CollectionGroups, baselinePlayerAppIndexes),
+			mongo.NewIndexSet(mongo.CollectionPlayers, baselineStatIndexes),
+		)
+		if err != nil {
+			return err
+		}
+
+		return mongo.DropIndexes(
+			mongo.NewIndexSet(mongo.CollectionPlayerApps, baselinePlayerAppIndexes),
+			mongo.NewIndexSet(mongo.CollectionStats, baselineStatIndexes),
+		)
+	},
+}
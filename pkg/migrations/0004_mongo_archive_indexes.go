@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
+)
+
+// The archive cron selects and deletes by day, and looks up its own progress by store
+var mongoArchiveIndexes = Migration{
+	ID:          "0004-mongo-archive-indexes",
+	Database:    DatabaseMongo,
+	Description: "Date indexes for archiving and the archives collection",
+	Up: func() error {
+		return mongo.CreateIndexes(archiveIndexes()...)
+	},
+	Down: func() error {
+		return mongo.DropIndexes(archiveIndexes()...)
+	},
+}
+
+func archiveIndexes() []mongo.IndexSet {
+
+	return []mongo.IndexSet{
+		mongo.NewIndexSet(mongo.CollectionProductPrices, []mongodb.IndexModel{{Keys: bson.D{{"created_at", 1}}}}),
+		mongo.NewIndexSet(mongo.CollectionChanges, []mongodb.IndexModel{{Keys: bson.D{{"created_at", 1}}}}),
+		mongo.NewIndexSet(mongo.CollectionPlayerAliases, []mongodb.IndexModel{{Keys: bson.D{{"time", 1}}}}),
+		mongo.NewIndexSet(mongo.CollectionArchives, []mongodb.IndexModel{{Keys: bson.D{{"store", 1}, {"day", -1}}}}),
+	}
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+var mysqlTwoFactorTables = Migration{
+	ID:          "0005-mysql-two-factor-tables",
+	Database:    DatabaseMySQL,
+	Description: "Tables for authenticator apps, recovery codes and security keys",
+	Up: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.AutoMigrate(&mysql.UserTOTP{}, &mysql.UserRecoveryCode{}, &mysql.UserSecurityKey{}).Error
+	},
+	Down: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.DropTableIfExists(&mysql.UserTOTP{}, &mysql.UserRecoveryCode{}, &mysql.UserSecurityKey{}).Error
+	},
+}
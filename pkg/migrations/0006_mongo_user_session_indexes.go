@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var mongoUserSessionIndexes = Migration{
+	ID:          "0006-mongo-user-session-indexes",
+	Database:    DatabaseMongo,
+	Description: "Indexes for listing and expiring user sessions",
+	Up: func() error {
+		return mongo.CreateIndexes(userSessionIndexes())
+	},
+	Down: func() error {
+		return mongo.DropIndexes(userSessionIndexes())
+	},
+}
+
+func userSessionIndexes() mongo.IndexSet {
+
+	return mongo.NewIndexSet(mongo.CollectionUserSessions, []mongodb.IndexModel{
+		{Keys: bson.D{{"user_id", 1}, {"last_seen", -1}}},
+		{Keys: bson.D{{"expires_at", 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+var mysqlUserSubscriptions = Migration{
+	ID:          "0007-mysql-user-subscriptions",
+	Database:    DatabaseMySQL,
+	Description: "Subscriptions from every payment provider, and payment IDs on donations",
+	Up: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.AutoMigrate(&mysql.UserSubscription{}, &mysql.Donation{}).Error
+	},
+	Down: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		db = db.DropTableIfExists(&mysql.UserSubscription{})
+		if db.Error != nil {
+			return db.Error
+		}
+
+		db, err = mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		db = db.Model(&mysql.Donation{}).RemoveIndex("idx_donations_external_ref")
+		if db.Error != nil {
+			return db.Error
+		}
+
+		db, err = mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.Model(&mysql.Donation{}).DropColumn("external_ref").Error
+	},
+}
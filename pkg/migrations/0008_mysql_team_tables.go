@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+var mysqlTeamTables = Migration{
+	ID:          "0008-mysql-team-tables",
+	Database:    DatabaseMySQL,
+	Description: "Tables for teams, their members and pending invites",
+	Up: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.AutoMigrate(&mysql.Team{}, &mysql.TeamMember{}, &mysql.TeamInvite{}).Error
+	},
+	Down: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.DropTableIfExists(&mysql.Team{}, &mysql.TeamMember{}, &mysql.TeamInvite{}).Error
+	},
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var mongoTeamEventIndexes = Migration{
+	ID:          "0009-mongo-team-event-indexes",
+	Database:    DatabaseMongo,
+	Description: "Index for a team's audit log",
+	Up: func() error {
+		return mongo.CreateIndexes(teamEventIndexes())
+	},
+	Down: func() error {
+		return mongo.DropIndexes(teamEventIndexes())
+	},
+}
+
+// Sparse, most events have no team
+func teamEventIndexes() mongo.IndexSet {
+
+	return mongo.NewIndexSet(mongo.CollectionEvents, []mongodb.IndexModel{
+		{Keys: bson.D{{"team_id", 1}, {"created_at", -1}}, Options: options.Index().SetSparse(true)},
+	})
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+var mysqlUserDataRequests = Migration{
+	ID:          "0010-mysql-user-data-requests",
+	Database:    DatabaseMySQL,
+	Description: "Table for data exports and account deletions",
+	Up: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.AutoMigrate(&mysql.UserDataRequest{}).Error
+	},
+	Down: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.DropTableIfExists(&mysql.UserDataRequest{}).Error
+	},
+}
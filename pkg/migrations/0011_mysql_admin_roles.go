@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+var mysqlAdminRoles = Migration{
+	ID:          "0011-mysql-admin-roles",
+	Database:    DatabaseMySQL,
+	Description: "Admin role on users, replacing the hardcoded admin user",
+	Up: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.AutoMigrate(&mysql.User{}).Error
+	},
+	Down: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.Model(&mysql.User{}).DropColumn("admin_role").Error
+	},
+}
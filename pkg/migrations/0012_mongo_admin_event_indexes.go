@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
+)
+
+var mongoAdminEventIndexes = Migration{
+	ID:          "0012-mongo-admin-event-indexes",
+	Database:    DatabaseMongo,
+	Description: "Indexes for the admin audit log",
+	Up: func() error {
+		return mongo.CreateIndexes(adminEventIndexes())
+	},
+	Down: func() error {
+		return mongo.DropIndexes(adminEventIndexes())
+	},
+}
+
+func adminEventIndexes() mongo.IndexSet {
+
+	return mongo.NewIndexSet(mongo.CollectionAdminEvents, []mongodb.IndexModel{
+		{Keys: bson.D{{"created_at", -1}}},
+		{Keys: bson.D{{"user_id", 1}, {"created_at", -1}}},
+	})
+}
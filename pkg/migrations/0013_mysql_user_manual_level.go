@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/jinzhu/gorm"
+)
+
+var mysqlUserManualLevel = Migration{
+	ID:          "0013-mysql-user-manual-level",
+	Database:    DatabaseMySQL,
+	Description: "Minimum level for users given one by hand, so subscriptions can't lower it",
+	Up: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		db = db.AutoMigrate(&mysql.User{})
+		if db.Error != nil {
+			return db.Error
+		}
+
+		db, err = mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		// Levels given by hand were recorded as manual donations
+		manual := db.Model(&mysql.Donation{}).Select("user_id").Where("source = ?", mysql.DonationSourceManual).QueryExpr()
+
+		return db.Model(&mysql.User{}).Where("level > ? AND id IN (?)", mysql.UserLevelFree, manual).UpdateColumn("manual_level", gorm.Expr("level")).Error
+	},
+	Down: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.Model(&mysql.User{}).DropColumn("manual_level").Error
+	},
+}
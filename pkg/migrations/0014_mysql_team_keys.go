@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+var mysqlTeamKeys = Migration{
+	ID:          "0014-mysql-team-keys",
+	Database:    DatabaseMySQL,
+	Description: "API keys shared by everyone in a team",
+	Up: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.AutoMigrate(&mysql.TeamKey{}).Error
+	},
+	Down: func() error {
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return err
+		}
+
+		return db.DropTableIfExists(&mysql.TeamKey{}).Error
+	},
+}
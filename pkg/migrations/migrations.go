@@ -0,0 +1,203 @@
+package migrations
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"go.uber.org/zap"
+)
+
+type Database string
+
+const (
+	DatabaseMongo Database = "mongo"
+	DatabaseMySQL Database = "mysql"
+)
+
+var ErrIrreversible = errors.New("migration can not be rolled back")
+
+type Migration struct {
+	ID          string // Applied in this order, never change once released
+	Database    Database
+	Description string
+	Up          func() error
+	Down        func() error // Nil if it can't be undone
+}
+
+// Append only, in the order they should run
+var migrations = []Migration{
+	mongoBaselineIndexes,
+	mysqlBaselineTables,
+	mongoMisplacedIndexes,
+	mongoArchiveIndexes,
+	mysqlTwoFactorTables,
+	mongoUserSessionIndexes,
+	mysqlUserSubscriptions,
+	mysqlTeamTables,
+	mongoTeamEventIndexes,
+	mysqlUserDataRequests,
+	mysqlAdminRoles,
+	mongoAdminEventIndexes,
+	mysqlUserManualLevel,
+	mysqlTeamKeys,
+}
+
+func init() {
+	m := make(map[string]bool, len(migrations))
+	for k, v := range migrations {
+		if _, ok := m[v.ID]; ok || (k > 0 && v.ID < migrations[k-1].ID) {
+			log.Err("Duplicate or out of order migration", zap.String("id", v.ID))
+			os.Exit(1)
+		}
+		m[v.ID] = true
+	}
+}
+
+type Status struct {
+	Migration Migration
+	StartedAt time.Time
+	AppliedAt time.Time
+}
+
+func (s Status) IsApplied() bool {
+	return !s.AppliedAt.IsZero()
+}
+
+// Claimed but not finished, either still running or it died part way
+func (s Status) IsRunning() bool {
+	return !s.StartedAt.IsZero() && s.AppliedAt.IsZero()
+}
+
+func GetStatuses() (statuses []Status, err error) {
+
+	applied, err := mongo.GetMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	appliedMap := map[string]mongo.Migration{}
+	for _, v := range applied {
+		appliedMap[v.ID] = v
+	}
+
+	for _, v := range migrations {
+		statuses = append(statuses, Status{Migration: v, StartedAt: appliedMap[v.ID].StartedAt, AppliedAt: appliedMap[v.ID].AppliedAt})
+	}
+
+	return statuses, nil
+}
+
+// Runs any pending migrations, a dry run only logs what would happen.
+// Returns the migrations that were (or would be) applied.
+func Up(dryRun bool) (ran []Migration, err error) {
+
+	statuses, err := GetStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, status := range statuses {
+
+		if status.IsApplied() {
+			continue
+		}
+
+		migration := status.Migration
+
+		// Later migrations can depend on it, so stop until it finishes or is cleared
+		if status.IsRunning() {
+			return ran, errors.New(migration.ID + ": started at " + status.StartedAt.Format(time.RFC3339) + " but not applied")
+		}
+
+		if dryRun {
+			log.Info("Would apply migration", zap.String("id", migration.ID), zap.String("description", migration.Description))
+			ran = append(ran, migration)
+			continue
+		}
+
+		// Claim it first so two processes don't run the same migration
+		claimed, err := mongo.ClaimMigration(mongo.Migration{
+			ID:          migration.ID,
+			Database:    string(migration.Database),
+			Description: migration.Description,
+			StartedAt:   time.Now(),
+		})
+		if err != nil {
+			return ran, err
+		}
+		if !claimed {
+			return ran, errors.New(migration.ID + ": being applied by another process")
+		}
+
+		log.Info("Applying migration", zap.String("id", migration.ID))
+
+		err = migration.Up()
+		if err != nil {
+
+			// Release the claim so it can be tried again
+			err2 := mongo.DeleteMigration(migration.ID)
+			if err2 != nil {
+				log.ErrS(err2)
+			}
+
+			return ran, errors.New(migration.ID + ": " + err.Error())
+		}
+
+		err = mongo.SetMigrationApplied(migration.ID, time.Now())
+		if err != nil {
+			return ran, err
+		}
+
+		ran = append(ran, migration)
+	}
+
+	return ran, nil
+}
+
+// Rolls back the last n applied migrations, newest first
+func Down(n int, dryRun bool) (ran []Migration, err error) {
+
+	statuses, err := GetStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(statuses) - 1; i >= 0 && len(ran) < n; i-- {
+
+		status := statuses[i]
+		if !status.IsApplied() {
+			continue
+		}
+
+		migration := status.Migration
+
+		if migration.Down == nil {
+			return ran, errors.New(migration.ID + ": " + ErrIrreversible.Error())
+		}
+
+		if dryRun {
+			log.Info("Would roll back migration", zap.String("id", migration.ID), zap.String("description", migration.Description))
+			ran = append(ran, migration)
+			continue
+		}
+
+		log.Info("Rolling back migration", zap.String("id", migration.ID))
+
+		err = migration.Down()
+		if err != nil {
+			return ran, errors.New(migration.ID + ": " + err.Error())
+		}
+
+		err = mongo.DeleteMigration(migration.ID)
+		if err != nil {
+			return ran, err
+		}
+
+		ran = append(ran, migration)
+	}
+
+	return ran, nil
+}
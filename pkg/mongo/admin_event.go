@@ -0,0 +1,106 @@
+package mongo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type AdminActionEnum string
+
+var (
+	AdminActionTask     AdminActionEnum = "task"
+	AdminActionSetting  AdminActionEnum = "setting"
+	AdminActionQueue    AdminActionEnum = "queue"
+	AdminActionIncident AdminActionEnum = "incident"
+	AdminActionRole     AdminActionEnum = "role"
+)
+
+func (action AdminActionEnum) ToString() string {
+
+	switch action {
+	case AdminActionTask:
+		return "Ran Task"
+	case AdminActionSetting:
+		return "Changed Setting"
+	case AdminActionQueue:
+		return "Queued"
+	case AdminActionRole:
+		return "Changed Role"
+	default:
+		return strings.Title(string(action))
+	}
+}
+
+// Admin audit log, kept apart from user events so it survives account deletion
+type AdminEvent struct {
+	CreatedAt time.Time       `bson:"created_at"`
+	UserID    int             `bson:"user_id"`
+	Role      string          `bson:"role"`
+	Action    AdminActionEnum `bson:"action"`
+	Details   string          `bson:"details"`
+	IP        string          `bson:"ip"`
+}
+
+func (event AdminEvent) BSON() bson.D {
+
+	return bson.D{
+		{"created_at", event.CreatedAt},
+		{"user_id", event.UserID},
+		{"role", event.Role},
+		{"action", event.Action},
+		{"details", event.Details},
+		{"ip", event.IP},
+	}
+}
+
+func (event AdminEvent) GetCreatedNice() string {
+	return event.CreatedAt.Format(helpers.DateTime)
+}
+
+func GetAdminEvents(filter bson.D, offset int64) (events []AdminEvent, err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionAdminEvents, offset, 100, filter, bson.D{{"created_at", -1}}, nil, nil)
+	if err != nil {
+		return events, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var event AdminEvent
+		err := cur.Decode(&event)
+		if err != nil {
+			log.ErrS(err)
+		} else {
+			events = append(events, event)
+		}
+	}
+
+	return events, cur.Err()
+}
+
+func NewAdminEvent(r *http.Request, userID int, role string, action AdminActionEnum, details string) (err error) {
+
+	event := AdminEvent{}
+	event.CreatedAt = time.Now()
+	event.UserID = userID
+	event.Role = role
+	event.Action = action
+	event.Details = details
+
+	if r != nil {
+		event.IP = r.RemoteAddr
+	}
+
+	_, err = InsertOne(context.TODO(), CollectionAdminEvents, event)
+	return err
+}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"errors"
 	"html/template"
 	"math"
@@ -84,10 +85,13 @@ type App struct {
 	PlaytimeAverage               float64                        `bson:"playtime_average"` // Minutes
 	PlaytimeTotal                 int64                          `bson:"playtime_total"`   // Minutes
 	Prices                        helpers.ProductPrices          `bson:"prices"`
+	PriceStats                    helpers.ProductPriceStats      `bson:"price_stats"`
 	PublicOnly                    bool                           `bson:"public_only"`
 	Publishers                    []int                          `bson:"publishers"`
 	RelatedAppIDs                 []int                          `bson:"related_app_ids"`             // Taken from store page
+	RelatedSimilarAppIDs          []int                          `bson:"related_similar_app_ids"`     // Calculated from tags etc, when the store page can't be scraped
 	RelatedOwnersAppIDsDate       time.Time                      `bson:"related_owners_app_ids_date"` // Calculated from owners - Last Updated
+	RelatedSimilarDate            time.Time                      `bson:"related_similar_date"`        // Calculated from tags etc - Last Updated
 	ReleaseDate                   string                         `bson:"release_date"`                // Steam release
 	ReleaseDateUnix               int64                          `bson:"release_date_unix"`           // Steam release
 	ReleaseDateOriginal           int64                          `bson:"release_date_original"`       // Game release
@@ -176,10 +180,13 @@ func (app App) BSON() bson.D {
 		{"playtime_average", app.PlaytimeAverage},
 		{"playtime_total", app.PlaytimeTotal},
 		{"prices", app.Prices},
+		{"price_stats", app.PriceStats},
 		{"public_only", app.PublicOnly},
 		{"publishers", app.Publishers},
 		{"related_app_ids", app.RelatedAppIDs},
+		{"related_similar_app_ids", app.RelatedSimilarAppIDs},
 		{"related_owners_app_ids_date", app.RelatedOwnersAppIDsDate},
+		{"related_similar_date", app.RelatedSimilarDate},
 		{"release_date", app.ReleaseDate},
 		{"release_date_unix", app.ReleaseDateUnix},
 		{"release_date_original", app.ReleaseDateOriginal},
@@ -395,14 +402,20 @@ func (app App) GetAppRelatedApps() (apps []App, err error) {
 
 	apps = []App{} // Needed for marshalling into type
 
-	if len(app.RelatedAppIDs) == 0 {
+	var ids = app.RelatedAppIDs
+	if len(ids) == 0 {
+		ids = app.RelatedSimilarAppIDs
+	}
+
+	if len(ids) == 0 {
 		return apps, nil
 	}
 
 	item := memcache.ItemAppRelated(app.ID)
 	err = memcache.Client().GetSet(item.Key, item.Expiration, &apps, func() (interface{}, error) {
 
-		return GetAppsByID(app.RelatedAppIDs, bson.M{"_id": 1, "name": 1, "icon": 1, "tags": 1})
+		apps, err := GetAppsByID(context.TODO(), ids, bson.M{"_id": 1, "name": 1, "icon": 1, "tags": 1})
+		return SortAppsByID(apps, ids), err
 	})
 
 	return apps, err
@@ -417,7 +430,7 @@ func (app App) GetDemos() (demos []App, err error) {
 	}
 
 	callback := func() (interface{}, error) {
-		return GetAppsByID(app.Demos, bson.M{"_id": 1, "name": 1})
+		return GetAppsByID(context.TODO(), app.Demos, bson.M{"_id": 1, "name": 1})
 	}
 
 	item := memcache.ItemAppDemos(app.ID)
@@ -643,55 +656,14 @@ func UpdateAppsInflux(writes []mongo.WriteModel) (err error) {
 	return err
 }
 
-func ensureAppIndexes() {
-
-	var indexModels = []mongo.IndexModel{
-		{Keys: bson.D{{"achievements_average_completion", -1}}},
-		{Keys: bson.D{{"achievements_count", -1}, {"achievements_average_completion", -1}}},
-		{Keys: bson.D{{"categories", 1}}},
-		{Keys: bson.D{{"developers", 1}}},
-		{Keys: bson.D{{"genres", 1}}},
-		{Keys: bson.D{{"genres", -1}}},
-		{Keys: bson.D{{"group_followers", -1}}},
-		{Keys: bson.D{{"player_peak_week", -1}}},
-		{Keys: bson.D{{"player_trend", -1}}},
-		{Keys: bson.D{{"publishers", 1}}},
-		{Keys: bson.D{{"release_date_unix", 1}}},
-		{Keys: bson.D{{"release_date_unix", -1}}},
-		{Keys: bson.D{{"reviews_score", -1}}},
-		{Keys: bson.D{{"tags", 1}}},
-		{Keys: bson.D{{"tags", -1}}},
-		{Keys: bson.D{{"type", 1}}},
-		{Keys: bson.D{{"wishlist_avg_position", 1}}},
-		{Keys: bson.D{{"wishlist_count", -1}}},
-
-		{Keys: bson.D{{"common.$**", 1}}, Options: &options.IndexOptions{Name: stringToPointer("common.wildcard_1")}},
-		{Keys: bson.D{{"config.$**", 1}}, Options: &options.IndexOptions{Name: stringToPointer("config.wildcard_1")}},
-		{Keys: bson.D{{"extended.$**", 1}}, Options: &options.IndexOptions{Name: stringToPointer("extended.wildcard_1")}},
-		{Keys: bson.D{{"ufs.$**", 1}}, Options: &options.IndexOptions{Name: stringToPointer("ufs.wildcard_1")}},
-	}
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionApps.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
-func BatchApps(filter bson.D, projection bson.M, callback func(apps []App)) (err error) {
+func BatchApps(ctx context.Context, filter bson.D, projection bson.M, callback func(apps []App)) (err error) {
 
 	var offset int64 = 0
 	var limit int64 = 10_000
 
 	for {
 
-		apps, err := GetApps(offset, limit, bson.D{{"_id", 1}}, filter, projection)
+		apps, err := GetApps(ctx, offset, limit, bson.D{{"_id", 1}}, filter, projection)
 		if err != nil {
 			return err
 		}
@@ -708,7 +680,7 @@ func BatchApps(filter bson.D, projection bson.M, callback func(apps []App)) (err
 	return nil
 }
 
-func GetApp(id int, full ...bool) (app App, err error) {
+func GetApp(ctx context.Context, id int, full ...bool) (app App, err error) {
 
 	if !helpers.IsValidAppID(id) {
 		return app, ErrInvalidAppID
@@ -717,17 +689,17 @@ func GetApp(id int, full ...bool) (app App, err error) {
 	if len(full) > 0 && full[0] {
 
 		// Load from Mongo
-		err = FindOne(CollectionApps, bson.D{{"_id", id}}, nil, nil, &app)
+		err = FindOne(ctx, CollectionApps, bson.D{{"_id", id}}, nil, nil, &app)
 
 	} else {
 
 		// Load from Memcache
 		item := memcache.ItemApp(id)
-		err = memcache.Client().GetSet(item.Key, item.Expiration, &app, func() (interface{}, error) {
+		err = memcache.Client().WithContext(ctx).GetSet(item.Key, item.Expiration, &app, func() (interface{}, error) {
 
 			var projection = bson.M{"reviews.reviews": 0, "localization": 0, "achievements": 0, "achievements_5": 0} // Too much for memcache
 
-			err = FindOne(CollectionApps, bson.D{{"_id", id}}, nil, projection, &app)
+			err = FindOne(ctx, CollectionApps, bson.D{{"_id", id}}, nil, projection, &app)
 			return app, err
 		})
 	}
@@ -735,9 +707,9 @@ func GetApp(id int, full ...bool) (app App, err error) {
 	return app, err
 }
 
-func GetApps(offset int64, limit int64, sort bson.D, filter bson.D, projection bson.M) (apps []App, err error) {
+func GetApps(ctx context.Context, offset int64, limit int64, sort bson.D, filter bson.D, projection bson.M) (apps []App, err error) {
 
-	cur, ctx, err := find(CollectionApps, offset, limit, filter, sort, projection, nil)
+	cur, err := find(ctx, CollectionApps, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return apps, err
 	}
@@ -758,7 +730,7 @@ func GetApps(offset int64, limit int64, sort bson.D, filter bson.D, projection b
 	return apps, cur.Err()
 }
 
-func GetAppsByID(ids []int, projection bson.M) (apps []App, err error) {
+func GetAppsByID(ctx context.Context, ids []int, projection bson.M) (apps []App, err error) {
 
 	if len(ids) < 1 {
 		return apps, nil
@@ -769,7 +741,24 @@ func GetAppsByID(ids []int, projection bson.M) (apps []App, err error) {
 		a = append(a, v)
 	}
 
-	return GetApps(0, 0, nil, bson.D{{"_id", bson.M{"$in": a}}}, projection)
+	return GetApps(ctx, 0, 0, nil, bson.D{{"_id", bson.M{"$in": a}}}, projection)
+}
+
+// Puts apps back in the order of the IDs they were fetched with, as $in doesn't keep it
+func SortAppsByID(apps []App, ids []int) []App {
+
+	var order = map[int]int{}
+	for k, v := range ids {
+		if _, ok := order[v]; !ok {
+			order[v] = k
+		}
+	}
+
+	sort.SliceStable(apps, func(i, j int) bool {
+		return order[apps[i].ID] < order[apps[j].ID]
+	})
+
+	return apps
 }
 
 func PopularApps() (apps []App, err error) {
@@ -777,7 +766,7 @@ func PopularApps() (apps []App, err error) {
 	item := memcache.ItemAppsPopular
 	err = memcache.Client().GetSet(item.Key, item.Expiration, &apps, func() (interface{}, error) {
 
-		return GetApps(
+		return GetApps(context.TODO(),
 			0,
 			50,
 			bson.D{{"player_peak_week", -1}},
@@ -796,7 +785,7 @@ func PopularNewApps() (apps []App, err error) {
 
 		releaseDate := time.Now().AddDate(0, 0, -config.C.NewReleaseDays).Unix()
 
-		return GetApps(
+		return GetApps(context.TODO(),
 			0,
 			10, // Keep even
 			bson.D{{Key: "player_peak_week", Value: -1}},
@@ -815,7 +804,7 @@ func TrendingApps() (apps []App, err error) {
 
 	item := memcache.ItemAppsTrending
 	err = memcache.Client().GetSet(item.Key, item.Expiration, &apps, func() (interface{}, error) {
-		return GetApps(
+		return GetApps(context.TODO(),
 			0,
 			10,
 			bson.D{{"player_trend", -1}},
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/Jleagle/steam-go/steamapi"
@@ -67,9 +68,9 @@ func (achievement *AppAchievement) Fill(appID int, response steamapi.SchemaForGa
 	achievement.Hidden = bool(response.Hidden)
 }
 
-func GetAppAchievements(offset int64, limit int64, filter bson.D, sort bson.D) (achievements []AppAchievement, err error) {
+func GetAppAchievements(ctx context.Context, offset int64, limit int64, filter bson.D, sort bson.D) (achievements []AppAchievement, err error) {
 
-	cur, ctx, err := find(CollectionAppAchievements, offset, limit, filter, sort, nil, nil)
+	cur, err := find(ctx, CollectionAppAchievements, offset, limit, filter, sort, nil, nil)
 	if err != nil {
 		return achievements, err
 	}
@@ -90,13 +91,13 @@ func GetAppAchievements(offset int64, limit int64, filter bson.D, sort bson.D) (
 	return achievements, cur.Err()
 }
 
-func ReplaceAppAchievements(achievements []AppAchievement) (err error) {
+func ReplaceAppAchievements(ctx context.Context, achievements []AppAchievement) (err error) {
 
 	if len(achievements) == 0 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"html/template"
 	"sort"
 	"time"
@@ -82,7 +83,9 @@ func getArticles(offset int64, limit int64, filter bson.D, order bson.D, project
 
 	filter = append(filter, bson.E{Key: "feed_name", Value: bson.M{"$ne": "Gamemag.ru"}})
 
-	cur, ctx, err := find(CollectionAppArticles, offset, limit, filter, order, projection, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionAppArticles, offset, limit, filter, order, projection, nil)
 	if err != nil {
 		return news, err
 	}
@@ -104,13 +107,13 @@ func getArticles(offset int64, limit int64, filter bson.D, order bson.D, project
 	return news, cur.Err()
 }
 
-func ReplaceArticles(articles []Article) (err error) {
+func ReplaceArticles(ctx context.Context, articles []Article) (err error) {
 
 	if len(articles) == 0 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gamedb/gamedb/pkg/config"
@@ -58,12 +59,12 @@ func GetDLCForApps(appIDs []int, offset int64, limit int64, filter bson.D, sort
 
 	filter = append(bson.D{{"app_id", bson.M{"$in": ids}}}, filter...)
 
-	return GetDLCForApp(offset, limit, filter, sort, projection)
+	return GetDLCForApp(context.TODO(), offset, limit, filter, sort, projection)
 }
 
-func GetDLCForApp(offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M) (dlcs []AppDLC, err error) {
+func GetDLCForApp(ctx context.Context, offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M) (dlcs []AppDLC, err error) {
 
-	cur, ctx, err := find(CollectionAppDLC, offset, limit, filter, sort, projection, nil)
+	cur, err := find(ctx, CollectionAppDLC, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return dlcs, err
 	}
@@ -84,13 +85,13 @@ func GetDLCForApp(offset int64, limit int64, filter bson.D, sort bson.D, project
 	return dlcs, cur.Err()
 }
 
-func ReplaceAppDLCs(DLCs []AppDLC) (err error) {
+func ReplaceAppDLCs(ctx context.Context, DLCs []AppDLC) (err error) {
 
 	if len(DLCs) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -111,13 +112,13 @@ func ReplaceAppDLCs(DLCs []AppDLC) (err error) {
 	return err
 }
 
-func DeleteAppDLC(appID int, DLCs []int) (err error) {
+func DeleteAppDLC(ctx context.Context, appID int, DLCs []int) (err error) {
 
 	if len(DLCs) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -147,11 +148,11 @@ func (item *AppItem) Image(size int, crop bool) string {
 	return "https://images.weserv.nl?" + params.Encode()
 }
 
-func GetAppItems(offset int64, limit int64, filter bson.D, projection bson.M) (items []AppItem, err error) {
+func GetAppItems(ctx context.Context, offset int64, limit int64, filter bson.D, projection bson.M) (items []AppItem, err error) {
 
 	var sort = bson.D{{"item_def_id", 1}}
 
-	cur, ctx, err := find(CollectionAppItems, offset, limit, filter, sort, projection, nil)
+	cur, err := find(ctx, CollectionAppItems, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return items, err
 	}
@@ -172,13 +173,13 @@ func GetAppItems(offset int64, limit int64, filter bson.D, projection bson.M) (i
 	return items, cur.Err()
 }
 
-func ReplaceAppItems(items []AppItem) (err error) {
+func ReplaceAppItems(ctx context.Context, items []AppItem) (err error) {
 
 	if len(items) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -199,13 +200,13 @@ func ReplaceAppItems(items []AppItem) (err error) {
 	return err
 }
 
-func DeleteAppItems(appID int, items []int) (err error) {
+func DeleteAppItems(ctx context.Context, appID int, items []int) (err error) {
 
 	if len(items) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gamedb/gamedb/pkg/config"
@@ -34,28 +35,9 @@ func (sameOwner AppSameOwners) GetKey() string {
 	return strconv.Itoa(sameOwner.AppID) + "-" + strconv.Itoa(sameOwner.SameAppID)
 }
 
-func ensureAppSameOwnersIndexes() {
+func GetAppSameOwners(ctx context.Context, appID int, limit int64) (sameOwners []AppSameOwners, err error) {
 
-	var indexModels = []mongo.IndexModel{
-		{Keys: bson.D{{"app_id", 1}, {"order", -1}}},
-	}
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionAppSameOwners.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
-func GetAppSameOwners(appID int, limit int64) (sameOwners []AppSameOwners, err error) {
-
-	cur, ctx, err := find(CollectionAppSameOwners, 0, limit, bson.D{{"app_id", appID}}, bson.D{{"order", -1}}, nil, nil)
+	cur, err := find(ctx, CollectionAppSameOwners, 0, limit, bson.D{{"app_id", appID}}, bson.D{{"order", -1}}, nil, nil)
 	if err != nil {
 		return sameOwners, err
 	}
@@ -76,9 +58,9 @@ func GetAppSameOwners(appID int, limit int64) (sameOwners []AppSameOwners, err e
 	return sameOwners, cur.Err()
 }
 
-func ReplaceAppSameOwners(appID int, sameApps []AppSameOwners) (err error) {
+func ReplaceAppSameOwners(ctx context.Context, appID int, sameApps []AppSameOwners) (err error) {
 
-	_, err = DeleteMany(CollectionAppSameOwners, bson.D{{"app_id", appID}})
+	_, err = DeleteMany(ctx, CollectionAppSameOwners, bson.D{{"app_id", appID}})
 	if err != nil {
 		return err
 	}
@@ -87,7 +69,7 @@ func ReplaceAppSameOwners(appID int, sameApps []AppSameOwners) (err error) {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,120 @@
+package mongo
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AppSimilar struct {
+	AppID        int     `bson:"app_id"`
+	SimilarAppID int     `bson:"similar_id"`
+	Score        float64 `bson:"score"`       // Score to sort by, 0-1
+	Tags         int     `bson:"tags"`        // Shared tags
+	Genres       int     `bson:"genres"`      // Shared genres
+	Developers   int     `bson:"developers"`  // Shared developers
+	SameOwners   int     `bson:"same_owners"` // Matching owners
+	Owners       int     `bson:"owners"`      // Total owners
+}
+
+func (similar AppSimilar) BSON() bson.D {
+
+	return bson.D{
+		{"_id", similar.GetKey()},
+		{"app_id", similar.AppID},
+		{"similar_id", similar.SimilarAppID},
+		{"score", similar.Score},
+		{"tags", similar.Tags},
+		{"genres", similar.Genres},
+		{"developers", similar.Developers},
+		{"same_owners", similar.SameOwners},
+		{"owners", similar.Owners},
+	}
+}
+
+func (similar AppSimilar) GetKey() string {
+	return strconv.Itoa(similar.AppID) + "-" + strconv.Itoa(similar.SimilarAppID)
+}
+
+func GetAppSimilar(ctx context.Context, appID int, limit int) (similar []AppSimilar, err error) {
+
+	item := memcache.ItemAppSimilar(appID)
+	err = memcache.Client().WithContext(ctx).GetSet(item.Key, item.Expiration, &similar, func() (interface{}, error) {
+
+		cur, err := find(ctx, CollectionAppSimilar, 0, 0, bson.D{{"app_id", appID}}, bson.D{{"score", -1}}, nil, nil)
+		if err != nil {
+			return similar, err
+		}
+
+		defer closeCursor(cur, ctx)
+
+		for cur.Next(ctx) {
+
+			var row AppSimilar
+			err := cur.Decode(&row)
+			if err != nil {
+				log.ErrS(err, row.GetKey())
+			} else {
+				similar = append(similar, row)
+			}
+		}
+
+		return similar, cur.Err()
+	})
+
+	if limit > 0 && len(similar) > limit {
+		similar = similar[0:limit]
+	}
+
+	return similar, err
+}
+
+func ReplaceAppSimilar(ctx context.Context, appID int, similarApps []AppSimilar) (err error) {
+
+	_, err = DeleteMany(ctx, CollectionAppSimilar, bson.D{{"app_id", appID}})
+	if err != nil {
+		return err
+	}
+
+	if len(similarApps) == 0 {
+		return nil
+	}
+
+	client, _, err := getMongo()
+	if err != nil {
+		return err
+	}
+
+	var writes []mongo.WriteModel
+	for _, similarApp := range similarApps {
+
+		if similarApp.SimilarAppID > 0 {
+
+			similarApp.AppID = appID
+
+			// Uses ReplaceOneModel to fix "duplicate key" errors
+			write := mongo.NewReplaceOneModel()
+			write.SetFilter(bson.M{"_id": similarApp.GetKey()})
+			write.SetReplacement(similarApp.BSON())
+			write.SetUpsert(true)
+
+			writes = append(writes, write)
+		}
+	}
+
+	if len(writes) == 0 {
+		return nil
+	}
+
+	c := client.Database(config.C.MongoDatabase).Collection(CollectionAppSimilar.String())
+
+	_, err = c.BulkWrite(ctx, writes, options.BulkWrite())
+
+	return err
+}
@@ -0,0 +1,164 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// A day of data that has been exported, see pkg/archive
+type Archive struct {
+	Store     string    `bson:"store"`
+	Day       time.Time `bson:"day"` // Midnight UTC
+	Count     int64     `bson:"count"`
+	Path      string    `bson:"path"`
+	CreatedAt time.Time `bson:"created_at"`
+	Pruning   bool      `bson:"pruning"` // Uploaded but not yet removed from the hot store
+}
+
+func (archive Archive) BSON() bson.D {
+
+	return bson.D{
+		{"_id", archive.GetKey()},
+		{"store", archive.Store},
+		{"day", archive.Day},
+		{"count", archive.Count},
+		{"path", archive.Path},
+		{"created_at", archive.CreatedAt},
+		{"pruning", archive.Pruning},
+	}
+}
+
+func (archive Archive) GetKey() string {
+	return archive.Store + "-" + archive.Day.Format("2006-01-02")
+}
+
+func SaveArchive(archive Archive) (err error) {
+
+	_, err = ReplaceOne(context.TODO(), CollectionArchives, bson.D{{"_id", archive.GetKey()}}, archive)
+	return err
+}
+
+func GetLatestArchive(store string) (archive Archive, err error) {
+
+	err = FindOne(context.TODO(), CollectionArchives, bson.D{{"store", store}}, bson.D{{"day", -1}}, nil, &archive)
+	return archive, err
+}
+
+func GetArchives(store string, from time.Time, to time.Time) (archives []Archive, err error) {
+
+	filter := bson.D{
+		{"store", store},
+		{"day", bson.M{"$gte": from, "$lt": to}},
+	}
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionArchives, 0, 0, filter, bson.D{{"day", 1}}, nil, nil)
+	if err != nil {
+		return archives, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var archive Archive
+		err := cur.Decode(&archive)
+		if err != nil {
+			log.ErrS(err, archive.GetKey())
+		} else {
+			archives = append(archives, archive)
+		}
+	}
+
+	return archives, cur.Err()
+}
+
+// A collection that can be archived by day
+type ArchiveSource struct {
+	Collection collection
+	DateField  string
+	UnixTime   bool // Date field is seconds, not a date
+}
+
+func (source ArchiveSource) filter(from time.Time, to time.Time) bson.D {
+
+	if source.UnixTime {
+		return bson.D{{source.DateField, bson.M{"$gte": from.Unix(), "$lt": to.Unix()}}}
+	}
+	return bson.D{{source.DateField, bson.M{"$gte": from, "$lt": to}}}
+}
+
+// Date of the oldest document
+func (source ArchiveSource) First() (t time.Time, err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, source.Collection, 0, 1, nil, bson.D{{source.DateField, 1}}, bson.M{source.DateField: 1}, nil)
+	if err != nil {
+		return t, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		val := cur.Current.Lookup(source.DateField)
+		if source.UnixTime {
+			t = time.Unix(val.AsInt64(), 0)
+		} else {
+			t = val.Time()
+		}
+	}
+
+	return t, cur.Err()
+}
+
+func (source ArchiveSource) Export(from time.Time, to time.Time, callback func(bson.Raw) error) (err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, source.Collection, 0, 0, source.filter(from, to), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		err = callback(cur.Current)
+		if err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+func (source ArchiveSource) Delete(from time.Time, to time.Time) (err error) {
+
+	_, err = DeleteMany(context.TODO(), source.Collection, source.filter(from, to))
+	return err
+}
+
+// Documents that already exist are skipped
+func (source ArchiveSource) Restore(documents []bson.D) (err error) {
+
+	var many []Document
+	for _, v := range documents {
+		many = append(many, rawDocument(v))
+	}
+
+	_, err = InsertMany(context.TODO(), source.Collection, many)
+	return err
+}
+
+type rawDocument bson.D
+
+func (doc rawDocument) BSON() bson.D {
+	return bson.D(doc)
+}
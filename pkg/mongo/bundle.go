@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"time"
 
 	"github.com/Jleagle/steam-go/steamapi"
@@ -193,12 +194,12 @@ func BatchBundles(filter bson.D, projection bson.M, callback func(bundles []Bund
 	return nil
 }
 
-func GetBundle(id int) (bundle Bundle, err error) {
+func GetBundle(ctx context.Context, id int) (bundle Bundle, err error) {
 
 	item := memcache.ItemBundle(id)
-	err = memcache.Client().GetSet(item.Key, item.Expiration, &bundle, func() (interface{}, error) {
+	err = memcache.Client().WithContext(ctx).GetSet(item.Key, item.Expiration, &bundle, func() (interface{}, error) {
 
-		err = FindOne(CollectionBundles, bson.D{{"_id", id}}, nil, nil, &bundle)
+		err = FindOne(ctx, CollectionBundles, bson.D{{"_id", id}}, nil, nil, &bundle)
 		return bundle, err
 	})
 
@@ -223,7 +224,9 @@ func GetBundlesByID(ids []int, projection bson.M) (bundles []Bundle, err error)
 
 func GetBundles(offset int64, limit int64, sort bson.D, filter bson.D, projection bson.M) (bundles []Bundle, err error) {
 
-	cur, ctx, err := find(CollectionBundles, offset, limit, filter, sort, projection, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionBundles, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return bundles, err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -42,7 +43,9 @@ func GetBundlePricesByID(bundleID int) (prices []BundlePrice, err error) {
 
 func getBundlePrices(filter, sort bson.D) (prices []BundlePrice, err error) {
 
-	cur, ctx, err := find(CollectionBundlePrices, 0, 0, filter, sort, nil, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionBundlePrices, 0, 0, filter, sort, nil, nil)
 	if err != nil {
 		return prices, err
 	}
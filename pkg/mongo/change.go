@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -103,7 +104,7 @@ func GetChange(id int64) (change Change, err error) {
 
 		var change Change
 
-		err = FindOne(CollectionChanges, bson.D{{"_id", id}}, nil, nil, &change)
+		err = FindOne(context.TODO(), CollectionChanges, bson.D{{"_id", id}}, nil, nil, &change)
 		if change.ID == 0 {
 			return change, ErrNoDocuments
 		}
@@ -114,11 +115,19 @@ func GetChange(id int64) (change Change, err error) {
 	return change, err
 }
 
+func GetLatestChange() (change Change, err error) {
+
+	err = FindOne(context.TODO(), CollectionChanges, nil, bson.D{{"_id", -1}}, nil, &change)
+	return change, err
+}
+
 func GetChanges(offset int64) (changes []Change, err error) {
 
 	var sort = bson.D{{"_id", -1}}
 
-	cur, ctx, err := find(CollectionChanges, offset, 100, nil, sort, nil, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionChanges, offset, 100, nil, sort, nil, nil)
 	if err != nil {
 		return changes, err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -33,7 +34,7 @@ func (guild DiscordGuild) BSON() bson.D {
 		guild.Icon = dg.IconURL()
 	}
 
-	requests, err := CountDocuments(CollectionChatBotCommands, bson.D{{"guild_id", guild.ID}}, 0)
+	requests, err := CountDocuments(context.TODO(), CollectionChatBotCommands, bson.D{{"guild_id", guild.ID}}, 0)
 	if err != nil {
 		log.ErrS(err)
 	}
@@ -58,7 +59,9 @@ func GetGuilds(offset int64, limit int64, sort bson.D, filter bson.D) (guilds []
 		Strength: 2, // Case insensitive
 	})
 
-	cur, ctx, err := find(CollectionDiscordGuilds, offset, limit, filter, sort, nil, ops)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionDiscordGuilds, offset, limit, filter, sort, nil, ops)
 	if err != nil {
 		return guilds, err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -75,7 +76,9 @@ func (c DiscordMessage) GetCommand() string {
 
 func GetChatBotCommandsRecent() (commands []DiscordMessage, err error) {
 
-	cur, ctx, err := find(CollectionChatBotCommands, 0, 100, nil, bson.D{{"_id", -1}}, nil, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionChatBotCommands, 0, 100, nil, bson.D{{"_id", -1}}, nil, nil)
 	if err != nil {
 		return commands, err
 	}
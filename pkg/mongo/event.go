@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"net/http"
 	"sort"
 	"strings"
@@ -24,8 +25,33 @@ var (
 	EventLogin          EventEnum = "login"
 	EventForgotPassword EventEnum = "forgot-password"
 	EventLogout         EventEnum = "logout"
+	EventLogoutRemote   EventEnum = "logout-remote"
+	EventLogoutAll      EventEnum = "logout-everywhere"
 	EventPatreonWebhook EventEnum = "patreon-webhook"
+	EventPatreonSync    EventEnum = "patreon-sync"
+	EventPatreonLapsed  EventEnum = "patreon-lapsed"
+	EventSupporterLevel EventEnum = "supporter-level"
+	EventSettings       EventEnum = "settings"
+	EventAPIKey         EventEnum = "api-key"
+	EventAPICall        EventEnum = "api-call"
+	EventTeamCreate     EventEnum = "team-create"
+	EventTeamDelete     EventEnum = "team-delete"
+	EventTeamInvite     EventEnum = "team-invite"
+	EventTeamJoin       EventEnum = "team-join"
+	EventTeamLeave      EventEnum = "team-leave"
+	EventTeamRemove     EventEnum = "team-remove"
+	EventTeamKeyReset   EventEnum = "team-key-reset"
+	EventTeamKeyCreate  EventEnum = "team-key-create"
+	EventTeamKeyDelete  EventEnum = "team-key-delete"
+	EventDataExport     EventEnum = "data-export"
+	EventDeleteRequest  EventEnum = "delete-request"
+	EventDeleteCancel   EventEnum = "delete-cancel"
 	EventRefresh        EventEnum = "refresh"
+	EventTOTPEnable     EventEnum = "totp-enable"
+	EventTOTPDisable    EventEnum = "totp-disable"
+	EventKeyAdd         EventEnum = "security-key-add"
+	EventKeyRemove      EventEnum = "security-key-remove"
+	EventTwoFactorFail  EventEnum = "two-factor-fail"
 	EventLink                     = func(provider oauth.ProviderEnum) EventEnum { return EventEnum("link-" + provider) }
 	EventUnlink                   = func(provider oauth.ProviderEnum) EventEnum { return EventEnum("unlink-" + provider) }
 )
@@ -52,10 +78,60 @@ func (event EventEnum) ToString() string {
 		return "User Login"
 	case EventLogout:
 		return "User Logout"
+	case EventLogoutRemote:
+		return "Session Logged Out"
+	case EventLogoutAll:
+		return "Logged Out Everywhere"
 	case EventRefresh:
 		return "Profile Update"
 	case EventForgotPassword:
 		return "Forgot Password"
+	case EventTOTPEnable:
+		return "Authenticator App Enabled"
+	case EventTOTPDisable:
+		return "Authenticator App Disabled"
+	case EventKeyAdd:
+		return "Security Key Added"
+	case EventKeyRemove:
+		return "Security Key Removed"
+	case EventTwoFactorFail:
+		return "Failed Two-Factor Attempt"
+	case EventPatreonSync:
+		return "Patreon Pledge Updated"
+	case EventPatreonLapsed:
+		return "Patreon Pledge Lapsed"
+	case EventSupporterLevel:
+		return "Supporter Level Changed"
+	case EventSettings:
+		return "Settings Changed"
+	case EventAPIKey:
+		return "New API Key"
+	case EventAPICall:
+		return "API Call"
+	case EventTeamCreate:
+		return "Team Created"
+	case EventTeamDelete:
+		return "Team Deleted"
+	case EventTeamInvite:
+		return "Team Invite Sent"
+	case EventTeamJoin:
+		return "Joined Team"
+	case EventTeamLeave:
+		return "Left Team"
+	case EventTeamRemove:
+		return "Removed From Team"
+	case EventTeamKeyReset:
+		return "Member API Key Reset"
+	case EventTeamKeyCreate:
+		return "Shared API Key Created"
+	case EventTeamKeyDelete:
+		return "Shared API Key Deleted"
+	case EventDataExport:
+		return "Data Export Requested"
+	case EventDeleteRequest:
+		return "Account Deletion Requested"
+	case EventDeleteCancel:
+		return "Account Deletion Cancelled"
 	default:
 		return strings.Title(string(event))
 	}
@@ -67,17 +143,28 @@ type Event struct {
 	UserID    int       `bson:"user_id"`
 	UserAgent string    `bson:"user_agent"`
 	IP        string    `bson:"ip"`
+	TeamID    int       `bson:"team_id,omitempty"` // Shows in the team's audit log
+	Details   string    `bson:"details,omitempty"`
 }
 
 func (event Event) BSON() bson.D {
 
-	return bson.D{
+	d := bson.D{
 		{"created_at", event.CreatedAt},
 		{"type", event.Type},
 		{"user_id", event.UserID},
 		{"user_agent", event.UserAgent},
 		{"ip", event.IP},
 	}
+
+	if event.TeamID > 0 {
+		d = append(d, bson.E{Key: "team_id", Value: event.TeamID})
+	}
+	if event.Details != "" {
+		d = append(d, bson.E{Key: "details", Value: event.Details})
+	}
+
+	return d
 }
 
 func (event Event) GetCreatedNice() (t string) {
@@ -89,10 +176,24 @@ func (event Event) GetIcon() string {
 	switch event.Type {
 	case EventLogin:
 		return "fa-sign-in-alt"
-	case EventLogout:
+	case EventLogout, EventLogoutRemote, EventLogoutAll:
 		return "fa-sign-out-alt"
 	case EventRefresh:
 		return "fa-sync-alt"
+	case EventTOTPEnable, EventTOTPDisable, EventKeyAdd, EventKeyRemove:
+		return "fa-shield-alt"
+	case EventAPICall, EventAPIKey, EventTeamKeyReset, EventTeamKeyCreate, EventTeamKeyDelete:
+		return "fa-key"
+	case EventTeamCreate, EventTeamDelete, EventTeamInvite, EventTeamJoin, EventTeamLeave, EventTeamRemove:
+		return "fa-users"
+	case EventSettings:
+		return "fa-cog"
+	case EventDataExport:
+		return "fa-file-archive"
+	case EventDeleteRequest, EventDeleteCancel:
+		return "fa-user-slash"
+	case EventTwoFactorFail:
+		return "fa-exclamation-triangle"
 	default:
 		return "fa-star"
 	}
@@ -102,7 +203,9 @@ func GetEvents(filter bson.D, offset int64) (events []Event, err error) {
 
 	var sortx = bson.D{{"created_at", -1}}
 
-	cur, ctx, err := find(CollectionEvents, offset, 100, filter, sortx, nil, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionEvents, offset, 100, filter, sortx, nil, nil)
 	if err != nil {
 		return events, err
 	}
@@ -166,18 +269,25 @@ func GetEventCounts(userID int) (counts []StringCount, err error) {
 }
 
 func NewEvent(r *http.Request, userID int, eventType EventEnum) (err error) {
+	return NewTeamEvent(r, userID, 0, eventType, "")
+}
+
+// NewTeamEvent also puts the event in the team's audit log, if the user is in one
+func NewTeamEvent(r *http.Request, userID int, teamID int, eventType EventEnum, details string) (err error) {
 
 	event := Event{}
 	event.CreatedAt = time.Now()
 	event.UserID = userID
+	event.TeamID = teamID
 	event.Type = eventType
+	event.Details = details
 
 	if r != nil {
 		event.UserAgent = r.Header.Get("User-Agent")
 		event.IP = r.RemoteAddr
 	}
 
-	_, err = InsertOne(CollectionEvents, event)
+	_, err = InsertOne(context.TODO(), CollectionEvents, event)
 	if err != nil {
 		return err
 	}
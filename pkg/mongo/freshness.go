@@ -0,0 +1,259 @@
+package mongo
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var FreshnessPercentiles = []int{50, 90, 99}
+
+type FreshnessTier struct {
+	Entity     string        // Display name
+	Tier       string        // Display name
+	Collection collection    //
+	Filter     bson.D        // Entities in this tier
+	SLO        time.Duration // Anything older than this is stale
+}
+
+// Entities older than the SLO, for crons to prioritise
+func (tier FreshnessTier) StaleFilter() bson.D {
+	return append(bson.D{{"updated_at", bson.M{"$lt": time.Now().Add(-tier.SLO)}}}, tier.Filter...)
+}
+
+var (
+	FreshnessPlayersPopular = FreshnessTier{
+		Entity:     "Players",
+		Tier:       "Level 100+",
+		Collection: CollectionPlayers,
+		Filter:     append(bson.D{{"level", bson.M{"$gte": 100}}}, helpers.LastUpdatedQuery...),
+		SLO:        time.Hour * 24 * 7,
+	}
+	FreshnessPlayersOther = FreshnessTier{
+		Entity:     "Players",
+		Tier:       "Other",
+		Collection: CollectionPlayers,
+		Filter:     append(bson.D{{"level", bson.M{"$lt": 100}}}, helpers.LastUpdatedQuery...),
+		SLO:        time.Hour * 24 * 30,
+	}
+	FreshnessAppsPopular = FreshnessTier{
+		Entity:     "Apps",
+		Tier:       "100+ weekly peak",
+		Collection: CollectionApps,
+		Filter:     bson.D{{"player_peak_week", bson.M{"$gte": 100}}},
+		SLO:        time.Hour * 24 * 2,
+	}
+	FreshnessAppsOther = FreshnessTier{
+		Entity:     "Apps",
+		Tier:       "Other",
+		Collection: CollectionApps,
+		Filter:     bson.D{{"player_peak_week", bson.M{"$lt": 100}}},
+		SLO:        time.Hour * 24 * 14,
+	}
+	FreshnessGroupsPopular = FreshnessTier{
+		Entity:     "Groups",
+		Tier:       "1,000+ members",
+		Collection: CollectionGroups,
+		Filter:     bson.D{{"members", bson.M{"$gte": 1000}}},
+		SLO:        time.Hour * 24 * 7,
+	}
+	FreshnessGroupsOther = FreshnessTier{
+		Entity:     "Groups",
+		Tier:       "Other",
+		Collection: CollectionGroups,
+		Filter:     bson.D{{"members", bson.M{"$lt": 1000}}},
+		SLO:        time.Hour * 24 * 60,
+	}
+	FreshnessBundles = FreshnessTier{
+		Entity:     "Bundles",
+		Tier:       "All",
+		Collection: CollectionBundles,
+		Filter:     bson.D{},
+		SLO:        time.Hour * 24 * 14,
+	}
+
+	FreshnessTiers = []FreshnessTier{
+		FreshnessPlayersPopular,
+		FreshnessPlayersOther,
+		FreshnessAppsPopular,
+		FreshnessAppsOther,
+		FreshnessGroupsPopular,
+		FreshnessGroupsOther,
+		FreshnessBundles,
+	}
+)
+
+type FreshnessReport struct {
+	CreatedAt time.Time            `bson:"created_at"`
+	Rows      []FreshnessReportRow `bson:"rows"`
+}
+
+func (report FreshnessReport) BSON() bson.D {
+
+	return bson.D{
+		{"created_at", report.CreatedAt},
+		{"rows", report.Rows},
+	}
+}
+
+// For table headers
+func (report FreshnessReport) GetPercentiles() []int {
+	return FreshnessPercentiles
+}
+
+type FreshnessReportRow struct {
+	Entity      string          `bson:"entity"`
+	Tier        string          `bson:"tier"`
+	SLO         time.Duration   `bson:"slo"`
+	Count       int64           `bson:"count"`
+	Stale       int64           `bson:"stale"`       // Older than the SLO
+	Percentiles []time.Duration `bson:"percentiles"` // Ages, matching FreshnessPercentiles
+	Oldest      time.Duration   `bson:"oldest"`
+}
+
+func (row FreshnessReportRow) GetStalePercent() float64 {
+
+	if row.Count == 0 {
+		return 0
+	}
+	return float64(row.Stale) / float64(row.Count) * 100
+}
+
+// Whether the SLO is being met for the highest percentile
+func (row FreshnessReportRow) IsHealthy() bool {
+
+	if len(row.Percentiles) == 0 {
+		return true
+	}
+	return row.Percentiles[len(row.Percentiles)-1] <= row.SLO
+}
+
+func (row FreshnessReportRow) GetSLO() string {
+	return formatFreshnessAge(row.SLO)
+}
+
+func (row FreshnessReportRow) GetPercentiles() (ret []string) {
+
+	for _, v := range row.Percentiles {
+		ret = append(ret, formatFreshnessAge(v))
+	}
+	return ret
+}
+
+func (row FreshnessReportRow) GetOldest() string {
+
+	if row.Count == 0 {
+		return "-"
+	}
+	return formatFreshnessAge(row.Oldest)
+}
+
+func formatFreshnessAge(d time.Duration) string {
+
+	if d < time.Hour*24 {
+		return strconv.FormatFloat(d.Hours(), 'f', 1, 64) + " hours"
+	}
+	return strconv.FormatFloat(d.Hours()/24, 'f', 1, 64) + " days"
+}
+
+// Counts and percentile ages for a tier, as of now
+func NewFreshnessReportRow(tier FreshnessTier) (row FreshnessReportRow, err error) {
+
+	now := time.Now()
+
+	row = FreshnessReportRow{
+		Entity: tier.Entity,
+		Tier:   tier.Tier,
+		SLO:    tier.SLO,
+	}
+
+	row.Count, err = CountDocuments(context.TODO(), tier.Collection, tier.Filter, 0)
+	if err != nil || row.Count == 0 {
+		return row, err
+	}
+
+	row.Stale, err = CountDocuments(context.TODO(), tier.Collection, tier.StaleFilter(), 0)
+	if err != nil {
+		return row, err
+	}
+
+	// Newest first, so the Nth percentile is N% of the way down the list
+	for _, p := range FreshnessPercentiles {
+
+		offset := int64(math.Ceil(float64(row.Count)*float64(p)/100)) - 1
+
+		updatedAt, err := getUpdatedAtAtOffset(tier, offset)
+		if err != nil {
+			return row, err
+		}
+
+		row.Percentiles = append(row.Percentiles, now.Sub(updatedAt))
+	}
+
+	updatedAt, err := getUpdatedAtAtOffset(tier, row.Count-1)
+	if err != nil {
+		return row, err
+	}
+
+	row.Oldest = now.Sub(updatedAt)
+
+	return row, nil
+}
+
+func getUpdatedAtAtOffset(tier FreshnessTier, offset int64) (updatedAt time.Time, err error) {
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, tier.Collection, offset, 1, tier.Filter, bson.D{{"updated_at", -1}}, bson.M{"updated_at": 1}, nil)
+	if err != nil {
+		return updatedAt, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var doc struct {
+			UpdatedAt time.Time `bson:"updated_at"`
+		}
+
+		err = cur.Decode(&doc)
+		if err != nil {
+			return updatedAt, err
+		}
+
+		updatedAt = doc.UpdatedAt
+	}
+
+	return updatedAt, cur.Err()
+}
+
+func InsertFreshnessReport(report FreshnessReport) (err error) {
+
+	_, err = InsertOne(context.TODO(), CollectionFreshnessReports, report)
+	if err != nil {
+		return err
+	}
+
+	return memcache.Client().Delete(memcache.ItemFreshnessReport.Key)
+}
+
+func GetLatestFreshnessReport() (report FreshnessReport, err error) {
+
+	err = memcache.Client().GetSet(memcache.ItemFreshnessReport.Key, memcache.ItemFreshnessReport.Expiration, &report, func() (interface{}, error) {
+
+		err := FindOne(context.TODO(), CollectionFreshnessReports, nil, bson.D{{"created_at", -1}}, nil, &report)
+		return report, err
+	})
+
+	return report, err
+}
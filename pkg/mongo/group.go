@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"time"
@@ -10,7 +11,6 @@ import (
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 var ErrInvalidGroupID = errors.New("invalid group id")
@@ -66,29 +66,6 @@ func (group Group) BSON() bson.D {
 	}
 }
 
-func ensureGroupIndexes() {
-
-	var indexModels = []mongo.IndexModel{
-		{Keys: bson.D{{"type", 1}, {"_id", 1}}},
-		{Keys: bson.D{{"type", 1}, {"members", -1}}},
-		{Keys: bson.D{{"type", 1}, {"trending", 1}}},
-		{Keys: bson.D{{"type", 1}, {"trending", -1}}},
-		{Keys: bson.D{{"type", 1}, {"primaries", -1}}},
-	}
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionGroups.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
 func (group Group) GetPath() string {
 	return helpers.GetGroupPath(group.ID, group.GetName())
 }
@@ -133,7 +110,7 @@ func (group Group) ShouldUpdate() bool {
 	return group.UpdatedAt.Before(time.Now().Add(time.Hour * -6))
 }
 
-func GetGroup(id string) (group Group, err error) {
+func GetGroup(ctx context.Context, id string) (group Group, err error) {
 
 	id, err = helpers.IsValidGroupID(id)
 	if err != nil {
@@ -141,16 +118,16 @@ func GetGroup(id string) (group Group, err error) {
 	}
 
 	item := memcache.ItemGroup(id)
-	err = memcache.Client().GetSet(item.Key, item.Expiration, &group, func() (interface{}, error) {
+	err = memcache.Client().WithContext(ctx).GetSet(item.Key, item.Expiration, &group, func() (interface{}, error) {
 
-		err = FindOne(CollectionGroups, bson.D{{"_id", id}}, nil, nil, &group)
+		err = FindOne(ctx, CollectionGroups, bson.D{{"_id", id}}, nil, nil, &group)
 		return group, err
 	})
 
 	return group, err
 }
 
-func GetGroupsByID(ids []string, projection bson.M) (groups []Group, err error) {
+func GetGroupsByID(ctx context.Context, ids []string, projection bson.M) (groups []Group, err error) {
 
 	var chunkSize = 100
 
@@ -206,7 +183,9 @@ func GetGroups(offset int64, limit int64, sort bson.D, filter bson.D, projection
 
 func getGroups(offset int64, limit int64, sort bson.D, filter bson.D, projection bson.M) (groups []Group, err error) {
 
-	cur, ctx, err := find(CollectionGroups, offset, limit, filter, sort, projection, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionGroups, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return groups, err
 	}
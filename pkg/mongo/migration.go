@@ -0,0 +1,150 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A migration that has been applied, see pkg/migrations
+type Migration struct {
+	ID          string    `bson:"_id"`
+	Database    string    `bson:"database"`
+	Description string    `bson:"description"`
+	StartedAt   time.Time `bson:"started_at"`
+	AppliedAt   time.Time `bson:"applied_at"` // Zero while it's running, or if it stopped part way
+}
+
+func (migration Migration) BSON() bson.D {
+
+	return bson.D{
+		{"_id", migration.ID},
+		{"database", migration.Database},
+		{"description", migration.Description},
+		{"started_at", migration.StartedAt},
+		{"applied_at", migration.AppliedAt},
+	}
+}
+
+func GetMigrations() (migrations []Migration, err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionMigrations, 0, 0, nil, bson.D{{"_id", 1}}, nil, nil)
+	if err != nil {
+		return migrations, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var migration Migration
+		err := cur.Decode(&migration)
+		if err != nil {
+			log.ErrS(err, migration.ID)
+		} else {
+			migrations = append(migrations, migration)
+		}
+	}
+
+	return migrations, cur.Err()
+}
+
+// Returns false if another process has already claimed it
+func ClaimMigration(migration Migration) (claimed bool, err error) {
+
+	_, err = InsertOne(context.TODO(), CollectionMigrations, migration)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func SetMigrationApplied(id string, appliedAt time.Time) (err error) {
+
+	_, err = UpdateOne(context.TODO(), CollectionMigrations, bson.D{{"_id", id}}, bson.D{{"applied_at", appliedAt}})
+	return err
+}
+
+func DeleteMigration(id string) (err error) {
+
+	_, err = DeleteOne(context.TODO(), CollectionMigrations, bson.D{{"_id", id}})
+	return err
+}
+
+// Indexes for a collection, declared in migrations
+type IndexSet struct {
+	collection collection
+	indexes    []mongo.IndexModel
+}
+
+func NewIndexSet(collection collection, indexes []mongo.IndexModel) IndexSet {
+	return IndexSet{collection: collection, indexes: indexes}
+}
+
+func CreateIndexes(sets ...IndexSet) (err error) {
+
+	client, ctx, err := getMongo()
+	if err != nil {
+		return err
+	}
+
+	for _, set := range sets {
+
+		_, err = client.Database(config.C.MongoDatabase).Collection(set.collection.String()).Indexes().CreateMany(ctx, set.indexes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Indexes that don't exist are skipped
+func DropIndexes(sets ...IndexSet) (err error) {
+
+	client, ctx, err := getMongo()
+	if err != nil {
+		return err
+	}
+
+	for _, set := range sets {
+
+		view := client.Database(config.C.MongoDatabase).Collection(set.collection.String()).Indexes()
+
+		for _, index := range set.indexes {
+
+			_, err = view.DropOne(ctx, IndexName(index))
+			if err != nil && !strings.Contains(err.Error(), "index not found") {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// The same name Mongo generates when one isn't set
+func IndexName(index mongo.IndexModel) string {
+
+	if index.Options != nil && index.Options.Name != nil {
+		return *index.Options.Name
+	}
+
+	var parts []string
+	if keys, ok := index.Keys.(bson.D); ok {
+		for _, v := range keys {
+			parts = append(parts, v.Key, fmt.Sprint(v.Value))
+		}
+	}
+
+	return strings.Join(parts, "_")
+}
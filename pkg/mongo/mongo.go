@@ -27,6 +27,7 @@ func (c collection) String() string {
 }
 
 const (
+	CollectionAdminEvents         collection = "admin_events"
 	CollectionAppAchievements     collection = "app_achievements"
 	CollectionAppArticles         collection = "app_articles"
 	CollectionAppDLC              collection = "app_dlc"
@@ -34,6 +35,8 @@ const (
 	CollectionApps                collection = "apps"
 	CollectionAppSales            collection = "app_offers"
 	CollectionAppSameOwners       collection = "app_same_owners"
+	CollectionAppSimilar          collection = "app_similar"
+	CollectionArchives            collection = "archives"
 	CollectionBundles             collection = "bundles"
 	CollectionBundlePrices        collection = "bundle_prices"
 	CollectionChangeItems         collection = "change_products"
@@ -42,7 +45,9 @@ const (
 	CollectionDelayQueue          collection = "delay_queue"
 	CollectionDiscordGuilds       collection = "discord_guilds"
 	CollectionEvents              collection = "events"
+	CollectionFreshnessReports    collection = "freshness_reports"
 	CollectionGroups              collection = "groups"
+	CollectionMigrations          collection = "migrations"
 	CollectionPackageApps         collection = "package_apps"
 	CollectionPackages            collection = "packages"
 	CollectionWebhooks            collection = "patreon_webhooks"
@@ -54,10 +59,16 @@ const (
 	CollectionPlayerBadgesSummary collection = "player_badges_summary"
 	CollectionPlayerFriends       collection = "player_friends"
 	CollectionPlayerGroups        collection = "player_groups"
+	CollectionPlayerIgnoredApps   collection = "player_ignored_apps"
 	CollectionPlayers             collection = "players"
 	CollectionPlayerWishlistApps  collection = "player_wishlist_apps"
 	CollectionProductPrices       collection = "product_prices"
+	CollectionProductPriceLows    collection = "product_price_lows"
+	CollectionSavedSearches       collection = "saved_searches"
 	CollectionStats               collection = "stats"
+	CollectionStatusChecks        collection = "status_checks"
+	CollectionStatusIncidents     collection = "status_incidents"
+	CollectionUserSessions        collection = "user_sessions"
 )
 
 var (
@@ -89,7 +100,8 @@ func getMongo() (client *mongo.Client, ctx context.Context, err error) {
 		ops := options.Client().
 			SetAuth(creds).
 			ApplyURI(config.MongoDSN()).
-			SetAppName("Global Steam")
+			SetAppName("Global Steam").
+			SetMonitor(commandMonitor)
 
 		client, err = mongo.NewClient(ops)
 
@@ -121,28 +133,13 @@ func closeCursor(cur *mongo.Cursor, ctx context.Context) {
 	}
 }
 
-func EnsureIndexes() {
-	log.Info("Starting migrations")
-	ensureAppIndexes()
-	ensureGroupIndexes()
-	ensurePackageIndexes()
-	ensurePlayerIndexes()
-	ensurePlayerAchievementIndexes()
-	ensurePlayerAppIndexes()
-	ensurePlayerFriendIndexes()
-	ensureSaleIndexes()
-	ensureStatIndexes()
-	ensureAppSameOwnersIndexes()
-	log.Info("Finished migrations")
-}
-
-func FindOne(collection collection, filter bson.D, sort bson.D, projection bson.M, document Document) (err error) {
+func FindOne(ctx context.Context, collection collection, filter bson.D, sort bson.D, projection bson.M, document Document) (err error) {
 
 	if filter == nil {
 		filter = bson.D{}
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -171,9 +168,9 @@ func FindOne(collection collection, filter bson.D, sort bson.D, projection bson.
 	return result.Decode(document)
 }
 
-func GetDistict(collection collection, field string) (values []interface{}, err error) {
+func GetDistict(ctx context.Context, collection collection, field string) (values []interface{}, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return values, err
 	}
@@ -182,9 +179,9 @@ func GetDistict(collection collection, field string) (values []interface{}, err
 }
 
 // Errors if key already exists
-func InsertOne(collection collection, document Document) (resp *mongo.InsertOneResult, err error) {
+func InsertOne(ctx context.Context, collection collection, document Document) (resp *mongo.InsertOneResult, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return resp, err
 	}
@@ -197,9 +194,9 @@ func InsertOne(collection collection, document Document) (resp *mongo.InsertOneR
 }
 
 // Create or update whole document
-func ReplaceOne(collection collection, filter bson.D, document Document) (resp *mongo.UpdateResult, err error) {
+func ReplaceOne(ctx context.Context, collection collection, filter bson.D, document Document) (resp *mongo.UpdateResult, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return resp, err
 	}
@@ -211,9 +208,9 @@ func ReplaceOne(collection collection, filter bson.D, document Document) (resp *
 	return resp, err
 }
 
-func DeleteMany(collection collection, filter bson.D) (resp *mongo.DeleteResult, err error) {
+func DeleteMany(ctx context.Context, collection collection, filter bson.D) (resp *mongo.DeleteResult, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return resp, nil
 	}
@@ -226,9 +223,9 @@ func DeleteMany(collection collection, filter bson.D) (resp *mongo.DeleteResult,
 }
 
 //noinspection GoUnusedExportedFunction
-func DeleteOne(collection collection, filter bson.D) (resp *mongo.DeleteResult, err error) {
+func DeleteOne(ctx context.Context, collection collection, filter bson.D) (resp *mongo.DeleteResult, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return resp, err
 	}
@@ -241,13 +238,13 @@ func DeleteOne(collection collection, filter bson.D) (resp *mongo.DeleteResult,
 }
 
 // Does NOT upsert
-func UpdateManySet(collection collection, filter bson.D, update bson.D) (resp *mongo.UpdateResult, err error) {
+func UpdateManySet(ctx context.Context, collection collection, filter bson.D, update bson.D) (resp *mongo.UpdateResult, err error) {
 
 	if filter == nil {
 		filter = bson.D{}
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return resp, nil
 	}
@@ -260,9 +257,9 @@ func UpdateManySet(collection collection, filter bson.D, update bson.D) (resp *m
 }
 
 //noinspection GoUnusedExportedFunction
-func UpdateManyUnset(collection collection, columns bson.D) (resp *mongo.UpdateResult, err error) {
+func UpdateManyUnset(ctx context.Context, collection collection, columns bson.D) (resp *mongo.UpdateResult, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return resp, nil
 	}
@@ -275,9 +272,9 @@ func UpdateManyUnset(collection collection, columns bson.D) (resp *mongo.UpdateR
 }
 
 // Does not upsert
-func UpdateOne(collection collection, filter bson.D, update bson.D) (resp *mongo.UpdateResult, err error) {
+func UpdateOne(ctx context.Context, collection collection, filter bson.D, update bson.D) (resp *mongo.UpdateResult, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return resp, nil
 	}
@@ -289,14 +286,29 @@ func UpdateOne(collection collection, filter bson.D, update bson.D) (resp *mongo
 	return resp, err
 }
 
+// Upserts, fields not in the update are left alone
+func UpsertOneSet(ctx context.Context, collection collection, filter bson.D, update bson.D) (resp *mongo.UpdateResult, err error) {
+
+	client, _, err := getMongo()
+	if err != nil {
+		return resp, err
+	}
+
+	resp, err = client.Database(config.C.MongoDatabase, options.Database()).
+		Collection(collection.String()).
+		UpdateOne(ctx, filter, bson.M{"$set": update}, options.Update().SetUpsert(true))
+
+	return resp, err
+}
+
 // Will skip documents that already exist
-func InsertMany(collection collection, documents []Document) (resp *mongo.InsertManyResult, err error) {
+func InsertMany(ctx context.Context, collection collection, documents []Document) (resp *mongo.InsertManyResult, err error) {
 
 	if documents == nil || len(documents) < 1 {
 		return resp, nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return resp, err
 	}
@@ -323,16 +335,16 @@ func InsertMany(collection collection, documents []Document) (resp *mongo.Insert
 	return resp, err
 }
 
-func CountDocuments(collection collection, filter bson.D, ttl uint32) (count int64, err error) {
+func CountDocuments(ctx context.Context, collection collection, filter bson.D, ttl uint32) (count int64, err error) {
 
 	item := memcache.ItemMongoCount(collection.String(), filter)
 	if ttl > 0 {
 		item.Expiration = ttl
 	}
 
-	err = memcache.Client().GetSet(item.Key, item.Expiration, &count, func() (interface{}, error) {
+	err = memcache.Client().WithContext(ctx).GetSet(item.Key, item.Expiration, &count, func() (interface{}, error) {
 
-		client, ctx, err := getMongo()
+		client, _, err := getMongo()
 		if err != nil {
 			return count, err
 		}
@@ -358,15 +370,15 @@ func CountDocuments(collection collection, filter bson.D, ttl uint32) (count int
 }
 
 // Need to close cursor after calling this
-func find(collection collection, offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M, ops *options.FindOptions) (cur *mongo.Cursor, ctx context.Context, err error) {
+func find(ctx context.Context, collection collection, offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M, ops *options.FindOptions) (cur *mongo.Cursor, err error) {
 
 	if filter == nil {
 		filter = bson.D{}
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
-		return cur, ctx, err
+		return cur, err
 	}
 
 	if ops == nil {
@@ -393,14 +405,14 @@ func find(collection collection, offset int64, limit int64, filter bson.D, sort
 
 	ql.End()
 
-	return cur, ctx, err
+	return cur, err
 }
 
-func GetRandomRows(collection collection, count int, filter bson.D, projection bson.M) (cur *mongo.Cursor, ctx context.Context, err error) {
+func GetRandomRows(ctx context.Context, collection collection, count int, filter bson.D, projection bson.M) (cur *mongo.Cursor, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
-		return cur, ctx, err
+		return cur, err
 	}
 
 	pipeline := mongo.Pipeline{}
@@ -425,7 +437,7 @@ func GetRandomRows(collection collection, count int, filter bson.D, projection b
 
 	ql.End()
 
-	return c, ctx, err
+	return c, err
 }
 
 type Count struct {
@@ -443,6 +455,16 @@ type DateCount struct {
 	Count int    `json:"count"`
 }
 
+func Ping(ctx context.Context) error {
+
+	client, _, err := getMongo()
+	if err != nil {
+		return err
+	}
+
+	return client.Ping(ctx, readpref.Primary())
+}
+
 func Close() {
 
 	client, ctx, err := getMongo()
@@ -459,7 +481,3 @@ func Close() {
 		}
 	}
 }
-
-func stringToPointer(s string) *string {
-	return &s
-}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"errors"
 	"html/template"
 	"strconv"
@@ -8,15 +9,11 @@ import (
 
 	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/Philipp15b/go-steam/protocol/steamlang"
-	"github.com/gamedb/gamedb/pkg/config"
 	"github.com/gamedb/gamedb/pkg/helpers"
-	"github.com/gamedb/gamedb/pkg/i18n"
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
 	"github.com/gamedb/gamedb/pkg/mysql/pics"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -25,32 +22,33 @@ var (
 )
 
 type Package struct {
-	Apps             []int                 `bson:"apps"`
-	AppItems         map[int]int           `bson:"app_items"`
-	AppsCount        int                   `bson:"apps_count"`
-	Bundles          []int                 `bson:"bundle_ids"`
-	BillingType      int32                 `bson:"billing_type"`
-	ChangeNumber     int                   `bson:"change_id"`
-	ChangeNumberDate time.Time             `bson:"change_number_date"`
-	ComingSoon       bool                  `bson:"coming_soon"`
-	Controller       pics.PICSController   `bson:"controller"`
-	CreatedAt        time.Time             `bson:"created_at"`
-	Depots           []int                 `bson:"depot_ids"`
-	Extended         pics.PICSKeyValues    `bson:"extended"`
-	Icon             string                `bson:"icon"`
-	ID               int                   `bson:"_id" json:"id"`
-	ImageLogo        string                `bson:"image_logo"`
-	ImagePage        string                `bson:"image_page"`
-	InStore          bool                  `bson:"in_store"` // todo
-	LicenseType      int32                 `bson:"license_type"`
-	Name             string                `bson:"name"`
-	Platforms        []string              `bson:"platforms"`
-	Prices           helpers.ProductPrices `bson:"prices"`
-	PurchaseText     string                `bson:"purchase_text"`
-	ReleaseDate      string                `bson:"release_date"`
-	ReleaseDateUnix  int64                 `bson:"release_date_unix"`
-	Status           int8                  `bson:"status"`
-	UpdatedAt        time.Time             `bson:"updated_at"`
+	Apps             []int                     `bson:"apps"`
+	AppItems         map[int]int               `bson:"app_items"`
+	AppsCount        int                       `bson:"apps_count"`
+	Bundles          []int                     `bson:"bundle_ids"`
+	BillingType      int32                     `bson:"billing_type"`
+	ChangeNumber     int                       `bson:"change_id"`
+	ChangeNumberDate time.Time                 `bson:"change_number_date"`
+	ComingSoon       bool                      `bson:"coming_soon"`
+	Controller       pics.PICSController       `bson:"controller"`
+	CreatedAt        time.Time                 `bson:"created_at"`
+	Depots           []int                     `bson:"depot_ids"`
+	Extended         pics.PICSKeyValues        `bson:"extended"`
+	Icon             string                    `bson:"icon"`
+	ID               int                       `bson:"_id" json:"id"`
+	ImageLogo        string                    `bson:"image_logo"`
+	ImagePage        string                    `bson:"image_page"`
+	InStore          bool                      `bson:"in_store"` // todo
+	LicenseType      int32                     `bson:"license_type"`
+	Name             string                    `bson:"name"`
+	Platforms        []string                  `bson:"platforms"`
+	Prices           helpers.ProductPrices     `bson:"prices"`
+	PriceStats       helpers.ProductPriceStats `bson:"price_stats"`
+	PurchaseText     string                    `bson:"purchase_text"`
+	ReleaseDate      string                    `bson:"release_date"`
+	ReleaseDateUnix  int64                     `bson:"release_date_unix"`
+	Status           int8                      `bson:"status"`
+	UpdatedAt        time.Time                 `bson:"updated_at"`
 }
 
 func (pack Package) BSON() bson.D {
@@ -79,6 +77,7 @@ func (pack Package) BSON() bson.D {
 		{"name", pack.Name},
 		{"platforms", pack.Platforms},
 		{"prices", pack.Prices},
+		{"price_stats", pack.PriceStats},
 		{"purchase_text", pack.PurchaseText},
 		{"release_date", pack.ReleaseDate},
 		{"release_date_unix", pack.ReleaseDateUnix},
@@ -87,52 +86,6 @@ func (pack Package) BSON() bson.D {
 	}
 }
 
-func ensurePackageIndexes() {
-
-	var indexModels []mongo.IndexModel
-
-	var cols = []string{
-		"apps_count",
-		"billing_type",
-		"change_number_date",
-		"license_type",
-		"platforms",
-		"status",
-	}
-
-	// Price fields
-	for _, v := range i18n.GetProdCCs(true) {
-		cols = append(cols, "prices."+string(v.ProductCode)+".final")
-		cols = append(cols, "prices."+string(v.ProductCode)+".discount_percent")
-	}
-
-	//
-	for _, v := range cols {
-		indexModels = append(indexModels,
-			mongo.IndexModel{Keys: bson.D{{v, 1}}},
-			mongo.IndexModel{Keys: bson.D{{v, -1}}},
-		)
-	}
-
-	//
-	indexModels = append(
-		indexModels,
-		mongo.IndexModel{Keys: bson.D{{"extended.$**", 1}}, Options: &options.IndexOptions{Name: stringToPointer("extended.wildcard_1")}},
-	)
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionPackages.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
 func (pack Package) GetID() int {
 	return pack.ID
 }
@@ -340,7 +293,7 @@ func (pack Package) Save() (err error) {
 
 	pack.UpdatedAt = time.Now()
 
-	_, err = ReplaceOne(CollectionPackages, bson.D{{"_id", pack.ID}}, pack)
+	_, err = ReplaceOne(context.TODO(), CollectionPackages, bson.D{{"_id", pack.ID}}, pack)
 	return err
 }
 
@@ -366,23 +319,23 @@ func (pack Package) HasEmptyIcon() bool {
 		pack.Icon == helpers.DefaultAppIcon
 }
 
-func GetPackage(id int) (pack Package, err error) {
+func GetPackage(ctx context.Context, id int) (pack Package, err error) {
 
 	if !helpers.IsValidPackageID(id) {
 		return pack, ErrInvalidPackageID
 	}
 
 	item := memcache.ItemPackage(id)
-	err = memcache.Client().GetSet(item.Key, item.Expiration, &pack, func() (interface{}, error) {
+	err = memcache.Client().WithContext(ctx).GetSet(item.Key, item.Expiration, &pack, func() (interface{}, error) {
 
-		err := FindOne(CollectionPackages, bson.D{{"_id", id}}, nil, nil, &pack)
+		err := FindOne(ctx, CollectionPackages, bson.D{{"_id", id}}, nil, nil, &pack)
 		return pack, err
 	})
 
 	return pack, err
 }
 
-func GetPackagesByID(ids []int, projection bson.M) (packages []Package, err error) {
+func GetPackagesByID(ctx context.Context, ids []int, projection bson.M) (packages []Package, err error) {
 
 	a := bson.A{}
 	for _, v := range ids {
@@ -394,7 +347,9 @@ func GetPackagesByID(ids []int, projection bson.M) (packages []Package, err erro
 
 func GetPackages(offset int64, limit int64, sort bson.D, filter bson.D, projection bson.M) (packages []Package, err error) {
 
-	cur, ctx, err := find(CollectionPackages, offset, limit, filter, sort, projection, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPackages, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return packages, err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gamedb/gamedb/pkg/config"
@@ -95,7 +96,9 @@ func GetPackageApps(packageID int, offset int64, sort bson.D) (apps []PackageApp
 
 	var filter = bson.D{{"package_id", packageID}}
 
-	cur, ctx, err := find(CollectionPackageApps, offset, 100, filter, sort, nil, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPackageApps, offset, 100, filter, sort, nil, nil)
 	if err != nil {
 		return apps, err
 	}
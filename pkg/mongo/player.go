@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"html/template"
 	"math"
 	"sort"
@@ -62,6 +63,7 @@ type Player struct {
 	PlayTimeLinux            int                        `bson:"play_time_linux"`
 	PrimaryGroupID           string                     `bson:"primary_clan_id_string"`
 	Private                  bool                       `bson:"private"`
+	Privacy                  PlayerPrivacy              `bson:"privacy"`
 	Ranks                    map[helpers.RankMetric]int `bson:"ranks"`
 	RecentAppsCount          int                        `bson:"recent_apps_count"`
 	Removed                  bool                       `bson:"removed"` // Removed from Steam
@@ -113,6 +115,7 @@ func (player Player) BSON() bson.D {
 		{"persona_name", player.PersonaName},
 		{"primary_clan_id_string", player.PrimaryGroupID},
 		{"private", player.Private},
+		{"privacy", player.Privacy},
 		{"status_code", player.StateCode},
 		{"time_created", player.TimeCreated},
 		{"updated_at", player.UpdatedAt},
@@ -142,6 +145,34 @@ func (player Player) BSON() bson.D {
 	}
 }
 
+// Opt outs set by the player in settings, on top of their Steam privacy
+type PlayerPrivacy struct {
+	HideRanks    bool `bson:"hide_ranks"`    // Not ranked, or listed on the players page
+	HideSearch   bool `bson:"hide_search"`   // Not in Elastic
+	HideFriends  bool `bson:"hide_friends"`  // Own friends list, and rows on other players' lists
+	HidePlaytime bool `bson:"hide_playtime"` // Totals and per game
+	NoHistory    bool `bson:"no_history"`    // No Influx points
+}
+
+// Blanks the fields the player has chosen to hide, before showing them to anyone else
+func (player *Player) ApplyPrivacy() {
+
+	if player.Privacy.HideRanks {
+		player.Ranks = nil
+	}
+
+	if player.Privacy.HidePlaytime {
+		player.PlayTime = 0
+		player.PlayTimeWindows = 0
+		player.PlayTimeMac = 0
+		player.PlayTimeLinux = 0
+		player.GameStats.All.Time = 0
+		player.GameStats.All.PriceHour = nil
+		player.GameStats.Played.Time = 0
+		player.GameStats.Played.PriceHour = nil
+	}
+}
+
 func (player Player) GetPath() string {
 	return helpers.GetPlayerPath(player.ID, player.GetName())
 }
@@ -365,90 +396,17 @@ func (player Player) GetLastBan() time.Time {
 	return player.LastBan
 }
 
-func ensurePlayerIndexes() {
-
-	var indexModels []mongo.IndexModel
-
-	// These are for the ranking cron
-	for col := range helpers.PlayerRankFields {
-		indexModels = append(indexModels, mongo.IndexModel{
-			Keys: bson.D{
-				{col, -1},
-			},
-		})
-		indexModels = append(indexModels, mongo.IndexModel{
-			Keys: bson.D{
-				{"continent_code", 1},
-				{col, -1},
-			},
-		})
-		indexModels = append(indexModels, mongo.IndexModel{
-			Keys: bson.D{
-				{"country_code", 1},
-				{col, -1},
-			},
-		})
-		indexModels = append(indexModels, mongo.IndexModel{
-			Keys: bson.D{
-				{"country_code", 1},
-				{"status_code", 1},
-				{col, -1},
-			},
-		})
-	}
-
-	// For last updated task
-	indexModels = append(indexModels, mongo.IndexModel{
-		Keys: bson.D{
-			{"community_visibility_state", 1},
-			{"removed", 1},
-			{"updated_at", 1},
-		},
-	})
-
-	// For admin stats
-	indexModels = append(indexModels,
-		mongo.IndexModel{Keys: bson.D{{"community_visibility_state", 1}}},
-		mongo.IndexModel{Keys: bson.D{{"removed", 1}}},
-	)
-
-	// Misc
-	indexModels = append(indexModels,
-
-		// Asc
-		mongo.IndexModel{Keys: bson.D{{"primary_clan_id_string", 1}}},
-
-		// Desc
-		mongo.IndexModel{Keys: bson.D{{"achievement_count_100", -1}}},
-		mongo.IndexModel{Keys: bson.D{{"bans_cav", -1}}},
-		mongo.IndexModel{Keys: bson.D{{"bans_game", -1}}},
-		mongo.IndexModel{Keys: bson.D{{"created_at", -1}}},
-	)
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionPlayers.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
-func GetPlayer(id int64) (player Player, err error) {
+func GetPlayer(ctx context.Context, id int64) (player Player, err error) {
 
 	item := memcache.ItemPlayer(id)
-	err = memcache.Client().GetSet(item.Key, item.Expiration, &player, func() (interface{}, error) {
+	err = memcache.Client().WithContext(ctx).GetSet(item.Key, item.Expiration, &player, func() (interface{}, error) {
 
 		id, err := helpers.IsValidPlayerID(id)
 		if err != nil {
 			return player, steamid.ErrInvalidPlayerID
 		}
 
-		err = FindOne(CollectionPlayers, bson.D{{"_id", id}}, nil, nil, &player)
+		err = FindOne(ctx, CollectionPlayers, bson.D{{"_id", id}}, nil, nil, &player)
 		return player, err
 	})
 
@@ -457,7 +415,20 @@ func GetPlayer(id int64) (player Player, err error) {
 	return player, err
 }
 
-func GetPlayersByID(ids []int64, projection bson.M) (players []Player, err error) {
+// Skips memcache, for when the privacy settings may have just been changed
+func GetPlayerPrivacy(ctx context.Context, id int64) (privacy PlayerPrivacy, err error) {
+
+	var player Player
+
+	err = FindOne(ctx, CollectionPlayers, bson.D{{"_id", id}}, nil, bson.M{"privacy": 1}, &player)
+	if err == ErrNoDocuments {
+		err = nil
+	}
+
+	return player.Privacy, err
+}
+
+func GetPlayersByID(ctx context.Context, ids []int64, projection bson.M) (players []Player, err error) {
 
 	if len(ids) < 1 {
 		return players, nil
@@ -468,12 +439,12 @@ func GetPlayersByID(ids []int64, projection bson.M) (players []Player, err error
 		idsBSON = append(idsBSON, v)
 	}
 
-	return GetPlayers(0, 0, nil, bson.D{{"_id", bson.M{"$in": idsBSON}}}, projection)
+	return GetPlayers(ctx, 0, 0, nil, bson.D{{"_id", bson.M{"$in": idsBSON}}}, projection)
 }
 
-func GetPlayers(offset int64, limit int64, sort bson.D, filter bson.D, projection bson.M) (players []Player, err error) {
+func GetPlayers(ctx context.Context, offset int64, limit int64, sort bson.D, filter bson.D, projection bson.M) (players []Player, err error) {
 
-	cur, ctx, err := find(CollectionPlayers, offset, limit, filter, sort, projection, nil)
+	cur, err := find(ctx, CollectionPlayers, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return players, err
 	}
@@ -636,13 +607,13 @@ func GetPlayerLevelsRounded() (counts []Count, err error) {
 	return counts, err
 }
 
-func BulkUpdatePlayers(writes []mongo.WriteModel) (err error) {
+func BulkUpdatePlayers(ctx context.Context, writes []mongo.WriteModel) (err error) {
 
 	if len(writes) == 0 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"sort"
 	"strconv"
 	"time"
@@ -64,51 +65,7 @@ func (a PlayerAchievement) GetDate() string {
 	return time.Unix(a.AchievementDate, 0).Format(helpers.DateSQLMinute)
 }
 
-func ensurePlayerAchievementIndexes() {
-
-	var indexModels = []mongo.IndexModel{
-		// GetPlayerAchievements
-		// {Keys: bson.D{
-		// 	{"player_id", 1},
-		// 	{"achievement_date", -1},
-		// }},
-		// GetPlayerAchievements
-		// {Keys: bson.D{
-		// 	{"player_id", 1},
-		// 	{"achievement_complete", 1},
-		// }},
-		// FindLatestPlayerAchievement
-		{Keys: bson.D{
-			{"player_id", 1},
-			{"app_id", 1},
-			{"achievement_date", -1},
-		}},
-		// GetPlayerAchievementsForApp
-		// {Keys: bson.D{
-		// 	{"player_id", 1},
-		// 	{"app_id", 1},
-		// 	{"achievement_id", 1}},
-		// },
-	}
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).
-		Collection(CollectionPlayerAchievements.String()).
-		Indexes().
-		CreateMany(ctx, indexModels)
-
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
-func FindLatestPlayerAchievement(playerID int64, appID int) (int64, error) {
+func FindLatestPlayerAchievement(ctx context.Context, playerID int64, appID int) (int64, error) {
 
 	var filter = bson.D{
 		{"player_id", playerID},
@@ -117,7 +74,7 @@ func FindLatestPlayerAchievement(playerID int64, appID int) (int64, error) {
 
 	var playerAchievement PlayerAchievement
 
-	err := FindOne(CollectionPlayerAchievements, filter, bson.D{{"achievement_date", -1}}, bson.M{"achievement_date": 1}, &playerAchievement)
+	err := FindOne(ctx, CollectionPlayerAchievements, filter, bson.D{{"achievement_date", -1}}, bson.M{"achievement_date": 1}, &playerAchievement)
 	err = helpers.IgnoreErrors(err, ErrNoDocuments)
 
 	return playerAchievement.AchievementDate, err
@@ -172,7 +129,9 @@ func GetPlayerAchievementsByPlayersAndApp(playerIDs []int64, appID int) (achieve
 
 func getPlayerAchievements(offset int64, limit int64, filter bson.D, sort bson.D, ops *options.FindOptions) (achievements []PlayerAchievement, err error) {
 
-	cur, ctx, err := find(CollectionPlayerAchievements, offset, limit, filter, sort, nil, ops)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPlayerAchievements, offset, limit, filter, sort, nil, ops)
 	if err != nil {
 		return achievements, err
 	}
@@ -249,13 +208,13 @@ func GetPlayerAchievementDays(playerID int64) (counts []DateCount, err error) {
 	return counts, err
 }
 
-func ReplacePlayerAchievements(achievements []PlayerAchievement) (err error) {
+func ReplacePlayerAchievements(ctx context.Context, achievements []PlayerAchievement) (err error) {
 
 	if len(achievements) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -36,13 +37,13 @@ func (a PlayerAlias) GetTime() string {
 	return time.Unix(a.Time, 0).Format(helpers.DateYearTime)
 }
 
-func ReplacePlayerAliases(aliases []PlayerAlias) (err error) {
+func ReplacePlayerAliases(ctx context.Context, aliases []PlayerAlias) (err error) {
 
 	if len(aliases) == 0 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -65,14 +66,14 @@ func ReplacePlayerAliases(aliases []PlayerAlias) (err error) {
 	return err
 }
 
-func GetPlayerAliases(playerID int64, limit int64, afterTimestamp int64) (aliases []PlayerAlias, err error) {
+func GetPlayerAliases(ctx context.Context, playerID int64, limit int64, afterTimestamp int64) (aliases []PlayerAlias, err error) {
 
 	filter := bson.D{
 		{"player_id", playerID},
 		{"time", bson.M{"$gt": afterTimestamp}},
 	}
 
-	cur, ctx, err := find(CollectionPlayerAliases, 0, limit, filter, bson.D{{"time", -1}}, nil, nil)
+	cur, err := find(ctx, CollectionPlayerAliases, 0, limit, filter, bson.D{{"time", -1}}, nil, nil)
 	if err != nil {
 		return aliases, err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"math"
 	"sort"
 	"strconv"
@@ -123,26 +124,6 @@ func (app PlayerApp) GetAchievementPercent() string {
 	return helpers.GetAchievementCompleted(app.AppAchievementsPercent)
 }
 
-func ensurePlayerAppIndexes() {
-
-	var indexModels = []mongo.IndexModel{
-		{Keys: bson.D{{"app_id", 1}, {"app_time", -1}, {"player_country", 1}}},
-		{Keys: bson.D{{"player_id", 1}, {"app_achievements_have", 1}}},
-	}
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionGroups.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
 func GetAchievmentCounts(appID int) (counts []Count, err error) {
 
 	item := memcache.ItemAppAchievementsCounts(appID)
@@ -207,7 +188,7 @@ func GetPlayerAppByKey(playerID int64, appID int) (playerApp PlayerApp, err erro
 	playerApp.PlayerID = playerID
 	playerApp.AppID = appID
 
-	err = FindOne(CollectionPlayerApps, bson.D{{"_id", playerApp.GetKey()}}, nil, nil, &playerApp)
+	err = FindOne(context.TODO(), CollectionPlayerApps, bson.D{{"_id", playerApp.GetKey()}}, nil, nil, &playerApp)
 
 	return playerApp, err
 }
@@ -217,7 +198,7 @@ func GetPlayerApps(offset int64, limit int64, filter bson.D, sort bson.D) (apps
 	return getPlayerApps(offset, limit, filter, sort, nil, nil)
 }
 
-func GetPlayerAppsByPlayers(playerIDs []int64, projection bson.M) (apps []PlayerApp, err error) {
+func GetPlayerAppsByPlayers(ctx context.Context, playerIDs []int64, projection bson.M) (apps []PlayerApp, err error) {
 
 	if len(playerIDs) < 1 {
 		return apps, err
@@ -250,19 +231,21 @@ func GetPlayerAppsByPlayersAndApp(playerIDs []int64, appID int) (apps []PlayerAp
 	return getPlayerApps(0, 0, filter, nil, bson.M{"player_id": 1}, nil)
 }
 
-func GetAppPlayTimes(appID int) ([]PlayerApp, error) {
+func GetAppPlayTimes(ctx context.Context, appID int) ([]PlayerApp, error) {
 
 	return getPlayerApps(0, 0, bson.D{{"app_id", appID}}, nil, bson.M{"_id": 0, "app_time": 1}, nil)
 }
 
-func GetAppOwners(appID int) ([]PlayerApp, error) {
+func GetAppOwners(ctx context.Context, appID int) ([]PlayerApp, error) {
 
 	return getPlayerApps(0, 0, bson.D{{"app_id", appID}}, nil, bson.M{"_id": 0, "player_id": 1}, nil)
 }
 
 func getPlayerApps(offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M, ops *options.FindOptions) (apps []PlayerApp, err error) {
 
-	cur, ctx, err := find(CollectionPlayerApps, offset, limit, filter, sort, projection, ops)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPlayerApps, offset, limit, filter, sort, projection, ops)
 	if err != nil {
 		return apps, err
 	}
@@ -283,13 +266,13 @@ func getPlayerApps(offset int64, limit int64, filter bson.D, sort bson.D, projec
 	return apps, cur.Err()
 }
 
-func UpdatePlayerApps(apps map[int]*PlayerApp) (err error) {
+func UpdatePlayerApps(ctx context.Context, apps map[int]*PlayerApp) (err error) {
 
 	if len(apps) == 0 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -313,9 +296,9 @@ func UpdatePlayerApps(apps map[int]*PlayerApp) (err error) {
 	return err
 }
 
-func GetAppPlayersByCountry(appID int) (items []PlayerAppsByCountry, err error) {
+func GetAppPlayersByCountry(ctx context.Context, appID int) (items []PlayerAppsByCountry, err error) {
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return items, err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gamedb/gamedb/pkg/config"
@@ -46,13 +47,13 @@ func (app PlayerRecentApp) GetIcon() string {
 	return helpers.GetAppIcon(app.AppID, app.Icon)
 }
 
-func DeleteRecentApps(playerID int64, apps []int) (err error) {
+func DeleteRecentApps(ctx context.Context, playerID int64, apps []int) (err error) {
 
 	if len(apps) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -72,13 +73,13 @@ func DeleteRecentApps(playerID int64, apps []int) (err error) {
 	return err
 }
 
-func ReplaceRecentApps(apps []PlayerRecentApp) (err error) {
+func ReplaceRecentApps(ctx context.Context, apps []PlayerRecentApp) (err error) {
 
 	if len(apps) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -99,11 +100,11 @@ func ReplaceRecentApps(apps []PlayerRecentApp) (err error) {
 	return err
 }
 
-func GetRecentApps(playerID int64, offset int64, limit int64, sort bson.D) (apps []PlayerRecentApp, err error) {
+func GetRecentApps(ctx context.Context, playerID int64, offset int64, limit int64, sort bson.D) (apps []PlayerRecentApp, err error) {
 
 	filter := bson.D{{"player_id", playerID}}
 
-	cur, ctx, err := find(CollectionPlayerAppsRecent, offset, limit, filter, sort, nil, nil)
+	cur, err := find(ctx, CollectionPlayerAppsRecent, offset, limit, filter, sort, nil, nil)
 	if err != nil {
 		return apps, err
 	}
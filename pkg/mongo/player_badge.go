@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -130,13 +131,13 @@ func (badge PlayerBadge) GetType() string {
 	}
 }
 
-func ReplacePlayerBadges(badges []PlayerBadge) (err error) {
+func ReplacePlayerBadges(ctx context.Context, badges []PlayerBadge) (err error) {
 
 	if len(badges) == 0 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -190,7 +191,9 @@ func GetBadgePlayers(offset int64, filter bson.D) (badges []PlayerBadge, err err
 
 func getPlayerBadges(offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M) (badges []PlayerBadge, err error) {
 
-	cur, ctx, err := find(CollectionPlayerBadges, offset, limit, filter, sort, projection, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPlayerBadges, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return badges, err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"html/template"
 	"strconv"
 	"time"
@@ -116,7 +117,9 @@ func GetBadgeSummaries() (badges []PlayerBadgeSummary, err error) {
 	// Filter to remove game badges that are no longer popular
 	filter := bson.D{{"updated_at", bson.M{"$gte": time.Now().Add(time.Hour * 24 * 7 * -1).Unix()}}}
 
-	cur, ctx, err := find(CollectionPlayerBadgesSummary, 0, 0, filter, nil, nil, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPlayerBadgesSummary, 0, 0, filter, nil, nil, nil)
 	if err != nil {
 		return badges, err
 	}
@@ -155,7 +158,7 @@ func UpdateBadgeSummary(id int) (err error) {
 			summary.AppIcon = badge.GetIcon()
 		} else {
 
-			app, err := GetApp(id)
+			app, err := GetApp(context.TODO(), id)
 			if err != nil {
 				return err
 			}
@@ -174,7 +177,7 @@ func UpdateBadgeSummary(id int) (err error) {
 	if badge.IsSpecial() {
 
 		// Get the top player badge
-		err = FindOne(
+		err = FindOne(context.TODO(),
 			CollectionPlayerBadges,
 			bson.D{{"app_id", 0}, {"badge_id", badge.BadgeID}},
 			bson.D{{"badge_level", -1}, {"badge_completion_time", 1}},
@@ -206,7 +209,7 @@ func UpdateBadgeSummary(id int) (err error) {
 			}
 
 			// Get number of players with badge
-			summary.PlayersCount, err = CountDocuments(CollectionPlayerBadges, bson.D{{"app_id", 0}, {"badge_id", badge.BadgeID}}, 0)
+			summary.PlayersCount, err = CountDocuments(context.TODO(), CollectionPlayerBadges, bson.D{{"app_id", 0}, {"badge_id", badge.BadgeID}}, 0)
 			if err != nil {
 				return err
 			}
@@ -215,7 +218,7 @@ func UpdateBadgeSummary(id int) (err error) {
 	} else {
 
 		// Get the top player badge, foil=false
-		err = FindOne(
+		err = FindOne(context.TODO(),
 			CollectionPlayerBadges,
 			bson.D{{"app_id", badge.AppID}, {"badge_id", bson.M{"$gt": 0}}, {"badge_foil", false}},
 			bson.D{{"badge_level", -1}, {"badge_completion_time", 1}},
@@ -231,7 +234,7 @@ func UpdateBadgeSummary(id int) (err error) {
 		}
 
 		// Get the top player badge, foil=true
-		err = FindOne(
+		err = FindOne(context.TODO(),
 			CollectionPlayerBadges,
 			bson.D{{"app_id", badge.AppID}, {"badge_id", bson.M{"$gt": 0}}, {"badge_foil", true}},
 			bson.D{{"badge_level", -1}, {"badge_completion_time", 1}},
@@ -247,12 +250,12 @@ func UpdateBadgeSummary(id int) (err error) {
 		}
 
 		// Get number of players with badge
-		summary.PlayersCount, err = CountDocuments(CollectionPlayerBadges, bson.D{{"app_id", badge.AppID}, {"badge_id", bson.M{"$gt": 0}}}, 0)
+		summary.PlayersCount, err = CountDocuments(context.TODO(), CollectionPlayerBadges, bson.D{{"app_id", badge.AppID}, {"badge_id", bson.M{"$gt": 0}}}, 0)
 		if err != nil {
 			return err
 		}
 	}
 
-	_, err = ReplaceOne(CollectionPlayerBadgesSummary, bson.D{{"_id", summary.getKey()}}, summary)
+	_, err = ReplaceOne(context.TODO(), CollectionPlayerBadgesSummary, bson.D{{"_id", summary.getKey()}}, summary)
 	return err
 }
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -22,6 +23,7 @@ type PlayerFriend struct {
 	Games        int       `bson:"games"`        //
 	Level        int       `bson:"level"`        //
 	Relationship string    `bson:"relationship"` // From Steam API
+	Hidden       bool      `bson:"hidden"`       // The friend hides from friends lists
 }
 
 func (friend PlayerFriend) BSON() bson.D {
@@ -34,6 +36,7 @@ func (friend PlayerFriend) BSON() bson.D {
 		{"name", friend.Name},
 		{"games", friend.Games},
 		{"level", friend.Level},
+		{"hidden", friend.Hidden},
 	}
 }
 
@@ -76,50 +79,20 @@ func (friend PlayerFriend) CommunityLink() string {
 	return helpers.GetPlayerCommunityLink(friend.FriendID)
 }
 
-func ensurePlayerFriendIndexes() {
-
-	var indexModels = []mongo.IndexModel{
-		{
-			Keys: bson.D{{"player_id", 1}, {"name", 1}},
-			Options: options.Index().SetCollation(&options.Collation{
-				Locale:   "en",
-				Strength: 2, // Case insensitive
-			}),
-		},
-		{
-			Keys: bson.D{{"friend_id", 1}},
-			Options: options.Index().SetCollation(&options.Collation{
-				Locale:   "en",
-				Strength: 2, // Case insensitive
-			}),
-		},
-	}
+func CountFriends(playerID int64, filter bson.D) (count int64, err error) {
 
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionPlayerFriends.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
-func CountFriends(playerID int64) (count int64, err error) {
+	filter = append(bson.D{{"player_id", playerID}}, filter...)
 
-	return CountDocuments(CollectionPlayerFriends, bson.D{{"player_id", playerID}}, 0)
+	return CountDocuments(context.TODO(), CollectionPlayerFriends, filter, 0)
 }
 
-func DeleteFriends(playerID int64, friends []int64) (err error) {
+func DeleteFriends(ctx context.Context, playerID int64, friends []int64) (err error) {
 
 	if len(friends) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -139,13 +112,13 @@ func DeleteFriends(playerID int64, friends []int64) (err error) {
 	return err
 }
 
-func ReplacePlayerFriends(friends []*PlayerFriend) (err error) {
+func ReplacePlayerFriends(ctx context.Context, friends []*PlayerFriend) (err error) {
 
 	if len(friends) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -166,11 +139,11 @@ func ReplacePlayerFriends(friends []*PlayerFriend) (err error) {
 	return err
 }
 
-func GetFriends(playerID int64, offset int64, limit int64, sort bson.D, filter bson.D) (friends []PlayerFriend, err error) {
+func GetFriends(ctx context.Context, playerID int64, offset int64, limit int64, sort bson.D, filter bson.D) (friends []PlayerFriend, err error) {
 
 	filter = append(bson.D{{"player_id", playerID}}, filter...)
 
-	cur, ctx, err := find(CollectionPlayerFriends, offset, limit, filter, sort, nil, nil)
+	cur, err := find(ctx, CollectionPlayerFriends, offset, limit, filter, sort, nil, nil)
 	if err != nil {
 		return friends, err
 	}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gamedb/gamedb/pkg/config"
@@ -102,13 +103,13 @@ func (group PlayerGroup) GetPlayerFlag() string {
 	return helpers.GetPlayerFlagPath(group.PlayerCountry)
 }
 
-func ReplacePlayerGroups(groups []PlayerGroup) (err error) {
+func ReplacePlayerGroups(ctx context.Context, groups []PlayerGroup) (err error) {
 
 	if len(groups) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -129,13 +130,13 @@ func ReplacePlayerGroups(groups []PlayerGroup) (err error) {
 	return err
 }
 
-func DeletePlayerGroups(playerID int64, groupIDs []string) (err error) {
+func DeletePlayerGroups(ctx context.Context, playerID int64, groupIDs []string) (err error) {
 
 	if len(groupIDs) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -155,7 +156,7 @@ func DeletePlayerGroups(playerID int64, groupIDs []string) (err error) {
 	return err
 }
 
-func GetPlayerGroups(playerID int64, offset int64, limit int64, sort bson.D) (groups []PlayerGroup, err error) {
+func GetPlayerGroups(ctx context.Context, playerID int64, offset int64, limit int64, sort bson.D) (groups []PlayerGroup, err error) {
 
 	var filter = bson.D{{"player_id", playerID}}
 
@@ -171,7 +172,9 @@ func GetGroupPlayers(groupID string, offset int64, order bson.D) (players []Play
 
 func getPlayerGroups(offset int64, limit int64, filter bson.D, sort bson.D) (players []PlayerGroup, err error) {
 
-	cur, ctx, err := find(CollectionPlayerGroups, offset, limit, filter, sort, nil, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPlayerGroups, offset, limit, filter, sort, nil, nil)
 	if err != nil {
 		return players, err
 	}
@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Apps a player does not want to be recommended
+type PlayerIgnoredApp struct {
+	PlayerID  int64     `bson:"player_id"`
+	AppID     int       `bson:"app_id"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (app PlayerIgnoredApp) BSON() bson.D {
+
+	return bson.D{
+		{"_id", app.getKey()},
+		{"player_id", app.PlayerID},
+		{"app_id", app.AppID},
+		{"created_at", app.CreatedAt},
+	}
+}
+
+func (app PlayerIgnoredApp) getKey() string {
+	return strconv.FormatInt(app.PlayerID, 10) + "-" + strconv.Itoa(app.AppID)
+}
+
+func IgnorePlayerApp(playerID int64, appID int) (err error) {
+
+	app := PlayerIgnoredApp{
+		PlayerID:  playerID,
+		AppID:     appID,
+		CreatedAt: time.Now(),
+	}
+
+	_, err = ReplaceOne(context.TODO(), CollectionPlayerIgnoredApps, bson.D{{"_id", app.getKey()}}, app)
+	if err != nil {
+		return err
+	}
+
+	return clearPlayerRecommendations(playerID)
+}
+
+func UnignorePlayerApp(playerID int64, appID int) (err error) {
+
+	app := PlayerIgnoredApp{PlayerID: playerID, AppID: appID}
+
+	_, err = DeleteMany(context.TODO(), CollectionPlayerIgnoredApps, bson.D{{"_id", app.getKey()}})
+	if err != nil {
+		return err
+	}
+
+	return clearPlayerRecommendations(playerID)
+}
+
+func GetPlayerIgnoredApps(playerID int64) (apps []PlayerIgnoredApp, err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPlayerIgnoredApps, 0, 0, bson.D{{"player_id", playerID}}, bson.D{{"created_at", -1}}, nil, nil)
+	if err != nil {
+		return apps, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var app PlayerIgnoredApp
+		err := cur.Decode(&app)
+		if err != nil {
+			log.ErrS(err, app.getKey())
+		} else {
+			apps = append(apps, app)
+		}
+	}
+
+	return apps, cur.Err()
+}
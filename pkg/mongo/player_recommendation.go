@@ -0,0 +1,362 @@
+package mongo
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Weights for each signal, should add up to 1
+const (
+	recommendWeightTags       = 0.45
+	recommendWeightFriends    = 0.20
+	recommendWeightSameOwners = 0.20
+	recommendWeightReviews    = 0.15
+
+	recommendTopApps    = 200 // Most played apps used for tag affinity
+	recommendTopTags    = 10  // Tags used to find candidates
+	recommendSeedApps   = 10  // Most played apps used for co-ownership
+	recommendCandidates = 500
+	recommendFriends    = 100
+	recommendKeep       = 100
+)
+
+type PlayerRecommendation struct {
+	AppID      int
+	AppName    string
+	AppIcon    string
+	Score      float64 // 0-1
+	Tags       int     // Tags matching the player's top tags
+	Friends    int     // Friends that own the app
+	SameOwners int     // Co-ownership with the player's most played apps
+}
+
+func (rec PlayerRecommendation) GetName() string {
+	return helpers.GetAppName(rec.AppID, rec.AppName)
+}
+
+func (rec PlayerRecommendation) GetPath() string {
+	return helpers.GetAppPath(rec.AppID, rec.AppName)
+}
+
+func (rec PlayerRecommendation) GetPathAbsolute() string {
+	return helpers.GetAppPathAbsolute(rec.AppID, rec.AppName)
+}
+
+func (rec PlayerRecommendation) GetIcon() string {
+	return helpers.GetAppIcon(rec.AppID, rec.AppIcon)
+}
+
+func (rec PlayerRecommendation) GetHeaderImage() string {
+	return App{ID: rec.AppID}.GetHeaderImage()
+}
+
+func (rec PlayerRecommendation) GetScore() string {
+	return helpers.FloatToString(rec.Score*100, 1) + "%"
+}
+
+func clearPlayerRecommendations(playerID int64) error {
+	return memcache.Client().Delete(memcache.ItemPlayerRecommendations(playerID).Key)
+}
+
+func GetPlayerRecommendations(playerID int64, limit int) (recs []PlayerRecommendation, err error) {
+
+	item := memcache.ItemPlayerRecommendations(playerID)
+	err = memcache.Client().GetSet(item.Key, item.Expiration, &recs, func() (interface{}, error) {
+		return getPlayerRecommendations(playerID)
+	})
+
+	if limit > 0 && len(recs) > limit {
+		recs = recs[0:limit]
+	}
+
+	return recs, err
+}
+
+func getPlayerRecommendations(playerID int64) (recs []PlayerRecommendation, err error) {
+
+	// Apps to exclude
+	var exclude = map[int]bool{}
+
+	owned, err := GetPlayerAppsByPlayer(playerID, 0, 0, bson.D{{"app_time", -1}}, bson.M{"_id": 0, "app_id": 1, "app_time": 1}, nil)
+	if err != nil {
+		return recs, err
+	}
+
+	for _, v := range owned {
+		exclude[v.AppID] = true
+	}
+
+	ignored, err := GetPlayerIgnoredApps(playerID)
+	if err != nil {
+		return recs, err
+	}
+
+	for _, v := range ignored {
+		exclude[v.AppID] = true
+	}
+
+	// Already on the player's radar, but still counts towards tag affinity
+	wishlist, err := GetPlayerWishlistAppsByPlayer(context.TODO(), playerID, 0, 0, nil, bson.M{"_id": 0, "app_id": 1})
+	if err != nil {
+		return recs, err
+	}
+
+	var affinityWeights = map[int]float64{}
+	for _, v := range wishlist {
+		exclude[v.AppID] = true
+		affinityWeights[v.AppID] = 1
+	}
+
+	// Tag affinity, weighted by hours played
+	if len(owned) > recommendTopApps {
+		owned = owned[0:recommendTopApps]
+	}
+
+	var seedApps []int
+	for k, v := range owned {
+		affinityWeights[v.AppID] = math.Max(affinityWeights[v.AppID], math.Log1p(float64(v.AppTime)/60))
+		if k < recommendSeedApps && v.AppTime > 0 {
+			seedApps = append(seedApps, v.AppID)
+		}
+	}
+
+	var affinityIDs []int
+	for k := range affinityWeights {
+		affinityIDs = append(affinityIDs, k)
+	}
+
+	affinityApps, err := GetAppsByID(context.TODO(), affinityIDs, bson.M{"_id": 1, "tags": 1})
+	if err != nil {
+		return recs, err
+	}
+
+	var affinity = map[int]float64{}
+	for _, app := range affinityApps {
+		for _, tag := range app.Tags {
+			affinity[tag] += affinityWeights[app.ID]
+		}
+	}
+
+	var topTags []int
+	for k, v := range affinity {
+		if v > 0 {
+			topTags = append(topTags, k)
+		}
+	}
+
+	sort.Slice(topTags, func(i, j int) bool {
+		return affinity[topTags[i]] > affinity[topTags[j]]
+	})
+
+	if len(topTags) > recommendTopTags {
+		topTags = topTags[0:recommendTopTags]
+	}
+
+	var affinityMagnitude float64
+	for _, v := range affinity {
+		affinityMagnitude += v * v
+	}
+	affinityMagnitude = math.Sqrt(affinityMagnitude)
+
+	// Candidates
+	var projection = bson.M{"_id": 1, "name": 1, "icon": 1, "type": 1, "tags": 1, "reviews_score": 1}
+	var candidates = map[int]App{}
+
+	if len(topTags) > 0 {
+
+		filter := bson.D{
+			{"type", "game"},
+			{"tags", bson.M{"$in": topTags}},
+		}
+
+		apps, err := GetApps(context.TODO(), 0, recommendCandidates, bson.D{{"player_peak_week", -1}}, filter, projection)
+		if err != nil {
+			return recs, err
+		}
+
+		for _, v := range apps {
+			if !exclude[v.ID] {
+				candidates[v.ID] = v
+			}
+		}
+	}
+
+	// Co-ownership with most played apps
+	var sameOwners = map[int]float64{}
+	var sameOwnersCounts = map[int]int{}
+	var sameOwnersMax float64
+
+	for _, appID := range seedApps {
+
+		rows, err := GetAppSameOwners(context.TODO(), appID, recommendKeep)
+		if err != nil {
+			return recs, err
+		}
+
+		for _, v := range rows {
+			if !exclude[v.SameAppID] {
+				sameOwners[v.SameAppID] += v.Order
+				sameOwnersCounts[v.SameAppID] += v.Count
+				sameOwnersMax = math.Max(sameOwnersMax, sameOwners[v.SameAppID])
+			}
+		}
+	}
+
+	// Friend ownership
+	friends, err := getRecommendationFriendApps(playerID, exclude)
+	if err != nil {
+		return recs, err
+	}
+
+	var friendsMax int
+	for _, v := range friends {
+		if v > friendsMax {
+			friendsMax = v
+		}
+	}
+
+	// Load any candidates only found through owners
+	var missing []int
+	for k := range sameOwners {
+		if _, ok := candidates[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	for k := range friends {
+		if _, ok := candidates[k]; !ok && !helpers.SliceHasInt(missing, k) {
+			missing = append(missing, k)
+		}
+	}
+
+	apps, err := GetAppsByID(context.TODO(), missing, projection)
+	if err != nil {
+		return recs, err
+	}
+
+	for _, v := range apps {
+		if v.Type == "game" {
+			candidates[v.ID] = v
+		}
+	}
+
+	// Score
+	for _, app := range candidates {
+
+		rec := PlayerRecommendation{
+			AppID:      app.ID,
+			AppName:    app.Name,
+			AppIcon:    app.Icon,
+			Friends:    friends[app.ID],
+			SameOwners: sameOwnersCounts[app.ID],
+		}
+
+		// Cosine similarity between the player's affinity and the app's tags
+		var tagsScore float64
+		if len(app.Tags) > 0 && affinityMagnitude > 0 {
+
+			var dot float64
+			for _, tag := range helpers.UniqueInt(app.Tags) {
+				dot += affinity[tag]
+				if helpers.SliceHasInt(topTags, tag) {
+					rec.Tags++
+				}
+			}
+
+			tagsScore = dot / (affinityMagnitude * math.Sqrt(float64(len(helpers.UniqueInt(app.Tags)))))
+		}
+
+		var friendsScore float64
+		if friendsMax > 0 {
+			friendsScore = float64(rec.Friends) / float64(friendsMax)
+		}
+
+		var sameOwnersScore float64
+		if sameOwnersMax > 0 {
+			sameOwnersScore = sameOwners[app.ID] / sameOwnersMax
+		}
+
+		rec.Score = (tagsScore * recommendWeightTags) +
+			(friendsScore * recommendWeightFriends) +
+			(sameOwnersScore * recommendWeightSameOwners) +
+			((app.ReviewsScore / 100) * recommendWeightReviews)
+
+		if rec.Score > 0 {
+			recs = append(recs, rec)
+		}
+	}
+
+	sort.Slice(recs, func(i, j int) bool {
+		return recs[i].Score > recs[j].Score
+	})
+
+	if len(recs) > recommendKeep {
+		recs = recs[0:recommendKeep]
+	}
+
+	return recs, nil
+}
+
+// Returns a count of friends owning each app
+func getRecommendationFriendApps(playerID int64, exclude map[int]bool) (counts map[int]int, err error) {
+
+	counts = map[int]int{}
+
+	friends, err := GetFriends(context.TODO(), playerID, 0, recommendFriends, bson.D{{"level", -1}}, nil)
+	if err != nil || len(friends) == 0 {
+		return counts, err
+	}
+
+	var friendIDs = bson.A{}
+	for _, v := range friends {
+		friendIDs = append(friendIDs, v.FriendID)
+	}
+
+	client, ctx, err := getMongo()
+	if err != nil {
+		return counts, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"player_id": bson.M{"$in": friendIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$app_id", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.M{"count": -1}}},
+		{{Key: "$limit", Value: recommendCandidates}},
+	}
+
+	cur, err := client.Database(config.C.MongoDatabase, options.Database()).Collection(CollectionPlayerApps.String()).Aggregate(ctx, pipeline, options.Aggregate())
+	if err != nil {
+		return counts, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var row struct {
+			AppID int `bson:"_id"`
+			Count int `bson:"count"`
+		}
+
+		err := cur.Decode(&row)
+		if err != nil {
+			log.Err(err.Error(), zap.Int64("player", playerID))
+			continue
+		}
+
+		if !exclude[row.AppID] {
+			counts[row.AppID] = row.Count
+		}
+	}
+
+	return counts, cur.Err()
+}
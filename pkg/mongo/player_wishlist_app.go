@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -69,13 +70,13 @@ func (app PlayerWishlistApp) GetReleaseDateNice() string {
 	return helpers.GetAppReleaseDateNice(0, app.AppReleaseDate.Unix(), app.AppReleaseDateNice)
 }
 
-func ReplacePlayerWishlistApps(apps []PlayerWishlistApp) (err error) {
+func ReplacePlayerWishlistApps(ctx context.Context, apps []PlayerWishlistApp) (err error) {
 
 	if len(apps) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -96,13 +97,13 @@ func ReplacePlayerWishlistApps(apps []PlayerWishlistApp) (err error) {
 	return err
 }
 
-func DeletePlayerWishlistApps(playerID int64, apps []int) (err error) {
+func DeletePlayerWishlistApps(ctx context.Context, playerID int64, apps []int) (err error) {
 
 	if len(apps) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -122,19 +123,21 @@ func DeletePlayerWishlistApps(playerID int64, apps []int) (err error) {
 	return err
 }
 
-func GetPlayerWishlistAppsByApp(appID int) (apps []PlayerWishlistApp, err error) {
+func GetPlayerWishlistAppsByApp(ctx context.Context, appID int) (apps []PlayerWishlistApp, err error) {
 
 	return getPlayerWishlistApps(0, 0, bson.D{{"app_id", appID}}, nil, bson.M{"order": 1})
 }
 
-func GetPlayerWishlistAppsByPlayer(playerID int64, offset int64, limit int64, order bson.D, projection bson.M) (apps []PlayerWishlistApp, err error) {
+func GetPlayerWishlistAppsByPlayer(ctx context.Context, playerID int64, offset int64, limit int64, order bson.D, projection bson.M) (apps []PlayerWishlistApp, err error) {
 
 	return getPlayerWishlistApps(offset, limit, bson.D{{"player_id", playerID}}, order, projection)
 }
 
 func getPlayerWishlistApps(offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M) (apps []PlayerWishlistApp, err error) {
 
-	cur, ctx, err := find(CollectionPlayerWishlistApps, offset, limit, filter, sort, projection, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionPlayerWishlistApps, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return apps, err
 	}
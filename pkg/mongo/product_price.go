@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"errors"
 	"math"
 	"strings"
@@ -96,7 +97,7 @@ func (price ProductPrice) OutputForJSON() (output []interface{}) {
 	}
 }
 
-func GetPricesByID(IDs []string) (prices []ProductPrice, err error) {
+func GetPricesByID(ctx context.Context, IDs []string) (prices []ProductPrice, err error) {
 
 	if IDs == nil || len(IDs) < 1 {
 		return prices, nil
@@ -150,6 +151,70 @@ func GetPricesForApps(appIDs []int, cc steamapi.ProductCC) (prices []ProductPric
 	return getProductPrices(filter, 0, 0, bson.D{{"created_at", 1}})
 }
 
+// GetPriceStats builds the price statistics for each region in prices from the product's price history
+func GetPriceStats(ctx context.Context, productID int, productType helpers.ProductType, prices helpers.ProductPrices) (stats helpers.ProductPriceStats, err error) {
+
+	stats = helpers.ProductPriceStats{}
+
+	if len(prices) == 0 {
+		return stats, nil
+	}
+
+	var ccs bson.A
+	for k := range prices {
+		ccs = append(ccs, string(k))
+	}
+
+	var filter = bson.D{{"prod_cc", bson.M{"$in": ccs}}}
+
+	if productType == helpers.ProductTypeApp {
+		filter = append(filter, bson.E{Key: "app_id", Value: productID})
+	} else if productType == helpers.ProductTypePackage {
+		filter = append(filter, bson.E{Key: "package_id", Value: productID})
+	} else {
+		return stats, errors.New("invalid product type")
+	}
+
+	history, err := getProductPrices(filter, 0, 0, bson.D{{"created_at", 1}})
+	if err != nil {
+		return stats, err
+	}
+
+	lows, err := GetProductPriceLows(filter)
+	if err != nil {
+		return stats, err
+	}
+
+	var changes = map[steamapi.ProductCC][]helpers.ProductPriceChange{}
+
+	// History older than two years has been archived, its lowest price goes first
+	for _, v := range lows {
+		changes[v.ProdCC] = append(changes[v.ProdCC], helpers.ProductPriceChange{
+			Time:   v.CreatedAt,
+			Before: v.Price,
+			After:  v.Price,
+		})
+	}
+
+	for _, v := range history {
+		changes[v.ProdCC] = append(changes[v.ProdCC], helpers.ProductPriceChange{
+			Time:   v.CreatedAt,
+			Before: v.PriceBefore,
+			After:  v.PriceAfter,
+		})
+	}
+
+	for k := range prices {
+
+		stat := helpers.NewProductPriceStat(prices.Get(k), changes[k])
+		if stat.Exists() {
+			stats[k] = stat
+		}
+	}
+
+	return stats, nil
+}
+
 func GetPrices(offset int64, limit int64, filter bson.D) (prices []ProductPrice, err error) {
 
 	return getProductPrices(filter, offset, limit, bson.D{{"created_at", -1}})
@@ -157,7 +222,9 @@ func GetPrices(offset int64, limit int64, filter bson.D) (prices []ProductPrice,
 
 func getProductPrices(filter bson.D, offset int64, limit int64, sort bson.D) (prices []ProductPrice, err error) {
 
-	cur, ctx, err := find(CollectionProductPrices, offset, limit, filter, sort, nil, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionProductPrices, offset, limit, filter, sort, nil, nil)
 	if err != nil {
 		return prices, err
 	}
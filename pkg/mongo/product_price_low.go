@@ -0,0 +1,140 @@
+package mongo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Jleagle/steam-go/steamapi"
+	"github.com/gamedb/gamedb/pkg/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The lowest price in the product_prices rows that have been archived and pruned
+type ProductPriceLow struct {
+	AppID     int                `bson:"app_id"`
+	PackageID int                `bson:"package_id"`
+	ProdCC    steamapi.ProductCC `bson:"prod_cc"`
+	Price     int                `bson:"price"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+func (low ProductPriceLow) BSON() bson.D {
+
+	return bson.D{
+		{"_id", low.GetKey()},
+		{"app_id", low.AppID},
+		{"package_id", low.PackageID},
+		{"prod_cc", low.ProdCC},
+		{"price", low.Price},
+		{"created_at", low.CreatedAt},
+	}
+}
+
+func (low ProductPriceLow) GetKey() string {
+	return strconv.Itoa(low.AppID) + "-" + strconv.Itoa(low.PackageID) + "-" + string(low.ProdCC)
+}
+
+func (low ProductPriceLow) lowerThan(other ProductPriceLow) bool {
+	return low.Price < other.Price || (low.Price == other.Price && low.CreatedAt.Before(other.CreatedAt))
+}
+
+func GetProductPriceLows(filter bson.D) (lows []ProductPriceLow, err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionProductPriceLows, 0, 0, filter, nil, nil, nil)
+	if err != nil {
+		return lows, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var low ProductPriceLow
+		err := cur.Decode(&low)
+		if err != nil {
+			return lows, err
+		}
+
+		lows = append(lows, low)
+	}
+
+	return lows, cur.Err()
+}
+
+// SaveProductPriceLows keeps the lowest prices from a range of product_prices, before it is pruned
+func SaveProductPriceLows(from time.Time, to time.Time) (err error) {
+
+	prices, err := getProductPrices(bson.D{{"created_at", bson.M{"$gte": from, "$lt": to}}}, 0, 0, bson.D{{"created_at", 1}})
+	if err != nil {
+		return err
+	}
+
+	var lows = map[string]ProductPriceLow{}
+	for _, price := range prices {
+
+		// Zero prices are usually products being removed from the store
+		for _, v := range []int{price.PriceBefore, price.PriceAfter} {
+
+			if v <= 0 {
+				continue
+			}
+
+			low := ProductPriceLow{AppID: price.AppID, PackageID: price.PackageID, ProdCC: price.ProdCC, Price: v, CreatedAt: price.CreatedAt}
+
+			if existing, ok := lows[low.GetKey()]; !ok || low.lowerThan(existing) {
+				lows[low.GetKey()] = low
+			}
+		}
+	}
+
+	if len(lows) == 0 {
+		return nil
+	}
+
+	var keys bson.A
+	for k := range lows {
+		keys = append(keys, k)
+	}
+
+	existing, err := GetProductPriceLows(bson.D{{"_id", bson.M{"$in": keys}}})
+	if err != nil {
+		return err
+	}
+
+	for _, v := range existing {
+		if !lows[v.GetKey()].lowerThan(v) {
+			delete(lows, v.GetKey())
+		}
+	}
+
+	if len(lows) == 0 {
+		return nil
+	}
+
+	client, ctx, err := getMongo()
+	if err != nil {
+		return err
+	}
+
+	var writes []mongo.WriteModel
+	for _, low := range lows {
+
+		write := mongo.NewReplaceOneModel()
+		write.SetFilter(bson.M{"_id": low.GetKey()})
+		write.SetReplacement(low.BSON())
+		write.SetUpsert(true)
+
+		writes = append(writes, write)
+	}
+
+	c := client.Database(config.C.MongoDatabase).Collection(CollectionProductPriceLows.String())
+
+	_, err = c.BulkWrite(ctx, writes, options.BulkWrite())
+
+	return err
+}
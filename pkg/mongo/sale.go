@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"sort"
 	"strconv"
 	"strings"
@@ -52,6 +53,7 @@ func (sale Sale) BSON() bson.D {
 		{"app_icon", sale.AppIcon},
 		{"app_rating", sale.AppRating},
 		{"app_date", sale.AppReleaseDate},
+		{"app_date_string", sale.AppReleaseDateString},
 		{"app_prices", sale.AppPrices},
 		{"app_lowest_price", sale.AppLowestPrice},
 		{"app_players", sale.AppPlayersWeek},
@@ -68,46 +70,6 @@ func (sale Sale) BSON() bson.D {
 	}
 }
 
-func ensureSaleIndexes() {
-
-	var indexModels []mongo.IndexModel
-
-	cols := []string{
-		"app_date",
-		"app_rating",
-		"offer_end",
-		"offer_name",
-		"offer_percent",
-	}
-
-	// Price fields
-	for _, v := range i18n.GetProdCCs(true) {
-		cols = append(cols, "app_prices."+string(v.ProductCode))
-		cols = append(cols, "app_lowest_price."+string(v.ProductCode))
-	}
-
-	//
-	for _, col := range cols {
-		indexModels = append(indexModels, mongo.IndexModel{
-			Keys: bson.D{{col, 1}},
-		}, mongo.IndexModel{
-			Keys: bson.D{{col, -1}},
-		})
-	}
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionAppSales.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
 func (sale Sale) GetKey() (ret string) {
 	return strconv.Itoa(sale.AppID) + "-" + strconv.Itoa(sale.SubID)
 }
@@ -170,7 +132,7 @@ func (sale Sale) IsLowest(code steamapi.ProductCC) int {
 	return 0
 }
 
-func GetAppSales(appID int) (offers []Sale, err error) {
+func GetAppSales(ctx context.Context, appID int) (offers []Sale, err error) {
 	return getSales(0, 0, bson.D{{"app_id", appID}}, bson.D{{"offer_end", 1}}, bson.M{"sub_id": 1, "offer_start": 1})
 }
 
@@ -183,13 +145,15 @@ func GetHighestSaleOrder() (int, error) {
 	return sales[0].SubOrder, nil
 }
 
-func GetAllSales(offset int64, limit int64, filter bson.D, sort bson.D) (offers []Sale, err error) {
+func GetAllSales(ctx context.Context, offset int64, limit int64, filter bson.D, sort bson.D) (offers []Sale, err error) {
 	return getSales(offset, limit, filter, sort, nil)
 }
 
 func getSales(offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M) (offers []Sale, err error) {
 
-	cur, ctx, err := find(CollectionAppSales, offset, limit, filter, sort, projection, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionAppSales, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return offers, err
 	}
@@ -210,13 +174,13 @@ func getSales(offset int64, limit int64, filter bson.D, sort bson.D, projection
 	return offers, cur.Err()
 }
 
-func ReplaceSales(offers []Sale) (err error) {
+func ReplaceSales(ctx context.Context, offers []Sale) (err error) {
 
 	if len(offers) < 1 {
 		return nil
 	}
 
-	client, ctx, err := getMongo()
+	client, _, err := getMongo()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,182 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Jleagle/steam-go/steamapi"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type SavedSearchType string
+
+const (
+	SavedSearchTypeGames   SavedSearchType = "games"
+	SavedSearchTypeSales   SavedSearchType = "sales"
+	SavedSearchTypePlayers SavedSearchType = "players"
+
+	SavedSearchesPerUser = 20
+	SavedSearchSeen      = 1_000 // IDs to remember, so we know what is new
+	SavedSearchItems     = 50    // New items to keep for the feeds
+)
+
+func (t SavedSearchType) IsValid() bool {
+	return t == SavedSearchTypeGames || t == SavedSearchTypeSales || t == SavedSearchTypePlayers
+}
+
+func (t SavedSearchType) GetPath() string {
+
+	switch t {
+	case SavedSearchTypeSales:
+		return "/games/sales"
+	case SavedSearchTypePlayers:
+		return "/players"
+	default:
+		return "/games"
+	}
+}
+
+type SavedSearch struct {
+	ID            string             `bson:"_id"`
+	UserID        int                `bson:"user_id"`
+	PlayerID      int64              `bson:"player_id"` // Used by the owned/played games filter
+	Name          string             `bson:"name"`
+	Type          SavedSearchType    `bson:"type"`
+	Query         string             `bson:"query"` // URL encoded datatable query
+	ProductCC     steamapi.ProductCC `bson:"prod_cc"`
+	NotifyEmail   bool               `bson:"notify_email"`
+	NotifyDiscord bool               `bson:"notify_discord"`
+	SeenIDs       []string           `bson:"seen_ids"`
+	Items         []SavedSearchItem  `bson:"items"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	CheckedAt     time.Time          `bson:"checked_at"`
+}
+
+type SavedSearchItem struct {
+	ID      string    `bson:"id"`
+	Name    string    `bson:"name"`
+	Path    string    `bson:"path"`
+	Icon    string    `bson:"icon"`
+	FoundAt time.Time `bson:"found_at"`
+}
+
+func (search SavedSearch) BSON() bson.D {
+
+	return bson.D{
+		{"_id", search.ID},
+		{"user_id", search.UserID},
+		{"player_id", search.PlayerID},
+		{"name", search.Name},
+		{"type", search.Type},
+		{"query", search.Query},
+		{"prod_cc", search.ProductCC},
+		{"notify_email", search.NotifyEmail},
+		{"notify_discord", search.NotifyDiscord},
+		{"seen_ids", search.SeenIDs},
+		{"items", search.Items},
+		{"created_at", search.CreatedAt},
+		{"checked_at", search.CheckedAt},
+	}
+}
+
+func (search SavedSearch) GetFeedPath(format string) string {
+	return "/saved-searches/" + search.ID + "." + format
+}
+
+func (search SavedSearch) GetCheckedNice() string {
+
+	if search.CheckedAt.IsZero() {
+		return "-"
+	}
+	return search.CheckedAt.Format(helpers.DateSQL)
+}
+
+func NewSavedSearch(search SavedSearch) (id string, err error) {
+
+	search.ID = helpers.RandString(20, helpers.Numbers+helpers.Letters)
+	search.CreatedAt = time.Now()
+
+	_, err = InsertOne(context.TODO(), CollectionSavedSearches, search)
+	return search.ID, err
+}
+
+func GetSavedSearch(ctx context.Context, id string) (search SavedSearch, err error) {
+
+	err = FindOne(ctx, CollectionSavedSearches, bson.D{{"_id", id}}, nil, nil, &search)
+	return search, err
+}
+
+func GetSavedSearchesByUser(userID int) (searches []SavedSearch, err error) {
+
+	return getSavedSearches(0, 0, bson.D{{"user_id", userID}}, bson.D{{"created_at", -1}}, bson.M{"seen_ids": 0})
+}
+
+// Returns the searches that have gone the longest without a check
+func GetSavedSearchesToCheck(before time.Time, limit int64) (searches []SavedSearch, err error) {
+
+	filter := bson.D{{"checked_at", bson.M{"$lt": before}}}
+
+	return getSavedSearches(0, limit, filter, bson.D{{"checked_at", 1}}, bson.M{"_id": 1})
+}
+
+func UpdateSavedSearchNotifications(userID int, id string, email bool, discord bool) (err error) {
+
+	filter := bson.D{{"_id", id}, {"user_id", userID}}
+	update := bson.D{{"notify_email", email}, {"notify_discord", discord}}
+
+	_, err = UpdateOne(context.TODO(), CollectionSavedSearches, filter, update)
+	return err
+}
+
+func UpdateSavedSearchResults(ctx context.Context, id string, seen []string, items []SavedSearchItem) (err error) {
+
+	if len(seen) > SavedSearchSeen {
+		seen = seen[0:SavedSearchSeen]
+	}
+
+	if len(items) > SavedSearchItems {
+		items = items[0:SavedSearchItems]
+	}
+
+	update := bson.D{
+		{"seen_ids", seen},
+		{"items", items},
+		{"checked_at", time.Now()},
+	}
+
+	_, err = UpdateOne(ctx, CollectionSavedSearches, bson.D{{"_id", id}}, update)
+	return err
+}
+
+func DeleteSavedSearch(userID int, id string) (err error) {
+
+	_, err = DeleteMany(context.TODO(), CollectionSavedSearches, bson.D{{"_id", id}, {"user_id", userID}})
+	return err
+}
+
+func getSavedSearches(offset int64, limit int64, filter bson.D, sort bson.D, projection bson.M) (searches []SavedSearch, err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionSavedSearches, offset, limit, filter, sort, projection, nil)
+	if err != nil {
+		return searches, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var search SavedSearch
+		err := cur.Decode(&search)
+		if err != nil {
+			log.ErrS(err, search.ID)
+		} else {
+			searches = append(searches, search)
+		}
+	}
+
+	return searches, cur.Err()
+}
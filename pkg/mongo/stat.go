@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"errors"
 	"sort"
 	"strconv"
@@ -15,7 +16,6 @@ import (
 	"github.com/gamedb/gamedb/pkg/log"
 	"github.com/gamedb/gamedb/pkg/memcache"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
@@ -130,34 +130,6 @@ func (st StatsType) Plural() string {
 	}
 }
 
-func ensureStatIndexes() {
-
-	var indexModels = []mongo.IndexModel{
-		{
-			Keys: bson.D{{"type", 1}, {"id", 1}},
-		},
-		{
-			Keys: bson.D{{"type", 1}, {"name", 1}},
-			Options: options.Index().SetCollation(&options.Collation{
-				Locale:   "en",
-				Strength: 2, // Case insensitive
-			}),
-		},
-	}
-
-	//
-	client, ctx, err := getMongo()
-	if err != nil {
-		log.ErrS(err)
-		return
-	}
-
-	_, err = client.Database(config.C.MongoDatabase).Collection(CollectionPlayers.String()).Indexes().CreateMany(ctx, indexModels)
-	if err != nil {
-		log.ErrS(err)
-	}
-}
-
 //
 func GetStat(typex StatsType, id int) (stat Stat, err error) {
 
@@ -167,7 +139,7 @@ func GetStat(typex StatsType, id int) (stat Stat, err error) {
 		stat.Type = typex
 		stat.ID = id
 
-		err = FindOne(CollectionStats, bson.D{{"_id", stat.GetKey()}}, nil, nil, &stat)
+		err = FindOne(context.TODO(), CollectionStats, bson.D{{"_id", stat.GetKey()}}, nil, nil, &stat)
 		return stat, err
 	})
 
@@ -177,7 +149,9 @@ func GetStat(typex StatsType, id int) (stat Stat, err error) {
 //
 func GetStats(offset int64, limit int64, filter bson.D, sort bson.D) (stats []Stat, err error) {
 
-	cur, ctx, err := find(CollectionStats, offset, limit, filter, sort, nil, options.Find())
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionStats, offset, limit, filter, sort, nil, options.Find())
 	if err != nil {
 		return stats, err
 	}
@@ -281,7 +255,7 @@ var (
 	tagLock          sync.Mutex
 )
 
-func FindOrCreateStatsByName(typex StatsType, names []string) (IDs []int, err error) {
+func FindOrCreateStatsByName(ctx context.Context, typex StatsType, names []string) (IDs []int, err error) {
 
 	tagLock.Lock()
 	defer tagLock.Unlock()
@@ -301,12 +275,12 @@ func FindOrCreateStatsByName(typex StatsType, names []string) (IDs []int, err er
 
 		// Check if it exists
 		existing := Stat{}
-		err = FindOne(CollectionStats, bson.D{{"type", typex}, {"name", name}}, nil, nil, &existing)
+		err = FindOne(ctx, CollectionStats, bson.D{{"type", typex}, {"name", name}}, nil, nil, &existing)
 		if err == ErrNoDocuments {
 
 			// Get highest ID to increment
 			highest := Stat{}
-			err = FindOne(CollectionStats, bson.D{{"type", typex}}, bson.D{{"id", -1}}, nil, &highest)
+			err = FindOne(ctx, CollectionStats, bson.D{{"type", typex}}, bson.D{{"id", -1}}, nil, &highest)
 			if err != nil {
 				return nil, err
 			}
@@ -318,7 +292,7 @@ func FindOrCreateStatsByName(typex StatsType, names []string) (IDs []int, err er
 
 			operation := func() (err error) {
 				newStat.ID++
-				_, err = InsertOne(CollectionStats, newStat)
+				_, err = InsertOne(ctx, CollectionStats, newStat)
 				return err
 			}
 
@@ -348,7 +322,7 @@ func FindOrCreateStatsByName(typex StatsType, names []string) (IDs []int, err er
 
 var existingTagIDs = map[StatsType]map[int]bool{}
 
-func EnsureStat(typex StatsType, ids []int, names []string) (err error) {
+func EnsureStat(ctx context.Context, typex StatsType, ids []int, names []string) (err error) {
 
 	if _, ok := existingTagIDs[typex]; !ok {
 		existingTagIDs[typex] = map[int]bool{}
@@ -373,7 +347,7 @@ func EnsureStat(typex StatsType, ids []int, names []string) (err error) {
 		})
 	}
 
-	_, err = InsertMany(CollectionStats, docs)
+	_, err = InsertMany(ctx, CollectionStats, docs)
 	return err
 }
 
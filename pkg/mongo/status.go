@@ -0,0 +1,283 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StatusComponent string
+
+const (
+	StatusComponentSite    StatusComponent = "site"
+	StatusComponentAPI     StatusComponent = "api"
+	StatusComponentChatbot StatusComponent = "chatbot"
+	StatusComponentPICS    StatusComponent = "pics"
+	StatusComponentQueues  StatusComponent = "queues"
+	StatusComponentData    StatusComponent = "data"
+)
+
+// In display order
+var StatusComponents = []StatusComponent{
+	StatusComponentSite,
+	StatusComponentAPI,
+	StatusComponentChatbot,
+	StatusComponentPICS,
+	StatusComponentQueues,
+	StatusComponentData,
+}
+
+func (c StatusComponent) IsValid() bool {
+
+	for _, v := range StatusComponents {
+		if v == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (c StatusComponent) GetName() string {
+
+	switch c {
+	case StatusComponentSite:
+		return "Website"
+	case StatusComponentAPI:
+		return "API"
+	case StatusComponentChatbot:
+		return "Discord Bot"
+	case StatusComponentPICS:
+		return "Steam Change Listener"
+	case StatusComponentQueues:
+		return "Update Queues"
+	case StatusComponentData:
+		return "Data Freshness"
+	default:
+		return string(c)
+	}
+}
+
+type StatusCheck struct {
+	Component StatusComponent `bson:"component"`
+	Healthy   bool            `bson:"healthy"`
+	Message   string          `bson:"message"`
+	CreatedAt time.Time       `bson:"created_at"`
+}
+
+func (check StatusCheck) BSON() bson.D {
+
+	return bson.D{
+		{"component", check.Component},
+		{"healthy", check.Healthy},
+		{"message", check.Message},
+		{"created_at", check.CreatedAt},
+	}
+}
+
+type StatusIncident struct {
+	ID         string            `bson:"_id" json:"id"`
+	Title      string            `bson:"title" json:"title"`
+	Message    string            `bson:"message" json:"message"`
+	Components []StatusComponent `bson:"components" json:"components"`
+	CreatedAt  time.Time         `bson:"created_at" json:"created_at"`
+	ResolvedAt *time.Time        `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+func (incident StatusIncident) BSON() bson.D {
+
+	d := bson.D{
+		{"_id", incident.ID},
+		{"title", incident.Title},
+		{"message", incident.Message},
+		{"components", incident.Components},
+		{"created_at", incident.CreatedAt},
+	}
+
+	if incident.ResolvedAt != nil {
+		d = append(d, bson.E{Key: "resolved_at", Value: *incident.ResolvedAt})
+	}
+
+	return d
+}
+
+func (incident StatusIncident) IsResolved() bool {
+	return incident.ResolvedAt != nil && !incident.ResolvedAt.IsZero()
+}
+
+func (incident StatusIncident) GetComponentNames() (names []string) {
+
+	for _, v := range incident.Components {
+		names = append(names, v.GetName())
+	}
+	return names
+}
+
+func InsertStatusChecks(checks []StatusCheck) (err error) {
+
+	var documents []Document
+	for _, v := range checks {
+		documents = append(documents, v)
+	}
+
+	_, err = InsertMany(context.TODO(), CollectionStatusChecks, documents)
+	return err
+}
+
+// Latest check for each component
+func GetLatestStatusChecks() (checks map[StatusComponent]StatusCheck, err error) {
+
+	checks = map[StatusComponent]StatusCheck{}
+
+	client, ctx, err := getMongo()
+	if err != nil {
+		return checks, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": time.Now().Add(time.Hour * -1)}}}},
+		{{Key: "$sort", Value: bson.M{"created_at": -1}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$component",
+			"component":  bson.M{"$first": "$component"},
+			"healthy":    bson.M{"$first": "$healthy"},
+			"message":    bson.M{"$first": "$message"},
+			"created_at": bson.M{"$first": "$created_at"},
+		}}},
+	}
+
+	cur, err := client.Database(config.C.MongoDatabase, options.Database()).Collection(CollectionStatusChecks.String()).Aggregate(ctx, pipeline, options.Aggregate())
+	if err != nil {
+		return checks, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var check StatusCheck
+		err := cur.Decode(&check)
+		if err != nil {
+			log.ErrS(err)
+			continue
+		}
+
+		checks[check.Component] = check
+	}
+
+	return checks, cur.Err()
+}
+
+type StatusUptimeDay struct {
+	Component StatusComponent `bson:"component"`
+	Day       string          `bson:"day"` // YYYY-MM-DD, UTC
+	Checks    int             `bson:"checks"`
+	Healthy   int             `bson:"healthy"`
+}
+
+func (day StatusUptimeDay) Percent() float64 {
+
+	if day.Checks == 0 {
+		return 0
+	}
+	return float64(day.Healthy) / float64(day.Checks) * 100
+}
+
+// Healthy checks per component per day
+func GetStatusUptime(since time.Time) (days []StatusUptimeDay, err error) {
+
+	client, ctx, err := getMongo()
+	if err != nil {
+		return days, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"component": "$component",
+				"day":       bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$created_at"}},
+			},
+			"checks":  bson.M{"$sum": 1},
+			"healthy": bson.M{"$sum": bson.M{"$cond": bson.A{"$healthy", 1, 0}}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       0,
+			"component": "$_id.component",
+			"day":       "$_id.day",
+			"checks":    1,
+			"healthy":   1,
+		}}},
+	}
+
+	cur, err := client.Database(config.C.MongoDatabase, options.Database()).Collection(CollectionStatusChecks.String()).Aggregate(ctx, pipeline, options.Aggregate())
+	if err != nil {
+		return days, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var day StatusUptimeDay
+		err := cur.Decode(&day)
+		if err != nil {
+			log.ErrS(err)
+			continue
+		}
+
+		days = append(days, day)
+	}
+
+	return days, cur.Err()
+}
+
+func NewStatusIncident(title string, message string, components []StatusComponent) (incident StatusIncident, err error) {
+
+	incident = StatusIncident{
+		ID:         helpers.RandString(10, helpers.Numbers+helpers.Letters),
+		Title:      title,
+		Message:    message,
+		Components: components,
+		CreatedAt:  time.Now(),
+	}
+
+	_, err = InsertOne(context.TODO(), CollectionStatusIncidents, incident)
+	return incident, err
+}
+
+func ResolveStatusIncident(id string) (err error) {
+
+	_, err = UpdateOne(context.TODO(), CollectionStatusIncidents, bson.D{{"_id", id}}, bson.D{{"resolved_at", time.Now()}})
+	return err
+}
+
+func GetStatusIncidents(limit int64) (incidents []StatusIncident, err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionStatusIncidents, 0, limit, nil, bson.D{{"created_at", -1}}, nil, nil)
+	if err != nil {
+		return incidents, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var incident StatusIncident
+		err := cur.Decode(&incident)
+		if err != nil {
+			log.ErrS(err, incident.ID)
+		} else {
+			incidents = append(incidents, incident)
+		}
+	}
+
+	return incidents, cur.Err()
+}
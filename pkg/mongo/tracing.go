@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/gamedb/gamedb/pkg/tracing"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// A span per Mongo command, keyed by connection and request ID until it finishes
+var commandSpans sync.Map
+
+var commandMonitor = &event.CommandMonitor{
+	Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+
+		name := e.CommandName
+		if coll, ok := e.Command.Lookup(e.CommandName).StringValueOK(); ok {
+			name += " " + coll
+		}
+
+		_, span := tracing.StartSpan(ctx, "mongo "+name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.name", e.DatabaseName),
+				attribute.String("db.operation", e.CommandName),
+			),
+		)
+
+		commandSpans.Store(commandSpanKey(e.ConnectionID, e.RequestID), span)
+	},
+	Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+		endCommandSpan(e.CommandFinishedEvent, nil)
+	},
+	Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+		endCommandSpan(e.CommandFinishedEvent, &e.Failure)
+	},
+}
+
+func commandSpanKey(connection string, request int64) string {
+	return connection + "-" + strconv.FormatInt(request, 10)
+}
+
+func endCommandSpan(e event.CommandFinishedEvent, failure *string) {
+
+	val, ok := commandSpans.LoadAndDelete(commandSpanKey(e.ConnectionID, e.RequestID))
+	if !ok {
+		return
+	}
+
+	span := val.(trace.Span)
+	if failure != nil {
+		span.SetStatus(codes.Error, *failure)
+	}
+	span.End()
+}
@@ -0,0 +1,43 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// The documents in a collection that belong to a user, see pkg/userdata
+type UserDataSource struct {
+	Name       string
+	Collection collection
+	Filter     bson.D
+}
+
+// Export calls back with every document, oldest first
+func (s UserDataSource) Export(callback func(bson.Raw) error) (err error) {
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, s.Collection, 0, 0, s.Filter, bson.D{{"_id", 1}}, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		err = callback(cur.Current)
+		if err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+func (s UserDataSource) Delete() (err error) {
+
+	_, err = DeleteMany(context.TODO(), s.Collection, s.Filter)
+	return err
+}
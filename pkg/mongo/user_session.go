@@ -0,0 +1,180 @@
+package mongo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	UserSessionLifetime = time.Hour * 24 * 28 // Same as the cookie
+	UserSessionTouch    = time.Minute * 5     // How often last seen gets updated
+)
+
+// A logged in session, the cookie only holds the ID.
+// Deleting the record logs that session out.
+type UserSession struct {
+	ID        string    `bson:"_id"`
+	UserID    int       `bson:"user_id"`
+	CreatedAt time.Time `bson:"created_at"`
+	LastSeen  time.Time `bson:"last_seen"`
+	ExpiresAt time.Time `bson:"expires_at"`
+	IP        string    `bson:"ip"`
+	UserAgent string    `bson:"user_agent"`
+}
+
+func (s UserSession) BSON() bson.D {
+
+	return bson.D{
+		{"_id", s.ID},
+		{"user_id", s.UserID},
+		{"created_at", s.CreatedAt},
+		{"last_seen", s.LastSeen},
+		{"expires_at", s.ExpiresAt},
+		{"ip", s.IP},
+		{"user_agent", s.UserAgent},
+	}
+}
+
+func (s UserSession) GetCreatedNice() string {
+	return s.CreatedAt.Format(helpers.DateTime)
+}
+
+func (s UserSession) GetLastSeenNice() string {
+	return s.LastSeen.Format(helpers.DateTime)
+}
+
+func CreateUserSession(r *http.Request, userID int) (s UserSession, err error) {
+
+	b := make([]byte, 16)
+	_, err = rand.Read(b)
+	if err != nil {
+		return s, err
+	}
+
+	s = UserSession{
+		ID:        hex.EncodeToString(b),
+		UserID:    userID,
+		CreatedAt: time.Now(),
+		LastSeen:  time.Now(),
+		ExpiresAt: time.Now().Add(UserSessionLifetime),
+		IP:        r.RemoteAddr,
+		UserAgent: r.UserAgent(),
+	}
+
+	_, err = InsertOne(context.TODO(), CollectionUserSessions, s)
+	return s, err
+}
+
+func GetUserSession(id string) (s UserSession, err error) {
+
+	if id == "" {
+		return s, ErrNoDocuments
+	}
+
+	item := memcache.ItemUserSession(id)
+	err = memcache.Client().GetSet(item.Key, item.Expiration, &s, func() (interface{}, error) {
+
+		err := FindOne(context.TODO(), CollectionUserSessions, bson.D{{"_id", id}}, nil, nil, &s)
+		return s, err
+	})
+
+	return s, err
+}
+
+func GetUserSessions(userID int) (sessions []UserSession, err error) {
+
+	filter := bson.D{
+		{"user_id", userID},
+		{"expires_at", bson.M{"$gt": time.Now()}},
+	}
+
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionUserSessions, 0, 0, filter, bson.D{{"last_seen", -1}}, nil, nil)
+	if err != nil {
+		return sessions, err
+	}
+
+	defer closeCursor(cur, ctx)
+
+	for cur.Next(ctx) {
+
+		var s UserSession
+		err := cur.Decode(&s)
+		if err != nil {
+			log.ErrS(err, s.ID)
+		} else {
+			sessions = append(sessions, s)
+		}
+	}
+
+	return sessions, cur.Err()
+}
+
+// Updates the last seen time and pushes back the expiry
+func TouchUserSession(id string, ip string, userAgent string) (err error) {
+
+	update := bson.D{
+		{"last_seen", time.Now()},
+		{"expires_at", time.Now().Add(UserSessionLifetime)},
+		{"ip", ip},
+		{"user_agent", userAgent},
+	}
+
+	_, err = UpdateOne(context.TODO(), CollectionUserSessions, bson.D{{"_id", id}}, update)
+	if err != nil {
+		return err
+	}
+
+	return memcache.Client().Delete(memcache.ItemUserSession(id).Key)
+}
+
+func DeleteUserSession(userID int, id string) (err error) {
+
+	_, err = DeleteOne(context.TODO(), CollectionUserSessions, bson.D{{"_id", id}, {"user_id", userID}})
+	if err != nil {
+		return err
+	}
+
+	return memcache.Client().Delete(memcache.ItemUserSession(id).Key)
+}
+
+// Logs out every session for the user apart from keepID, which can be blank
+func DeleteUserSessions(userID int, keepID string) (err error) {
+
+	sessions, err := GetUserSessions(userID)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, v := range sessions {
+		if v.ID != keepID {
+			ids = append(ids, v.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = DeleteMany(context.TODO(), CollectionUserSessions, bson.D{{"_id", bson.M{"$in": ids}}})
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	for _, id := range ids {
+		keys = append(keys, memcache.ItemUserSession(id).Key)
+	}
+
+	return memcache.Client().Delete(keys...)
+}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -18,17 +19,24 @@ func (s WebhookService) ToString() string {
 		return "GitHub"
 	case WebhookServiceSendgrid:
 		return "SendGrid"
+	case WebhookServiceKofi:
+		return "Ko-fi"
+	case WebhookServiceGithubSponsors:
+		return "GitHub Sponsors"
 	default:
 		return strings.Title(string(s))
 	}
 }
 
 const (
-	WebhookServicePatreon  WebhookService = "patreon"
-	WebhookServiceGithub   WebhookService = "github"
-	WebhookServiceTwitter  WebhookService = "twitter"
-	WebhookServiceSendgrid WebhookService = "sendgrid"
-	WebhookServiceMailjet  WebhookService = "mailjet"
+	WebhookServicePatreon        WebhookService = "patreon"
+	WebhookServiceGithub         WebhookService = "github"
+	WebhookServiceGithubSponsors WebhookService = "github-sponsors"
+	WebhookServiceTwitter        WebhookService = "twitter"
+	WebhookServiceSendgrid       WebhookService = "sendgrid"
+	WebhookServiceMailjet        WebhookService = "mailjet"
+	WebhookServiceStripe         WebhookService = "stripe"
+	WebhookServiceKofi           WebhookService = "kofi"
 )
 
 type Webhook struct {
@@ -72,13 +80,15 @@ func SaveWebhook(service WebhookService, event string, body string) error {
 		Service:     service,
 	}
 
-	_, err := InsertOne(CollectionWebhooks, row)
+	_, err := InsertOne(context.TODO(), CollectionWebhooks, row)
 	return err
 }
 
 func GetWebhooks(offset int64, limit int64, sort bson.D, filter bson.D, projection bson.M) (webhooks []Webhook, err error) {
 
-	cur, ctx, err := find(CollectionWebhooks, offset, limit, filter, sort, projection, nil)
+	ctx := context.TODO()
+
+	cur, err := find(ctx, CollectionWebhooks, offset, limit, filter, sort, projection, nil)
 	if err != nil {
 		return webhooks, err
 	}
@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"strings"
+)
+
+// The first user is always the owner, so there is always someone who can hand out roles
+const AdminOwnerUserID = 1
+
+type AdminRole string
+
+const (
+	AdminRoleNone      AdminRole = ""
+	AdminRoleOwner     AdminRole = "owner"
+	AdminRoleOps       AdminRole = "ops"
+	AdminRoleModerator AdminRole = "moderator"
+	AdminRoleSupport   AdminRole = "support"
+)
+
+var AdminRoles = []AdminRole{
+	AdminRoleOwner,
+	AdminRoleOps,
+	AdminRoleModerator,
+	AdminRoleSupport,
+}
+
+type AdminPermission string
+
+const (
+	AdminPermissionStats     AdminPermission = "stats"     // Stats, health, consumers and websockets
+	AdminPermissionTasks     AdminPermission = "tasks"     // Run crons
+	AdminPermissionQueues    AdminPermission = "queues"    // Queue items for an update
+	AdminPermissionSettings  AdminPermission = "settings"  // Down message and memcache
+	AdminPermissionIncidents AdminPermission = "incidents" // Status page incidents
+	AdminPermissionUsers     AdminPermission = "users"     // User list, including emails
+	AdminPermissionWebhooks  AdminPermission = "webhooks"  // Payment provider webhooks, including payer emails
+	AdminPermissionGuilds    AdminPermission = "guilds"    // Discord guilds
+	AdminPermissionRoles     AdminPermission = "roles"     // Give other users roles
+	AdminPermissionAudit     AdminPermission = "audit"     // Admin audit log
+	AdminPermissionAbuse     AdminPermission = "abuse"     // Top requesters of player lookups
+)
+
+// The owner has every permission, including ones not listed here
+var adminRolePermissions = map[AdminRole][]AdminPermission{
+	AdminRoleOps: {
+		AdminPermissionStats,
+		AdminPermissionTasks,
+		AdminPermissionQueues,
+		AdminPermissionSettings,
+		AdminPermissionIncidents,
+		AdminPermissionAudit,
+		AdminPermissionAbuse,
+	},
+	AdminRoleModerator: {
+		AdminPermissionStats,
+		AdminPermissionQueues,
+		AdminPermissionGuilds,
+		AdminPermissionAbuse,
+	},
+	AdminRoleSupport: {
+		AdminPermissionStats,
+		AdminPermissionUsers,
+		AdminPermissionWebhooks,
+		AdminPermissionIncidents,
+	},
+}
+
+func (role AdminRole) IsValid() bool {
+
+	for _, v := range AdminRoles {
+		if v == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (role AdminRole) GetName() string {
+
+	if role == AdminRoleNone {
+		return "None"
+	}
+	return strings.Title(string(role))
+}
+
+func (role AdminRole) Can(permission AdminPermission) bool {
+
+	if role == AdminRoleOwner {
+		return true
+	}
+
+	for _, v := range adminRolePermissions[role] {
+		if v == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (user User) GetAdminRole() AdminRole {
+
+	if user.ID == AdminOwnerUserID {
+		return AdminRoleOwner
+	}
+	return user.AdminRole
+}
+
+func SetUserAdminRole(userID int, role AdminRole) error {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	update := map[string]interface{}{
+		"admin_role": role,
+	}
+
+	return db.Model(&User{}).Where("id = ?", userID).Updates(update).Error
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"strings"
 	"time"
 
 	"github.com/gamedb/gamedb/pkg/helpers"
@@ -11,6 +12,9 @@ type DonationSource string
 const (
 	DonationSourcePatreon = "patreon"
 	DonationSourceManual  = "manual"
+	DonationSourceStripe  = "stripe"
+	DonationSourceKofi    = "kofi"
+	DonationSourceGitHub  = "github"
 )
 
 type Donation struct {
@@ -24,13 +28,51 @@ type Donation struct {
 	OriginalAmount   int       `gorm:"not null;column:original_amount"`
 	Source           string    `gorm:"not null;column:source"`
 	Anon             bool      `gorm:"not null;column:anon"`
-	PatreonRef       string    `gorm:"column:patreon_ref"` // Nullable, indexed
+	PatreonRef       string    `gorm:"column:patreon_ref"`        // Nullable, indexed
+	ExternalRef      string    `gorm:"column:external_ref;index"` // Payment ID from the other providers, so retried webhooks are only counted once
 }
 
 func (d Donation) Format() string {
 	return helpers.FloatToString(float64(d.AmountUSD)/100, 2)
 }
 
+func (d Donation) GetCreatedNice() string {
+	return d.CreatedAt.Format(helpers.DateYearTime)
+}
+
+// Amount in the currency it was paid in
+func (d Donation) FormatOriginal() string {
+	return helpers.FloatToString(float64(d.OriginalAmount)/100, 2) + " " + d.OriginalCurrency
+}
+
+func (d Donation) GetSourceNice() string {
+	return DonationSourceName(d.Source)
+}
+
+func DonationSourceName(source string) string {
+
+	switch source {
+	case DonationSourceKofi:
+		return "Ko-fi"
+	case DonationSourceGitHub:
+		return "GitHub Sponsors"
+	default:
+		return strings.Title(source)
+	}
+}
+
+func DonationExists(source string, ref string) (exists bool, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	db = db.Model(&Donation{}).Where("source = ? AND external_ref = ?", source, ref).Count(&count)
+	return count > 0, db.Error
+}
+
 func GetDonationsByUser(userID int, offset int) (donations []Donation, err error) {
 
 	db, err := GetMySQLClient()
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"net/url"
 	"sync"
 	"time"
@@ -77,6 +78,16 @@ func (logger mySQLLogger) Print(v ...interface{}) {
 	zap.S().Named(log.LogNameSQL).Debug(v...)
 }
 
+func Ping(ctx context.Context) error {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	return db.DB().PingContext(ctx)
+}
+
 func Close() {
 
 	db, err := GetMySQLClient()
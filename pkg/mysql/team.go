@@ -0,0 +1,520 @@
+package mysql
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/memcache"
+)
+
+const (
+	TeamMaxMembers     = 25
+	TeamMaxKeys        = 10
+	TeamInviteLifetime = time.Hour * 24 * 7
+)
+
+var (
+	ErrTeamFull     = errors.New("team is full")
+	ErrTeamKeysFull = errors.New("team has too many keys")
+)
+
+// A group of users sharing the owner's level and one API quota
+type Team struct {
+	ID        int       `gorm:"not null;column:id;primary_key;auto_increment"`
+	CreatedAt time.Time `gorm:"not null;column:created_at"`
+	UpdatedAt time.Time `gorm:"not null;column:updated_at"`
+	Name      string    `gorm:"not null;column:name"`
+	OwnerID   int       `gorm:"not null;column:owner_id;index"`
+}
+
+func (t Team) GetCreatedNice() string {
+	return t.CreatedAt.Format(helpers.DateYear)
+}
+
+// A user can only be in one team, so the user ID is the key
+type TeamMember struct {
+	UserID    int       `gorm:"not null;column:user_id;primary_key"`
+	TeamID    int       `gorm:"not null;column:team_id;index"`
+	CreatedAt time.Time `gorm:"not null;column:created_at"`
+}
+
+type TeamInvite struct {
+	ID        int       `gorm:"not null;column:id;primary_key;auto_increment"`
+	CreatedAt time.Time `gorm:"not null;column:created_at"`
+	ExpiresAt time.Time `gorm:"not null;column:expires_at"`
+	TeamID    int       `gorm:"not null;column:team_id;index"`
+	InvitedBy int       `gorm:"not null;column:invited_by"`
+	Email     string    `gorm:"not null;column:email"`
+	Hash      string    `gorm:"not null;column:hash;unique_index"` // sha256 of the emailed token
+}
+
+func (i TeamInvite) GetExpiresNice() string {
+	return i.ExpiresAt.Format(helpers.DateYear)
+}
+
+// An API key owned by the team rather than a member, for shared services and scripts
+type TeamKey struct {
+	ID        int       `gorm:"not null;column:id;primary_key;auto_increment"`
+	CreatedAt time.Time `gorm:"not null;column:created_at"`
+	TeamID    int       `gorm:"not null;column:team_id;index"`
+	CreatedBy int       `gorm:"not null;column:created_by"`
+	Name      string    `gorm:"not null;column:name"`
+	Key       string    `gorm:"not null;column:key;unique_index"`
+}
+
+func (k TeamKey) GetCreatedNice() string {
+	return k.CreatedAt.Format(helpers.DateYear)
+}
+
+// What a user gets from their team, cached because the API checks it on every call
+type TeamAccess struct {
+	TeamID  int
+	Level   UserLevel
+	KeyName string // Set when the call used a shared team key
+}
+
+func CreateTeam(owner User, name string) (team Team, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return team, err
+	}
+
+	tx := db.Begin()
+
+	team = Team{Name: name, OwnerID: owner.ID}
+	err = tx.Create(&team).Error
+	if err != nil {
+		tx.Rollback()
+		return team, err
+	}
+
+	err = tx.Create(&TeamMember{UserID: owner.ID, TeamID: team.ID}).Error
+	if err != nil {
+		tx.Rollback()
+		return team, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return team, err
+	}
+
+	return team, clearTeamAccess(owner.ID)
+}
+
+func GetTeam(id int) (team Team, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return team, err
+	}
+
+	db = db.Where("id = ?", id).First(&team)
+	return team, db.Error
+}
+
+func GetTeamByUserID(userID int) (team Team, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return team, err
+	}
+
+	member := TeamMember{}
+	db = db.Where("user_id = ?", userID).First(&member)
+	if db.Error != nil {
+		return team, db.Error
+	}
+
+	return GetTeam(member.TeamID)
+}
+
+func GetTeamMembers(teamID int) (users []User, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return users, err
+	}
+
+	db = db.
+		Joins("JOIN team_members ON team_members.user_id = users.id").
+		Where("team_members.team_id = ?", teamID).
+		Order("team_members.created_at asc").
+		Find(&users)
+
+	return users, db.Error
+}
+
+func CountTeamMembers(teamID int) (count int, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return count, err
+	}
+
+	db = db.Model(&TeamMember{}).Where("team_id = ?", teamID).Count(&count)
+	return count, db.Error
+}
+
+func AddTeamMember(teamID int, userID int) (err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	tx := db.Begin()
+
+	// Lock the team row so two joins can't both see a free place
+	var team Team
+	err = tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", teamID).First(&team).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var count int
+	err = tx.Model(&TeamMember{}).Where("team_id = ?", teamID).Count(&count).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if count >= TeamMaxMembers {
+		tx.Rollback()
+		return ErrTeamFull
+	}
+
+	err = tx.Create(&TeamMember{UserID: userID, TeamID: teamID}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return err
+	}
+
+	return clearTeamAccess(userID)
+}
+
+func RemoveTeamMember(teamID int, userID int) (err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	db = db.Where("team_id = ? AND user_id = ?", teamID, userID).Delete(&TeamMember{})
+	if db.Error != nil {
+		return db.Error
+	}
+
+	return clearTeamAccess(userID)
+}
+
+func DeleteTeam(team Team) (err error) {
+
+	members, err := GetTeamMembers(team.ID)
+	if err != nil {
+		return err
+	}
+
+	keys, err := GetTeamKeys(team.ID)
+	if err != nil {
+		return err
+	}
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	tx := db.Begin()
+
+	for _, v := range []interface{}{&TeamInvite{}, &TeamMember{}, &TeamKey{}} {
+		err = tx.Where("team_id = ?", team.ID).Delete(v).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = tx.Delete(&team).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return err
+	}
+
+	var userIDs []int
+	for _, v := range members {
+		userIDs = append(userIDs, v.ID)
+	}
+
+	err = clearTeamKeys(keys...)
+	if err != nil {
+		return err
+	}
+
+	return clearTeamAccess(userIDs...)
+}
+
+// The team's level is whatever the owner pays for
+func GetTeamAccess(userID int) (access TeamAccess, err error) {
+
+	item := memcache.ItemUserTeamAccess(userID)
+	err = memcache.Client().GetSet(item.Key, item.Expiration, &access, func() (interface{}, error) {
+
+		team, err := GetTeamByUserID(userID)
+		if err == ErrRecordNotFound {
+			return access, nil
+		} else if err != nil {
+			return access, err
+		}
+
+		owner, err := GetUserByID(team.OwnerID)
+		if err != nil {
+			return access, err
+		}
+
+		return TeamAccess{TeamID: team.ID, Level: owner.Level}, nil
+	})
+
+	return access, err
+}
+
+// A user gets the better of their own level and their team's
+func GetEffectiveUserLevel(user User) (level UserLevel, access TeamAccess, err error) {
+
+	access, err = GetTeamAccess(user.ID)
+	if err != nil {
+		return user.Level, access, err
+	}
+
+	if access.Level > user.Level {
+		return access.Level, access, nil
+	}
+
+	return user.Level, access, nil
+}
+
+// Call when an owner's level changes, members have it cached
+func ClearTeamAccessForOwner(ownerID int) (err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	var teams []Team
+	db = db.Where("owner_id = ?", ownerID).Find(&teams)
+	if db.Error != nil {
+		return db.Error
+	}
+
+	for _, team := range teams {
+
+		members, err := GetTeamMembers(team.ID)
+		if err != nil {
+			return err
+		}
+
+		var userIDs []int
+		for _, v := range members {
+			userIDs = append(userIDs, v.ID)
+		}
+
+		err = clearTeamAccess(userIDs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func clearTeamAccess(userIDs ...int) error {
+
+	var keys []string
+	for _, v := range userIDs {
+		keys = append(keys, memcache.ItemUserTeamAccess(v).Key)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return memcache.Client().Delete(keys...)
+}
+
+// Returns the token to email, only the hash is stored
+func CreateTeamInvite(teamID int, invitedBy int, email string) (token string, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return "", err
+	}
+
+	token, err = newToken()
+	if err != nil {
+		return "", err
+	}
+
+	invite := TeamInvite{
+		ExpiresAt: time.Now().Add(TeamInviteLifetime),
+		TeamID:    teamID,
+		InvitedBy: invitedBy,
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		Hash:      hashToken(token),
+	}
+
+	return token, db.Create(&invite).Error
+}
+
+// Expired invites count as missing
+func GetTeamInvite(token string) (invite TeamInvite, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return invite, err
+	}
+
+	db = db.Where("hash = ? AND expires_at > ?", hashToken(token), time.Now()).First(&invite)
+	return invite, db.Error
+}
+
+func GetTeamInvites(teamID int) (invites []TeamInvite, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return invites, err
+	}
+
+	db = db.Where("team_id = ? AND expires_at > ?", teamID, time.Now()).Order("created_at desc").Find(&invites)
+	return invites, db.Error
+}
+
+func DeleteTeamInvite(teamID int, id int) (err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	return db.Where("team_id = ? AND id = ?", teamID, id).Delete(&TeamInvite{}).Error
+}
+
+func CreateTeamKey(teamID int, createdBy int, name string) (key TeamKey, err error) {
+
+	count, err := countTeamKeys(teamID)
+	if err != nil {
+		return key, err
+	}
+
+	if count >= TeamMaxKeys {
+		return key, ErrTeamKeysFull
+	}
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return key, err
+	}
+
+	// Same format as user keys, so the API accepts either
+	key = TeamKey{
+		TeamID:    teamID,
+		CreatedBy: createdBy,
+		Name:      name,
+		Key:       helpers.RandString(20, helpers.Numbers+helpers.LettersCaps),
+	}
+
+	return key, db.Create(&key).Error
+}
+
+func GetTeamKeys(teamID int) (keys []TeamKey, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return keys, err
+	}
+
+	db = db.Where("team_id = ?", teamID).Order("created_at asc").Find(&keys)
+	return keys, db.Error
+}
+
+func countTeamKeys(teamID int) (count int, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return count, err
+	}
+
+	db = db.Model(&TeamKey{}).Where("team_id = ?", teamID).Count(&count)
+	return count, db.Error
+}
+
+func GetTeamKeyByKey(key string) (teamKey TeamKey, err error) {
+
+	item := memcache.ItemTeamKey(key)
+	err = memcache.Client().GetSet(item.Key, item.Expiration, &teamKey, func() (interface{}, error) {
+
+		db, err := GetMySQLClient()
+		if err != nil {
+			return teamKey, err
+		}
+
+		db = db.Where("`key` = ?", key).First(&teamKey)
+		return teamKey, db.Error
+	})
+
+	return teamKey, err
+}
+
+func DeleteTeamKey(teamID int, id int) (key TeamKey, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return key, err
+	}
+
+	db = db.Where("team_id = ? AND id = ?", teamID, id).First(&key)
+	if db.Error != nil {
+		return key, db.Error
+	}
+
+	db, err = GetMySQLClient()
+	if err != nil {
+		return key, err
+	}
+
+	db = db.Delete(&key)
+	if db.Error != nil {
+		return key, db.Error
+	}
+
+	return key, clearTeamKeys(key)
+}
+
+// The API has keys cached, so they would keep working for a while
+func clearTeamKeys(keys ...TeamKey) error {
+
+	var items []string
+	for _, v := range keys {
+		items = append(items, memcache.ItemTeamKey(v.Key).Key)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	return memcache.Client().Delete(items...)
+}
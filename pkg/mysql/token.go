@@ -0,0 +1,24 @@
+package mysql
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// A random token to put in an emailed link, only its hash should be stored
+func newToken() (token string, err error) {
+
+	b := make([]byte, 20)
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
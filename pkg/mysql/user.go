@@ -3,6 +3,7 @@ package mysql
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Jleagle/steam-go/steamapi"
@@ -34,6 +35,22 @@ const (
 	UserLevelLimit3     = 0   // Level 3
 )
 
+func (ul UserLevel) GetName() string {
+
+	switch ul {
+	case UserLevelFree:
+		return "Free"
+	case UserLevel1:
+		return "Level 1"
+	case UserLevel2:
+		return "Level 2"
+	case UserLevel3:
+		return "Level 3"
+	default:
+		return "Guest"
+	}
+}
+
 func (ul UserLevel) MaxResults(limit int64) int64 {
 
 	switch ul {
@@ -71,6 +88,8 @@ type User struct {
 	ProductCC      steamapi.ProductCC `gorm:"not null;column:country_code"`
 	APIKey         string             `gorm:"not null;column:api_key"`
 	DonatedPatreon int                `gorm:"not null;column:donated_patreon"`
+	AdminRole      AdminRole          `gorm:"not null;column:admin_role;type:varchar(20)"`
+	ManualLevel    UserLevel          `gorm:"not null;column:manual_level"` // Given by hand, subscriptions never lower the level below this
 }
 
 func (user *User) SetAPIKey() {
@@ -304,3 +323,49 @@ func CountUsers() (count int, err error) {
 
 	return count, db.Error
 }
+
+// Hard deletes a user and every row that belongs to them, teams should be left first
+func DeleteUser(user User) (err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	tx := db.Begin()
+
+	// Unscoped, as unlinked providers are only soft deleted
+	tx = tx.Unscoped()
+
+	var rows = []interface{}{
+		&UserProvider{},
+		&Donation{},
+		&UserSubscription{},
+		&UserTOTP{},
+		&UserRecoveryCode{},
+		&UserSecurityKey{},
+		&UserVerification{},
+	}
+
+	for _, v := range rows {
+		err = tx.Where("user_id = ?", user.ID).Delete(v).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = tx.Where("email = ?", strings.ToLower(user.Email)).Delete(&TeamInvite{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Delete(&user).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,189 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/helpers"
+)
+
+const (
+	UserDataExport = "export"
+	UserDataDelete = "delete"
+
+	UserDataPending   = "pending"
+	UserDataReady     = "ready"
+	UserDataExpired   = "expired"
+	UserDataCancelled = "cancelled"
+	UserDataDone      = "done"
+)
+
+// An export or account deletion, both are done later by a consumer
+type UserDataRequest struct {
+	ID        int        `gorm:"not null;column:id;primary_key;auto_increment"`
+	CreatedAt time.Time  `gorm:"not null;column:created_at"`
+	UpdatedAt time.Time  `gorm:"not null;column:updated_at"`
+	UserID    int        `gorm:"not null;column:user_id;index"`
+	Type      string     `gorm:"not null;column:type"`
+	Status    string     `gorm:"not null;column:status"`
+	DueAt     time.Time  `gorm:"not null;column:due_at"`          // Deletes wait here so they can be cancelled
+	ExpiresAt *time.Time `gorm:"column:expires_at;type:datetime"` // When an export stops being downloadable
+	File      string     `gorm:"not null;column:file"`
+	Hash      string     `gorm:"not null;column:hash;index"` // sha256 of the emailed download token
+}
+
+func (r UserDataRequest) GetCreatedNice() string {
+	return r.CreatedAt.Format(helpers.DateYearTime)
+}
+
+func (r UserDataRequest) GetDueNice() string {
+	return r.DueAt.Format(helpers.DateYearTime)
+}
+
+func (r UserDataRequest) GetExpiresNice() string {
+	if r.ExpiresAt == nil {
+		return "-"
+	}
+	return r.ExpiresAt.Format(helpers.DateYearTime)
+}
+
+func CreateUserDataRequest(userID int, requestType string, dueAt time.Time) (request UserDataRequest, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return request, err
+	}
+
+	request = UserDataRequest{
+		UserID: userID,
+		Type:   requestType,
+		Status: UserDataPending,
+		DueAt:  dueAt,
+	}
+
+	return request, db.Create(&request).Error
+}
+
+func GetUserDataRequest(id int) (request UserDataRequest, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return request, err
+	}
+
+	db = db.Where("id = ?", id).First(&request)
+	return request, db.Error
+}
+
+func GetUserDataRequests(userID int) (requests []UserDataRequest, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return requests, err
+	}
+
+	db = db.Where("user_id = ?", userID).Order("created_at desc").Limit(20).Find(&requests)
+	return requests, db.Error
+}
+
+// The pending request of a type, if there is one
+func GetPendingUserDataRequest(userID int, requestType string) (request UserDataRequest, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return request, err
+	}
+
+	db = db.Where("user_id = ? AND type = ? AND status = ?", userID, requestType, UserDataPending).First(&request)
+	return request, db.Error
+}
+
+// Only the user it was made for can download an export
+func GetUserDataExport(userID int, token string) (request UserDataRequest, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return request, err
+	}
+
+	db = db.Where("user_id = ? AND type = ? AND status = ? AND hash = ? AND expires_at > ?", userID, UserDataExport, UserDataReady, hashToken(token), time.Now()).First(&request)
+	return request, db.Error
+}
+
+// Deletes whose cancel window has passed
+func GetDueUserDataDeletes() (requests []UserDataRequest, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return requests, err
+	}
+
+	db = db.Where("type = ? AND status = ? AND due_at <= ?", UserDataDelete, UserDataPending, time.Now()).Find(&requests)
+	return requests, db.Error
+}
+
+// Exports with a file that can no longer be downloaded
+func GetExpiredUserDataExports() (requests []UserDataRequest, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return requests, err
+	}
+
+	db = db.Where("type = ? AND status = ? AND expires_at <= ?", UserDataExport, UserDataReady, time.Now()).Find(&requests)
+	return requests, db.Error
+}
+
+// Exports of a user that still have a file
+func GetUserDataExportFiles(userID int) (requests []UserDataRequest, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return requests, err
+	}
+
+	db = db.Where("user_id = ? AND type = ? AND file != ?", userID, UserDataExport, "").Find(&requests)
+	return requests, db.Error
+}
+
+// Marks an export as ready and returns the token for the download link
+func SetUserDataExportReady(request UserDataRequest, file string, lifetime time.Duration) (token string, err error) {
+
+	token, err = newToken()
+	if err != nil {
+		return "", err
+	}
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(lifetime)
+
+	db = db.Model(&request).Updates(map[string]interface{}{
+		"status":     UserDataReady,
+		"file":       file,
+		"hash":       hashToken(token),
+		"expires_at": &expires,
+	})
+
+	return token, db.Error
+}
+
+func SetUserDataRequestStatus(request UserDataRequest, status string) (err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{"status": status}
+
+	// The file is gone once an export is no longer ready
+	if request.Type == UserDataExport && status != UserDataReady {
+		updates["file"] = ""
+		updates["hash"] = ""
+	}
+
+	return db.Model(&request).Updates(updates).Error
+}
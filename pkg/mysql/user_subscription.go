@@ -0,0 +1,182 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/helpers"
+)
+
+// A recurring payment from any provider, the user's level is the best of their active ones
+type UserSubscription struct {
+	ID         int        `gorm:"not null;column:id;primary_key;auto_increment"`
+	CreatedAt  time.Time  `gorm:"not null;column:created_at"`
+	UpdatedAt  time.Time  `gorm:"not null;column:updated_at"`
+	UserID     int        `gorm:"not null;column:user_id;index"`
+	Source     string     `gorm:"not null;column:source;unique_index:source_external_id"`
+	ExternalID string     `gorm:"not null;column:external_id;unique_index:source_external_id"` // The provider's subscription/membership ID
+	Level      UserLevel  `gorm:"not null;column:level"`
+	Active     bool       `gorm:"not null;column:active"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at;type:datetime"` // Nil until cancelled, or the end of the last paid period
+}
+
+func (s UserSubscription) IsActive() bool {
+	return s.Active && (s.ExpiresAt == nil || s.ExpiresAt.After(time.Now()))
+}
+
+func (s UserSubscription) GetSourceNice() string {
+	return DonationSourceName(s.Source)
+}
+
+func (s UserSubscription) GetCreatedNice() string {
+	return s.CreatedAt.Format(helpers.DateYear)
+}
+
+func (s UserSubscription) GetExpiresNice() string {
+	if s.ExpiresAt == nil {
+		return "-"
+	}
+	return s.ExpiresAt.Format(helpers.DateYear)
+}
+
+func GetUserSubscription(source string, externalID string) (sub UserSubscription, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return sub, err
+	}
+
+	db = db.Where("source = ? AND external_id = ?", source, externalID).First(&sub)
+	return sub, db.Error
+}
+
+func GetUserSubscriptions(userID int) (subs []UserSubscription, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return subs, err
+	}
+
+	db = db.Where("user_id = ?", userID).Order("created_at desc").Find(&subs)
+	return subs, db.Error
+}
+
+// Users who still have a level from a subscription that has run out
+func GetExpiredUserSubscriptionUserIDs() (userIDs []int, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return userIDs, err
+	}
+
+	db = db.Model(&UserSubscription{}).Where("active = ? AND expires_at < ?", true, time.Now()).Pluck("DISTINCT user_id", &userIDs)
+	return userIDs, db.Error
+}
+
+// Inserts or updates by source and external ID
+func SaveUserSubscription(sub UserSubscription) (err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	existing, err := GetUserSubscription(sub.Source, sub.ExternalID)
+	if err == nil {
+		sub.ID = existing.ID
+		sub.CreatedAt = existing.CreatedAt
+	} else if err != ErrRecordNotFound {
+		return err
+	}
+
+	return db.Save(&sub).Error
+}
+
+// Sets the user's level to the best active subscription or their manual level, runs out subscriptions that have expired
+func RecalculateUserLevel(userID int) (oldLevel UserLevel, newLevel UserLevel, err error) {
+
+	user, err := GetUserByID(userID)
+	if err != nil {
+		return oldLevel, newLevel, err
+	}
+
+	subs, err := GetUserSubscriptions(userID)
+	if err != nil {
+		return user.Level, user.Level, err
+	}
+
+	newLevel = subscriptionLevel(user.ManualLevel, subs)
+
+	for _, sub := range subs {
+		if !sub.IsActive() && sub.Active {
+
+			db, err := GetMySQLClient()
+			if err != nil {
+				return user.Level, user.Level, err
+			}
+
+			sub := sub
+			db = db.Model(&sub).Update("active", false)
+			if db.Error != nil {
+				return user.Level, user.Level, db.Error
+			}
+		}
+	}
+
+	// Guests are not real users
+	if user.Level < UserLevelFree || newLevel == user.Level {
+		return user.Level, user.Level, nil
+	}
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return user.Level, user.Level, err
+	}
+
+	db = db.Model(&user).Update("level", newLevel)
+	if db.Error != nil {
+		return user.Level, user.Level, db.Error
+	}
+
+	// Their team gets the new level too
+	return user.Level, newLevel, ClearTeamAccessForOwner(user.ID)
+}
+
+// The best level of the active subscriptions, never below the level given by hand
+func subscriptionLevel(manualLevel UserLevel, subs []UserSubscription) (level UserLevel) {
+
+	level = UserLevelFree
+	if manualLevel > level {
+		level = manualLevel
+	}
+
+	for _, sub := range subs {
+		if sub.IsActive() && sub.Level > level {
+			level = sub.Level
+		}
+	}
+
+	return level
+}
+
+// For when a provider stops listing a user at all
+func DeactivateUserSubscriptions(userID int, source string) (err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	db = db.Model(&UserSubscription{}).Where("user_id = ? AND source = ? AND active = ?", userID, source, true).Update("active", false)
+	return db.Error
+}
+
+func GetActiveUserSubscriptionUserIDs(source string) (userIDs []int, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return userIDs, err
+	}
+
+	db = db.Model(&UserSubscription{}).Where("source = ? AND active = ?", source, true).Pluck("DISTINCT user_id", &userIDs)
+	return userIDs, db.Error
+}
@@ -0,0 +1,36 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscriptionLevel(t *testing.T) {
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		manual UserLevel
+		subs   []UserSubscription
+		want   UserLevel
+	}{
+		{"none", UserLevelFree, nil, UserLevelFree},
+		{"active", UserLevelFree, []UserSubscription{{Level: UserLevel3, Active: true}}, UserLevel3},
+		{"dropped to free", UserLevelFree, []UserSubscription{{Level: UserLevelFree, Active: true}}, UserLevelFree},
+		{"cancelled", UserLevelFree, []UserSubscription{{Level: UserLevel3, Active: false}}, UserLevelFree},
+		{"expired", UserLevelFree, []UserSubscription{{Level: UserLevel3, Active: true, ExpiresAt: &past}}, UserLevelFree},
+		{"paid until", UserLevelFree, []UserSubscription{{Level: UserLevel2, Active: true, ExpiresAt: &future}}, UserLevel2},
+		{"best of several", UserLevelFree, []UserSubscription{{Level: UserLevel1, Active: true}, {Level: UserLevel2, Active: true}}, UserLevel2},
+		{"manual floor", UserLevel2, []UserSubscription{{Level: UserLevelFree, Active: true}}, UserLevel2},
+		{"manual below subscription", UserLevel1, []UserSubscription{{Level: UserLevel3, Active: true}}, UserLevel3},
+		{"manual without subscriptions", UserLevel3, nil, UserLevel3},
+	}
+
+	for _, test := range tests {
+		if got := subscriptionLevel(test.manual, test.subs); got != test.want {
+			t.Errorf("%s: expected %d, got %d", test.name, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package mysql
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/helpers"
+)
+
+// An authenticator app, the row only exists once the first code has been confirmed
+type UserTOTP struct {
+	UserID    int       `gorm:"not null;column:user_id;primary_key"`
+	CreatedAt time.Time `gorm:"not null;column:created_at"`
+	Secret    string    `gorm:"not null;column:secret"`
+	LastStep  int64     `gorm:"not null;column:last_step"` // Time step of the last accepted code, so it can't be used twice
+}
+
+func GetUserTOTP(userID int) (totp UserTOTP, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return totp, err
+	}
+
+	db = db.Where("user_id = ?", userID).First(&totp)
+	return totp, db.Error
+}
+
+func SaveUserTOTP(userID int, secret string, step int64) error {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	totp := UserTOTP{
+		UserID:   userID,
+		Secret:   secret,
+		LastStep: step,
+	}
+
+	return db.Save(&totp).Error
+}
+
+// Returns false if the step has already been used, two requests with the same code can't both pass
+func UseUserTOTPStep(userID int, step int64) (ok bool, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return false, err
+	}
+
+	db = db.Model(&UserTOTP{}).Where("user_id = ? AND last_step < ?", userID, step).Update("last_step", step)
+	return db.RowsAffected == 1, db.Error
+}
+
+func DeleteUserTOTP(userID int) error {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	return db.Where("user_id = ?", userID).Delete(UserTOTP{}).Error
+}
+
+// Single use codes for when the second factor is lost, only a hash is stored
+type UserRecoveryCode struct {
+	UserID    int       `gorm:"not null;column:user_id;primary_key"`
+	Hash      string    `gorm:"not null;column:hash;primary_key"`
+	CreatedAt time.Time `gorm:"not null;column:created_at"`
+}
+
+const (
+	recoveryCodeCount = 10
+	recoveryCodeChars = "abcdefghjkmnpqrstuvwxyz23456789" // No lookalikes
+)
+
+func hashRecoveryCode(code string) string {
+
+	code = strings.ToLower(code)
+	code = strings.Replace(code, "-", "", -1)
+	code = strings.Replace(code, " ", "", -1)
+
+	b := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(b[:])
+}
+
+// Replaces any existing codes, the returned codes can't be fetched again
+func CreateUserRecoveryCodes(userID int) (codes []string, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := db.Begin()
+
+	tx = tx.Where("user_id = ?", userID).Delete(UserRecoveryCode{})
+	if tx.Error != nil {
+		tx.Rollback()
+		return nil, tx.Error
+	}
+
+	for i := 0; i < recoveryCodeCount; i++ {
+
+		b := make([]byte, 10)
+		for k := range b {
+
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeChars))))
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+
+			b[k] = recoveryCodeChars[n.Int64()]
+		}
+
+		code := string(b[:5]) + "-" + string(b[5:])
+
+		tx = tx.Create(&UserRecoveryCode{UserID: userID, Hash: hashRecoveryCode(code)})
+		if tx.Error != nil {
+			tx.Rollback()
+			return nil, tx.Error
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, tx.Commit().Error
+}
+
+// Deletes the code if it matches
+func UseUserRecoveryCode(userID int, code string) (ok bool, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return false, err
+	}
+
+	db = db.Where("user_id = ? AND hash = ?", userID, hashRecoveryCode(code)).Delete(UserRecoveryCode{})
+	return db.RowsAffected == 1, db.Error
+}
+
+func CountUserRecoveryCodes(userID int) (count int, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return 0, err
+	}
+
+	db = db.Model(&UserRecoveryCode{}).Where("user_id = ?", userID).Count(&count)
+	return count, db.Error
+}
+
+func DeleteUserRecoveryCodes(userID int) error {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	return db.Where("user_id = ?", userID).Delete(UserRecoveryCode{}).Error
+}
+
+// A WebAuthn credential
+type UserSecurityKey struct {
+	ID           int        `gorm:"not null;column:id;primary_key"`
+	UserID       int        `gorm:"not null;column:user_id;index"`
+	CreatedAt    time.Time  `gorm:"not null;column:created_at"`
+	UsedAt       *time.Time `gorm:"column:used_at;type:datetime"`
+	Name         string     `gorm:"not null;column:name"`
+	CredentialID []byte     `gorm:"not null;column:credential_id;type:varbinary(1023);unique_index"`
+	PublicKey    []byte     `gorm:"not null;column:public_key;type:blob"`
+	AAGUID       []byte     `gorm:"not null;column:aaguid;type:varbinary(16)"`
+	SignCount    uint32     `gorm:"not null;column:sign_count"`
+}
+
+func (key UserSecurityKey) GetUsedNice() string {
+
+	if key.UsedAt == nil {
+		return "Never"
+	}
+	return key.UsedAt.Format(helpers.DateTime)
+}
+
+func GetUserSecurityKeys(userID int) (keys []UserSecurityKey, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	db = db.Where("user_id = ?", userID).Order("created_at ASC").Find(&keys)
+	return keys, db.Error
+}
+
+func CountUserSecurityKeys(userID int) (count int, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return 0, err
+	}
+
+	db = db.Model(&UserSecurityKey{}).Where("user_id = ?", userID).Count(&count)
+	return count, db.Error
+}
+
+func CreateUserSecurityKey(key UserSecurityKey) error {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	return db.Create(&key).Error
+}
+
+func TouchUserSecurityKey(userID int, credentialID []byte, signCount uint32) error {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return err
+	}
+
+	update := map[string]interface{}{
+		"used_at":    time.Now(),
+		"sign_count": signCount,
+	}
+
+	return db.Model(&UserSecurityKey{}).Where("user_id = ? AND credential_id = ?", userID, credentialID).Updates(update).Error
+}
+
+func DeleteUserSecurityKey(userID int, id int) (ok bool, err error) {
+
+	db, err := GetMySQLClient()
+	if err != nil {
+		return false, err
+	}
+
+	db = db.Where("user_id = ? AND id = ?", userID, id).Delete(UserSecurityKey{})
+	return db.RowsAffected == 1, db.Error
+}
@@ -0,0 +1,158 @@
+package patreon
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+const (
+	defaultBaseURL = "https://www.patreon.com"
+	membersPerPage = 500
+	maxMemberPages = 100
+
+	PatronStatusActive   = "active_patron"
+	PatronStatusDeclined = "declined_patron"
+	PatronStatusFormer   = "former_patron"
+)
+
+var ErrMissingCredentials = errors.New("missing patreon campaign id or creator token")
+
+// Client reads the campaign with the creator's access token
+type Client struct {
+	BaseURL    string
+	CampaignID string
+	Token      string
+}
+
+func NewClient() Client {
+	return Client{
+		BaseURL:    defaultBaseURL,
+		CampaignID: config.C.PatreonCampaignID,
+		Token:      config.C.PatreonCreatorToken,
+	}
+}
+
+type Member struct {
+	ID                   string // Membership UUID
+	UserID               string // Patreon user ID, what we store as the provider ID
+	Email                string
+	PatronStatus         string
+	LifetimeSupportCents int
+	Tiers                []Tier
+}
+
+// UserLevel is what the member has paid for right now, declined and former patrons get nothing
+func (m Member) UserLevel() mysql.UserLevel {
+
+	if m.PatronStatus != PatronStatusActive {
+		return mysql.UserLevelFree
+	}
+
+	return TiersToUserLevel(m.Tiers)
+}
+
+type membersResponse struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Email                string `json:"email"`
+			PatronStatus         string `json:"patron_status"`
+			LifetimeSupportCents int    `json:"lifetime_support_cents"`
+		} `json:"attributes"`
+		Relationships struct {
+			CurrentlyEntitledTiers struct {
+				Data []struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			} `json:"currently_entitled_tiers"`
+			User struct {
+				Data struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			} `json:"user"`
+		} `json:"relationships"`
+	} `json:"data"`
+	Meta struct {
+		Pagination struct {
+			Cursors struct {
+				Next string `json:"next"`
+			} `json:"cursors"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+// Members returns every member of the campaign, including lapsed ones
+func (c Client) Members() (members []Member, err error) {
+
+	if c.CampaignID == "" || c.Token == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+c.Token)
+
+	var cursor string
+
+	for page := 0; page < maxMemberPages; page++ {
+
+		b, _, err := helpers.Get(c.membersURL(cursor), 0, headers)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := membersResponse{}
+		err = json.Unmarshal(b, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range resp.Data {
+
+			member := Member{
+				ID:                   v.ID,
+				UserID:               v.Relationships.User.Data.ID,
+				Email:                v.Attributes.Email,
+				PatronStatus:         v.Attributes.PatronStatus,
+				LifetimeSupportCents: v.Attributes.LifetimeSupportCents,
+			}
+
+			for _, tier := range v.Relationships.CurrentlyEntitledTiers.Data {
+				i, err := strconv.Atoi(tier.ID)
+				if err != nil {
+					return nil, err
+				}
+				member.Tiers = append(member.Tiers, Tier(i))
+			}
+
+			members = append(members, member)
+		}
+
+		cursor = resp.Meta.Pagination.Cursors.Next
+		if cursor == "" {
+			return members, nil
+		}
+	}
+
+	return members, errors.New("too many patreon member pages")
+}
+
+func (c Client) membersURL(cursor string) string {
+
+	q := url.Values{}
+	q.Set("include", "currently_entitled_tiers,user")
+	q.Set("fields[member]", "email,patron_status,lifetime_support_cents")
+	q.Set("page[count]", strconv.Itoa(membersPerPage))
+	if cursor != "" {
+		q.Set("page[cursor]", cursor)
+	}
+
+	return strings.TrimSuffix(c.BaseURL, "/") + "/api/oauth2/v2/campaigns/" + url.PathEscape(c.CampaignID) + "/members?" + q.Encode()
+}
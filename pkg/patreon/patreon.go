@@ -0,0 +1,73 @@
+package patreon
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+var (
+	ErrMissingSecret    = errors.New("missing patreon webhook secret")
+	ErrInvalidSignature = errors.New("invalid patreon signature")
+)
+
+type Tier int
+
+const (
+	Tier1 Tier = 2431311
+	Tier2 Tier = 2431320
+	Tier3 Tier = 2431347
+)
+
+func (t Tier) UserLevel() mysql.UserLevel {
+	switch t {
+	case Tier1:
+		return mysql.UserLevel1
+	case Tier2:
+		return mysql.UserLevel2
+	case Tier3:
+		return mysql.UserLevel3
+	}
+	return mysql.UserLevelFree
+}
+
+// TiersToUserLevel returns the highest level any of the tiers grants
+func TiersToUserLevel(tiers []Tier) mysql.UserLevel {
+
+	var level = mysql.UserLevelFree
+
+	for _, tier := range tiers {
+		if tier.UserLevel() > level {
+			level = tier.UserLevel()
+		}
+	}
+
+	return level
+}
+
+// ValidateSignature checks the X-Patreon-Signature header, a hex HMAC-MD5 of the raw body
+func ValidateSignature(body []byte, signature string, secret string) error {
+
+	// An empty key is as good as no key
+	if secret == "" {
+		return ErrMissingSecret
+	}
+
+	if len(signature) != md5.Size*2 {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(md5.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
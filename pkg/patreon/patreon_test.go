@@ -0,0 +1,218 @@
+package patreon
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+)
+
+// Two pages of members, the way Patreon's v2 API pages them
+var fakeMemberPages = map[string]string{
+	"": `{
+		"data": [
+			{
+				"id": "member-1",
+				"type": "member",
+				"attributes": {"email": "one@example.com", "patron_status": "active_patron", "lifetime_support_cents": 1500},
+				"relationships": {
+					"currently_entitled_tiers": {"data": [{"id": "2431311", "type": "tier"}, {"id": "2431347", "type": "tier"}]},
+					"user": {"data": {"id": "101", "type": "user"}}
+				}
+			},
+			{
+				"id": "member-2",
+				"type": "member",
+				"attributes": {"email": "two@example.com", "patron_status": "declined_patron", "lifetime_support_cents": 300},
+				"relationships": {
+					"currently_entitled_tiers": {"data": [{"id": "2431320", "type": "tier"}]},
+					"user": {"data": {"id": "102", "type": "user"}}
+				}
+			}
+		],
+		"meta": {"pagination": {"cursors": {"next": "page-2"}, "total": 3}}
+	}`,
+	"page-2": `{
+		"data": [
+			{
+				"id": "member-3",
+				"type": "member",
+				"attributes": {"email": "three@example.com", "patron_status": "former_patron", "lifetime_support_cents": 900},
+				"relationships": {
+					"currently_entitled_tiers": {"data": []},
+					"user": {"data": {"id": "103", "type": "user"}}
+				}
+			}
+		],
+		"meta": {"pagination": {"cursors": {"next": null}, "total": 3}}
+	}`,
+}
+
+func fakePatreon(t *testing.T) *httptest.Server {
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path != "/api/oauth2/v2/campaigns/123/members" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer creator-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		page, ok := fakeMemberPages[r.URL.Query().Get("page[cursor]")]
+		if !ok {
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("page[cursor]"))
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(page))
+	}))
+}
+
+func TestMembers(t *testing.T) {
+
+	server := fakePatreon(t)
+	defer server.Close()
+
+	client := Client{BaseURL: server.URL, CampaignID: "123", Token: "creator-token"}
+
+	members, err := client.Members()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != 3 {
+		t.Fatalf("expected 3 members across both pages, got %d", len(members))
+	}
+
+	tests := []struct {
+		userID   string
+		email    string
+		level    mysql.UserLevel
+		lifetime int
+	}{
+		{"101", "one@example.com", mysql.UserLevel3, 1500}, // Highest of two tiers
+		{"102", "two@example.com", mysql.UserLevelFree, 300},
+		{"103", "three@example.com", mysql.UserLevelFree, 900},
+	}
+
+	for k, test := range tests {
+
+		member := members[k]
+
+		if member.UserID != test.userID || member.Email != test.email {
+			t.Errorf("member %d: got %s %s", k, member.UserID, member.Email)
+		}
+		if member.UserLevel() != test.level {
+			t.Errorf("member %d: expected level %d, got %d", k, test.level, member.UserLevel())
+		}
+		if member.LifetimeSupportCents != test.lifetime {
+			t.Errorf("member %d: expected lifetime %d, got %d", k, test.lifetime, member.LifetimeSupportCents)
+		}
+	}
+}
+
+func TestMembersErrors(t *testing.T) {
+
+	server := fakePatreon(t)
+	defer server.Close()
+
+	_, err := Client{BaseURL: server.URL, CampaignID: "123", Token: "wrong"}.Members()
+	if err == nil {
+		t.Error("expected an error for a bad token")
+	}
+
+	_, err = Client{BaseURL: server.URL, CampaignID: "123"}.Members()
+	if err != ErrMissingCredentials {
+		t.Errorf("expected ErrMissingCredentials, got %v", err)
+	}
+}
+
+func TestNewDonated(t *testing.T) {
+
+	member := &Member{LifetimeSupportCents: 2000}
+
+	tests := []struct {
+		name    string
+		user    mysql.User
+		member  *Member
+		donated int
+	}{
+		{"in sync", mysql.User{DonatedPatreon: 2000}, member, 2000},
+		{"missed payment", mysql.User{DonatedPatreon: 500}, member, 2000},
+		{"left campaign", mysql.User{DonatedPatreon: 900}, nil, 900},
+		{"lifetime never drops", mysql.User{DonatedPatreon: 5000}, member, 5000},
+	}
+
+	for _, test := range tests {
+		if donated := newDonated(test.user, test.member); donated != test.donated {
+			t.Errorf("%s: expected donated %d, got %d", test.name, test.donated, donated)
+		}
+	}
+}
+
+func TestMemberSubscription(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		member Member
+		level  mysql.UserLevel
+		active bool
+	}{
+		{"active", Member{ID: "m", PatronStatus: PatronStatusActive, Tiers: []Tier{Tier2}}, mysql.UserLevel2, true},
+		{"declined card", Member{ID: "m", PatronStatus: PatronStatusDeclined, Tiers: []Tier{Tier2}}, mysql.UserLevelFree, false},
+	}
+
+	for _, test := range tests {
+
+		sub := memberSubscription(1, test.member)
+
+		if sub.UserID != 1 || sub.ExternalID != "m" || sub.Source != mysql.DonationSourcePatreon {
+			t.Errorf("%s: unexpected subscription %+v", test.name, sub)
+		}
+		if sub.Level != test.level {
+			t.Errorf("%s: expected level %d, got %d", test.name, test.level, sub.Level)
+		}
+		if sub.Active != test.active {
+			t.Errorf("%s: expected active %v, got %v", test.name, test.active, sub.Active)
+		}
+	}
+}
+
+func TestValidateSignature(t *testing.T) {
+
+	body := []byte(`{"data":{"id":"member-1"}}`)
+
+	mac := hmac.New(md5.New, []byte("secret"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		body      []byte
+		signature string
+		secret    string
+		err       error
+	}{
+		{"valid", body, signature, "secret", nil},
+		{"tampered body", []byte(`{"data":{"id":"member-2"}}`), signature, "secret", ErrInvalidSignature},
+		{"wrong secret", body, signature, "other", ErrInvalidSignature},
+		{"missing header", body, "", "secret", ErrInvalidSignature},
+		{"no secret configured", body, signature, "", ErrMissingSecret},
+	}
+
+	for _, test := range tests {
+		if err := ValidateSignature(test.body, test.signature, test.secret); err != test.err {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+}
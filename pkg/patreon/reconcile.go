@@ -0,0 +1,264 @@
+package patreon
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/oauth"
+	"go.uber.org/zap"
+	"golang.org/x/text/currency"
+)
+
+var ErrNoMembers = errors.New("patreon returned no members")
+
+type Change struct {
+	UserID     int
+	OldLevel   mysql.UserLevel
+	NewLevel   mysql.UserLevel
+	OldDonated int
+	NewDonated int
+}
+
+func (c Change) Lapsed() bool {
+	return c.NewLevel < c.OldLevel
+}
+
+// Reconcile brings user levels and Patreon totals in line with the campaign's member list,
+// catching anything the webhooks missed
+func Reconcile(members []Member) (changes []Change, err error) {
+
+	// An empty list would downgrade every patron, more likely a bad response than reality
+	if len(members) == 0 {
+		return nil, ErrNoMembers
+	}
+
+	var users = map[int]mysql.User{}
+	var best = map[int]Member{}
+
+	for _, member := range members {
+
+		user, err := findUser(member)
+		if err == mysql.ErrRecordNotFound {
+			continue
+		} else if err != nil {
+			return changes, err
+		}
+
+		// The email fallback can match a second Patreon account, keep the better membership
+		if current, ok := best[user.ID]; ok && current.UserLevel() >= member.UserLevel() {
+			continue
+		}
+
+		users[user.ID] = user
+		best[user.ID] = member
+	}
+
+	for userID, member := range best {
+
+		member := member
+		change, changed, err := apply(users[userID], &member)
+		if err != nil {
+			return changes, err
+		}
+		if changed {
+			changes = append(changes, change)
+		}
+	}
+
+	// Anyone who is no longer a member at all, including patrons from before subscriptions were stored
+	db, err := mysql.GetMySQLClient()
+	if err != nil {
+		return changes, err
+	}
+
+	var paying []mysql.User
+	db = db.Where("level > ?", mysql.UserLevelFree).Where("donated_patreon > ?", 0).Find(&paying)
+	if db.Error != nil {
+		return changes, db.Error
+	}
+
+	subscribed, err := mysql.GetActiveUserSubscriptionUserIDs(mysql.DonationSourcePatreon)
+	if err != nil {
+		return changes, err
+	}
+
+	var lapsed = map[int]bool{}
+	for _, user := range paying {
+		lapsed[user.ID] = true
+	}
+	for _, userID := range subscribed {
+		lapsed[userID] = true
+	}
+
+	for userID := range lapsed {
+
+		if _, ok := best[userID]; ok {
+			continue
+		}
+
+		user, err := mysql.GetUserByID(userID)
+		if err == mysql.ErrRecordNotFound {
+			continue
+		} else if err != nil {
+			return changes, err
+		}
+
+		change, changed, err := apply(user, nil)
+		if err != nil {
+			return changes, err
+		}
+		if changed {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+func findUser(member Member) (user mysql.User, err error) {
+
+	if member.UserID != "" {
+		user, err = mysql.GetUserByProviderID(oauth.ProviderPatreon, member.UserID)
+		if err != mysql.ErrRecordNotFound {
+			return user, err
+		}
+	}
+
+	if member.Email == "" {
+		return user, mysql.ErrRecordNotFound
+	}
+
+	return mysql.GetUserByEmail(member.Email)
+}
+
+// Lifetime totals only go up, refunds are handled by hand
+func newDonated(user mysql.User, member *Member) int {
+
+	if member != nil && member.LifetimeSupportCents > user.DonatedPatreon {
+		return member.LifetimeSupportCents
+	}
+	return user.DonatedPatreon
+}
+
+// The subscription a current member has, declined payments keep the row but don't give a level
+func memberSubscription(userID int, member Member) mysql.UserSubscription {
+
+	return mysql.UserSubscription{
+		UserID:     userID,
+		Source:     mysql.DonationSourcePatreon,
+		ExternalID: member.ID,
+		Level:      member.UserLevel(),
+		Active:     member.PatronStatus == PatronStatusActive,
+	}
+}
+
+// apply saves what Patreon says about the user, their level comes from all of their subscriptions
+func apply(user mysql.User, member *Member) (change Change, changed bool, err error) {
+
+	change = Change{
+		UserID:     user.ID,
+		OldDonated: user.DonatedPatreon,
+		NewDonated: newDonated(user, member),
+	}
+
+	// Record whatever was paid while we weren't listening
+	if member != nil && change.NewDonated > change.OldDonated {
+
+		var playerID int64
+
+		steam, err := mysql.GetUserProviderByUserID(oauth.ProviderSteam, user.ID)
+		if err != nil && err != mysql.ErrRecordNotFound {
+			return change, false, err
+		} else if err == nil {
+			playerID, err = strconv.ParseInt(steam.ID, 10, 64)
+			if err != nil {
+				log.ErrS(err)
+			}
+		}
+
+		amount := change.NewDonated - change.OldDonated
+
+		donation := mysql.Donation{
+			UserID:           user.ID,
+			PlayerID:         playerID,
+			Email:            user.Email,
+			AmountUSD:        amount,
+			OriginalCurrency: currency.USD.String(),
+			OriginalAmount:   amount,
+			Source:           mysql.DonationSourcePatreon,
+			PatreonRef:       member.ID,
+		}
+
+		db, err := mysql.GetMySQLClient()
+		if err != nil {
+			return change, false, err
+		}
+
+		db = db.Create(&donation)
+		if db.Error != nil {
+			return change, false, db.Error
+		}
+
+		db, err = mysql.GetMySQLClient()
+		if err != nil {
+			return change, false, err
+		}
+
+		db = db.Model(&user).Update("donated_patreon", change.NewDonated)
+		if db.Error != nil {
+			return change, false, db.Error
+		}
+	}
+
+	// Subscription
+	if member == nil {
+		err = mysql.DeactivateUserSubscriptions(user.ID, mysql.DonationSourcePatreon)
+	} else {
+		err = mysql.SaveUserSubscription(memberSubscription(user.ID, *member))
+	}
+	if err != nil {
+		return change, false, err
+	}
+
+	change.OldLevel, change.NewLevel, err = mysql.RecalculateUserLevel(user.ID)
+	if err != nil {
+		return change, false, err
+	}
+
+	changed = change.NewLevel != change.OldLevel || change.NewDonated != change.OldDonated
+	if !changed {
+		return change, false, nil
+	}
+
+	var event = mongo.EventPatreonSync
+	if change.Lapsed() {
+		event = mongo.EventPatreonLapsed
+	}
+
+	err = mongo.NewEvent(nil, user.ID, event)
+	if err != nil {
+		return change, false, err
+	}
+
+	log.Info("Patreon pledge corrected",
+		zap.Int("user", user.ID),
+		zap.Int("old level", int(change.OldLevel)),
+		zap.Int("new level", int(change.NewLevel)),
+		zap.Int("old donated", change.OldDonated),
+		zap.Int("new donated", change.NewDonated),
+	)
+
+	// API level is cached by key
+	if change.NewLevel != change.OldLevel && user.APIKey != "" {
+		err = memcache.Client().Delete(memcache.ItemUserByAPIKey(user.APIKey).Key)
+		if err != nil {
+			log.ErrS(err)
+		}
+	}
+
+	return change, true, nil
+}
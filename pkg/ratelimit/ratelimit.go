@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"go.uber.org/zap"
+)
+
+// Token buckets that can be shared between every replica of a process
+
+type Bucket struct {
+	Name  string        // Sent in X-RateLimit-Bucket
+	Every time.Duration // A token is added this often
+	Burst int           // Size of the bucket
+}
+
+// Group gives a route group its own bucket with the same limits
+func (b Bucket) Group(group string) Bucket {
+
+	if group != "" {
+		b.Name = b.Name + "." + group
+	}
+	return b
+}
+
+type Result struct {
+	Bucket    string
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration // Until the bucket is full again
+	Wait      time.Duration // Until the next token, zero if allowed
+}
+
+type Store interface {
+	// Take removes a token from the key's bucket, if there is one
+	Take(bucket Bucket, key string) (Result, error)
+
+	// Increment adds one to a counter, the ttl starts when the counter is created
+	Increment(key string, ttl time.Duration) (int, error)
+
+	// Get returns a counter or value, or "" if it's missing
+	Get(key string) (string, error)
+
+	// Update swaps a value for what update returns, update gets "" if it's missing
+	Update(key string, ttl time.Duration, update func(val string) (string, error)) error
+
+	Delete(key string) error
+}
+
+// What gets stored for each key
+type state struct {
+	Tokens  float64 `json:"t"`
+	Updated int64   `json:"u"` // Unix nano
+}
+
+func (b Bucket) take(s state, exists bool, now time.Time) (state, Result) {
+
+	if !exists {
+		s.Tokens = float64(b.Burst)
+	} else if b.Every > 0 {
+		elapsed := now.Sub(time.Unix(0, s.Updated))
+		if elapsed > 0 {
+			s.Tokens = math.Min(float64(b.Burst), s.Tokens+float64(elapsed)/float64(b.Every))
+		}
+	}
+
+	s.Updated = now.UnixNano()
+
+	res := Result{
+		Bucket: b.Name,
+		Limit:  b.Burst,
+	}
+
+	if s.Tokens >= 1 {
+		s.Tokens--
+		res.Allowed = true
+	} else {
+		res.Wait = time.Duration((1 - s.Tokens) * float64(b.Every))
+	}
+
+	res.Remaining = int(math.Floor(s.Tokens))
+	res.Reset = time.Duration((float64(b.Burst) - s.Tokens) * float64(b.Every))
+
+	return s, res
+}
+
+// How long until a key's bucket is full, after which it doesn't need storing
+func (b Bucket) ttl() time.Duration {
+	return b.Every*time.Duration(b.Burst) + time.Second
+}
+
+type Limiter struct {
+	bucket Bucket
+	store  Store
+}
+
+func New(bucket Bucket, store Store) *Limiter {
+	return &Limiter{bucket: bucket, store: store}
+}
+
+func (l Limiter) Bucket() Bucket {
+	return l.bucket
+}
+
+// Take uses the route group's bucket, pass an empty group to share one bucket
+func (l Limiter) Take(group string, key string) Result {
+
+	bucket := l.bucket.Group(group)
+
+	res, err := l.store.Take(bucket, key)
+	if err != nil {
+
+		// Better to let a request through than block everyone
+		log.Err("Taking rate limit token", zap.Error(err), zap.String("bucket", bucket.Name))
+		return Result{Bucket: bucket.Name, Allowed: true, Limit: bucket.Burst}
+	}
+
+	return res
+}
@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+
+	now := time.Unix(1600000000, 0)
+
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	bucket := Bucket{Name: "test", Every: time.Second, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		res, _ := store.Take(bucket, "a")
+		if !res.Allowed || res.Remaining != i {
+			t.Fatalf("expected allowed with %d remaining, got %+v", i, res)
+		}
+	}
+
+	res, _ := store.Take(bucket, "a")
+	if res.Allowed {
+		t.Fatal("expected empty bucket to block")
+	}
+	if res.Wait != time.Second || res.Reset != time.Second*3 || res.Bucket != "test" || res.Limit != 3 {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	// Other keys have their own bucket
+	res, _ = store.Take(bucket, "b")
+	if !res.Allowed {
+		t.Error("expected other key to be allowed")
+	}
+
+	// Refills over time
+	now = now.Add(time.Millisecond * 1500)
+
+	res, _ = store.Take(bucket, "a")
+	if !res.Allowed || res.Remaining != 0 || res.Reset != time.Millisecond*2500 {
+		t.Errorf("unexpected result after refill %+v", res)
+	}
+
+	// Never more than the burst
+	now = now.Add(time.Hour)
+
+	res, _ = store.Take(bucket, "a")
+	if !res.Allowed || res.Remaining != 2 {
+		t.Errorf("unexpected result after full refill %+v", res)
+	}
+}
+
+func TestMemoryStoreValues(t *testing.T) {
+
+	now := time.Unix(1600000000, 0)
+
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	for i := 1; i <= 3; i++ {
+		if n, _ := store.Increment("count", time.Minute); n != i {
+			t.Fatalf("expected %d, got %d", i, n)
+		}
+	}
+
+	// The ttl doesn't move when the counter goes up
+	now = now.Add(time.Minute + time.Second)
+
+	if val, _ := store.Get("count"); val != "" {
+		t.Errorf("expected counter to have expired, got %q", val)
+	}
+
+	err := store.Update("value", time.Minute, func(val string) (string, error) { return val + "a", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Update("value", time.Minute, func(val string) (string, error) { return val + "b", nil })
+
+	if val, _ := store.Get("value"); val != "ab" {
+		t.Errorf("expected ab, got %q", val)
+	}
+
+	_ = store.Delete("value")
+
+	if val, _ := store.Get("value"); val != "" {
+		t.Errorf("expected deleted value, got %q", val)
+	}
+}
+
+func TestGroup(t *testing.T) {
+
+	store := NewMemoryStore()
+	limiter := New(Bucket{Name: "frontend", Every: time.Hour, Burst: 1}, store)
+
+	if res := limiter.Take("players", "a"); !res.Allowed || res.Bucket != "frontend.players" {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if res := limiter.Take("players", "a"); res.Allowed {
+		t.Error("expected second request to the group to block")
+	}
+	if res := limiter.Take("games", "a"); !res.Allowed || res.Bucket != "frontend.games" {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if res := limiter.Take("", "a"); !res.Allowed || res.Bucket != "frontend" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+type brokenStore struct{}
+
+var errBroken = errors.New("broken")
+
+func (brokenStore) Take(bucket Bucket, key string) (Result, error) {
+	return Result{}, errBroken
+}
+
+func (brokenStore) Increment(key string, ttl time.Duration) (int, error) {
+	return 0, errBroken
+}
+
+func (brokenStore) Get(key string) (string, error) {
+	return "", errBroken
+}
+
+func (brokenStore) Update(key string, ttl time.Duration, update func(val string) (string, error)) error {
+	return errBroken
+}
+
+func (brokenStore) Delete(key string) error {
+	return errBroken
+}
+
+func TestFallback(t *testing.T) {
+
+	bucket := Bucket{Name: "test", Every: time.Hour, Burst: 1}
+	store := WithFallback(brokenStore{}, NewMemoryStore())
+
+	res, err := store.Take(bucket, "a")
+	if err != nil || !res.Allowed {
+		t.Errorf("expected fallback to allow, got %+v %v", res, err)
+	}
+
+	res, err = store.Take(bucket, "a")
+	if err != nil || res.Allowed {
+		t.Errorf("expected fallback to block, got %+v %v", res, err)
+	}
+
+	if n, err := store.Increment("count", time.Hour); err != nil || n != 1 {
+		t.Errorf("expected fallback counter, got %d %v", n, err)
+	}
+
+	// A limiter without a fallback lets requests through
+	if res := New(bucket, brokenStore{}).Take("", "a"); !res.Allowed {
+		t.Error("expected a broken store to let requests through")
+	}
+}
@@ -0,0 +1,317 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"go.uber.org/zap"
+)
+
+// Keeps buckets in process memory, for tests and as a fallback
+type MemoryStore struct {
+	states    map[string]state
+	values    map[string]memoryValue
+	lastClean time.Time
+	now       func() time.Time
+	lock      sync.Mutex
+}
+
+type memoryValue struct {
+	val     string
+	expires time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: map[string]state{}, values: map[string]memoryValue{}, lastClean: time.Now(), now: time.Now}
+}
+
+func (m *MemoryStore) Take(bucket Bucket, key string) (Result, error) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.now()
+	k := bucket.Name + "-" + key
+
+	s, exists := m.states[k]
+	s, res := bucket.take(s, exists, now)
+	m.states[k] = s
+
+	m.clean(now)
+
+	return res, nil
+}
+
+func (m *MemoryStore) Increment(key string, ttl time.Duration) (int, error) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.now()
+
+	v, ok := m.get(key, now)
+	if !ok {
+		v.expires = now.Add(ttl)
+	}
+
+	i, _ := strconv.Atoi(v.val)
+	v.val = strconv.Itoa(i + 1)
+	m.values[key] = v
+
+	m.clean(now)
+
+	return i + 1, nil
+}
+
+func (m *MemoryStore) Get(key string) (string, error) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	v, _ := m.get(key, m.now())
+	return v.val, nil
+}
+
+func (m *MemoryStore) Update(key string, ttl time.Duration, update func(val string) (string, error)) error {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.now()
+
+	v, _ := m.get(key, now)
+
+	val, err := update(v.val)
+	if err != nil {
+		return err
+	}
+
+	m.values[key] = memoryValue{val: val, expires: now.Add(ttl)}
+
+	m.clean(now)
+
+	return nil
+}
+
+func (m *MemoryStore) Delete(key string) error {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.values, key)
+	return nil
+}
+
+func (m *MemoryStore) get(key string, now time.Time) (memoryValue, bool) {
+
+	v, ok := m.values[key]
+	if !ok || now.After(v.expires) {
+		return memoryValue{}, false
+	}
+	return v, true
+}
+
+func (m *MemoryStore) clean(now time.Time) {
+
+	if now.Sub(m.lastClean) < time.Minute {
+		return
+	}
+
+	m.lastClean = now
+
+	// Full buckets are the same as missing ones
+	for k, v := range m.states {
+		if now.Sub(time.Unix(0, v.Updated)) > time.Hour {
+			delete(m.states, k)
+		}
+	}
+
+	for k, v := range m.values {
+		if now.After(v.expires) {
+			delete(m.values, k)
+		}
+	}
+}
+
+const memcacheAttempts = 5
+
+var errTooManyAttempts = errors.New("too many rate limit cas conflicts")
+
+// Keeps buckets in memcache so every replica shares them
+type MemcacheStore struct{}
+
+func (m MemcacheStore) Take(bucket Bucket, key string) (res Result, err error) {
+
+	item := memcache.ItemRateLimit(bucket.Name, key)
+	client := memcache.Client()
+
+	for i := 0; i < memcacheAttempts; i++ {
+
+		var s state
+
+		val, cas, err := client.GetCAS(item.Key)
+		if err == memcache.ErrNotFound {
+			cas = 0
+		} else if err != nil {
+			return res, err
+		} else if err = json.Unmarshal([]byte(val), &s); err != nil {
+			return res, err
+		}
+
+		s, res = bucket.take(s, cas > 0, time.Now())
+
+		b, err := json.Marshal(s)
+		if err != nil {
+			return res, err
+		}
+
+		err = client.SetCAS(item.Key, string(b), uint32(bucket.ttl().Seconds()), cas)
+		if err == memcache.ErrCASConflict {
+			continue // Another request changed the bucket, try again with its value
+		}
+
+		return res, err
+	}
+
+	return res, errTooManyAttempts
+}
+
+func (m MemcacheStore) Increment(key string, ttl time.Duration) (int, error) {
+
+	n, err := memcache.Client().Increment(memcache.ItemRateLimitValue(key).Key, uint32(ttl.Seconds()))
+	return int(n), err
+}
+
+func (m MemcacheStore) Get(key string) (string, error) {
+
+	val, _, err := memcache.Client().GetCAS(memcache.ItemRateLimitValue(key).Key)
+	if err == memcache.ErrNotFound {
+		return "", nil
+	}
+	return val, err
+}
+
+func (m MemcacheStore) Update(key string, ttl time.Duration, update func(val string) (string, error)) error {
+
+	item := memcache.ItemRateLimitValue(key)
+	client := memcache.Client()
+
+	for i := 0; i < memcacheAttempts; i++ {
+
+		val, cas, err := client.GetCAS(item.Key)
+		if err == memcache.ErrNotFound {
+			val, cas = "", 0
+		} else if err != nil {
+			return err
+		}
+
+		val, err = update(val)
+		if err != nil {
+			return err
+		}
+
+		err = client.SetCAS(item.Key, val, uint32(ttl.Seconds()), cas)
+		if err == memcache.ErrCASConflict {
+			continue
+		}
+
+		return err
+	}
+
+	return errTooManyAttempts
+}
+
+func (m MemcacheStore) Delete(key string) error {
+
+	err := memcache.Client().Delete(memcache.ItemRateLimitValue(key).Key)
+	if err == memcache.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// Uses the fallback, with its own buckets, while the primary store is erroring
+type fallbackStore struct {
+	primary  Store
+	fallback Store
+	warned   int64 // Unix time, so a broken store doesn't log on every request
+}
+
+func WithFallback(primary Store, fallback Store) Store {
+	return &fallbackStore{primary: primary, fallback: fallback}
+}
+
+func (f *fallbackStore) Take(bucket Bucket, key string) (Result, error) {
+
+	res, err := f.primary.Take(bucket, key)
+	if err != nil {
+		f.warn(err, bucket.Name)
+		return f.fallback.Take(bucket, key)
+	}
+
+	return res, nil
+}
+
+func (f *fallbackStore) Increment(key string, ttl time.Duration) (int, error) {
+
+	n, err := f.primary.Increment(key, ttl)
+	if err != nil {
+		f.warn(err, key)
+		return f.fallback.Increment(key, ttl)
+	}
+
+	return n, nil
+}
+
+func (f *fallbackStore) Get(key string) (string, error) {
+
+	val, err := f.primary.Get(key)
+	if err != nil {
+		f.warn(err, key)
+		return f.fallback.Get(key)
+	}
+
+	return val, nil
+}
+
+func (f *fallbackStore) Update(key string, ttl time.Duration, update func(val string) (string, error)) error {
+
+	err := f.primary.Update(key, ttl, update)
+	if err != nil {
+		f.warn(err, key)
+		return f.fallback.Update(key, ttl, update)
+	}
+
+	return nil
+}
+
+func (f *fallbackStore) Delete(key string) error {
+
+	err := f.primary.Delete(key)
+	if err != nil {
+		f.warn(err, key)
+	}
+
+	// Could have been written to the fallback while the primary was down
+	return f.fallback.Delete(key)
+}
+
+func (f *fallbackStore) warn(err error, key string) {
+
+	now := time.Now().Unix()
+	last := atomic.LoadInt64(&f.warned)
+	if now-last >= 60 && atomic.CompareAndSwapInt64(&f.warned, last, now) {
+		log.Warn("Rate limit store failing, using fallback", zap.Error(err), zap.String("key", key))
+	}
+}
+
+// Shared between replicas, falls back to per process limits if memcache is down
+func NewSharedStore() Store {
+	return WithFallback(MemcacheStore{}, NewMemoryStore())
+}
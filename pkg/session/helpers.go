@@ -25,6 +25,8 @@ const (
 	SessionUserShowAlerts = "user-alerts"
 	SessionUserAPIKey     = "user-api-key"
 	SessionUserLevel      = "user-level"
+	SessionUserAdminRole  = "user-admin-role"
+	SessionUserSessionID  = "user-session-id" // mongo.UserSession
 
 	// Set if player exists at login
 	SessionPlayerID    = "player-id"
@@ -35,6 +37,10 @@ const (
 	SessionLastPage    = "last-page"
 	SessionCountryCode = "country-code"
 
+	// Abuse protection
+	SessionClientID        = "client-id"        // Random, to rate limit logged out users
+	SessionCaptchaRequired = "captcha-required" // Set after too many blocked requests
+
 	// Flash groups
 	SessionGood FlashGroup = "good"
 	SessionBad  FlashGroup = "bad"
@@ -206,9 +212,24 @@ func GetUserLevel(r *http.Request) mysql.UserLevel {
 	return mysql.UserLevel(i)
 }
 
+func GetAdminRole(r *http.Request) mysql.AdminRole {
+
+	// Also covers sessions from before roles existed
+	if Get(r, SessionUserID) == strconv.Itoa(mysql.AdminOwnerUserID) {
+		return mysql.AdminRoleOwner
+	}
+
+	return mysql.AdminRole(Get(r, SessionUserAdminRole))
+}
+
 func IsAdmin(r *http.Request) bool {
 
-	return Get(r, SessionUserID) == "1"
+	return GetAdminRole(r).IsValid()
+}
+
+func HasAdminPermission(r *http.Request, permission mysql.AdminPermission) bool {
+
+	return GetAdminRole(r).Can(permission)
 }
 
 func IsLoggedIn(r *http.Request) (val bool) {
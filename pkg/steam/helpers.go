@@ -11,6 +11,7 @@ import (
 
 	"github.com/Jleagle/steam-go/steamapi"
 	"github.com/gamedb/gamedb/pkg/helpers"
+	"github.com/gamedb/gamedb/pkg/metrics"
 	"github.com/gocolly/colly/v2"
 	"go.uber.org/zap"
 )
@@ -34,6 +35,16 @@ func AllowSteamCodes(err error, allowedCodes ...int) error {
 // Downgrade some Steam errors to info.
 func LogSteamError(err error, interfaces ...zap.Field) {
 
+	// Transport errors have the URL but no status code
+	var urlErr *url.Error
+	if val, ok := err.(steamapi.Error); ok {
+		metrics.SteamError(val.URL, val.Code)
+	} else if errors.As(err, &urlErr) {
+		metrics.SteamError(urlErr.URL, 0)
+	} else {
+		metrics.SteamError("", 0)
+	}
+
 	isError := func() bool {
 
 		// Sleeps on rate limits etc
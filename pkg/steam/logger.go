@@ -2,13 +2,18 @@ package steam
 
 import (
 	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/gamedb/gamedb/pkg/metrics"
 	"go.uber.org/zap"
 )
 
 type steamLogger struct {
 }
 
+// Called by the library after every successful request, with the full URL
 func (l steamLogger) Info(s string) {
+
+	metrics.SteamCall(s)
+
 	// if config.IsLocal() {
 	// 	zap.S().Named(log.LogNameSteamErrors).Info(s)
 	// }
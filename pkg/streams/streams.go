@@ -0,0 +1,97 @@
+package streams
+
+import (
+	"sync"
+
+	"github.com/satori/go.uuid"
+)
+
+type StreamTopic string
+
+const (
+	TopicAppPlayers StreamTopic = "app-players"
+	TopicChanges    StreamTopic = "changes"
+	TopicPrices     StreamTopic = "prices"
+)
+
+// Slow subscribers miss messages rather than holding up everyone else
+const bufferSize = 100
+
+var (
+	Topics = map[StreamTopic]*Topic{}
+)
+
+func init() {
+
+	topicsSlice := []StreamTopic{
+		TopicAppPlayers,
+		TopicChanges,
+		TopicPrices,
+	}
+	for _, v := range topicsSlice {
+		Topics[v] = &Topic{
+			name:        v,
+			subscribers: map[uuid.UUID]chan []byte{},
+		}
+	}
+}
+
+func GetTopic(topic StreamTopic) (ret *Topic) {
+
+	if val, ok := Topics[topic]; ok {
+		return val
+	}
+
+	return ret
+}
+
+type Topic struct {
+	name        StreamTopic
+	subscribers map[uuid.UUID]chan []byte
+	sync.Mutex
+}
+
+func (t *Topic) GetName() StreamTopic {
+	return t.name
+}
+
+func (t *Topic) CountSubscribers() int {
+
+	t.Lock()
+	defer t.Unlock()
+
+	return len(t.subscribers)
+}
+
+// The returned function must be called when the subscriber goes away
+func (t *Topic) Subscribe() (messages <-chan []byte, unsubscribe func()) {
+
+	t.Lock()
+	defer t.Unlock()
+
+	id := uuid.NewV4()
+	c := make(chan []byte, bufferSize)
+
+	t.subscribers[id] = c
+
+	return c, func() {
+
+		t.Lock()
+		defer t.Unlock()
+
+		delete(t.subscribers, id)
+	}
+}
+
+func (t *Topic) Send(message []byte) {
+
+	t.Lock()
+	defer t.Unlock()
+
+	for _, c := range t.subscribers {
+		select {
+		case c <- message:
+		default:
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/gamedb/gamedb/pkg/config"
+	"github.com/gamedb/gamedb/pkg/log"
+	"github.com/go-chi/chi/v5"
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const tracerName = "github.com/gamedb/gamedb"
+
+// Exports to an OTLP collector if one is configured, otherwise to a local file.
+// With neither, spans are created but dropped.
+func Init(service string) (shutdown func()) {
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch {
+	case config.C.TracingOTLPEndpoint != "":
+
+		exporter, err = otlptracegrpc.New(context.Background(),
+			otlptracegrpc.WithEndpoint(config.C.TracingOTLPEndpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+
+	case config.C.TracingFilePath != "":
+
+		var f *os.File
+		f, err = os.OpenFile(config.C.TracingFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err == nil {
+			exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+		}
+
+	default:
+		return func() {}
+	}
+
+	if err != nil {
+		log.Err("creating trace exporter", zap.Error(err))
+		return func() {}
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(service),
+			semconv.DeploymentEnvironmentKey.String(config.C.Environment),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return func() {
+		err := provider.Shutdown(context.Background())
+		if err != nil {
+			log.Err("shutting down tracer", zap.Error(err))
+		}
+	}
+}
+
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// Chi middleware, spans are named after the matched route rather than the path
+func Middleware(next http.Handler) http.Handler {
+
+	named := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		next.ServeHTTP(w, r)
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			trace.SpanFromContext(r.Context()).SetName(r.Method + " " + rctx.RoutePattern())
+		}
+	})
+
+	return otelhttp.NewHandler(named, "http", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method
+	}))
+}
+
+// For outgoing HTTP calls, e.g. to Elasticsearch
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
+
+// Rabbit message headers
+func Inject(ctx context.Context, headers amqp.Table) {
+	otel.GetTextMapPropagator().Inject(ctx, amqpCarrier(headers))
+}
+
+func Extract(ctx context.Context, headers amqp.Table) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, amqpCarrier(headers))
+}
+
+type amqpCarrier amqp.Table
+
+func (c amqpCarrier) Get(key string) string {
+
+	if val, ok := c[key].(string); ok {
+		return val
+	}
+	return ""
+}
+
+func (c amqpCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+func (c amqpCarrier) Keys() (keys []string) {
+
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,301 @@
+package userdata
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/archive"
+	"github.com/gamedb/gamedb/pkg/influx"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// A JSON file in the export, write calls add once per item
+type section struct {
+	name  string
+	write func(add func(interface{}) error) error
+}
+
+func writeArchive(w io.Writer, sections []section) (err error) {
+
+	zw := zip.NewWriter(w)
+
+	for _, s := range sections {
+
+		f, err := zw.Create(s.name + ".json")
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(f, "[")
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = s.write(func(item interface{}) error {
+
+			b, ok := item.(json.RawMessage)
+			if !ok {
+				var err error
+				b, err = json.Marshal(item)
+				if err != nil {
+					return err
+				}
+			}
+
+			sep := ",\n"
+			if count == 0 {
+				sep = "\n"
+			}
+			count++
+
+			_, err := io.WriteString(f, sep)
+			if err != nil {
+				return err
+			}
+
+			_, err = f.Write(b)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(f, "\n]\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// Secrets like password hashes, OAuth tokens and two-factor keys are left out
+func userSections(user mysql.User, providers []mysql.UserProvider) (sections []section) {
+
+	sections = []section{
+		{"account", func(add func(interface{}) error) error {
+
+			totp := true
+			_, err := mysql.GetUserTOTP(user.ID)
+			if err == mysql.ErrRecordNotFound {
+				totp = false
+			} else if err != nil {
+				return err
+			}
+
+			codes, err := mysql.CountUserRecoveryCodes(user.ID)
+			if err != nil {
+				return err
+			}
+
+			return add(exportAccount{
+				ID:               user.ID,
+				CreatedAt:        user.CreatedAt,
+				UpdatedAt:        user.UpdatedAt,
+				LoggedInAt:       user.LoggedInAt,
+				Email:            user.Email,
+				EmailVerified:    user.EmailVerified,
+				Level:            user.Level.GetName(),
+				Country:          string(user.ProductCC),
+				APIKey:           user.APIKey,
+				DonatedPatreon:   user.DonatedPatreon,
+				AuthenticatorApp: totp,
+				RecoveryCodes:    codes,
+			})
+		}},
+		{"providers", func(add func(interface{}) error) error {
+
+			for _, v := range providers {
+				err := add(exportProvider{
+					Provider:   string(v.Provider),
+					ID:         v.ID,
+					Email:      v.Email,
+					Username:   v.Username,
+					Avatar:     v.Avatar,
+					CreatedAt:  v.CreatedAt,
+					UpdatedAt:  v.UpdatedAt,
+					UnlinkedAt: v.DeletedAt,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"donations", func(add func(interface{}) error) error {
+
+			for offset := 0; ; offset += 100 {
+
+				donations, err := mysql.GetDonationsByUser(user.ID, offset)
+				if err != nil {
+					return err
+				}
+
+				for _, v := range donations {
+					err = add(exportDonation{
+						CreatedAt:        v.CreatedAt,
+						Source:           v.Source,
+						AmountUSDCents:   v.AmountUSD,
+						OriginalCurrency: v.OriginalCurrency,
+						OriginalAmount:   v.OriginalAmount,
+						Anonymous:        v.Anon,
+					})
+					if err != nil {
+						return err
+					}
+				}
+
+				if len(donations) < 100 {
+					return nil
+				}
+			}
+		}},
+		{"subscriptions", func(add func(interface{}) error) error {
+
+			subs, err := mysql.GetUserSubscriptions(user.ID)
+			if err != nil {
+				return err
+			}
+
+			for _, v := range subs {
+				err = add(exportSubscription{
+					Source:    v.Source,
+					Level:     v.Level.GetName(),
+					Active:    v.IsActive(),
+					CreatedAt: v.CreatedAt,
+					ExpiresAt: v.ExpiresAt,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"security_keys", func(add func(interface{}) error) error {
+
+			keys, err := mysql.GetUserSecurityKeys(user.ID)
+			if err != nil {
+				return err
+			}
+
+			for _, v := range keys {
+				err = add(exportSecurityKey{Name: v.Name, CreatedAt: v.CreatedAt, UsedAt: v.UsedAt})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"team", func(add func(interface{}) error) error {
+
+			team, err := mysql.GetTeamByUserID(user.ID)
+			if err == mysql.ErrRecordNotFound {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			return add(exportTeam{ID: team.ID, Name: team.Name, Owner: team.OwnerID == user.ID})
+		}},
+	}
+
+	for _, source := range mongoSources(user, providers) {
+
+		source := source
+
+		sections = append(sections, section{source.Name, func(add func(interface{}) error) error {
+			return source.Export(func(raw bson.Raw) error {
+
+				b, err := bson.MarshalExtJSON(raw, false, false)
+				if err != nil {
+					return err
+				}
+
+				return add(json.RawMessage(b))
+			})
+		}})
+	}
+
+	// Calls older than a year have been moved to the archive, so read those first
+	sections = append(sections, section{"api_calls", func(add func(interface{}) error) error {
+
+		callback := func(point influx.ArchivedPoint) error {
+			return add(exportAPICall{Time: point.Time, Path: point.Tags["path"], Code: point.Tags["code"]})
+		}
+
+		err := archive.ExportTagPoints(influx.InfluxMeasurementAPICalls, "user_id", strconv.Itoa(user.ID), callback)
+		if err != nil {
+			return err
+		}
+
+		return influx.ExportTagPoints(influx.InfluxRetentionPolicyAllTime, influx.InfluxMeasurementAPICalls, "user_id", strconv.Itoa(user.ID), callback)
+	}})
+
+	return sections
+}
+
+type exportAccount struct {
+	ID               int        `json:"id"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	LoggedInAt       *time.Time `json:"logged_in_at"`
+	Email            string     `json:"email"`
+	EmailVerified    bool       `json:"email_verified"`
+	Level            string     `json:"level"`
+	Country          string     `json:"country"`
+	APIKey           string     `json:"api_key"`
+	DonatedPatreon   int        `json:"donated_patreon_cents"`
+	AuthenticatorApp bool       `json:"authenticator_app"`
+	RecoveryCodes    int        `json:"recovery_codes_left"`
+}
+
+type exportProvider struct {
+	Provider   string     `json:"provider"`
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	Username   string     `json:"username"`
+	Avatar     string     `json:"avatar"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	UnlinkedAt *time.Time `json:"unlinked_at"`
+}
+
+type exportDonation struct {
+	CreatedAt        time.Time `json:"created_at"`
+	Source           string    `json:"source"`
+	AmountUSDCents   int       `json:"amount_usd_cents"`
+	OriginalCurrency string    `json:"original_currency"`
+	OriginalAmount   int       `json:"original_amount_cents"`
+	Anonymous        bool      `json:"anonymous"`
+}
+
+type exportSubscription struct {
+	Source    string     `json:"source"`
+	Level     string     `json:"level"`
+	Active    bool       `json:"active"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type exportSecurityKey struct {
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UsedAt    *time.Time `json:"used_at"`
+}
+
+type exportTeam struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Owner bool   `json:"owner"`
+}
+
+type exportAPICall struct {
+	Time time.Time `json:"time"`
+	Path string    `json:"path"`
+	Code string    `json:"code"`
+}
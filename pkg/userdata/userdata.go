@@ -0,0 +1,226 @@
+package userdata
+
+import (
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gamedb/gamedb/pkg/archive"
+	"github.com/gamedb/gamedb/pkg/influx"
+	"github.com/gamedb/gamedb/pkg/memcache"
+	"github.com/gamedb/gamedb/pkg/mongo"
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/oauth"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ExportLifetime = time.Hour * 24 * 7  // How long the download link works
+	ExportCooldown = time.Hour * 24      // Between export requests
+	DeleteDelay    = time.Hour * 24 * 14 // How long a deletion can be cancelled for
+)
+
+// Export zips everything held on a user into the archive bucket and returns the file name
+func Export(user mysql.User, requestID int) (file string, err error) {
+
+	providers, err := getProviders(user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := ioutil.TempFile("", "export-*.zip")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	err = writeArchive(f, userSections(user, providers))
+	if err != nil {
+		return "", err
+	}
+
+	_, err = f.Seek(0, 0)
+	if err != nil {
+		return "", err
+	}
+
+	file = exportFile(user.ID, requestID)
+
+	return file, archive.PutFile(file, f)
+}
+
+func exportFile(userID int, requestID int) string {
+	return "exports/" + strconv.Itoa(userID) + "/" + strconv.Itoa(requestID) + ".zip"
+}
+
+// RemoveExport deletes an export's file, the link stops working
+func RemoveExport(request mysql.UserDataRequest) (err error) {
+
+	if request.File != "" {
+		err = archive.DeleteFile(request.File)
+		if err != nil {
+			return err
+		}
+	}
+
+	return mysql.SetUserDataRequestStatus(request, mysql.UserDataExpired)
+}
+
+// Delete removes a user and everything linked to them, it can't be undone
+func Delete(user mysql.User) (err error) {
+
+	providers, err := getProviders(user.ID)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := mongo.GetUserSessions(user.ID)
+	if err != nil {
+		return err
+	}
+
+	// Teams
+	team, err := mysql.GetTeamByUserID(user.ID)
+	if err == nil {
+		if team.OwnerID == user.ID {
+			err = mysql.DeleteTeam(team)
+		} else {
+			err = mysql.RemoveTeamMember(team.ID, user.ID)
+		}
+	} else if err == mysql.ErrRecordNotFound {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Exports
+	exports, err := mysql.GetUserDataExportFiles(user.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range exports {
+		err = RemoveExport(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Mongo
+	for _, v := range mongoSources(user, providers) {
+		err = v.Delete()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Influx
+	err = influx.DeleteTagPoints(influx.InfluxMeasurementAPICalls, "user_id", strconv.Itoa(user.ID))
+	if err != nil {
+		return err
+	}
+
+	err = archive.DeleteTagPoints(influx.InfluxMeasurementAPICalls, "user_id", strconv.Itoa(user.ID))
+	if err != nil {
+		return err
+	}
+
+	// MySQL, last so a failed run can be retried
+	err = mysql.DeleteUser(user)
+	if err != nil {
+		return err
+	}
+
+	// Memcache
+	var keys = []string{
+		memcache.ItemUserByAPIKey(user.APIKey).Key,
+		memcache.ItemUserEvents(user.ID).Key,
+		memcache.ItemUserTeamAccess(user.ID).Key,
+	}
+
+	for _, v := range sessions {
+		keys = append(keys, memcache.ItemUserSession(v.ID).Key)
+	}
+
+	for _, v := range providers {
+		if v.Provider == oauth.ProviderDiscord {
+			keys = append(keys, memcache.ItemUserInDiscord(v.ID).Key)
+		}
+	}
+
+	return memcache.Client().Delete(keys...)
+}
+
+// Including unlinked ones, which are only soft deleted
+func getProviders(userID int) (providers []mysql.UserProvider, err error) {
+
+	db, err := mysql.GetMySQLClient()
+	if err != nil {
+		return providers, err
+	}
+
+	db = db.Unscoped().Where("user_id = ?", userID).Find(&providers)
+	return providers, db.Error
+}
+
+// Mongo data is keyed on the user, or on the Steam and Discord accounts they have linked
+func mongoSources(user mysql.User, providers []mysql.UserProvider) (sources []mongo.UserDataSource) {
+
+	sources = []mongo.UserDataSource{
+		{Name: "events", Collection: mongo.CollectionEvents, Filter: bson.D{{Key: "user_id", Value: user.ID}}},
+		{Name: "sessions", Collection: mongo.CollectionUserSessions, Filter: bson.D{{Key: "user_id", Value: user.ID}}},
+		{Name: "saved_searches", Collection: mongo.CollectionSavedSearches, Filter: bson.D{{Key: "user_id", Value: user.ID}}},
+	}
+
+	var steamIDs []int64
+	var discordIDs []string
+	var emails = []string{user.Email}
+
+	for _, v := range providers {
+
+		if v.Email != "" && v.Email != user.Email {
+			emails = append(emails, v.Email)
+		}
+
+		switch v.Provider {
+		case oauth.ProviderSteam:
+			id, err := strconv.ParseInt(v.ID, 10, 64)
+			if err == nil {
+				steamIDs = append(steamIDs, id)
+			}
+		case oauth.ProviderDiscord:
+			discordIDs = append(discordIDs, v.ID)
+		}
+	}
+
+	if len(steamIDs) > 0 {
+		sources = append(sources, mongo.UserDataSource{Name: "ignored_apps", Collection: mongo.CollectionPlayerIgnoredApps, Filter: bson.D{{Key: "player_id", Value: bson.M{"$in": steamIDs}}}})
+	}
+
+	if len(discordIDs) > 0 {
+		sources = append(sources, mongo.UserDataSource{Name: "chat_bot_commands", Collection: mongo.CollectionChatBotCommands, Filter: bson.D{{Key: "author_id", Value: bson.M{"$in": discordIDs}}}})
+	}
+
+	// Webhooks aren't linked to users, but the raw bodies include the payer's email
+	var patterns []string
+	for _, v := range emails {
+		if v != "" {
+			patterns = append(patterns, regexp.QuoteMeta(v))
+		}
+	}
+
+	if len(patterns) > 0 {
+		sources = append(sources, mongo.UserDataSource{Name: "webhooks", Collection: mongo.CollectionWebhooks, Filter: bson.D{{Key: "request_body", Value: primitive.Regex{Pattern: strings.Join(patterns, "|"), Options: "i"}}}})
+	}
+
+	return sources
+}
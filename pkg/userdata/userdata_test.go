@@ -0,0 +1,125 @@
+package userdata
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gamedb/gamedb/pkg/mysql"
+	"github.com/gamedb/gamedb/pkg/oauth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWriteArchive(t *testing.T) {
+
+	sections := []section{
+		{"empty", func(add func(interface{}) error) error {
+			return nil
+		}},
+		{"items", func(add func(interface{}) error) error {
+			for _, v := range []interface{}{
+				map[string]int{"a": 1},
+				json.RawMessage(`{"b":2}`),
+			} {
+				err := add(v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+	}
+
+	var buf bytes.Buffer
+	err := writeArchive(&buf, sections)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(zr.File) != 2 || zr.File[0].Name != "empty.json" || zr.File[1].Name != "items.json" {
+		t.Fatalf("unexpected files %+v", zr.File)
+	}
+
+	var lengths []int
+	for _, f := range zr.File {
+
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := ioutil.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var items []map[string]int
+		err = json.Unmarshal(b, &items)
+		if err != nil {
+			t.Fatalf("%s is not a JSON array: %s", f.Name, b)
+		}
+
+		lengths = append(lengths, len(items))
+	}
+
+	if lengths[0] != 0 || lengths[1] != 2 {
+		t.Errorf("expected 0 and 2 items, got %v", lengths)
+	}
+
+	// Errors stop the export
+	expected := errors.New("failed")
+	err = writeArchive(&buf, []section{{"broken", func(add func(interface{}) error) error { return expected }}})
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestMongoSources(t *testing.T) {
+
+	user := mysql.User{ID: 1}
+
+	sources := mongoSources(user, nil)
+	if len(sources) != 3 {
+		t.Fatalf("expected only the user keyed sources, got %d", len(sources))
+	}
+
+	providers := []mysql.UserProvider{
+		{Provider: oauth.ProviderSteam, ID: "76561197960287930"},
+		{Provider: oauth.ProviderDiscord, ID: "123"},
+		{Provider: oauth.ProviderSteam, ID: "not a number"},
+		{Provider: oauth.ProviderGithub, ID: "456"},
+	}
+
+	var names []string
+	for _, v := range mongoSources(user, providers) {
+		names = append(names, v.Name)
+	}
+
+	if len(names) != 5 || names[3] != "ignored_apps" || names[4] != "chat_bot_commands" {
+		t.Errorf("unexpected sources %v", names)
+	}
+
+	user.Email = "a.b+c@example.com"
+	providers = []mysql.UserProvider{
+		{Provider: oauth.ProviderPatreon, ID: "789", Email: "patreon@example.com"},
+		{Provider: oauth.ProviderGoogle, ID: "012", Email: user.Email},
+	}
+
+	sources = mongoSources(user, providers)
+	if len(sources) != 4 || sources[3].Name != "webhooks" {
+		t.Fatalf("expected a webhooks source, got %d", len(sources))
+	}
+
+	regex, ok := sources[3].Filter[0].Value.(primitive.Regex)
+	if !ok || regex.Pattern != `a\.b\+c@example\.com|patreon@example\.com` || regex.Options != "i" {
+		t.Errorf("unexpected webhooks filter %v", sources[3].Filter)
+	}
+}